@@ -104,6 +104,12 @@ type WorkersClass struct {
 	// a set of worker nodes.
 	// +optional
 	MachineDeployments []MachineDeploymentClass `json:"machineDeployments,omitempty"`
+
+	// MachinePools is a list of machine pool classes that can be used to create
+	// a set of worker nodes.
+	// NOTE: This field is considered only when the MachinePool feature flag is enabled.
+	// +optional
+	MachinePools []MachinePoolClass `json:"machinePools,omitempty"`
 }
 
 // MachineDeploymentClass serves as a template to define a set of worker nodes of the cluster
@@ -140,6 +146,36 @@ type MachineDeploymentClassTemplate struct {
 	Infrastructure LocalObjectTemplate `json:"infrastructure"`
 }
 
+// MachinePoolClass serves as a template to define a pool of worker nodes of the cluster
+// provisioned using the `ClusterClass`.
+type MachinePoolClass struct {
+	// Class denotes a type of machine pool present in the cluster,
+	// this name MUST be unique within a ClusterClass and can be referenced
+	// in the Cluster to create a managed MachinePool.
+	Class string `json:"class"`
+
+	// Template is a local struct containing a collection of templates for creation of
+	// MachinePool objects representing a pool of worker nodes.
+	Template MachinePoolClassTemplate `json:"template"`
+}
+
+// MachinePoolClassTemplate defines how a MachinePool generated from a MachinePoolClass
+// should look like.
+type MachinePoolClassTemplate struct {
+	// Metadata is the metadata applied to the MachinePool.
+	// At runtime this metadata is merged with the corresponding metadata from the topology.
+	// +optional
+	Metadata ObjectMeta `json:"metadata,omitempty"`
+
+	// Bootstrap contains the bootstrap template reference to be used
+	// for the creation of the bootstrap config of the MachinePool.
+	Bootstrap LocalObjectTemplate `json:"bootstrap"`
+
+	// Infrastructure contains the infrastructure template reference to be used
+	// for the creation of the InfrastructureMachinePool.
+	Infrastructure LocalObjectTemplate `json:"infrastructure"`
+}
+
 // MachineHealthCheckClass defines a MachineHealthCheck for a group of Machines.
 type MachineHealthCheckClass struct {
 	// UnhealthyConditions contains a list of the conditions that determine
@@ -336,9 +372,16 @@ type ClusterClassPatch struct {
 	// +optional
 	EnabledIf *string `json:"enabledIf,omitempty"`
 
-	// Definitions define the patches inline.
+	// Definitions define inline patches.
 	// Note: Patches will be applied in the order of the array.
-	Definitions []PatchDefinition `json:"definitions"`
+	// Note: Exactly one of Definitions or External must be set.
+	// +optional
+	Definitions []PatchDefinition `json:"definitions,omitempty"`
+
+	// External defines an external patch.
+	// Note: Exactly one of Definitions or External must be set.
+	// +optional
+	External *ExternalPatchDefinition `json:"external,omitempty"`
 }
 
 // PatchDefinition defines a patch which is applied to customize the referenced templates.
@@ -352,6 +395,18 @@ type PatchDefinition struct {
 	JSONPatches []JSONPatch `json:"jsonPatches"`
 }
 
+// ExternalPatchDefinition defines an external patch.
+// Note: At least one of GenerateExtension or ValidateExtension must be set.
+type ExternalPatchDefinition struct {
+	// GenerateExtension references an extension which is called to generate patches.
+	// +optional
+	GenerateExtension *string `json:"generateExtension,omitempty"`
+
+	// ValidateExtension references an extension which is called to validate the topology.
+	// +optional
+	ValidateExtension *string `json:"validateExtension,omitempty"`
+}
+
 // PatchSelector defines on which templates the patch should be applied.
 // Note: Matching on APIVersion and Kind is mandatory, to enforce that the patches are
 // written for the correct version. The version of the references in the ClusterClass may
@@ -386,6 +441,11 @@ type PatchSelectorMatch struct {
 	// .spec.workers.machineDeployments.
 	// +optional
 	MachineDeploymentClass *PatchSelectorMatchMachineDeploymentClass `json:"machineDeploymentClass,omitempty"`
+
+	// MachinePoolClass selects templates referenced in specific MachinePoolClasses in
+	// .spec.workers.machinePools.
+	// +optional
+	MachinePoolClass *PatchSelectorMatchMachinePoolClass `json:"machinePoolClass,omitempty"`
 }
 
 // PatchSelectorMatchMachineDeploymentClass selects templates referenced
@@ -396,6 +456,14 @@ type PatchSelectorMatchMachineDeploymentClass struct {
 	Names []string `json:"names,omitempty"`
 }
 
+// PatchSelectorMatchMachinePoolClass selects templates referenced
+// in specific MachinePoolClasses in .spec.workers.machinePools.
+type PatchSelectorMatchMachinePoolClass struct {
+	// Names selects templates by class names.
+	// +optional
+	Names []string `json:"names,omitempty"`
+}
+
 // JSONPatch defines a JSON patch.
 type JSONPatch struct {
 	// Op defines the operation of the patch.
@@ -47,6 +47,10 @@ const (
 	// to track the name of the MachineDeployment topology it represents.
 	ClusterTopologyMachineDeploymentLabelName = "topology.cluster.x-k8s.io/deployment-name"
 
+	// ClusterTopologyMachinePoolLabelName is the label set on the generated MachinePool objects
+	// to track the name of the MachinePool topology it represents.
+	ClusterTopologyMachinePoolLabelName = "topology.cluster.x-k8s.io/pool-name"
+
 	// ClusterTopologyUnsafeUpdateClassNameAnnotation can be used to disable the webhook check on
 	// update that disallows a pre-existing Cluster to be populated with Topology information and Class.
 	ClusterTopologyUnsafeUpdateClassNameAnnotation = "unsafe.topology.cluster.x-k8s.io/disable-update-class-name-check"
@@ -71,6 +75,28 @@ const (
 	// OwnerNameAnnotation is the annotation set on nodes identifying the owner name.
 	OwnerNameAnnotation = "cluster.x-k8s.io/owner-name"
 
+	// ManagedNodeMetadataDomain is the domain reserved for Machine labels and annotations that should be
+	// kept in sync onto the corresponding Node, e.g. "node.cluster.x-k8s.io/pool".
+	ManagedNodeMetadataDomain = "node.cluster.x-k8s.io"
+
+	// LabelsFromMachineAnnotation is the annotation set on nodes to track the labels originated from the machine;
+	// the value is a comma separated list of label keys.
+	LabelsFromMachineAnnotation = "cluster.x-k8s.io/labels-from-machine"
+
+	// AnnotationsFromMachineAnnotation is the annotation set on nodes to track the annotations originated from the machine;
+	// the value is a comma separated list of annotation keys.
+	AnnotationsFromMachineAnnotation = "cluster.x-k8s.io/annotations-from-machine"
+
+	// LabelsFromTemplateAnnotation is the annotation set on machines, and on their infrastructure machines and
+	// bootstrap configs, to track the labels originated from the machine template of the owning MachineSet or
+	// KubeadmControlPlane; the value is a comma separated list of label keys.
+	LabelsFromTemplateAnnotation = "cluster.x-k8s.io/labels-from-template"
+
+	// AnnotationsFromTemplateAnnotation is the annotation set on machines, and on their infrastructure machines and
+	// bootstrap configs, to track the annotations originated from the machine template of the owning MachineSet or
+	// KubeadmControlPlane; the value is a comma separated list of annotation keys.
+	AnnotationsFromTemplateAnnotation = "cluster.x-k8s.io/annotations-from-template"
+
 	// PausedAnnotation is an annotation that can be applied to any Cluster API
 	// object to prevent a controller from processing a resource.
 	//
@@ -104,6 +130,12 @@ const (
 	// MachineSkipRemediationAnnotation is the annotation used to mark the machines that should not be considered for remediation by MachineHealthCheck reconciler.
 	MachineSkipRemediationAnnotation = "cluster.x-k8s.io/skip-remediation"
 
+	// MachineCertificatesExpiryDateAnnotation annotation specifies the expiry date of the machine certificates in RFC3339 format.
+	// This annotation can be used on control plane machines to trigger rollout before certificates expire.
+	// This annotation can be set on BootstrapConfig or Machine objects. The value set on the Machine object takes precedence.
+	// This annotation can only be used on Control Plane Machines.
+	MachineCertificatesExpiryDateAnnotation = "machine.cluster.x-k8s.io/certificates-expiry"
+
 	// ClusterSecretType defines the type of secret created by core components.
 	ClusterSecretType corev1.SecretType = "cluster.x-k8s.io/secret" //nolint:gosec
 
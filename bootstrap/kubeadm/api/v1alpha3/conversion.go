@@ -70,6 +70,8 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.InitConfiguration.Patches = restored.Spec.InitConfiguration.Patches
 		dst.Spec.InitConfiguration.SkipPhases = restored.Spec.InitConfiguration.SkipPhases
+		dst.Spec.InitConfiguration.Timeouts = restored.Spec.InitConfiguration.Timeouts
+		dst.Spec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.JoinConfiguration != nil {
 		if dst.Spec.JoinConfiguration == nil {
@@ -77,6 +79,15 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.JoinConfiguration.Patches = restored.Spec.JoinConfiguration.Patches
 		dst.Spec.JoinConfiguration.SkipPhases = restored.Spec.JoinConfiguration.SkipPhases
+		dst.Spec.JoinConfiguration.Timeouts = restored.Spec.JoinConfiguration.Timeouts
+		dst.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.ClusterConfiguration != nil {
+		if dst.Spec.ClusterConfiguration == nil {
+			dst.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.ClusterConfiguration.Proxy = restored.Spec.ClusterConfiguration.Proxy
+		dst.Spec.ClusterConfiguration.DNS.Disabled = restored.Spec.ClusterConfiguration.DNS.Disabled
 	}
 
 	return nil
@@ -154,6 +165,8 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.InitConfiguration.Patches = restored.Spec.Template.Spec.InitConfiguration.Patches
 		dst.Spec.Template.Spec.InitConfiguration.SkipPhases = restored.Spec.Template.Spec.InitConfiguration.SkipPhases
+		dst.Spec.Template.Spec.InitConfiguration.Timeouts = restored.Spec.Template.Spec.InitConfiguration.Timeouts
+		dst.Spec.Template.Spec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.Template.Spec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.Template.Spec.JoinConfiguration != nil {
 		if dst.Spec.Template.Spec.JoinConfiguration == nil {
@@ -161,6 +174,15 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.JoinConfiguration.Patches = restored.Spec.Template.Spec.JoinConfiguration.Patches
 		dst.Spec.Template.Spec.JoinConfiguration.SkipPhases = restored.Spec.Template.Spec.JoinConfiguration.SkipPhases
+		dst.Spec.Template.Spec.JoinConfiguration.Timeouts = restored.Spec.Template.Spec.JoinConfiguration.Timeouts
+		dst.Spec.Template.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.Template.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.Template.Spec.ClusterConfiguration != nil {
+		if dst.Spec.Template.Spec.ClusterConfiguration == nil {
+			dst.Spec.Template.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.Template.Spec.ClusterConfiguration.Proxy = restored.Spec.Template.Spec.ClusterConfiguration.Proxy
+		dst.Spec.Template.Spec.ClusterConfiguration.DNS.Disabled = restored.Spec.Template.Spec.ClusterConfiguration.DNS.Disabled
 	}
 
 	return nil
@@ -55,6 +55,8 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.InitConfiguration.Patches = restored.Spec.InitConfiguration.Patches
 		dst.Spec.InitConfiguration.SkipPhases = restored.Spec.InitConfiguration.SkipPhases
+		dst.Spec.InitConfiguration.Timeouts = restored.Spec.InitConfiguration.Timeouts
+		dst.Spec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.JoinConfiguration != nil {
 		if dst.Spec.JoinConfiguration == nil {
@@ -62,6 +64,15 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.JoinConfiguration.Patches = restored.Spec.JoinConfiguration.Patches
 		dst.Spec.JoinConfiguration.SkipPhases = restored.Spec.JoinConfiguration.SkipPhases
+		dst.Spec.JoinConfiguration.Timeouts = restored.Spec.JoinConfiguration.Timeouts
+		dst.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.ClusterConfiguration != nil {
+		if dst.Spec.ClusterConfiguration == nil {
+			dst.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.ClusterConfiguration.Proxy = restored.Spec.ClusterConfiguration.Proxy
+		dst.Spec.ClusterConfiguration.DNS.Disabled = restored.Spec.ClusterConfiguration.DNS.Disabled
 	}
 
 	return nil
@@ -120,6 +131,8 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.InitConfiguration.Patches = restored.Spec.Template.Spec.InitConfiguration.Patches
 		dst.Spec.Template.Spec.InitConfiguration.SkipPhases = restored.Spec.Template.Spec.InitConfiguration.SkipPhases
+		dst.Spec.Template.Spec.InitConfiguration.Timeouts = restored.Spec.Template.Spec.InitConfiguration.Timeouts
+		dst.Spec.Template.Spec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.Template.Spec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.Template.Spec.JoinConfiguration != nil {
 		if dst.Spec.Template.Spec.JoinConfiguration == nil {
@@ -127,6 +140,15 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.JoinConfiguration.Patches = restored.Spec.Template.Spec.JoinConfiguration.Patches
 		dst.Spec.Template.Spec.JoinConfiguration.SkipPhases = restored.Spec.Template.Spec.JoinConfiguration.SkipPhases
+		dst.Spec.Template.Spec.JoinConfiguration.Timeouts = restored.Spec.Template.Spec.JoinConfiguration.Timeouts
+		dst.Spec.Template.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.Template.Spec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.Template.Spec.ClusterConfiguration != nil {
+		if dst.Spec.Template.Spec.ClusterConfiguration == nil {
+			dst.Spec.Template.Spec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.Template.Spec.ClusterConfiguration.Proxy = restored.Spec.Template.Spec.ClusterConfiguration.Proxy
+		dst.Spec.Template.Spec.ClusterConfiguration.DNS.Disabled = restored.Spec.Template.Spec.ClusterConfiguration.DNS.Disabled
 	}
 
 	return nil
@@ -162,14 +184,31 @@ func Convert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec(in *bootstr
 
 func Convert_v1beta1_InitConfiguration_To_v1alpha4_InitConfiguration(in *bootstrapv1.InitConfiguration, out *InitConfiguration, s apiconversion.Scope) error {
 	// InitConfiguration.Patches does not exist in kubeadm v1alpha4 API.
+	// InitConfiguration.Timeouts does not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_InitConfiguration_To_v1alpha4_InitConfiguration(in, out, s)
 }
 
 func Convert_v1beta1_JoinConfiguration_To_v1alpha4_JoinConfiguration(in *bootstrapv1.JoinConfiguration, out *JoinConfiguration, s apiconversion.Scope) error {
 	// InitConfiguration.Patches does not exist in kubeadm v1alpha4 API.
+	// JoinConfiguration.Timeouts does not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_JoinConfiguration_To_v1alpha4_JoinConfiguration(in, out, s)
 }
 
+func Convert_v1beta1_ClusterConfiguration_To_v1alpha4_ClusterConfiguration(in *bootstrapv1.ClusterConfiguration, out *ClusterConfiguration, s apiconversion.Scope) error {
+	// ClusterConfiguration.Proxy does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_ClusterConfiguration_To_v1alpha4_ClusterConfiguration(in, out, s)
+}
+
+func Convert_v1beta1_DNS_To_v1alpha4_DNS(in *bootstrapv1.DNS, out *DNS, s apiconversion.Scope) error {
+	// DNS.Disabled does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_DNS_To_v1alpha4_DNS(in, out, s)
+}
+
+func Convert_v1beta1_NodeRegistrationOptions_To_v1alpha4_NodeRegistrationOptions(in *bootstrapv1.NodeRegistrationOptions, out *NodeRegistrationOptions, s apiconversion.Scope) error {
+	// NodeRegistrationOptions.ImagePullSerial does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_NodeRegistrationOptions_To_v1alpha4_NodeRegistrationOptions(in, out, s)
+}
+
 func Convert_v1beta1_File_To_v1alpha4_File(in *bootstrapv1.File, out *File, s apiconversion.Scope) error {
 	// File.Append does not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_File_To_v1alpha4_File(in, out, s)
@@ -64,6 +64,11 @@ type InitConfiguration struct {
 	// "kubeadm init". The minimum kubernetes version needed to support Patches is v1.22
 	// +optional
 	Patches *Patches `json:"patches,omitempty"`
+
+	// Timeouts holds various timeouts that apply to kubeadm commands.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -118,6 +123,11 @@ type ClusterConfiguration struct {
 	// +optional
 	DNS DNS `json:"dns,omitempty"`
 
+	// Proxy defines the options for the proxy add-on installed in the cluster.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Proxy Proxy `json:"proxy,omitempty"`
+
 	// CertificatesDir specifies where to store or look for all required certificates.
 	// NB: if not provided, this will default to `/etc/kubernetes/pki`
 	// +optional
@@ -169,6 +179,19 @@ type APIServer struct {
 type DNS struct {
 	// ImageMeta allows to customize the image used for the DNS component
 	ImageMeta `json:",inline"`
+
+	// Disabled specifies whether to disable this addon in the cluster.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// Proxy defines the proxy addon that should be used in the cluster.
+type Proxy struct {
+	// Disabled specifies whether to disable this addon in the cluster.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
 }
 
 // ImageMeta allows to customize the image used for components that are not
@@ -241,6 +264,12 @@ type NodeRegistrationOptions struct {
 	// IgnorePreflightErrors provides a slice of pre-flight errors to be ignored when the current node is registered.
 	// +optional
 	IgnorePreflightErrors []string `json:"ignorePreflightErrors,omitempty"`
+
+	// ImagePullSerial specifies if image pulling performed by kubeadm must be done serially or in parallel.
+	// Defaults to true in kubeadm.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	ImagePullSerial *bool `json:"imagePullSerial,omitempty"`
 }
 
 // Networking contains elements describing cluster's networking configuration.
@@ -382,6 +411,46 @@ type JoinConfiguration struct {
 	// "kubeadm join". The minimum kubernetes version needed to support Patches is v1.22
 	// +optional
 	Patches *Patches `json:"patches,omitempty"`
+
+	// Timeouts holds various timeouts that apply to kubeadm commands.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
+}
+
+// Timeouts holds various timeouts that apply to kubeadm commands.
+type Timeouts struct {
+	// ControlPlaneComponentHealthCheck is the amount of time to wait for a control plane
+	// component, such as the API server, to be healthy during "kubeadm init" and "kubeadm join".
+	// If not set, APIServer.TimeoutForControlPlane from the ClusterConfiguration is used for "kubeadm init".
+	// +optional
+	ControlPlaneComponentHealthCheck *metav1.Duration `json:"controlPlaneComponentHealthCheck,omitempty"`
+
+	// KubeletHealthCheck is the amount of time to wait for a healthy kubelet
+	// during "kubeadm init" and "kubeadm join".
+	// +optional
+	KubeletHealthCheck *metav1.Duration `json:"kubeletHealthCheck,omitempty"`
+
+	// KubernetesAPICall is the amount of time to wait for the kubeadm client to complete a request
+	// to the API server. This applies to all types of methods (GET, POST, etc).
+	// +optional
+	KubernetesAPICall *metav1.Duration `json:"kubernetesAPICall,omitempty"`
+
+	// EtcdAPICall is the amount of time to wait for the kubeadm etcd client to complete a request
+	// to the etcd cluster.
+	// +optional
+	EtcdAPICall *metav1.Duration `json:"etcdAPICall,omitempty"`
+
+	// TLSBootstrap is the amount of time to wait for the kubelet to complete TLS bootstrap
+	// for a joining node.
+	// +optional
+	TLSBootstrap *metav1.Duration `json:"tlsBootstrap,omitempty"`
+
+	// Discovery is the amount of time to wait for kubeadm to validate the API server identity
+	// for a joining node.
+	// If not set, Discovery.Timeout from the JoinConfiguration is used.
+	// +optional
+	Discovery *metav1.Duration `json:"discovery,omitempty"`
 }
 
 // JoinControlPlane contains elements describing an additional control plane instance to be deployed on the joining node.
@@ -400,7 +400,17 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 			},
 		}
 	}
-	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.InitConfiguration, parsedVersion)
+	// kubeadm v1beta4 API replaces APIServer.TimeoutForControlPlane with InitConfiguration.Timeouts.ControlPlaneComponentHealthCheck,
+	// so the former is used as a default for the latter.
+	initConfiguration := scope.Config.Spec.InitConfiguration.DeepCopy()
+	if scope.Config.Spec.ClusterConfiguration != nil && scope.Config.Spec.ClusterConfiguration.APIServer.TimeoutForControlPlane != nil &&
+		(initConfiguration.Timeouts == nil || initConfiguration.Timeouts.ControlPlaneComponentHealthCheck == nil) {
+		if initConfiguration.Timeouts == nil {
+			initConfiguration.Timeouts = &bootstrapv1.Timeouts{}
+		}
+		initConfiguration.Timeouts.ControlPlaneComponentHealthCheck = scope.Config.Spec.ClusterConfiguration.APIServer.TimeoutForControlPlane.DeepCopy()
+	}
+	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(initConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal init configuration")
 		return ctrl.Result{}, err
@@ -65,6 +65,8 @@ func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 		kubeadmNodeRegistrationOptionsFuzzer,
 		kubeadmInitConfigurationFuzzer,
 		kubeadmJoinConfigurationFuzzer,
+		kubeadmClusterConfigurationFuzzer,
+		kubeadmDNSFuzzer,
 	}
 }
 
@@ -88,6 +90,10 @@ func kubeadmNodeRegistrationOptionsFuzzer(obj *bootstrapv1.NodeRegistrationOptio
 	// NodeRegistrationOptions.IgnorePreflightErrors does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
 	// v1alpha4 --> v1beta1 -> v1alpha4 round trip errors.
 	obj.IgnorePreflightErrors = nil
+
+	// NodeRegistrationOptions.ImagePullSerial does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
+	obj.ImagePullSerial = nil
 }
 
 func kubeadmInitConfigurationFuzzer(obj *bootstrapv1.InitConfiguration, c fuzz.Continue) {
@@ -100,6 +106,10 @@ func kubeadmInitConfigurationFuzzer(obj *bootstrapv1.InitConfiguration, c fuzz.C
 	// InitConfiguration.SkipPhases does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
 	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
 	obj.SkipPhases = nil
+
+	// InitConfiguration.Timeouts does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
 }
 
 func kubeadmJoinConfigurationFuzzer(obj *bootstrapv1.JoinConfiguration, c fuzz.Continue) {
@@ -112,4 +122,24 @@ func kubeadmJoinConfigurationFuzzer(obj *bootstrapv1.JoinConfiguration, c fuzz.C
 	// JoinConfiguration.SkipPhases does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
 	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
 	obj.SkipPhases = nil
+
+	// JoinConfiguration.Timeouts does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
+}
+
+func kubeadmClusterConfigurationFuzzer(obj *bootstrapv1.ClusterConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ClusterConfiguration.Proxy does not exist in kubeadm v1beta1 API, so setting it to an empty struct in order to avoid
+	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
+	obj.Proxy = bootstrapv1.Proxy{}
+}
+
+func kubeadmDNSFuzzer(obj *bootstrapv1.DNS, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// DNS.Disabled does not exist in kubeadm v1beta1 API, so setting it to false in order to avoid
+	// v1beta1 --> upstream v1beta1 -> v1beta1 round trip errors.
+	obj.Disabled = false
 }
@@ -85,10 +85,27 @@ func Convert_upstreamv1beta2_ClusterConfiguration_To_v1beta1_ClusterConfiguratio
 
 func Convert_v1beta1_InitConfiguration_To_upstreamv1beta2_InitConfiguration(in *bootstrapv1.InitConfiguration, out *InitConfiguration, s apimachineryconversion.Scope) error {
 	// InitConfiguration.Patches does not exist in kubeadm v1beta2 API
+	// InitConfiguration.Timeouts does not exist in kubeadm v1beta2 API
 	return autoConvert_v1beta1_InitConfiguration_To_upstreamv1beta2_InitConfiguration(in, out, s)
 }
 
 func Convert_v1beta1_JoinConfiguration_To_upstreamv1beta2_JoinConfiguration(in *bootstrapv1.JoinConfiguration, out *JoinConfiguration, s apimachineryconversion.Scope) error {
 	// JoinConfiguration.Patches does not exist in kubeadm v1beta2 API
+	// JoinConfiguration.Timeouts does not exist in kubeadm v1beta2 API
 	return autoConvert_v1beta1_JoinConfiguration_To_upstreamv1beta2_JoinConfiguration(in, out, s)
 }
+
+func Convert_v1beta1_ClusterConfiguration_To_upstreamv1beta2_ClusterConfiguration(in *bootstrapv1.ClusterConfiguration, out *ClusterConfiguration, s apimachineryconversion.Scope) error {
+	// ClusterConfiguration.Proxy does not exist in kubeadm v1beta2 API
+	return autoConvert_v1beta1_ClusterConfiguration_To_upstreamv1beta2_ClusterConfiguration(in, out, s)
+}
+
+func Convert_v1beta1_DNS_To_upstreamv1beta2_DNS(in *bootstrapv1.DNS, out *DNS, s apimachineryconversion.Scope) error {
+	// DNS.Disabled does not exist in kubeadm v1beta2 API
+	return autoConvert_v1beta1_DNS_To_upstreamv1beta2_DNS(in, out, s)
+}
+
+func Convert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta2_NodeRegistrationOptions(in *bootstrapv1.NodeRegistrationOptions, out *NodeRegistrationOptions, s apimachineryconversion.Scope) error {
+	// NodeRegistrationOptions.ImagePullSerial does not exist in kubeadm v1beta2 API
+	return autoConvert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta2_NodeRegistrationOptions(in, out, s)
+}
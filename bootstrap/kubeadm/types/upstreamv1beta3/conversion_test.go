@@ -67,6 +67,11 @@ func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 		initConfigurationFuzzer,
 		joinConfigurationFuzzer,
 		joinControlPlanesFuzzer,
+		kubeadmClusterConfigurationFuzzer,
+		kubeadmDNSFuzzer,
+		kubeadmNodeRegistrationOptionsFuzzer,
+		kubeadmInitConfigurationFuzzer,
+		kubeadmJoinConfigurationFuzzer,
 	}
 }
 
@@ -100,3 +105,43 @@ func joinConfigurationFuzzer(obj *JoinConfiguration, c fuzz.Continue) {
 	// JoinConfiguration.SkipPhases does not exists in v1alpha4, so setting it to empty string in order to avoid v1beta3 --> v1alpha4 --> v1beta3 round trip errors.
 	obj.SkipPhases = nil
 }
+
+func kubeadmClusterConfigurationFuzzer(obj *bootstrapv1.ClusterConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ClusterConfiguration.Proxy does not exist in kubeadm v1beta3 API, so setting it to an empty struct in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.Proxy = bootstrapv1.Proxy{}
+}
+
+func kubeadmDNSFuzzer(obj *bootstrapv1.DNS, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// DNS.Disabled does not exist in kubeadm v1beta3 API, so setting it to false in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.Disabled = false
+}
+
+func kubeadmNodeRegistrationOptionsFuzzer(obj *bootstrapv1.NodeRegistrationOptions, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// NodeRegistrationOptions.ImagePullSerial does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.ImagePullSerial = nil
+}
+
+func kubeadmInitConfigurationFuzzer(obj *bootstrapv1.InitConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// InitConfiguration.Timeouts does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
+}
+
+func kubeadmJoinConfigurationFuzzer(obj *bootstrapv1.JoinConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// JoinConfiguration.Timeouts does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
+}
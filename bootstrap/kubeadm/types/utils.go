@@ -30,32 +30,37 @@ import (
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 )
 
 var (
 	v1beta1KubeadmVersion = semver.MustParse("1.13.0")
 	v1beta2KubeadmVersion = semver.MustParse("1.15.0")
 	v1beta3KubeadmVersion = semver.MustParse("1.22.0")
+	v1beta4KubeadmVersion = semver.MustParse("1.31.0")
 
 	clusterConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.ClusterConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.ClusterConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.ClusterConfiguration{},
 	}
 
 	clusterStatusVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
-		// ClusterStatus has been removed in v1beta3, so we don't need an entry for v1beta3
+		// ClusterStatus has been removed in v1beta3, so we don't need an entry for v1beta3 and newer versions
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterStatus{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.ClusterStatus{},
 	}
 
 	initConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.InitConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.InitConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.InitConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.InitConfiguration{},
 	}
 
 	joinConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.JoinConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.JoinConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.JoinConfiguration{},
 		upstreamv1beta1.GroupVersion: &upstreamv1beta1.JoinConfiguration{},
@@ -73,14 +78,17 @@ func KubeVersionToKubeadmAPIGroupVersion(version semver.Version) (schema.GroupVe
 	case version.LT(v1beta3KubeadmVersion):
 		// NOTE: All the Kubernetes version >= v1.15 and < v1.22 should use the kubeadm API version v1beta2
 		return upstreamv1beta2.GroupVersion, nil
+	case version.LT(v1beta4KubeadmVersion):
+		// NOTE: All the Kubernetes version >= v1.22 and < v1.31 should use the kubeadm API version v1beta3
+		return upstreamv1beta3.GroupVersion, nil
 	default:
-		// NOTE: All the Kubernetes version greater or equal to v1.22 should use the kubeadm API version v1beta3.
-		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta3,
-		// no matter if kubeadm API versions newer than v1beta3 could be introduced by those release.
-		// This is acceptable because v1beta3 will be supported by kubeadm until the deprecation cycle completes
+		// NOTE: All the Kubernetes version greater or equal to v1.31 should use the kubeadm API version v1beta4.
+		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta4,
+		// no matter if kubeadm API versions newer than v1beta4 could be introduced by those release.
+		// This is acceptable because v1beta4 will be supported by kubeadm until the deprecation cycle completes
 		// (9 months minimum after the deprecation date, not yet announced now); this gives Cluster API project time to
 		// introduce support for newer releases without blocking users to deploy newer version of Kubernetes.
-		return upstreamv1beta3.GroupVersion, nil
+		return upstreamv1beta4.GroupVersion, nil
 	}
 }
 
@@ -18,16 +18,19 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 )
 
 func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
@@ -96,12 +99,20 @@ func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
 			want:    upstreamv1beta3.GroupVersion,
 			wantErr: false,
 		},
+		{
+			name: "pass with minimum kubernetes version for kubeadm API v1beta4",
+			args: args{
+				version: semver.MustParse("1.31.0"),
+			},
+			want:    upstreamv1beta4.GroupVersion,
+			wantErr: false,
+		},
 		{
 			name: "pass with future kubernetes version",
 			args: args{
 				version: semver.MustParse("99.99.99"),
 			},
-			want:    upstreamv1beta3.GroupVersion,
+			want:    upstreamv1beta4.GroupVersion,
 			wantErr: false,
 		},
 	}
@@ -179,6 +190,37 @@ func TestMarshalClusterConfigurationForVersion(t *testing.T) {
 				"scheduler: {}\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+							ExtraArgs: map[string]string{
+								"v":              "5",
+								"audit-log-path": "/var/log/audit.log",
+							},
+						},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiServer:\n" +
+				"  extraArgs:\n" +
+				"  - name: audit-log-path\n" +
+				"    value: /var/log/audit.log\n" +
+				"  - name: v\n" +
+				"    value: \"5\"\n" +
+				"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"controllerManager: {}\n" +
+				"dns: {}\n" +
+				"etcd: {}\n" +
+				"kind: ClusterConfiguration\n" +
+				"networking: {}\n" +
+				"proxy: {}\n" +
+				"scheduler: {}\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -314,6 +356,31 @@ func TestMarshalInitConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.InitConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						IgnorePreflightErrors: []string{"some-preflight-check"},
+						KubeletExtraArgs: map[string]string{
+							"v": "5",
+						},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"kind: InitConfiguration\n" +
+				"localAPIEndpoint: {}\n" +
+				"nodeRegistration:\n" +
+				"  ignorePreflightErrors:\n" +
+				"  - some-preflight-check\n" +
+				"  kubeletExtraArgs:\n" +
+				"  - name: v\n" +
+				"    value: \"5\"\n" +
+				"  taints: null\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -390,6 +457,30 @@ func TestMarshalJoinConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.JoinConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						IgnorePreflightErrors: []string{"some-preflight-check"},
+					},
+					Discovery: bootstrapv1.Discovery{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"discovery: {}\n" +
+				"kind: JoinConfiguration\n" +
+				"nodeRegistration:\n" +
+				"  ignorePreflightErrors:\n" +
+				"  - some-preflight-check\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  discovery: 10m0s\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -461,6 +552,33 @@ func TestUnmarshalClusterConfiguration(t *testing.T) {
 			want:    &bootstrapv1.ClusterConfiguration{},
 			wantErr: false,
 		},
+		{
+			name: "Parses a v1beta4 kubeadm configuration",
+			args: args{
+				yaml: "apiServer:\n" +
+					"  extraArgs:\n" +
+					"  - name: v\n" +
+					"    value: \"5\"\n" +
+					"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+					"controllerManager: {}\n" +
+					"dns: {}\n" +
+					"etcd: {}\n" +
+					"kind: ClusterConfiguration\n" +
+					"networking: {}\n" +
+					"proxy: {}\n" +
+					"scheduler: {}\n",
+			},
+			want: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{
+					ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+						ExtraArgs: map[string]string{
+							"v": "5",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -92,7 +92,10 @@ func (p *Provider) GetProviderType() ProviderType {
 		CoreProviderType,
 		BootstrapProviderType,
 		InfrastructureProviderType,
-		ControlPlaneProviderType:
+		ControlPlaneProviderType,
+		IPAMProviderType,
+		RuntimeExtensionProviderType,
+		AddonProviderType:
 		return t
 	default:
 		return ProviderTypeUnknown
@@ -118,6 +121,18 @@ const (
 	// control-plane capabilities.
 	ControlPlaneProviderType = ProviderType("ControlPlaneProvider")
 
+	// IPAMProviderType is the type associated with codebases that provide
+	// IPAM capabilities.
+	IPAMProviderType = ProviderType("IPAMProvider")
+
+	// RuntimeExtensionProviderType is the type associated with codebases that provide
+	// runtime extensions.
+	RuntimeExtensionProviderType = ProviderType("RuntimeExtensionProvider")
+
+	// AddonProviderType is the type associated with codebases that provide
+	// add-on capabilities, e.g. installing Helm charts in workload clusters.
+	AddonProviderType = ProviderType("AddonProvider")
+
 	// ProviderTypeUnknown is used when the type is unknown.
 	ProviderTypeUnknown = ProviderType("")
 )
@@ -133,8 +148,14 @@ func (p ProviderType) Order() int {
 		return 2
 	case InfrastructureProviderType:
 		return 3
-	default:
+	case IPAMProviderType:
 		return 4
+	case RuntimeExtensionProviderType:
+		return 5
+	case AddonProviderType:
+		return 6
+	default:
+		return 7
 	}
 }
 
@@ -80,6 +80,30 @@ func Test_Provider_ManifestLabel(t *testing.T) {
 			},
 			want: "infrastructure-xx",
 		},
+		{
+			name: "ipam providers gets prefix",
+			fields: fields{
+				provider:     "xx",
+				providerType: IPAMProviderType,
+			},
+			want: "ipam-xx",
+		},
+		{
+			name: "runtime extension providers gets prefix",
+			fields: fields{
+				provider:     "xx",
+				providerType: RuntimeExtensionProviderType,
+			},
+			want: "runtime-extension-xx",
+		},
+		{
+			name: "addon providers gets prefix",
+			fields: fields{
+				provider:     "xx",
+				providerType: AddonProviderType,
+			},
+			want: "addon-xx",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -75,6 +75,10 @@ type Client interface {
 	// DescribeCluster returns the object tree representing the status of a Cluster API cluster.
 	DescribeCluster(options DescribeClusterOptions) (*tree.ObjectTree, error)
 
+	// DescribeClusterClass returns an object tree for each ClusterClass, representing the Clusters using it
+	// and whether they are in sync with it.
+	DescribeClusterClass(options DescribeClusterClassOptions) ([]*tree.ObjectTree, error)
+
 	// AlphaClient is an Interface for alpha features in clusterctl
 	AlphaClient
 }
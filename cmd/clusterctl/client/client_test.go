@@ -136,6 +136,10 @@ func (f fakeClient) DescribeCluster(options DescribeClusterOptions) (*tree.Objec
 	return f.internalClient.DescribeCluster(options)
 }
 
+func (f fakeClient) DescribeClusterClass(options DescribeClusterClassOptions) ([]*tree.ObjectTree, error) {
+	return f.internalClient.DescribeClusterClass(options)
+}
+
 func (f fakeClient) RolloutPause(options RolloutOptions) error {
 	return f.internalClient.RolloutPause(options)
 }
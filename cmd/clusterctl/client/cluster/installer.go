@@ -133,7 +133,7 @@ func (i *providerInstaller) waitManagerDeploymentsReady(opts InstallOptions) err
 	for _, components := range i.installQueue {
 		for _, obj := range components.Objs() {
 			if util.IsDeploymentWithManager(obj) {
-				if err := i.waitDeploymentReady(obj, opts.WaitProviderTimeout); err != nil {
+				if err := waitDeploymentReady(i.proxy, obj, opts.WaitProviderTimeout); err != nil {
 					return err
 				}
 			}
@@ -142,9 +142,10 @@ func (i *providerInstaller) waitManagerDeploymentsReady(opts InstallOptions) err
 	return nil
 }
 
-func (i *providerInstaller) waitDeploymentReady(deployment unstructured.Unstructured, timeout time.Duration) error {
+// waitDeploymentReady waits till a deployment is available.
+func waitDeploymentReady(proxy Proxy, deployment unstructured.Unstructured, timeout time.Duration) error {
 	return wait.Poll(100*time.Millisecond, timeout, func() (bool, error) {
-		c, err := i.proxy.NewClient()
+		c, err := proxy.NewClient()
 		if err != nil {
 			return false, err
 		}
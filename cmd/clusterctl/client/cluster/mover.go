@@ -19,6 +19,7 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -27,6 +28,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -34,6 +36,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
 	"sigs.k8s.io/cluster-api/util/annotations"
@@ -45,11 +48,56 @@ import (
 // ObjectMover defines methods for moving Cluster API objects to another management cluster.
 type ObjectMover interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
-	Move(namespace string, toCluster Client, dryRun bool) error
+	// If the selector is not empty, only the Clusters matching the selector and the objects they depend on are moved.
+	// If the checkpoint file is not empty, the progress of the move is recorded into it, so the move can be resumed or rolled back in case of failures.
+	Move(namespace string, selector ClusterSelector, toCluster Client, dryRun bool, checkpointFile string) error
+	// ResumeMove completes a failed move using the progress recorded in a checkpoint file.
+	ResumeMove(toCluster Client, checkpointFile string) error
+	// RollbackMove reverts a failed move using the progress recorded in a checkpoint file, restoring the source management cluster.
+	RollbackMove(toCluster Client, checkpointFile string) error
 	// Backup saves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Backup(namespace string, directory string) error
+	// BackupArchive saves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a versioned archive file,
+	// optionally encrypted.
+	BackupArchive(namespace string, file string, encryption ArchiveEncryption) error
 	// Restore restores all the Cluster API objects existing in a configured directory to a target management cluster.
 	Restore(toCluster Client, directory string) error
+	// RestoreArchive restores all the Cluster API objects existing in an archive file to a target management cluster,
+	// after checking the archive is compatible with the providers installed in the target management cluster.
+	RestoreArchive(toCluster Client, file string, encryption ArchiveEncryption) error
+	// Snapshot writes all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to w, using the same format
+	// of BackupArchive. Unlike BackupArchive, Clusters are not paused and their provisioning is not required to be completed,
+	// so it can be used for taking periodic backups of a management cluster.
+	Snapshot(namespace string, w io.Writer, encryption ArchiveEncryption) error
+}
+
+// ClusterSelector defines the subset of the Clusters to be moved; an empty ClusterSelector selects all the Clusters.
+// A Cluster is selected if it matches the label selector or if it is included in the list of Clusters.
+type ClusterSelector struct {
+	// LabelSelector selects the Clusters with matching labels.
+	LabelSelector labels.Selector
+
+	// Clusters selects Clusters by namespace and name.
+	Clusters []types.NamespacedName
+}
+
+// IsEmpty returns true if the ClusterSelector selects all the Clusters.
+func (s ClusterSelector) IsEmpty() bool {
+	return (s.LabelSelector == nil || s.LabelSelector.Empty()) && len(s.Clusters) == 0
+}
+
+// matches returns true if the node of a Cluster is selected.
+func (s ClusterSelector) matches(cluster *node) bool {
+	for _, key := range s.Clusters {
+		if cluster.identity.Namespace == key.Namespace && cluster.identity.Name == key.Name {
+			return true
+		}
+	}
+	if s.LabelSelector == nil || s.LabelSelector.Empty() {
+		return false
+	}
+	clusterLabels, _ := cluster.additionalInfo[clusterLabelsKey].(map[string]string)
+	return s.LabelSelector.Matches(labels.Set(clusterLabels))
 }
 
 // objectMover implements the ObjectMover interface.
@@ -57,12 +105,13 @@ type objectMover struct {
 	fromProxy             Proxy
 	fromProviderInventory InventoryClient
 	dryRun                bool
+	checkpointFile        string
 }
 
 // ensure objectMover implements the ObjectMover interface.
 var _ ObjectMover = &objectMover{}
 
-func (o *objectMover) Move(namespace string, toCluster Client, dryRun bool) error {
+func (o *objectMover) Move(namespace string, selector ClusterSelector, toCluster Client, dryRun bool, checkpointFile string) error {
 	log := logf.Log
 	log.Info("Performing move...")
 	o.dryRun = dryRun
@@ -72,6 +121,14 @@ func (o *objectMover) Move(namespace string, toCluster Client, dryRun bool) erro
 		log.Info("********************************************************")
 	}
 
+	// checks there is no checkpoint left by a previous move, which should be resumed or rolled back first.
+	if !o.dryRun && checkpointFile != "" {
+		if _, err := os.Stat(checkpointFile); err == nil {
+			return errors.Errorf("a checkpoint of a previous move exists in %s, the previous move should be resumed or rolled back first", checkpointFile)
+		}
+	}
+	o.checkpointFile = checkpointFile
+
 	// checks that all the required providers in place in the target cluster.
 	if !o.dryRun {
 		if err := o.checkTargetProviders(toCluster.ProviderInventory()); err != nil {
@@ -79,7 +136,7 @@ func (o *objectMover) Move(namespace string, toCluster Client, dryRun bool) erro
 		}
 	}
 
-	objectGraph, err := o.getObjectGraph(namespace)
+	objectGraph, err := o.getObjectGraph(namespace, selector)
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
@@ -97,34 +154,38 @@ func (o *objectMover) Backup(namespace string, directory string) error {
 	log := logf.Log
 	log.Info("Performing backup...")
 
-	objectGraph, err := o.getObjectGraph(namespace)
+	objectGraph, err := o.getObjectGraph(namespace, ClusterSelector{})
 	if err != nil {
 		return errors.Wrap(err, "failed to get object graph")
 	}
 
-	return o.backup(objectGraph, directory)
+	return o.backup(objectGraph, directoryBackupWriter(directory))
 }
 
 func (o *objectMover) Restore(toCluster Client, directory string) error {
 	log := logf.Log
 	log.Info("Performing restore...")
 
+	objs, err := o.filesToObjs(directory)
+	if err != nil {
+		return errors.Wrap(err, "failed to process object files")
+	}
+
+	return o.restoreObjs(toCluster, objs)
+}
+
+// restoreObjs restores the given objects, read from a backup, to the target management cluster.
+func (o *objectMover) restoreObjs(toCluster Client, objs []unstructured.Unstructured) error {
 	// Build an empty object graph used for the restore sequence not tied to a specific namespace
 	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
 
 	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
-	err := objectGraph.getDiscoveryTypes()
-	if err != nil {
+	if err := objectGraph.getDiscoveryTypes(); err != nil {
 		return errors.Wrap(err, "failed to retrieve discovery types")
 	}
 
-	objs, err := o.filesToObjs(directory)
-	if err != nil {
-		return errors.Wrap(err, "failed to process object files")
-	}
-
 	for i := range objs {
-		if err = objectGraph.addRestoredObj(&objs[i]); err != nil {
+		if err := objectGraph.addRestoredObj(&objs[i]); err != nil {
 			return err
 		}
 	}
@@ -176,7 +237,7 @@ func (o *objectMover) filesToObjs(dir string) ([]unstructured.Unstructured, erro
 	return objs, nil
 }
 
-func (o *objectMover) getObjectGraph(namespace string) (*objectGraph, error) {
+func (o *objectMover) getObjectGraph(namespace string, selector ClusterSelector) (*objectGraph, error) {
 	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
 
 	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
@@ -192,6 +253,11 @@ func (o *objectMover) getObjectGraph(namespace string) (*objectGraph, error) {
 		return nil, errors.Wrap(err, "failed to discover the object graph")
 	}
 
+	// Restricts the object graph to the selected Clusters and to the objects they depend on, if required.
+	if err := objectGraph.filterClusters(selector); err != nil {
+		return nil, errors.Wrap(err, "failed to select the Clusters to move")
+	}
+
 	// Checks if Cluster API has already completed the provisioning of the infrastructure for the objects involved in the move/backup operation.
 	// This is required because if the infrastructure is provisioned, then we can reasonably assume that the objects we are moving/backing up are
 	// not currently waiting for long-running reconciliation loops, and so we can safely rely on the pause field on the Cluster object
@@ -316,6 +382,23 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 	clusterClasses := graph.getClusterClasses()
 	log.Info("Moving Cluster API objects", "ClusterClasses", len(clusterClasses))
 
+	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
+	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
+	// - All the Clusters should be moved first (group 1, processed in parallel)
+	// - All the MachineDeployments should be moved second (group 1, processed in parallel)
+	// - then all the MachineSets, then all the Machines, etc.
+	moveSequence := getMoveSequence(graph)
+
+	// Record the move sequence in the checkpoint before changing anything, so the move can be resumed or rolled back in case of failures.
+	checkpoint := &moveCheckpoint{
+		sequence:       moveSequence,
+		clusters:       clusters,
+		clusterClasses: clusterClasses,
+	}
+	if err := o.saveCheckpoint(checkpoint); err != nil {
+		return err
+	}
+
 	// Sets the pause field on the Cluster object in the source management cluster, so the controllers stop reconciling it.
 	log.V(1).Info("Pausing the source cluster")
 	if err := setClusterPause(o.fromProxy, clusters, true, o.dryRun); err != nil {
@@ -333,19 +416,31 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 		return err
 	}
 
-	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
-	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
-	// - All the Clusters should be moved first (group 1, processed in parallel)
-	// - All the MachineDeployments should be moved second (group 1, processed in parallel)
-	// - then all the MachineSets, then all the Machines, etc.
-	moveSequence := getMoveSequence(graph)
+	return o.completeMove(checkpoint, toProxy)
+}
+
+// completeMove creates the objects in the move sequence not yet created in the target management cluster, then it deletes
+// the objects from the source management cluster and resumes the target management cluster; progress is recorded in the checkpoint.
+func (o *objectMover) completeMove(checkpoint *moveCheckpoint, toProxy Proxy) error {
+	log := logf.Log
+	moveSequence := checkpoint.sequence
 
 	// Create all objects group by group, ensuring all the ownerReferences are re-created.
 	log.Info("Creating objects in the target cluster")
-	for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
+	for groupIndex := checkpoint.createdGroups; groupIndex < len(moveSequence.groups); groupIndex++ {
 		if err := o.createGroup(moveSequence.getGroup(groupIndex), toProxy); err != nil {
 			return err
 		}
+
+		checkpoint.createdGroups = groupIndex + 1
+		if err := o.saveCheckpoint(checkpoint); err != nil {
+			return err
+		}
+	}
+
+	checkpoint.deletingSource = true
+	if err := o.saveCheckpoint(checkpoint); err != nil {
+		return err
 	}
 
 	// Delete all objects group by group in reverse order.
@@ -356,18 +451,35 @@ func (o *objectMover) move(graph *objectGraph, toProxy Proxy) error {
 		}
 	}
 
+	// Resume the shared cluster classes in the source management cluster, given that they are not deleted and still
+	// in use by Clusters not moved.
+	sharedClusterClasses := []*node{}
+	for _, clusterClass := range checkpoint.clusterClasses {
+		if clusterClass.shared {
+			sharedClusterClasses = append(sharedClusterClasses, clusterClass)
+		}
+	}
+	log.V(1).Info("Resuming the shared source cluster classes")
+	if err := setClusterClassPause(o.fromProxy, sharedClusterClasses, false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming shared cluster classes")
+	}
+
 	// Resume the cluster classes in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluter classes")
-	if err := setClusterClassPause(toProxy, clusterClasses, false, o.dryRun); err != nil {
+	if err := setClusterClassPause(toProxy, checkpoint.clusterClasses, false, o.dryRun); err != nil {
 		return errors.Wrap(err, "error resuming cluster classes")
 	}
 
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(toProxy, clusters, false, o.dryRun)
+	if err := setClusterPause(toProxy, checkpoint.clusters, false, o.dryRun); err != nil {
+		return err
+	}
+
+	return o.removeCheckpoint()
 }
 
-func (o *objectMover) backup(graph *objectGraph, directory string) error {
+func (o *objectMover) backup(graph *objectGraph, w backupWriter) error {
 	log := logf.Log
 
 	clusters := graph.getClusters()
@@ -387,6 +499,26 @@ func (o *objectMover) backup(graph *objectGraph, directory string) error {
 		return errors.Wrap(err, "error pausing cluser classes")
 	}
 
+	// Save all the objects, following the ownerReference chain.
+	if err := o.backupObjects(graph, w); err != nil {
+		return err
+	}
+
+	// Resume the cluster classes in the target management cluster, so the controllers start reconciling it.
+	log.V(1).Info("Resuming the target cluter classes")
+	if err := setClusterClassPause(o.fromProxy, clusterClasses, false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming cluster classes")
+	}
+
+	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
+	log.V(1).Info("Resuming the source cluster")
+	return setClusterPause(o.fromProxy, clusters, false, o.dryRun)
+}
+
+// backupObjects saves all the objects in the graph, without pausing Clusters and ClusterClasses.
+func (o *objectMover) backupObjects(graph *objectGraph, w backupWriter) error {
+	log := logf.Log
+
 	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
 	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
 	// - All the Clusters should be moved first (group 1, processed in parallel)
@@ -395,22 +527,13 @@ func (o *objectMover) backup(graph *objectGraph, directory string) error {
 	moveSequence := getMoveSequence(graph)
 
 	// Save all objects group by group
-	log.Info(fmt.Sprintf("Saving files to %s", directory))
+	log.Info(fmt.Sprintf("Saving files to %s", w))
 	for groupIndex := 0; groupIndex < len(moveSequence.groups); groupIndex++ {
-		if err := o.backupGroup(moveSequence.getGroup(groupIndex), directory); err != nil {
+		if err := o.backupGroup(moveSequence.getGroup(groupIndex), w); err != nil {
 			return err
 		}
 	}
-
-	// Resume the cluster classes in the target management cluster, so the controllers start reconciling it.
-	log.V(1).Info("Resuming the target cluter classes")
-	if err := setClusterClassPause(o.fromProxy, clusterClasses, false, o.dryRun); err != nil {
-		return errors.Wrap(err, "error resuming cluster classes")
-	}
-
-	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
-	log.V(1).Info("Resuming the source cluster")
-	return setClusterPause(o.fromProxy, clusters, false, o.dryRun)
+	return nil
 }
 
 func (o *objectMover) restore(graph *objectGraph, toProxy Proxy) error {
@@ -482,6 +605,15 @@ func (s *moveSequence) getGroup(i int) moveGroup {
 	return s.groups[i]
 }
 
+// getNodes returns all the nodes in the move sequence.
+func (s *moveSequence) getNodes() []*node {
+	nodes := []*node{}
+	for _, group := range s.groups {
+		nodes = append(nodes, group...)
+	}
+	return nodes
+}
+
 // Define the move sequence by processing the ownerReference chain.
 func getMoveSequence(graph *objectGraph) *moveSequence {
 	moveSequence := &moveSequence{
@@ -656,13 +788,18 @@ func pauseClusterClass(proxy Proxy, n *node, pause bool) error {
 
 // ensureNamespaces ensures all the expected target namespaces are in place before creating objects.
 func (o *objectMover) ensureNamespaces(graph *objectGraph, toProxy Proxy) error {
+	return o.ensureNamespacesForNodes(graph.getMoveNodes(), toProxy)
+}
+
+// ensureNamespacesForNodes ensures the target namespaces for a list of nodes are in place before creating objects.
+func (o *objectMover) ensureNamespacesForNodes(nodes []*node, toProxy Proxy) error {
 	if o.dryRun {
 		return nil
 	}
 
 	ensureNamespaceBackoff := newWriteBackoff()
 	namespaces := sets.NewString()
-	for _, node := range graph.getMoveNodes() {
+	for _, node := range nodes {
 		// ignore global/cluster-wide objects
 		if node.isGlobal {
 			continue
@@ -772,7 +909,7 @@ func (o *objectMover) createGroup(group moveGroup, toProxy Proxy) error {
 	return nil
 }
 
-func (o *objectMover) backupGroup(group moveGroup, directory string) error {
+func (o *objectMover) backupGroup(group moveGroup, w backupWriter) error {
 	backupTargetObjectBackoff := newWriteBackoff()
 	errList := []error{}
 
@@ -780,7 +917,7 @@ func (o *objectMover) backupGroup(group moveGroup, directory string) error {
 		// Backs-up the Kubernetes object corresponding to the nodeToBackup.
 		// Nb. The operation is wrapped in a retry loop to make move more resilient to unexpected conditions.
 		err := retryWithExponentialBackoff(backupTargetObjectBackoff, func() error {
-			return o.backupTargetObject(nodeToBackup, directory)
+			return o.backupTargetObject(nodeToBackup, w)
 		})
 		if err != nil {
 			errList = append(errList, err)
@@ -874,9 +1011,24 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
 
-		// If the object already exists, try to update it if it is node a global object / something belonging to a global object hierarchy (e.g. a secrets owned by a global identity object).
-		if nodeToCreate.isGlobal || nodeToCreate.isGlobalHierarchy {
-			log.V(5).Info("Object already exists, skipping upgrade because it is global/it is owned by a global object", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+		// If the object already exists, try to update it if it is node a global object / something belonging to a global object hierarchy (e.g. a secrets owned by a global identity object)
+		// or an object shared with other Clusters (e.g. a ClusterClass already moved together with other Clusters).
+		if nodeToCreate.isGlobal || nodeToCreate.isGlobalHierarchy || nodeToCreate.shared {
+			if nodeToCreate.shared {
+				log.V(5).Info("Object already exists, skipping upgrade because it is shared with other Clusters", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+			} else {
+				log.V(5).Info("Object already exists, skipping upgrade because it is global/it is owned by a global object", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
+			}
+
+			// Retrieve the UID, so the OwnerReferences to this object can be rebuilt.
+			existingTargetObj := &unstructured.Unstructured{}
+			existingTargetObj.SetAPIVersion(obj.GetAPIVersion())
+			existingTargetObj.SetKind(obj.GetKind())
+			if err := cTo.Get(ctx, objKey, existingTargetObj); err != nil {
+				return errors.Wrapf(err, "error reading resource for %q %s/%s",
+					existingTargetObj.GroupVersionKind(), existingTargetObj.GetNamespace(), existingTargetObj.GetName())
+			}
+			obj.SetUID(existingTargetObj.GetUID())
 		} else {
 			// Nb. This should not happen, but it is supported to make move more resilient to unexpected interrupt/restarts of the move process.
 			log.V(5).Info("Object already exists, updating", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
@@ -909,7 +1061,7 @@ func (o *objectMover) createTargetObject(nodeToCreate *node, toProxy Proxy) erro
 	return nil
 }
 
-func (o *objectMover) backupTargetObject(nodeToCreate *node, directory string) error {
+func (o *objectMover) backupTargetObject(nodeToCreate *node, w backupWriter) error {
 	log := logf.Log
 	log.V(1).Info("Saving", nodeToCreate.identity.Kind, nodeToCreate.identity.Name, "Namespace", nodeToCreate.identity.Namespace)
 
@@ -932,17 +1084,29 @@ func (o *objectMover) backupTargetObject(nodeToCreate *node, directory string) e
 			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
-	// Get JSON for object and write it into the configured directory
+	// Get JSON for object and write it into the configured backup
 	byObj, err := obj.MarshalJSON()
 	if err != nil {
 		return err
 	}
 
-	filenameObj := nodeToCreate.getFilename()
-	objectFile := filepath.Join(directory, filenameObj)
+	return w.write(nodeToCreate.getFilename(), byObj)
+}
+
+// backupWriter stores the objects saved by backup.
+type backupWriter interface {
+	write(filename string, data []byte) error
+	String() string
+}
+
+// directoryBackupWriter stores each object saved by backup in a separated file into a directory.
+type directoryBackupWriter string
+
+func (d directoryBackupWriter) write(filename string, data []byte) error {
+	objectFile := filepath.Join(string(d), filename)
 
 	// If file exists, then remove it to be written again
-	_, err = os.Stat(objectFile)
+	_, err := os.Stat(objectFile)
 	if err != nil && !os.IsNotExist(err) {
 		return err
 	}
@@ -952,12 +1116,11 @@ func (o *objectMover) backupTargetObject(nodeToCreate *node, directory string) e
 		}
 	}
 
-	err = os.WriteFile(objectFile, byObj, 0600)
-	if err != nil {
-		return err
-	}
+	return os.WriteFile(objectFile, data, 0600)
+}
 
-	return nil
+func (d directoryBackupWriter) String() string {
+	return string(d)
 }
 
 func (o *objectMover) restoreTargetObject(nodeToCreate *node, toProxy Proxy) error {
@@ -1022,6 +1185,12 @@ func (o *objectMover) buildOwnerChain(obj *unstructured.Unstructured, n *node) {
 	if len(n.owners) > 0 {
 		ownerRefs := []metav1.OwnerReference{}
 		for ownerNode := range n.owners {
+			// Owners not moved to the target management cluster, e.g. virtual nodes or objects excluded from the graph
+			// when moving only some Clusters, don't have a newUID; OwnerReferences to them can't be rebuilt.
+			if ownerNode.newUID == "" {
+				continue
+			}
+
 			ownerRef := metav1.OwnerReference{
 				APIVersion: ownerNode.identity.APIVersion,
 				Kind:       ownerNode.identity.Kind,
@@ -1074,6 +1243,11 @@ func (o *objectMover) deleteSourceObject(nodeToDelete *node) error {
 		return nil
 	}
 
+	// Don't delete nodes shared with Clusters not being moved (e.g. a ClusterClass used by other Clusters).
+	if nodeToDelete.shared {
+		return nil
+	}
+
 	log := logf.Log
 	log.V(1).Info("Deleting", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
 
@@ -1081,40 +1255,48 @@ func (o *objectMover) deleteSourceObject(nodeToDelete *node) error {
 		return nil
 	}
 
-	cFrom, err := o.fromProxy.NewClient()
+	return forceDeleteObject(o.fromProxy, nodeToDelete)
+}
+
+// forceDeleteObject deletes the Kubernetes object corresponding to the node, taking care of removing all the finalizers so
+// the objects gets immediately deleted.
+func forceDeleteObject(proxy Proxy, nodeToDelete *node) error {
+	log := logf.Log
+
+	c, err := proxy.NewClient()
 	if err != nil {
 		return err
 	}
 
-	// Get the source object
-	sourceObj := &unstructured.Unstructured{}
-	sourceObj.SetAPIVersion(nodeToDelete.identity.APIVersion)
-	sourceObj.SetKind(nodeToDelete.identity.Kind)
-	sourceObjKey := client.ObjectKey{
+	// Get the object
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(nodeToDelete.identity.APIVersion)
+	obj.SetKind(nodeToDelete.identity.Kind)
+	objKey := client.ObjectKey{
 		Namespace: nodeToDelete.identity.Namespace,
 		Name:      nodeToDelete.identity.Name,
 	}
 
-	if err := cFrom.Get(ctx, sourceObjKey, sourceObj); err != nil {
+	if err := c.Get(ctx, objKey, obj); err != nil {
 		if apierrors.IsNotFound(err) {
 			// If the object is already deleted, move on.
 			log.V(5).Info("Object already deleted, skipping delete for", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
 			return nil
 		}
 		return errors.Wrapf(err, "error reading %q %s/%s",
-			sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
-	if len(sourceObj.GetFinalizers()) > 0 {
-		if err := cFrom.Patch(ctx, sourceObj, removeFinalizersPatch); err != nil {
+	if len(obj.GetFinalizers()) > 0 {
+		if err := c.Patch(ctx, obj, removeFinalizersPatch); err != nil {
 			return errors.Wrapf(err, "error removing finalizers from %q %s/%s",
-				sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+				obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 		}
 	}
 
-	if err := cFrom.Delete(ctx, sourceObj); err != nil {
+	if err := c.Delete(ctx, obj); err != nil {
 		return errors.Wrapf(err, "error deleting %q %s/%s",
-			sourceObj.GroupVersionKind(), sourceObj.GetNamespace(), sourceObj.GetName())
+			obj.GroupVersionKind(), obj.GetNamespace(), obj.GetName())
 	}
 
 	return nil
@@ -1137,9 +1319,14 @@ func (o *objectMover) checkTargetProviders(toInventory InventoryClient) error {
 		return errors.Wrapf(err, "failed to get provider list from the target cluster")
 	}
 
+	return checkProviderVersions(fromProviders.Items, toProviders.Items)
+}
+
+// checkProviderVersions checks that all the source providers exists in the list of target providers as well (with a version >= of the source version).
+func checkProviderVersions(fromProviders, toProviders []clusterctlv1.Provider) error {
 	// Checks all the providers installed in the source cluster
 	errList := []error{}
-	for _, sourceProvider := range fromProviders.Items {
+	for _, sourceProvider := range fromProviders {
 		sourceVersion, err := version.ParseSemantic(sourceProvider.Version)
 		if err != nil {
 			return errors.Wrapf(err, "unable to parse version %q for the %s provider in the source cluster", sourceProvider.Version, sourceProvider.InstanceName())
@@ -1147,7 +1334,7 @@ func (o *objectMover) checkTargetProviders(toInventory InventoryClient) error {
 
 		// Check corresponding providers in the target cluster and gets the latest version installed.
 		var maxTargetVersion *version.Version
-		for _, targetProvider := range toProviders.Items {
+		for _, targetProvider := range toProviders {
 			// Skips other providers.
 			if !sourceProvider.SameAs(targetProvider) {
 				continue
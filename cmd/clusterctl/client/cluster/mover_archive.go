@@ -0,0 +1,555 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+const (
+	// backupArchiveFormatVersion is the version of the layout of backup archives.
+	backupArchiveFormatVersion = 1
+
+	backupArchiveManifestFile = "manifest.yaml"
+	backupArchiveObjectsDir   = "objects"
+
+	// maxBackupArchiveEntrySize limits the size of a single file read from a backup archive.
+	maxBackupArchiveEntrySize = 64 * 1024 * 1024
+
+	// ageIntro is the first line of every age encrypted file, used to detect encrypted backup archives.
+	ageIntro = "age-encryption.org/v1\n"
+)
+
+// ArchiveEncryption defines how a backup archive is encrypted or decrypted.
+// Encryption uses the age format (https://age-encryption.org), so archives can be also decrypted using the age CLI.
+type ArchiveEncryption struct {
+	// Recipients are the age public keys (age1...) the archive is encrypted for.
+	Recipients []string
+
+	// IdentityFile is the path of a file containing the age private keys (AGE-SECRET-KEY-1...) used to decrypt the archive.
+	IdentityFile string
+
+	// PassphraseFile is the path of a file containing the passphrase the key for encrypting or decrypting the archive is derived from.
+	// A passphrase can't be combined with Recipients.
+	PassphraseFile string
+
+	// Passphrase is the passphrase the key for encrypting or decrypting the archive is derived from.
+	// It can't be combined with PassphraseFile.
+	Passphrase string
+}
+
+// IsEmpty returns true if the archive is not encrypted.
+func (e ArchiveEncryption) IsEmpty() bool {
+	return len(e.Recipients) == 0 && e.IdentityFile == "" && e.PassphraseFile == "" && e.Passphrase == ""
+}
+
+// passphrase returns the passphrase, if any, reading it from the PassphraseFile if necessary.
+func (e ArchiveEncryption) passphrase() (string, error) {
+	if e.PassphraseFile != "" && e.Passphrase != "" {
+		return "", errors.New("a passphrase and a passphrase file can't be used together")
+	}
+	if e.PassphraseFile != "" {
+		return readPassphrase(e.PassphraseFile)
+	}
+	return e.Passphrase, nil
+}
+
+func (e ArchiveEncryption) recipients() ([]age.Recipient, error) {
+	if e.IdentityFile != "" {
+		return nil, errors.New("an identity file can't be used for encrypting a backup archive, use its public key as a recipient")
+	}
+	passphrase, err := e.passphrase()
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != "" && len(e.Recipients) > 0 {
+		return nil, errors.New("a passphrase can't be combined with recipients")
+	}
+
+	recipients := []age.Recipient{}
+	if passphrase != "" {
+		r, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, r)
+	}
+	for _, s := range e.Recipients {
+		r, err := age.ParseX25519Recipient(s)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, r)
+	}
+	return recipients, nil
+}
+
+func (e ArchiveEncryption) identities() ([]age.Identity, error) {
+	if len(e.Recipients) > 0 {
+		return nil, errors.New("recipients can't be used for decrypting a backup archive, use the corresponding identity file")
+	}
+
+	passphrase, err := e.passphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	identities := []age.Identity{}
+	if passphrase != "" {
+		id, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, id)
+	}
+	if e.IdentityFile != "" {
+		data, err := os.ReadFile(e.IdentityFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read identity file %s", e.IdentityFile)
+		}
+		ids, err := age.ParseIdentities(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse identity file %s", e.IdentityFile)
+		}
+		identities = append(identities, ids...)
+	}
+	return identities, nil
+}
+
+func readPassphrase(file string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read passphrase file %s", file)
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", errors.Errorf("passphrase file %s is empty", file)
+	}
+	return passphrase, nil
+}
+
+// backupManifest describes the content of a backup archive.
+type backupManifest struct {
+	// FormatVersion is the version of the layout of the archive.
+	FormatVersion int `json:"formatVersion"`
+
+	// Contract is the Cluster API contract of the management cluster the backup was taken from.
+	Contract string `json:"contract"`
+
+	// CreationTimestamp is the time the backup was taken.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+
+	// Namespace is the namespace the backup was taken from; empty means all the namespaces.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Providers are the providers installed in the management cluster the backup was taken from.
+	Providers []backupManifestProvider `json:"providers"`
+
+	// Objects are the objects included in the archive, in the order they should be restored.
+	Objects []backupManifestObject `json:"objects"`
+}
+
+// backupManifestProvider describes a provider installed in the management cluster the backup was taken from.
+type backupManifestProvider struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Version   string `json:"version"`
+	Namespace string `json:"namespace"`
+}
+
+// backupManifestObject describes an object included in the archive.
+type backupManifestObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	File       string `json:"file"`
+}
+
+// getProviders returns the providers in the manifest as inventory items.
+func (m *backupManifest) getProviders() []clusterctlv1.Provider {
+	providers := make([]clusterctlv1.Provider, 0, len(m.Providers))
+	for _, p := range m.Providers {
+		providers = append(providers, clusterctlv1.Provider{
+			ObjectMeta:   metav1.ObjectMeta{Namespace: p.Namespace},
+			ProviderName: p.Name,
+			Type:         p.Type,
+			Version:      p.Version,
+		})
+	}
+	return providers
+}
+
+func (o *objectMover) BackupArchive(namespace string, file string, encryption ArchiveEncryption) error {
+	log := logf.Log
+	log.Info("Performing backup...")
+
+	recipients, err := encryption.recipients()
+	if err != nil {
+		return err
+	}
+
+	objectGraph, err := o.getObjectGraph(namespace, ClusterSelector{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get object graph")
+	}
+
+	manifest, err := o.getBackupManifest(namespace, objectGraph)
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) == 0 {
+		log.Info("WARNING: the backup archive is not encrypted; it contains credentials like kubeconfig and certificate authority private keys in clear text")
+	}
+
+	return o.backupArchive(objectGraph, manifest, file, recipients)
+}
+
+// getBackupManifest returns the manifest describing the backup of the objects in the graph.
+func (o *objectMover) getBackupManifest(namespace string, graph *objectGraph) (*backupManifest, error) {
+	providers, err := o.fromProviderInventory.List()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get provider list from the source cluster")
+	}
+
+	manifest := &backupManifest{
+		FormatVersion:     backupArchiveFormatVersion,
+		Contract:          clusterv1.GroupVersion.Version,
+		CreationTimestamp: metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+		Namespace:         namespace,
+		Providers:         []backupManifestProvider{},
+		Objects:           []backupManifestObject{},
+	}
+	for _, p := range providers.Items {
+		manifest.Providers = append(manifest.Providers, backupManifestProvider{
+			Name:      p.ProviderName,
+			Type:      p.Type,
+			Version:   p.Version,
+			Namespace: p.Namespace,
+		})
+	}
+	for _, n := range getMoveSequence(graph).getNodes() {
+		manifest.Objects = append(manifest.Objects, backupManifestObject{
+			APIVersion: n.identity.APIVersion,
+			Kind:       n.identity.Kind,
+			Namespace:  n.identity.Namespace,
+			Name:       n.identity.Name,
+			File:       path.Join(backupArchiveObjectsDir, n.getFilename()),
+		})
+	}
+	return manifest, nil
+}
+
+// backupArchive saves the objects in the graph into a gzipped tar archive, optionally encrypted for the given recipients.
+// The archive is written to a temporary file first, so an existing archive is replaced only if the backup succeeds.
+func (o *objectMover) backupArchive(graph *objectGraph, manifest *backupManifest, file string, recipients []age.Recipient) (reterr error) {
+	if dir := filepath.Dir(file); dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return errors.Wrapf(err, "failed to create the directory for the backup archive %s", file)
+		}
+	}
+
+	tmpFile := file + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create the backup archive %s", file)
+	}
+	defer func() {
+		if reterr != nil {
+			_ = f.Close()
+			_ = os.Remove(tmpFile)
+		}
+	}()
+
+	if err := writeBackupArchive(f, file, manifest, recipients, func(w backupWriter) error {
+		return o.backup(graph, w)
+	}); err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the backup archive")
+	}
+	return errors.Wrapf(os.Rename(tmpFile, file), "failed to write the backup archive %s", file)
+}
+
+// writeBackupArchive writes to out a gzipped tar archive, optionally encrypted for the given recipients, containing
+// the manifest and the objects saved by backupObjects.
+func writeBackupArchive(out io.Writer, name string, manifest *backupManifest, recipients []age.Recipient, backupObjects func(backupWriter) error) error {
+	var encrypter io.WriteCloser
+	if len(recipients) > 0 {
+		var err error
+		if encrypter, err = age.Encrypt(out, recipients...); err != nil {
+			return errors.Wrap(err, "failed to encrypt the backup archive")
+		}
+		out = encrypter
+	}
+	gw := gzip.NewWriter(out)
+	w := &archiveBackupWriter{
+		name:    name,
+		tw:      tar.NewWriter(gw),
+		modTime: manifest.CreationTimestamp.Time,
+	}
+
+	manifestData, err := yaml.Marshal(manifest)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the backup manifest")
+	}
+	if err := w.writeEntry(backupArchiveManifestFile, manifestData); err != nil {
+		return err
+	}
+
+	if err := backupObjects(w); err != nil {
+		return err
+	}
+
+	if err := w.tw.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the backup archive")
+	}
+	if err := gw.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the backup archive")
+	}
+	if encrypter != nil {
+		if err := encrypter.Close(); err != nil {
+			return errors.Wrap(err, "failed to write the backup archive")
+		}
+	}
+	return nil
+}
+
+// archiveBackupWriter stores the objects saved by backup into the objects folder of a tar archive.
+type archiveBackupWriter struct {
+	name    string
+	tw      *tar.Writer
+	modTime time.Time
+}
+
+func (w *archiveBackupWriter) write(filename string, data []byte) error {
+	return w.writeEntry(path.Join(backupArchiveObjectsDir, filename), data)
+}
+
+func (w *archiveBackupWriter) writeEntry(name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: w.modTime,
+	}
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return errors.Wrapf(err, "failed to write %s to the backup archive", name)
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write %s to the backup archive", name)
+	}
+	return nil
+}
+
+func (w *archiveBackupWriter) String() string {
+	return w.name
+}
+
+func (o *objectMover) Snapshot(namespace string, w io.Writer, encryption ArchiveEncryption) error {
+	log := logf.Log
+	log.Info("Performing snapshot...")
+
+	recipients, err := encryption.recipients()
+	if err != nil {
+		return err
+	}
+
+	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
+
+	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
+	if err := objectGraph.getDiscoveryTypes(); err != nil {
+		return errors.Wrap(err, "failed to retrieve discovery types")
+	}
+
+	// Discovery the object graph for the selected types.
+	if err := objectGraph.Discovery(namespace); err != nil {
+		return errors.Wrap(err, "failed to discover the object graph")
+	}
+
+	// Check whether nodes are not included in GVK considered for backup.
+	objectGraph.checkVirtualNode()
+
+	manifest, err := o.getBackupManifest(namespace, objectGraph)
+	if err != nil {
+		return err
+	}
+
+	return writeBackupArchive(w, "snapshot", manifest, recipients, func(bw backupWriter) error {
+		return o.backupObjects(objectGraph, bw)
+	})
+}
+
+func (o *objectMover) RestoreArchive(toCluster Client, file string, encryption ArchiveEncryption) error {
+	log := logf.Log
+	log.Info("Performing restore...")
+
+	identities, err := encryption.identities()
+	if err != nil {
+		return err
+	}
+
+	manifest, objs, err := readBackupArchive(file, identities)
+	if err != nil {
+		return err
+	}
+
+	if err := o.checkBackupManifest(manifest); err != nil {
+		return errors.Wrapf(err, "backup archive %s can't be restored into the target cluster", file)
+	}
+
+	return o.restoreObjs(toCluster, objs)
+}
+
+// checkBackupManifest checks that the backup archive can be restored into the management cluster of the mover;
+// the Cluster API contract must match, and all the providers in the backup must be installed with a version >= of the version in the backup.
+func (o *objectMover) checkBackupManifest(manifest *backupManifest) error {
+	if manifest.Contract != clusterv1.GroupVersion.Version {
+		return errors.Errorf("the backup was taken from a management cluster with Cluster API contract %s, while the current contract is %s", manifest.Contract, clusterv1.GroupVersion.Version)
+	}
+
+	if err := o.fromProviderInventory.CheckCAPIContract(); err != nil {
+		return err
+	}
+
+	toProviders, err := o.fromProviderInventory.List()
+	if err != nil {
+		return errors.Wrap(err, "failed to get provider list from the target cluster")
+	}
+	return checkProviderVersions(manifest.getProviders(), toProviders.Items)
+}
+
+// readBackupArchive reads the manifest and the objects from a backup archive, decrypting it if necessary.
+func readBackupArchive(file string, identities []age.Identity) (*backupManifest, []unstructured.Unstructured, error) {
+	log := logf.Log
+	log.Info(fmt.Sprintf("Restoring objects from %s", file))
+
+	f, err := os.Open(filepath.Clean(file))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to open the backup archive %s", file)
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(f)
+	var in io.Reader = br
+	intro, err := br.Peek(len(ageIntro))
+	if err != nil && err != io.EOF {
+		return nil, nil, errors.Wrapf(err, "failed to read the backup archive %s", file)
+	}
+	if bytes.HasPrefix(intro, []byte(ageIntro)) {
+		if len(identities) == 0 {
+			return nil, nil, errors.Errorf("the backup archive %s is encrypted, an identity file or a passphrase file is required", file)
+		}
+		if in, err = age.Decrypt(br, identities...); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to decrypt the backup archive %s", file)
+		}
+	}
+
+	gr, err := gzip.NewReader(in)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read the backup archive %s", file)
+	}
+
+	var manifest *backupManifest
+	files := map[string][]byte{}
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read the backup archive %s", file)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if hdr.Size > maxBackupArchiveEntrySize {
+			return nil, nil, errors.Errorf("file %s in the backup archive is too large", hdr.Name)
+		}
+
+		data := &bytes.Buffer{}
+		if _, err := io.CopyN(data, tr, hdr.Size); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to read %s from the backup archive %s", hdr.Name, file)
+		}
+
+		if hdr.Name == backupArchiveManifestFile {
+			manifest = &backupManifest{}
+			if err := yaml.UnmarshalStrict(data.Bytes(), manifest); err != nil {
+				return nil, nil, errors.Wrapf(err, "failed to parse the manifest of the backup archive %s", file)
+			}
+			continue
+		}
+		files[hdr.Name] = data.Bytes()
+	}
+
+	if manifest == nil {
+		return nil, nil, errors.Errorf("the backup archive %s does not contain a manifest", file)
+	}
+	if manifest.FormatVersion != backupArchiveFormatVersion {
+		return nil, nil, errors.Errorf("unsupported backup archive format version %d", manifest.FormatVersion)
+	}
+
+	// Read the objects in the order defined in the manifest, and check the archive does not contain unexpected files.
+	errList := []error{}
+	rawYAMLs := make([][]byte, 0, len(manifest.Objects))
+	for _, obj := range manifest.Objects {
+		data, ok := files[obj.File]
+		if !ok {
+			errList = append(errList, errors.Errorf("file %s for %s %s/%s is missing", obj.File, obj.Kind, obj.Namespace, obj.Name))
+			continue
+		}
+		rawYAMLs = append(rawYAMLs, data)
+		delete(files, obj.File)
+	}
+	for name := range files {
+		errList = append(errList, errors.Errorf("file %s is not included in the manifest", name))
+	}
+	if len(errList) > 0 {
+		return nil, nil, errors.Wrapf(kerrors.NewAggregate(errList), "invalid backup archive %s", file)
+	}
+
+	objs, err := utilyaml.ToUnstructured(utilyaml.JoinYaml(rawYAMLs...))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to parse the objects in the backup archive %s", file)
+	}
+	return manifest, objs, nil
+}
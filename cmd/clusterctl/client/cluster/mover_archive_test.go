@@ -0,0 +1,380 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_objectMover_backupArchive(t *testing.T) {
+	x25519Identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherIdentity, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	scryptRecipient, err := age.NewScryptRecipient("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scryptRecipient.SetWorkFactor(10)
+	scryptIdentity, err := age.NewScryptIdentity("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encryptionTests := []struct {
+		name          string
+		recipients    []age.Recipient
+		identities    []age.Identity
+		badIdentities []age.Identity
+	}{
+		{
+			name: "not encrypted",
+		},
+		{
+			name:          "encrypted with a public key",
+			recipients:    []age.Recipient{x25519Identity.Recipient()},
+			identities:    []age.Identity{x25519Identity},
+			badIdentities: []age.Identity{otherIdentity},
+		},
+		{
+			name:          "encrypted with a passphrase",
+			recipients:    []age.Recipient{scryptRecipient},
+			identities:    []age.Identity{scryptIdentity},
+			badIdentities: []age.Identity{x25519Identity},
+		},
+	}
+
+	for _, et := range encryptionTests {
+		for _, tt := range backupRestoreTests {
+			t.Run(et.name+"/"+tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+				graph := getObjectGraphWithObjs(tt.fields.objs)
+
+				// Get all the types to be considered for discovery
+				g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+
+				// trigger discovery the content of the source cluster
+				g.Expect(graph.Discovery("")).To(Succeed())
+
+				mover := objectMover{
+					fromProxy:             graph.proxy,
+					fromProviderInventory: graph.providerInventory,
+				}
+
+				manifest, err := mover.getBackupManifest("", graph)
+				g.Expect(err).NotTo(HaveOccurred())
+
+				file := filepath.Join(t.TempDir(), "backup", "backup.tar.gz")
+				g.Expect(mover.backupArchive(graph, manifest, file, et.recipients)).To(Succeed())
+
+				// The archive replaces the temporary file and it is readable only by the owner.
+				info, err := os.Stat(file)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+				_, err = os.Stat(file + ".tmp")
+				g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+				data, err := os.ReadFile(file)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(bytes.HasPrefix(data, []byte(ageIntro))).To(Equal(len(et.recipients) > 0))
+
+				if len(et.recipients) > 0 {
+					_, _, err := readBackupArchive(file, nil)
+					g.Expect(err).To(HaveOccurred())
+					_, _, err = readBackupArchive(file, et.badIdentities)
+					g.Expect(err).To(HaveOccurred())
+				}
+
+				gotManifest, objs, err := readBackupArchive(file, et.identities)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(gotManifest.FormatVersion).To(Equal(backupArchiveFormatVersion))
+				g.Expect(gotManifest.Contract).To(Equal(clusterv1.GroupVersion.Version))
+				g.Expect(gotManifest.Providers).To(Equal(manifest.Providers))
+				g.Expect(gotManifest.Objects).To(Equal(manifest.Objects))
+
+				// All the objects in the graph are in the archive.
+				g.Expect(objs).To(HaveLen(len(tt.files)))
+				for _, obj := range objs {
+					n, ok := graph.uidToNode[obj.GetUID()]
+					g.Expect(ok).To(BeTrue())
+					g.Expect(tt.files).To(HaveKey(n.getFilename()))
+				}
+			})
+		}
+	}
+}
+
+func Test_readBackupArchive(t *testing.T) {
+	manifest := &backupManifest{
+		FormatVersion: backupArchiveFormatVersion,
+		Contract:      clusterv1.GroupVersion.Version,
+		Objects: []backupManifestObject{
+			{APIVersion: "v1", Kind: "Secret", Namespace: "ns1", Name: "foo-ca", File: "objects/Secret_ns1_foo-ca.yaml"},
+		},
+	}
+	secret := `{"apiVersion":"v1","kind":"Secret","metadata":{"name":"foo-ca","namespace":"ns1","uid":"/v1, Kind=Secret, ns1/foo-ca"}}`
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{
+			name: "valid archive",
+			files: map[string]string{
+				"manifest.yaml":                  toYAML(t, manifest),
+				"objects/Secret_ns1_foo-ca.yaml": secret,
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails if the manifest is missing",
+			files: map[string]string{
+				"objects/Secret_ns1_foo-ca.yaml": secret,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the format version is not supported",
+			files: map[string]string{
+				"manifest.yaml":                  toYAML(t, &backupManifest{FormatVersion: 2, Contract: clusterv1.GroupVersion.Version}),
+				"objects/Secret_ns1_foo-ca.yaml": secret,
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if an object in the manifest is missing",
+			files: map[string]string{
+				"manifest.yaml": toYAML(t, manifest),
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if an object is not in the manifest",
+			files: map[string]string{
+				"manifest.yaml":                  toYAML(t, manifest),
+				"objects/Secret_ns1_foo-ca.yaml": secret,
+				"objects/Secret_ns1_bar-ca.yaml": secret,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			buf := &bytes.Buffer{}
+			gw := gzip.NewWriter(buf)
+			tw := tar.NewWriter(gw)
+			for name, content := range tt.files {
+				g.Expect(tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content))})).To(Succeed())
+				_, err := tw.Write([]byte(content))
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(tw.Close()).To(Succeed())
+			g.Expect(gw.Close()).To(Succeed())
+
+			file := filepath.Join(t.TempDir(), "backup.tar.gz")
+			g.Expect(os.WriteFile(file, buf.Bytes(), 0600)).To(Succeed())
+
+			_, objs, err := readBackupArchive(file, nil)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(objs).To(HaveLen(1))
+			g.Expect(objs[0].GetName()).To(Equal("foo-ca"))
+		})
+	}
+}
+
+func Test_objectMover_checkBackupManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest *backupManifest
+		toProxy  *test.FakeProxy
+		wantErr  bool
+	}{
+		{
+			name: "all the providers in place",
+			manifest: &backupManifest{
+				Contract: clusterv1.GroupVersion.Version,
+				Providers: []backupManifestProvider{
+					{Name: "cluster-api", Type: string(clusterctlv1.CoreProviderType), Version: "v1.0.0", Namespace: "capi-system"},
+				},
+			},
+			toProxy: test.NewFakeProxy().WithFakeCAPISetup().
+				WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.1.0", "capi-system"),
+			wantErr: false,
+		},
+		{
+			name: "fails if the contract does not match",
+			manifest: &backupManifest{
+				Contract: "v1alpha4",
+				Providers: []backupManifestProvider{
+					{Name: "cluster-api", Type: string(clusterctlv1.CoreProviderType), Version: "v0.4.0", Namespace: "capi-system"},
+				},
+			},
+			toProxy: test.NewFakeProxy().WithFakeCAPISetup().
+				WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.1.0", "capi-system"),
+			wantErr: true,
+		},
+		{
+			name: "fails if a provider is missing",
+			manifest: &backupManifest{
+				Contract: clusterv1.GroupVersion.Version,
+				Providers: []backupManifestProvider{
+					{Name: "cluster-api", Type: string(clusterctlv1.CoreProviderType), Version: "v1.0.0", Namespace: "capi-system"},
+					{Name: "aws", Type: string(clusterctlv1.InfrastructureProviderType), Version: "v1.0.0", Namespace: "capa-system"},
+				},
+			},
+			toProxy: test.NewFakeProxy().WithFakeCAPISetup().
+				WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.1.0", "capi-system"),
+			wantErr: true,
+		},
+		{
+			name: "fails if a provider version is older than in the backup",
+			manifest: &backupManifest{
+				Contract: clusterv1.GroupVersion.Version,
+				Providers: []backupManifestProvider{
+					{Name: "cluster-api", Type: string(clusterctlv1.CoreProviderType), Version: "v1.2.0", Namespace: "capi-system"},
+				},
+			},
+			toProxy: test.NewFakeProxy().WithFakeCAPISetup().
+				WithProviderInventory("cluster-api", clusterctlv1.CoreProviderType, "v1.1.0", "capi-system"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			o := &objectMover{
+				fromProxy:             tt.toProxy,
+				fromProviderInventory: newInventoryClient(tt.toProxy, nil),
+			}
+			err := o.checkBackupManifest(tt.manifest)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+		})
+	}
+}
+
+func toYAML(t *testing.T, obj interface{}) string {
+	t.Helper()
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func Test_ArchiveEncryption_recipients(t *testing.T) {
+	id, err := age.GenerateX25519Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	passphraseFile := filepath.Join(t.TempDir(), "passphrase")
+	if err := os.WriteFile(passphraseFile, []byte("passphrase\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		encryption     ArchiveEncryption
+		wantRecipients int
+		wantErr        bool
+	}{
+		{
+			name:           "not encrypted",
+			encryption:     ArchiveEncryption{},
+			wantRecipients: 0,
+		},
+		{
+			name:           "public keys",
+			encryption:     ArchiveEncryption{Recipients: []string{id.Recipient().String(), id.Recipient().String()}},
+			wantRecipients: 2,
+		},
+		{
+			name:           "passphrase",
+			encryption:     ArchiveEncryption{Passphrase: "passphrase"},
+			wantRecipients: 1,
+		},
+		{
+			name:           "passphrase file",
+			encryption:     ArchiveEncryption{PassphraseFile: passphraseFile},
+			wantRecipients: 1,
+		},
+		{
+			name:       "fails with an invalid public key",
+			encryption: ArchiveEncryption{Recipients: []string{"age1invalid"}},
+			wantErr:    true,
+		},
+		{
+			name:       "fails if a passphrase is combined with public keys",
+			encryption: ArchiveEncryption{Recipients: []string{id.Recipient().String()}, Passphrase: "passphrase"},
+			wantErr:    true,
+		},
+		{
+			name:       "fails if both a passphrase and a passphrase file are set",
+			encryption: ArchiveEncryption{Passphrase: "passphrase", PassphraseFile: passphraseFile},
+			wantErr:    true,
+		},
+		{
+			name:       "fails if an identity file is set",
+			encryption: ArchiveEncryption{IdentityFile: "key.txt"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			recipients, err := tt.encryption.recipients()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(recipients).To(HaveLen(tt.wantRecipients))
+		})
+	}
+}
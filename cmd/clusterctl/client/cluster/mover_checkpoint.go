@@ -0,0 +1,386 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// moveCheckpoint records the progress of a move operation, so the move can be resumed or rolled back in case of failures.
+type moveCheckpoint struct {
+	// sequence is the move sequence.
+	sequence *moveSequence
+
+	// clusters and clusterClasses are the Clusters and the ClusterClasses paused during the move.
+	clusters       []*node
+	clusterClasses []*node
+
+	// createdGroups is the number of groups in the move sequence already created in the target management cluster.
+	createdGroups int
+
+	// deletingSource is set to true when the deletion of the objects from the source management cluster starts;
+	// from this point the move can only be resumed, not rolled back.
+	deletingSource bool
+}
+
+// moveCheckpointFile defines the format of the file where a moveCheckpoint is stored.
+type moveCheckpointFile struct {
+	Nodes          []moveCheckpointNode `json:"nodes"`
+	Groups         [][]types.UID        `json:"groups"`
+	Clusters       []types.UID          `json:"clusters,omitempty"`
+	ClusterClasses []types.UID          `json:"clusterClasses,omitempty"`
+	CreatedGroups  int                  `json:"createdGroups"`
+	DeletingSource bool                 `json:"deletingSource,omitempty"`
+}
+
+// moveCheckpointNode defines the information of a node stored in a checkpoint file.
+type moveCheckpointNode struct {
+	// Identity of the object in the source management cluster, including the original UID.
+	Identity corev1.ObjectReference `json:"identity"`
+
+	// Owners of the object, identified by their original UID.
+	Owners []moveCheckpointOwner `json:"owners,omitempty"`
+
+	// NewUID is the UID of the object in the target management cluster, if already created.
+	NewUID types.UID `json:"newUID,omitempty"`
+
+	IsGlobal          bool `json:"isGlobal,omitempty"`
+	IsGlobalHierarchy bool `json:"isGlobalHierarchy,omitempty"`
+	Shared            bool `json:"shared,omitempty"`
+}
+
+// moveCheckpointOwner defines an OwnerReference of a node stored in a checkpoint file.
+type moveCheckpointOwner struct {
+	UID                types.UID `json:"uid"`
+	Controller         *bool     `json:"controller,omitempty"`
+	BlockOwnerDeletion *bool     `json:"blockOwnerDeletion,omitempty"`
+}
+
+// toFile converts a moveCheckpoint into the format used for storing it in a file.
+func (c *moveCheckpoint) toFile() *moveCheckpointFile {
+	f := &moveCheckpointFile{
+		CreatedGroups:  c.createdGroups,
+		DeletingSource: c.deletingSource,
+	}
+
+	nodes := map[types.UID]*node{}
+	for _, group := range c.sequence.groups {
+		uids := []types.UID{}
+		for _, n := range group {
+			nodes[n.identity.UID] = n
+			uids = append(uids, n.identity.UID)
+		}
+		f.Groups = append(f.Groups, uids)
+	}
+	for _, n := range c.clusters {
+		nodes[n.identity.UID] = n
+		f.Clusters = append(f.Clusters, n.identity.UID)
+	}
+	for _, n := range c.clusterClasses {
+		nodes[n.identity.UID] = n
+		f.ClusterClasses = append(f.ClusterClasses, n.identity.UID)
+	}
+
+	for _, n := range nodes {
+		fn := moveCheckpointNode{
+			Identity:          n.identity,
+			NewUID:            n.newUID,
+			IsGlobal:          n.isGlobal,
+			IsGlobalHierarchy: n.isGlobalHierarchy,
+			Shared:            n.shared,
+		}
+		for owner, attributes := range n.owners {
+			// Owners not moved, e.g. virtual nodes or objects excluded from the graph when moving only some Clusters,
+			// are not stored; OwnerReferences to them are not rebuilt in the target management cluster anyway.
+			if _, ok := nodes[owner.identity.UID]; !ok {
+				continue
+			}
+			fn.Owners = append(fn.Owners, moveCheckpointOwner{
+				UID:                owner.identity.UID,
+				Controller:         attributes.Controller,
+				BlockOwnerDeletion: attributes.BlockOwnerDeletion,
+			})
+		}
+		f.Nodes = append(f.Nodes, fn)
+	}
+	return f
+}
+
+// toCheckpoint rebuilds a moveCheckpoint from the format used for storing it in a file.
+func (f *moveCheckpointFile) toCheckpoint() (*moveCheckpoint, error) {
+	nodes := map[types.UID]*node{}
+	for _, fn := range f.Nodes {
+		nodes[fn.Identity.UID] = &node{
+			identity:          fn.Identity,
+			owners:            make(map[*node]ownerReferenceAttributes),
+			softOwners:        make(map[*node]empty),
+			tenant:            make(map[*node]empty),
+			newUID:            fn.NewUID,
+			isGlobal:          fn.IsGlobal,
+			isGlobalHierarchy: fn.IsGlobalHierarchy,
+			shared:            fn.Shared,
+		}
+	}
+
+	getNode := func(uid types.UID) (*node, error) {
+		n, ok := nodes[uid]
+		if !ok {
+			return nil, errors.Errorf("object with UID %s not found", uid)
+		}
+		return n, nil
+	}
+
+	for _, fn := range f.Nodes {
+		n := nodes[fn.Identity.UID]
+		for _, owner := range fn.Owners {
+			ownerNode, err := getNode(owner.UID)
+			if err != nil {
+				return nil, err
+			}
+			n.addOwner(ownerNode, ownerReferenceAttributes{
+				Controller:         owner.Controller,
+				BlockOwnerDeletion: owner.BlockOwnerDeletion,
+			})
+		}
+	}
+
+	c := &moveCheckpoint{
+		sequence: &moveSequence{
+			groups:   []moveGroup{},
+			nodesMap: make(map[*node]empty),
+		},
+		createdGroups:  f.CreatedGroups,
+		deletingSource: f.DeletingSource,
+	}
+	for _, uids := range f.Groups {
+		group := moveGroup{}
+		for _, uid := range uids {
+			n, err := getNode(uid)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, n)
+		}
+		c.sequence.addGroup(group)
+	}
+	for _, uid := range f.Clusters {
+		n, err := getNode(uid)
+		if err != nil {
+			return nil, err
+		}
+		c.clusters = append(c.clusters, n)
+	}
+	for _, uid := range f.ClusterClasses {
+		n, err := getNode(uid)
+		if err != nil {
+			return nil, err
+		}
+		c.clusterClasses = append(c.clusterClasses, n)
+	}
+
+	if c.createdGroups < 0 || c.createdGroups > len(c.sequence.groups) {
+		return nil, errors.Errorf("invalid number of created groups %d", c.createdGroups)
+	}
+	return c, nil
+}
+
+// loadMoveCheckpoint reads a moveCheckpoint from a file.
+func loadMoveCheckpoint(path string) (*moveCheckpoint, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the move checkpoint from %s", path)
+	}
+
+	f := &moveCheckpointFile{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the move checkpoint from %s", path)
+	}
+
+	c, err := f.toCheckpoint()
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid move checkpoint in %s", path)
+	}
+	return c, nil
+}
+
+// saveCheckpoint writes the checkpoint into the checkpoint file, if defined.
+func (o *objectMover) saveCheckpoint(checkpoint *moveCheckpoint) error {
+	if o.dryRun || o.checkpointFile == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(checkpoint.toFile())
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the move checkpoint")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(o.checkpointFile), 0700); err != nil {
+		return errors.Wrapf(err, "failed to create the directory for the move checkpoint %s", o.checkpointFile)
+	}
+
+	// Writes a temporary file and then renames it, so the checkpoint file is never left partially written.
+	tmpFile := o.checkpointFile + ".tmp"
+	if err := os.WriteFile(tmpFile, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write the move checkpoint %s", o.checkpointFile)
+	}
+	if err := os.Rename(tmpFile, o.checkpointFile); err != nil {
+		return errors.Wrapf(err, "failed to write the move checkpoint %s", o.checkpointFile)
+	}
+	return nil
+}
+
+// removeCheckpoint removes the checkpoint file, if defined.
+func (o *objectMover) removeCheckpoint() error {
+	if o.dryRun || o.checkpointFile == "" {
+		return nil
+	}
+
+	if err := os.Remove(o.checkpointFile); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove the move checkpoint %s", o.checkpointFile)
+	}
+	return nil
+}
+
+func (o *objectMover) ResumeMove(toCluster Client, checkpointFile string) error {
+	log := logf.Log
+	log.Info("Resuming move...")
+
+	checkpoint, err := loadMoveCheckpoint(checkpointFile)
+	if err != nil {
+		return err
+	}
+	o.checkpointFile = checkpointFile
+
+	return o.resumeMove(checkpoint, toCluster.Proxy())
+}
+
+func (o *objectMover) RollbackMove(toCluster Client, checkpointFile string) error {
+	log := logf.Log
+	log.Info("Rolling back move...")
+
+	checkpoint, err := loadMoveCheckpoint(checkpointFile)
+	if err != nil {
+		return err
+	}
+	o.checkpointFile = checkpointFile
+
+	return o.rollbackMove(checkpoint, toCluster.Proxy())
+}
+
+// resumeMove completes a move operation starting from the progress recorded in a checkpoint.
+func (o *objectMover) resumeMove(checkpoint *moveCheckpoint, toProxy Proxy) error {
+	log := logf.Log
+
+	// If the deletion of the objects from the source management cluster is not yet started, ensures the source
+	// objects are paused and all the expected target namespaces are in place before creating the remaining objects.
+	if !checkpoint.deletingSource {
+		log.V(1).Info("Pausing the source cluster")
+		if err := setClusterPause(o.fromProxy, checkpoint.clusters, true, o.dryRun); err != nil {
+			return err
+		}
+
+		log.V(1).Info("Pausing the source cluster classes")
+		if err := setClusterClassPause(o.fromProxy, checkpoint.clusterClasses, true, o.dryRun); err != nil {
+			return errors.Wrap(err, "error pausing cluster classes")
+		}
+
+		log.V(1).Info("Creating target namespaces, if missing")
+		if err := o.ensureNamespacesForNodes(checkpoint.sequence.getNodes(), toProxy); err != nil {
+			return err
+		}
+	}
+
+	return o.completeMove(checkpoint, toProxy)
+}
+
+// rollbackMove reverts a move operation starting from the progress recorded in a checkpoint, by deleting the objects
+// created in the target management cluster and by resuming the Clusters in the source management cluster.
+func (o *objectMover) rollbackMove(checkpoint *moveCheckpoint, toProxy Proxy) error {
+	log := logf.Log
+
+	if checkpoint.deletingSource {
+		return errors.New("the move cannot be rolled back because objects have already been deleted from the source cluster, resume the move instead")
+	}
+
+	// Delete all objects created in the target cluster group by group in reverse order, including the group that was
+	// being created when the move failed.
+	log.Info("Deleting objects from the target cluster")
+	lastGroup := checkpoint.createdGroups
+	if lastGroup >= len(checkpoint.sequence.groups) {
+		lastGroup = len(checkpoint.sequence.groups) - 1
+	}
+	for groupIndex := lastGroup; groupIndex >= 0; groupIndex-- {
+		if err := o.deleteTargetGroup(checkpoint.sequence.getGroup(groupIndex), toProxy); err != nil {
+			return err
+		}
+	}
+
+	// Resume the cluster classes in the source management cluster, so the controllers start reconciling it.
+	log.V(1).Info("Resuming the source cluster classes")
+	if err := setClusterClassPause(o.fromProxy, checkpoint.clusterClasses, false, o.dryRun); err != nil {
+		return errors.Wrap(err, "error resuming cluster classes")
+	}
+
+	// Reset the pause field on the Cluster object in the source management cluster, so the controllers start reconciling it.
+	log.V(1).Info("Resuming the source cluster")
+	if err := setClusterPause(o.fromProxy, checkpoint.clusters, false, o.dryRun); err != nil {
+		return err
+	}
+
+	return o.removeCheckpoint()
+}
+
+// deleteTargetGroup deletes all the Kubernetes objects from the target management cluster corresponding to the object graph nodes in a moveGroup.
+func (o *objectMover) deleteTargetGroup(group moveGroup, toProxy Proxy) error {
+	log := logf.Log
+
+	deleteTargetObjectBackoff := newWriteBackoff()
+	errList := []error{}
+	for i := range group {
+		nodeToDelete := group[i]
+
+		// Don't delete global objects, objects below a hierarchy that starts with a global object or shared objects,
+		// given that they could exist in the target cluster before the move.
+		if nodeToDelete.isGlobal || nodeToDelete.isGlobalHierarchy || nodeToDelete.shared {
+			continue
+		}
+
+		log.V(1).Info("Deleting from target", nodeToDelete.identity.Kind, nodeToDelete.identity.Name, "Namespace", nodeToDelete.identity.Namespace)
+		if o.dryRun {
+			continue
+		}
+
+		// Nb. The operation is wrapped in a retry loop to make rollback more resilient to unexpected conditions.
+		err := retryWithExponentialBackoff(deleteTargetObjectBackoff, func() error {
+			return forceDeleteObject(toProxy, nodeToDelete)
+		})
+		if err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	return kerrors.NewAggregate(errList)
+}
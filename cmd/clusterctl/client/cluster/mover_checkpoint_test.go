@@ -0,0 +1,309 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+// setupPartialMove simulates a move failed after creating half of the groups in the target cluster, and returns
+// the source graph, the target proxy and the checkpoint file recording the progress of the move.
+func setupPartialMove(g *WithT, objs []client.Object, dir string) (*objectGraph, Proxy, string) {
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(objs)
+
+	// Get all the types to be considered for discovery
+	g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+
+	// trigger discovery the content of the source cluster
+	g.Expect(graph.Discovery("")).To(Succeed())
+
+	// gets a fakeProxy to an empty cluster with all the required CRDs
+	toProxy := getFakeProxyWithCRDs()
+
+	checkpointFile := filepath.Join(dir, "checkpoint.json")
+	mover := objectMover{
+		fromProxy:      graph.proxy,
+		checkpointFile: checkpointFile,
+	}
+
+	checkpoint := &moveCheckpoint{
+		sequence:       getMoveSequence(graph),
+		clusters:       graph.getClusters(),
+		clusterClasses: graph.getClusterClasses(),
+	}
+	g.Expect(setClusterPause(graph.proxy, checkpoint.clusters, true, false)).To(Succeed())
+	g.Expect(mover.ensureNamespaces(graph, toProxy)).To(Succeed())
+	for i := 0; i < len(checkpoint.sequence.groups)/2; i++ {
+		g.Expect(mover.createGroup(checkpoint.sequence.getGroup(i), toProxy)).To(Succeed())
+		checkpoint.createdGroups = i + 1
+	}
+	g.Expect(mover.saveCheckpoint(checkpoint)).To(Succeed())
+
+	return graph, toProxy, checkpointFile
+}
+
+func Test_moveCheckpoint_toFile(t *testing.T) {
+	g := NewWithT(t)
+
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").WithMachines(test.NewFakeMachine("m1")).Objs())
+	g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+	g.Expect(graph.Discovery("")).To(Succeed())
+
+	checkpoint := &moveCheckpoint{
+		sequence:       getMoveSequence(graph),
+		clusters:       graph.getClusters(),
+		clusterClasses: graph.getClusterClasses(),
+		createdGroups:  1,
+	}
+	checkpoint.sequence.getGroup(0)[0].newUID = "new-uid"
+
+	got, err := checkpoint.toFile().toCheckpoint()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(got.createdGroups).To(Equal(1))
+	g.Expect(got.deletingSource).To(BeFalse())
+	g.Expect(got.clusters).To(HaveLen(1))
+	g.Expect(got.clusters[0].identity).To(Equal(graph.getClusters()[0].identity))
+	g.Expect(got.sequence.groups).To(HaveLen(len(checkpoint.sequence.groups)))
+	for i, group := range checkpoint.sequence.groups {
+		gotGroup := got.sequence.getGroup(i)
+		g.Expect(gotGroup).To(HaveLen(len(group)))
+		for j, n := range group {
+			gotNode := gotGroup[j]
+			g.Expect(gotNode.identity).To(Equal(n.identity))
+			g.Expect(gotNode.newUID).To(Equal(n.newUID))
+			g.Expect(gotNode.owners).To(HaveLen(len(n.owners)))
+			for owner, attributes := range n.owners {
+				found := false
+				for gotOwner, gotAttributes := range gotNode.owners {
+					if gotOwner.identity.UID == owner.identity.UID {
+						found = true
+						g.Expect(gotAttributes).To(Equal(attributes))
+					}
+				}
+				g.Expect(found).To(BeTrue())
+			}
+		}
+	}
+
+	// Checkpoints referencing unknown objects are rejected.
+	f := checkpoint.toFile()
+	f.Groups = append(f.Groups, []types.UID{"does-not-exist"})
+	_, err = f.toCheckpoint()
+	g.Expect(err).To(HaveOccurred())
+}
+
+func Test_moveCheckpoint_toFile_ownersNotMoved(t *testing.T) {
+	g := NewWithT(t)
+
+	graph := getObjectGraphWithObjs(test.NewFakeCluster("ns1", "foo").Objs())
+	g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+	g.Expect(graph.Discovery("")).To(Succeed())
+
+	// Add to the Cluster an owner of a kind not moved.
+	cluster := graph.getClusters()[0]
+	virtualOwner := graph.ownerToVirtualNode(metav1.OwnerReference{
+		APIVersion: "something.x-k8s.io/v1beta1",
+		Kind:       "Something",
+		Name:       "bar",
+		UID:        "something-uid",
+	})
+	cluster.addOwner(virtualOwner, ownerReferenceAttributes{})
+
+	checkpoint := &moveCheckpoint{
+		sequence:       getMoveSequence(graph),
+		clusters:       graph.getClusters(),
+		clusterClasses: graph.getClusterClasses(),
+	}
+
+	got, err := checkpoint.toFile().toCheckpoint()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(got.clusters).To(HaveLen(1))
+	g.Expect(got.clusters[0].identity).To(Equal(cluster.identity))
+	g.Expect(got.clusters[0].owners).To(BeEmpty())
+}
+
+func Test_objectMover_resumeMove(t *testing.T) {
+	for _, tt := range moveTests {
+		if tt.wantErr {
+			continue
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir := t.TempDir()
+			graph, toProxy, checkpointFile := setupPartialMove(g, tt.fields.objs, dir)
+
+			// Resume the move using a new mover, like a new execution of clusterctl would do.
+			checkpoint, err := loadMoveCheckpoint(checkpointFile)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			mover := objectMover{
+				fromProxy:      graph.proxy,
+				checkpointFile: checkpointFile,
+			}
+			g.Expect(mover.resumeMove(checkpoint, toProxy)).To(Succeed())
+
+			// check that the objects are removed from the source cluster and are created in the target cluster
+			csFrom, err := graph.proxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.getMoveNodes() {
+				key := client.ObjectKey{
+					Namespace: node.identity.Namespace,
+					Name:      node.identity.Name,
+				}
+
+				oFrom := &unstructured.Unstructured{}
+				oFrom.SetAPIVersion(node.identity.APIVersion)
+				oFrom.SetKind(node.identity.Kind)
+				err := csFrom.Get(ctx, key, oFrom)
+				if err == nil {
+					g.Expect(node.isGlobal || node.isGlobalHierarchy).To(BeTrue(), "%v not deleted in source cluster", key)
+				} else {
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}
+
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+				g.Expect(csTo.Get(ctx, key, oTo)).To(Succeed(), "%v not created in target cluster", key)
+			}
+
+			// check that the clusters are resumed in the target cluster.
+			for _, node := range graph.getClusters() {
+				c := &clusterv1.Cluster{}
+				g.Expect(csTo.Get(ctx, client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}, c)).To(Succeed())
+				g.Expect(c.Spec.Paused).To(BeFalse())
+			}
+
+			// check that the checkpoint is removed.
+			_, err = os.Stat(checkpointFile)
+			g.Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	}
+}
+
+func Test_objectMover_rollbackMove(t *testing.T) {
+	for _, tt := range moveTests {
+		if tt.wantErr {
+			continue
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			dir := t.TempDir()
+			graph, toProxy, checkpointFile := setupPartialMove(g, tt.fields.objs, dir)
+
+			// Rollback the move using a new mover, like a new execution of clusterctl would do.
+			checkpoint, err := loadMoveCheckpoint(checkpointFile)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			mover := objectMover{
+				fromProxy:      graph.proxy,
+				checkpointFile: checkpointFile,
+			}
+			g.Expect(mover.rollbackMove(checkpoint, toProxy)).To(Succeed())
+
+			// check that the objects are still in the source cluster and are deleted from the target cluster
+			csFrom, err := graph.proxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			csTo, err := toProxy.NewClient()
+			g.Expect(err).NotTo(HaveOccurred())
+
+			for _, node := range graph.getMoveNodes() {
+				key := client.ObjectKey{
+					Namespace: node.identity.Namespace,
+					Name:      node.identity.Name,
+				}
+
+				oFrom := &unstructured.Unstructured{}
+				oFrom.SetAPIVersion(node.identity.APIVersion)
+				oFrom.SetKind(node.identity.Kind)
+				g.Expect(csFrom.Get(ctx, key, oFrom)).To(Succeed(), "%v deleted from source cluster", key)
+
+				if node.isGlobal || node.isGlobalHierarchy {
+					continue
+				}
+				oTo := &unstructured.Unstructured{}
+				oTo.SetAPIVersion(node.identity.APIVersion)
+				oTo.SetKind(node.identity.Kind)
+				err := csTo.Get(ctx, key, oTo)
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%v not deleted from target cluster", key)
+			}
+
+			// check that the clusters are resumed in the source cluster.
+			for _, node := range graph.getClusters() {
+				c := &clusterv1.Cluster{}
+				g.Expect(csFrom.Get(ctx, client.ObjectKey{Namespace: node.identity.Namespace, Name: node.identity.Name}, c)).To(Succeed())
+				g.Expect(c.Spec.Paused).To(BeFalse())
+			}
+
+			// check that the checkpoint is removed.
+			_, err = os.Stat(checkpointFile)
+			g.Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	}
+}
+
+func Test_objectMover_rollbackMove_deletingSource(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	graph, toProxy, checkpointFile := setupPartialMove(g, test.NewFakeCluster("ns1", "foo").Objs(), dir)
+
+	checkpoint, err := loadMoveCheckpoint(checkpointFile)
+	g.Expect(err).NotTo(HaveOccurred())
+	checkpoint.deletingSource = true
+
+	mover := objectMover{
+		fromProxy:      graph.proxy,
+		checkpointFile: checkpointFile,
+	}
+	g.Expect(mover.rollbackMove(checkpoint, toProxy)).ToNot(Succeed())
+
+	// the checkpoint is preserved, so the move can still be resumed.
+	_, err = os.Stat(checkpointFile)
+	g.Expect(err).NotTo(HaveOccurred())
+}
+
+func Test_objectMover_Move_existingCheckpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	checkpointFile := filepath.Join(t.TempDir(), "checkpoint.json")
+	g.Expect(os.WriteFile(checkpointFile, []byte("{}"), 0600)).To(Succeed())
+
+	mover := newObjectMover(test.NewFakeProxy(), nil)
+	g.Expect(mover.Move("", ClusterSelector{}, nil, false, checkpointFile)).ToNot(Succeed())
+}
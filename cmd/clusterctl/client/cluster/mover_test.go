@@ -672,7 +672,7 @@ func Test_objectMover_backupTargetObject(t *testing.T) {
 			defer os.RemoveAll(dir)
 
 			for _, node := range graph.uidToNode {
-				err = mover.backupTargetObject(node, dir)
+				err = mover.backupTargetObject(node, directoryBackupWriter(dir))
 				if tt.wantErr {
 					g.Expect(err).To(HaveOccurred())
 					return
@@ -707,7 +707,7 @@ func Test_objectMover_backupTargetObject(t *testing.T) {
 				time.Sleep(time.Millisecond * 50)
 
 				// Running backupTargetObject should override any existing files since it represents a new backup
-				err = mover.backupTargetObject(node, dir)
+				err = mover.backupTargetObject(node, directoryBackupWriter(dir))
 				if tt.wantErr {
 					g.Expect(err).To(HaveOccurred())
 					return
@@ -866,7 +866,7 @@ func Test_objectMover_backup(t *testing.T) {
 			}
 			defer os.RemoveAll(dir)
 
-			err = mover.backup(graph, dir)
+			err = mover.backup(graph, directoryBackupWriter(dir))
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				return
@@ -1790,6 +1790,7 @@ func Test_createTargetObject(t *testing.T) {
 								Name:       "bar",
 								APIVersion: "cluster.x-k8s.io/v1beta1",
 							},
+							newUID: "bar-uid",
 						}: {
 							Controller: pointer.BoolPtr(true),
 						},
@@ -1804,9 +1805,61 @@ func Test_createTargetObject(t *testing.T) {
 				}
 				g.Expect(toClient.Get(ctx, key, c)).ToNot(HaveOccurred())
 				g.Expect(c.OwnerReferences).To(HaveLen(1))
+				g.Expect(c.OwnerReferences[0].UID).To(BeEquivalentTo("bar-uid"))
 				g.Expect(c.OwnerReferences[0].Controller).To(Equal(pointer.BoolPtr(true)))
 			},
 		},
+		{
+			name: "creates the object without owner references to owners not moved",
+			args: args{
+				fromProxy: test.NewFakeProxy().WithObjs(
+					&clusterv1.Cluster{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "foo",
+							Namespace: "ns1",
+						},
+					},
+				),
+				toProxy: test.NewFakeProxy(),
+				node: &node{
+					identity: corev1.ObjectReference{
+						Kind:       "Cluster",
+						Namespace:  "ns1",
+						Name:       "foo",
+						APIVersion: "cluster.x-k8s.io/v1beta1",
+					},
+					owners: map[*node]ownerReferenceAttributes{
+						{
+							identity: corev1.ObjectReference{
+								Kind:       "Something",
+								Namespace:  "ns1",
+								Name:       "bar",
+								APIVersion: "cluster.x-k8s.io/v1beta1",
+							},
+							newUID: "bar-uid",
+						}: {},
+						{
+							identity: corev1.ObjectReference{
+								Kind:       "Something",
+								Namespace:  "ns1",
+								Name:       "baz",
+								APIVersion: "cluster.x-k8s.io/v1beta1",
+							},
+						}: {},
+					},
+				},
+			},
+			want: func(g *WithT, toClient client.Client) {
+				c := &clusterv1.Cluster{}
+				key := client.ObjectKey{
+					Namespace: "ns1",
+					Name:      "foo",
+				}
+				g.Expect(toClient.Get(ctx, key, c)).ToNot(HaveOccurred())
+				g.Expect(c.OwnerReferences).To(HaveLen(1))
+				g.Expect(c.OwnerReferences[0].Name).To(Equal("bar"))
+			},
+		},
 		{
 			name: "updates the object if it already exists and the object is not Global/GlobalHierarchy",
 			args: args{
@@ -2067,6 +2120,36 @@ func Test_deleteSourceObject(t *testing.T) {
 				g.Expect(apierrors.IsNotFound(toClient.Get(ctx, key, c))).To(BeTrue())
 			},
 		},
+		{
+			name: "does not delete from source if the object is shared",
+			args: args{
+				fromProxy: test.NewFakeProxy().WithObjs(
+					&clusterv1.ClusterClass{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "foo",
+							Namespace: "ns1",
+						},
+					},
+				),
+				node: &node{
+					identity: corev1.ObjectReference{
+						Kind:       "ClusterClass",
+						Namespace:  "ns1",
+						Name:       "foo",
+						APIVersion: "cluster.x-k8s.io/v1beta1",
+					},
+					shared: true,
+				},
+			},
+			want: func(g *WithT, toClient client.Client) {
+				c := &clusterv1.ClusterClass{}
+				key := client.ObjectKey{
+					Namespace: "ns1",
+					Name:      "foo",
+				}
+				g.Expect(toClient.Get(ctx, key, c)).To(Succeed())
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -36,7 +36,10 @@ import (
 	secretutil "sigs.k8s.io/cluster-api/util/secret"
 )
 
-const clusterTopologyNameKey = "cluster.spec.topology.class"
+const (
+	clusterTopologyNameKey = "cluster.spec.topology.class"
+	clusterLabelsKey       = "cluster.metadata.labels"
+)
 
 type empty struct{}
 
@@ -72,6 +75,11 @@ type node struct {
 	// When this flag is true the object should not be deleted from the source cluster.
 	isGlobalHierarchy bool
 
+	// shared gets set to true if this object is required by the Clusters selected for move, but it is not part of their
+	// hierarchy only, e.g. a ClusterClass used also by other Clusters.
+	// When this flag is true the object should not be deleted from the source cluster.
+	shared bool
+
 	// virtual records if this node was discovered indirectly, e.g. by processing an OwnerRef, but not yet observed as a concrete object.
 	virtual bool
 
@@ -138,12 +146,13 @@ func (n *node) captureAdditionalInformation(obj *unstructured.Unstructured) erro
 		if err := localScheme.Convert(obj, cluster, nil); err != nil {
 			return errors.Wrapf(err, "failed to convert object %s to Cluster", n.identityStr())
 		}
+		if n.additionalInfo == nil {
+			n.additionalInfo = map[string]interface{}{}
+		}
 		if cluster.Spec.Topology != nil {
-			if n.additionalInfo == nil {
-				n.additionalInfo = map[string]interface{}{}
-			}
 			n.additionalInfo[clusterTopologyNameKey] = cluster.Spec.Topology.Class
 		}
+		n.additionalInfo[clusterLabelsKey] = cluster.GetLabels()
 	}
 
 	return nil
@@ -426,7 +435,7 @@ func (o *objectGraph) Discovery(namespace string) error {
 				return err
 			}
 			for _, p := range providers.Items {
-				if p.Type == string(clusterctlv1.InfrastructureProviderType) {
+				if p.Type == string(clusterctlv1.InfrastructureProviderType) || p.Type == string(clusterctlv1.IPAMProviderType) {
 					providerNamespaceSelector := []client.ListOption{client.InNamespace(p.Namespace)}
 					providerNamespaceSecretList := new(unstructured.UnstructuredList)
 					if err := retryWithExponentialBackoff(discoveryBackoff, func() error {
@@ -620,6 +629,155 @@ func (o *objectGraph) setTenant(node, tenant *node, isGlobalHierarchy bool) {
 	}
 }
 
+// filterClusters restricts the object graph to the hierarchy of the Clusters matching the selector, plus the objects
+// those Clusters depend on, e.g. the ClusterClass they are using or the ClusterResourceSets applied to them, plus global objects.
+// Objects required by the selected Clusters but not belonging only to them are marked as shared, so they are
+// copied to the target management cluster without being deleted from the source management cluster.
+func (o *objectGraph) filterClusters(selector ClusterSelector) error {
+	if selector.IsEmpty() {
+		return nil
+	}
+
+	selected := map[*node]empty{}
+	for _, cluster := range o.getClusters() {
+		if selector.matches(cluster) {
+			selected[cluster] = empty{}
+		}
+	}
+
+	missing := []string{}
+	for _, key := range selector.Clusters {
+		found := false
+		for cluster := range selected {
+			if cluster.identity.Namespace == key.Namespace && cluster.identity.Name == key.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, key.String())
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("failed to find Clusters %s", strings.Join(missing, ", "))
+	}
+	if len(selected) == 0 {
+		return errors.New("failed to find Clusters matching the selector")
+	}
+
+	included := map[*node]empty{}
+	queue := []*node{}
+	include := func(n *node, shared bool) {
+		if _, ok := included[n]; ok {
+			return
+		}
+		included[n] = empty{}
+		n.shared = shared
+		queue = append(queue, n)
+	}
+
+	// Includes all the objects in the hierarchy of the selected Clusters, plus global objects.
+	for _, n := range o.getNodes() {
+		selectedTenant, otherTenant := false, false
+		for tenant := range n.tenant {
+			if !isClusterNode(tenant) {
+				continue
+			}
+			if _, ok := selected[tenant]; ok {
+				selectedTenant = true
+			} else {
+				otherTenant = true
+			}
+		}
+
+		switch {
+		case selectedTenant:
+			// If the object belongs also to Clusters not selected, it is shared.
+			include(n, otherTenant)
+		case otherTenant:
+			// The object belongs only to Clusters not selected, skip it.
+		case n.isGlobal || n.isGlobalHierarchy:
+			include(n, false)
+		}
+	}
+
+	// Includes all the owners of the objects already included, so the owner reference chain can be rebuilt in the target
+	// management cluster; if an owner is the root of a move hierarchy, e.g. a ClusterClass or a ClusterResourceSet, its
+	// hierarchy is included as well, excluding objects belonging to Clusters not selected.
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		owners := []*node{}
+		for owner := range n.owners {
+			owners = append(owners, owner)
+		}
+		for owner := range n.softOwners {
+			owners = append(owners, owner)
+		}
+
+		for _, owner := range owners {
+			// Virtual nodes are not moved; objects belonging to Clusters are moved only if they belong to a selected
+			// Cluster, and in this case they are already included.
+			if owner.virtual || hasClusterTenant(owner) {
+				continue
+			}
+
+			// The owner is shared if it is required also by Clusters not selected, e.g. a ClusterClass used by other Clusters.
+			shared := o.isOwnerOfClusterNotSelected(owner, selected)
+			include(owner, shared)
+			if !owner.forceMoveHierarchy {
+				continue
+			}
+			for _, other := range o.getNodes() {
+				if _, ok := other.tenant[owner]; !ok || hasClusterTenant(other) {
+					continue
+				}
+				include(other, shared)
+			}
+		}
+	}
+
+	// Removes from the graph all the objects not included.
+	for uid, n := range o.uidToNode {
+		if _, ok := included[n]; !ok {
+			delete(o.uidToNode, uid)
+		}
+	}
+	return nil
+}
+
+// isClusterNode returns true if the node is a Cluster.
+func isClusterNode(n *node) bool {
+	return n.identity.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("Cluster").GroupKind()
+}
+
+// isOwnerOfClusterNotSelected returns true if owner owns, or soft owns, at least one object belonging to the hierarchy
+// of a Cluster not selected.
+func (o *objectGraph) isOwnerOfClusterNotSelected(owner *node, selected map[*node]empty) bool {
+	for _, n := range o.getNodes() {
+		if !n.isOwnedBy(owner) && !n.isSoftOwnedBy(owner) {
+			continue
+		}
+		for tenant := range n.tenant {
+			if _, ok := selected[tenant]; !ok && isClusterNode(tenant) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasClusterTenant returns true if the node belongs to the hierarchy of at least one Cluster.
+func hasClusterTenant(n *node) bool {
+	for tenant := range n.tenant {
+		if isClusterNode(tenant) {
+			return true
+		}
+	}
+	return false
+}
+
 // checkVirtualNode logs if nodes are still virtual.
 func (o *objectGraph) checkVirtualNode() {
 	log := logf.Log
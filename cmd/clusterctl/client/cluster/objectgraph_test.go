@@ -23,12 +23,15 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
@@ -1882,6 +1885,280 @@ func TestObjectGraph_DiscoveryByNamespace(t *testing.T) {
 	}
 }
 
+func Test_objectGraph_filterClusters(t *testing.T) {
+	type args struct {
+		objs     []client.Object
+		selector ClusterSelector
+	}
+	tests := []struct {
+		name       string
+		args       args
+		wantMoved  []string
+		wantShared []string
+		wantErr    bool
+	}{
+		{
+			name: "empty selector, all the objects are kept",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns2", "bar").Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns2/bar",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns2/bar",
+				"/v1, Kind=Secret, ns2/bar-ca",
+				"/v1, Kind=Secret, ns2/bar-kubeconfig",
+			},
+		},
+		{
+			name: "select clusters by label, from different namespaces",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").WithLabels(map[string]string{"tenant": "a"}).Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").WithLabels(map[string]string{"tenant": "b"}).Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns2", "baz").WithLabels(map[string]string{"tenant": "a"}).Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{LabelSelector: labels.SelectorFromSet(labels.Set{"tenant": "a"})},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns2/baz",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns2/baz",
+				"/v1, Kind=Secret, ns2/baz-ca",
+				"/v1, Kind=Secret, ns2/baz-kubeconfig",
+			},
+		},
+		{
+			name: "select a cluster by name, the ClusterClass used also by another cluster is shared",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeClusterClass("ns1", "class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").WithTopologyClass("class1").Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "foo"}}},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+			},
+			wantShared: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=ClusterClass, ns1/class1",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureClusterTemplate, ns1/class1",
+				"controlplane.cluster.x-k8s.io/v1beta1, Kind=GenericControlPlaneTemplate, ns1/class1",
+			},
+		},
+		{
+			name: "select a cluster by name, the ClusterResourceSet applied also to another cluster is shared",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					foo := test.NewFakeCluster("ns1", "foo")
+					bar := test.NewFakeCluster("ns1", "bar")
+					fooObjs := foo.Objs()
+					barObjs := bar.Objs()
+					objs = append(objs, fooObjs...)
+					objs = append(objs, barObjs...)
+					objs = append(objs, test.NewFakeClusterResourceSet("ns1", "crs1").
+						WithSecret("resource-s1").
+						ApplyToCluster(fooObjs[0].(*clusterv1.Cluster)).
+						ApplyToCluster(barObjs[0].(*clusterv1.Cluster)).
+						Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "foo"}}},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"addons.cluster.x-k8s.io/v1beta1, Kind=ClusterResourceSetBinding, ns1/foo",
+			},
+			wantShared: []string{
+				"addons.cluster.x-k8s.io/v1beta1, Kind=ClusterResourceSet, ns1/crs1",
+				"/v1, Kind=Secret, ns1/resource-s1",
+			},
+		},
+		{
+			name: "select a cluster by name, the ClusterClass used only by the selected clusters is moved",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeClusterClass("ns1", "class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").WithTopologyClass("class1").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "foo"}}},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"cluster.x-k8s.io/v1beta1, Kind=ClusterClass, ns1/class1",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureClusterTemplate, ns1/class1",
+				"controlplane.cluster.x-k8s.io/v1beta1, Kind=GenericControlPlaneTemplate, ns1/class1",
+			},
+		},
+		{
+			name: "select a cluster by name, the ClusterResourceSet applied only to the selected clusters is moved",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					foo := test.NewFakeCluster("ns1", "foo")
+					fooObjs := foo.Objs()
+					objs = append(objs, fooObjs...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					objs = append(objs, test.NewFakeClusterResourceSet("ns1", "crs1").
+						WithSecret("resource-s1").
+						ApplyToCluster(fooObjs[0].(*clusterv1.Cluster)).
+						Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "foo"}}},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"addons.cluster.x-k8s.io/v1beta1, Kind=ClusterResourceSetBinding, ns1/foo",
+				"addons.cluster.x-k8s.io/v1beta1, Kind=ClusterResourceSet, ns1/crs1",
+				"/v1, Kind=Secret, ns1/resource-s1",
+			},
+		},
+		{
+			name: "select a cluster by name, an object owned also by the infrastructure cluster of another cluster is shared",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					ownerRef := func(name string) metav1.OwnerReference {
+						return metav1.OwnerReference{
+							APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+							Kind:       "GenericInfrastructureCluster",
+							Name:       name,
+							UID:        types.UID("infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/" + name),
+						}
+					}
+					objs = append(objs, &corev1.Secret{
+						TypeMeta: metav1.TypeMeta{
+							APIVersion: "v1",
+							Kind:       "Secret",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:            "identity",
+							Namespace:       "ns1",
+							UID:             "/v1, Kind=Secret, ns1/identity",
+							OwnerReferences: []metav1.OwnerReference{ownerRef("foo"), ownerRef("bar")},
+						},
+					})
+					return objs
+				}(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "foo"}}},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+			},
+			wantShared: []string{
+				"/v1, Kind=Secret, ns1/identity",
+			},
+		},
+		{
+			name: "global objects are always moved",
+			args: args{
+				objs: func() []client.Object {
+					objs := []client.Object{}
+					objs = append(objs, test.NewFakeCluster("ns1", "foo").Objs()...)
+					objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+					objs = append(objs, test.NewFakeClusterInfrastructureIdentity("infra1-identity").Objs()...)
+					return objs
+				}(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "foo"}}},
+			},
+			wantMoved: []string{
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/foo",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/foo",
+				"/v1, Kind=Secret, ns1/foo-ca",
+				"/v1, Kind=Secret, ns1/foo-kubeconfig",
+				"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericClusterInfrastructureIdentity, /infra1-identity",
+			},
+		},
+		{
+			name: "fails if a cluster does not exist",
+			args: args{
+				objs:     test.NewFakeCluster("ns1", "foo").Objs(),
+				selector: ClusterSelector{Clusters: []types.NamespacedName{{Namespace: "ns1", Name: "bar"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if no clusters are matching the selector",
+			args: args{
+				objs:     test.NewFakeCluster("ns1", "foo").Objs(),
+				selector: ClusterSelector{LabelSelector: labels.SelectorFromSet(labels.Set{"tenant": "a"})},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			// Create an objectGraph bound to a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(tt.args.objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(getFakeDiscoveryTypes(graph)).To(Succeed())
+
+			g.Expect(graph.Discovery("")).To(Succeed())
+
+			err := graph.filterClusters(tt.args.selector)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			gotMoved := []string{}
+			gotShared := []string{}
+			for _, node := range graph.getMoveNodes() {
+				if node.shared {
+					gotShared = append(gotShared, string(node.identity.UID))
+					continue
+				}
+				gotMoved = append(gotMoved, string(node.identity.UID))
+			}
+			g.Expect(gotMoved).To(ConsistOf(tt.wantMoved))
+			g.Expect(gotShared).To(ConsistOf(tt.wantShared))
+		})
+	}
+}
+
 func Test_objectGraph_setSoftOwnership(t *testing.T) {
 	type fields struct {
 		objs []client.Object
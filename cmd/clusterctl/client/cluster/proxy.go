@@ -81,6 +81,7 @@ type proxy struct {
 	kubeconfig         Kubeconfig
 	timeout            time.Duration
 	configLoadingRules *clientcmd.ClientConfigLoadingRules
+	restConfig         *rest.Config
 }
 
 var _ Proxy = &proxy{}
@@ -130,6 +131,10 @@ func (k *proxy) ValidateKubernetesVersion() error {
 
 // GetConfig returns the config for a kubernetes client.
 func (k *proxy) GetConfig() (*rest.Config, error) {
+	if k.restConfig != nil {
+		return rest.CopyConfig(k.restConfig), nil
+	}
+
 	config, err := k.configLoadingRules.Load()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load Kubeconfig")
@@ -376,6 +381,19 @@ func InjectKubeconfigPaths(paths []string) ProxyOption {
 	}
 }
 
+// InjectRestConfig sets the rest.Config used for connecting to the management cluster instead of loading it from the kubeconfig;
+// this allows to use the proxy from controllers running in the management cluster.
+func InjectRestConfig(config *rest.Config) ProxyOption {
+	return func(p *proxy) {
+		p.restConfig = config
+	}
+}
+
+// NewProxy returns a Proxy for working on the management cluster.
+func NewProxy(kubeconfig Kubeconfig, opts ...ProxyOption) Proxy {
+	return newProxy(kubeconfig, opts...)
+}
+
 func newProxy(kubeconfig Kubeconfig, opts ...ProxyOption) Proxy {
 	// If a kubeconfig file isn't provided, find one in the standard locations.
 	rules := clientcmd.NewDefaultClientConfigLoadingRules()
@@ -17,9 +17,11 @@ limitations under the License.
 package cluster
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	jsonpatch "github.com/evanphx/json-patch"
@@ -42,8 +44,10 @@ import (
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster/internal/dryrun"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
 	clustertopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
 
 const (
@@ -78,6 +82,13 @@ type TopologyPlanInput struct {
 	Objs              []*unstructured.Unstructured
 	TargetClusterName string
 	TargetNamespace   string
+
+	// EditClusterClass is the name of a ClusterClass in the management cluster to be edited in place
+	// using Editor; the edited ClusterClass is then added to the input objects.
+	EditClusterClass string
+
+	// Editor edits the YAML of the ClusterClass to be edited in place, and returns the modified YAML.
+	Editor func(original []byte) ([]byte, error)
 }
 
 // PatchSummary defined the patch observed on an object.
@@ -100,6 +111,31 @@ type TopologyPlanOutput struct {
 	// ChangeSummary is the full list of changes (objects created, modified and deleted) observed
 	// on the ReconciledCluster. ChangeSummary is empty if ReconciledCluster is empty.
 	*ChangeSummary
+	// ClusterChanges is the list of changes observed on each of the affected clusters, sorted by namespace and name.
+	// If a target cluster is specified in the TopologyPlanInput, only the changes for the target cluster are included.
+	ClusterChanges []*ClusterChanges
+}
+
+// ClusterChanges defines the changes observed on one of the affected clusters, and the machine rollouts they trigger.
+type ClusterChanges struct {
+	// Cluster is the affected cluster.
+	Cluster client.ObjectKey
+	// ChangeSummary is the full list of changes (objects created, modified and deleted) observed on the cluster.
+	*ChangeSummary
+	// Rollouts is the list of the control plane and MachineDeployments of the cluster which are
+	// going to roll out their machines because of the changes.
+	Rollouts []MachineRollout
+	// Error is the error which prevented computing the changes for the cluster, if any;
+	// in this case ChangeSummary and Rollouts are empty.
+	Error error
+}
+
+// MachineRollout defines a control plane or a MachineDeployment which is going to roll out its machines.
+type MachineRollout struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Replicas is the number of machines which are going to be rolled out.
+	Replicas int64 `json:"replicas"`
 }
 
 // Plan performs a dry run execution of the topology reconciler using the given inputs.
@@ -108,11 +144,6 @@ func (t *topologyClient) Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error
 	ctx := context.TODO()
 	log := logf.Log
 
-	// Make sure the inputs are valid.
-	if err := t.validateInput(in); err != nil {
-		return nil, errors.Wrap(err, "input failed validation")
-	}
-
 	// If there is a reachable apiserver with CAPI installed fetch a client for the server.
 	// This client will be used as a fall back client when looking for objects that are not
 	// in the input.
@@ -129,6 +160,18 @@ func (t *topologyClient) Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error
 		}
 	}
 
+	// If a ClusterClass should be edited in place, add the edited ClusterClass to the input.
+	if in.EditClusterClass != "" {
+		if err := t.editClusterClass(ctx, in, c); err != nil {
+			return nil, errors.Wrapf(err, "failed to edit ClusterClass %q", in.EditClusterClass)
+		}
+	}
+
+	// Make sure the inputs are valid.
+	if err := t.validateInput(in); err != nil {
+		return nil, errors.Wrap(err, "input failed validation")
+	}
+
 	// Prepare the inputs for dry running the reconciler. This includes steps like setting missing namespaces on objects
 	// and adjusting cluster objects to reflect updated state.
 	if err := t.prepareInput(ctx, in, c); err != nil {
@@ -190,32 +233,215 @@ func (t *topologyClient) Plan(in *TopologyPlanInput) (*TopologyPlanOutput, error
 		targetCluster = &affectedClusters[0]
 	}
 
-	if targetCluster == nil {
-		// There is no target cluster, return here. We will
-		// not generate a full change summary.
-		return res, nil
+	// Run the topology reconciler on the target cluster, if specified, or on each of the affected clusters.
+	// Note: The full change summary is only reported for the target cluster, while the changes observed
+	// on each of the reconciled clusters are reported in the cluster changes.
+	// Note: A failure on the target cluster fails the plan, while failures on the other clusters are reported
+	// in the cluster changes, so they do not prevent computing the changes for the remaining clusters.
+	reconciledClusters := affectedClusters
+	if in.TargetClusterName != "" {
+		reconciledClusters = []client.ObjectKey{*targetCluster}
+	}
+	sort.Slice(reconciledClusters, func(i, j int) bool {
+		return reconciledClusters[i].String() < reconciledClusters[j].String()
+	})
+	for _, cluster := range reconciledClusters {
+		// Use a new dry run client for each cluster, so the changes observed on a cluster do not include
+		// the changes observed on the clusters reconciled before.
+		changes, err := t.reconcileCluster(ctx, dryrun.NewClient(c, objs), cluster)
+		if err != nil {
+			if targetCluster != nil && cluster == *targetCluster {
+				return nil, err
+			}
+			log.Info("Failed to compute the changes for Cluster", "Cluster", cluster.String(), "Error", err.Error())
+			changes = &ClusterChanges{
+				Cluster:       cluster,
+				ChangeSummary: &dryrun.ChangeSummary{},
+				Rollouts:      []MachineRollout{},
+				Error:         err,
+			}
+		}
+		if targetCluster != nil && cluster == *targetCluster {
+			res.ReconciledCluster = targetCluster
+			res.ChangeSummary = changes.ChangeSummary
+		}
+		res.ClusterChanges = append(res.ClusterChanges, changes)
 	}
 
-	res.ReconciledCluster = targetCluster
+	return res, nil
+}
+
+// reconcileCluster runs the topology reconciler on a cluster using the given dry run client, and returns
+// the changes observed by the dry run client and the machine rollouts they trigger.
+func (t *topologyClient) reconcileCluster(ctx context.Context, dryRunClient *dryrun.Client, cluster client.ObjectKey) (*ClusterChanges, error) {
 	reconciler := &clustertopologycontroller.Reconciler{
 		Client:                    dryRunClient,
 		APIReader:                 dryRunClient,
 		UnstructuredCachingClient: dryRunClient,
 	}
 	reconciler.SetupForDryRun(&noOpRecorder{})
-	request := reconcile.Request{NamespacedName: *targetCluster}
+	request := reconcile.Request{NamespacedName: cluster}
 	// Run the topology reconciler.
 	if _, err := reconciler.Reconcile(ctx, request); err != nil {
-		return nil, errors.Wrap(err, "failed to dry run the topology controller")
+		return nil, errors.Wrapf(err, "failed to dry run the topology controller on Cluster %s", cluster.String())
 	}
 	// Calculate changes observed by dry run client.
 	changes, err := dryRunClient.Changes(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get changes made by the topology controller")
+		return nil, errors.Wrapf(err, "failed to get changes made by the topology controller on Cluster %s", cluster.String())
 	}
-	res.ChangeSummary = changes
 
-	return res, nil
+	// Gets the cluster, so the changes to its control plane can be identified.
+	c := &clusterv1.Cluster{}
+	if err := dryRunClient.Get(ctx, cluster, c); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", cluster.String())
+	}
+
+	return &ClusterChanges{
+		Cluster:       cluster,
+		ChangeSummary: changes,
+		Rollouts:      machineRollouts(changes, c.Spec.ControlPlaneRef),
+	}, nil
+}
+
+// machineRollouts returns the control plane and the MachineDeployments which are going to roll out their machines
+// because of the given changes; this happens when:
+// - the version, the machine template or the configuration of a control plane changes (changes to the number
+//   of replicas and to the in-place mutable fields of the machine template do not trigger a rollout).
+// - the machine template of a MachineDeployment changes (changes to the in-place mutable fields of the machine
+//   template do not trigger a rollout).
+// In-place mutable fields are labels, annotations, NodeDrainTimeout and NodeDeletionTimeout.
+// NOTE: Newly created objects are not considered, because they create new machines instead of rolling out the existing ones.
+func machineRollouts(changes *ChangeSummary, controlPlaneRef *corev1.ObjectReference) []MachineRollout {
+	rollouts := []MachineRollout{}
+	for _, m := range changes.Modified {
+		var rollout bool
+		switch {
+		case controlPlaneRef != nil && m.After.GetKind() == controlPlaneRef.Kind && m.After.GetName() == controlPlaneRef.Name:
+			rollout = controlPlaneSpecChanged(m.Before, m.After)
+		case m.After.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind():
+			rollout = machineDeploymentTemplateChanged(m.Before, m.After)
+		}
+		if !rollout {
+			continue
+		}
+
+		replicas, found, err := unstructured.NestedInt64(m.After.Object, "spec", "replicas")
+		if err != nil || !found {
+			// Both the control plane and MachineDeployments default to 1 replica.
+			replicas = 1
+		}
+		rollouts = append(rollouts, MachineRollout{
+			Kind:     m.After.GetKind(),
+			Name:     m.After.GetName(),
+			Replicas: replicas,
+		})
+	}
+	sort.Slice(rollouts, func(i, j int) bool {
+		if rollouts[i].Kind == rollouts[j].Kind {
+			return rollouts[i].Name < rollouts[j].Name
+		}
+		return rollouts[i].Kind < rollouts[j].Kind
+	})
+	return rollouts
+}
+
+// controlPlaneSpecChanged returns true if the spec of a control plane changed in one of the fields
+// triggering a rollout of the control plane machines, which are the version, the infrastructure
+// machine template and the KubeadmConfigSpec.
+// NOTE: JoinConfiguration.Discovery is ignored like the KubeadmControlPlane controller does, because it is
+// relevant only for the join process and not for comparing the configuration of the machines.
+func controlPlaneSpecChanged(before, after *unstructured.Unstructured) bool {
+	if nestedFieldChanged(before, after, "spec", "version") ||
+		nestedFieldChanged(before, after, "spec", "machineTemplate", "infrastructureRef") {
+		return true
+	}
+	kubeadmConfigSpecWithoutDiscovery := func(u *unstructured.Unstructured) *unstructured.Unstructured {
+		kubeadmConfigSpec, _, _ := unstructured.NestedMap(u.Object, "spec", "kubeadmConfigSpec")
+		res := &unstructured.Unstructured{Object: map[string]interface{}{"kubeadmConfigSpec": kubeadmConfigSpec}}
+		unstructured.RemoveNestedField(res.Object, "kubeadmConfigSpec", "joinConfiguration", "discovery")
+		return res
+	}
+	return nestedFieldChanged(kubeadmConfigSpecWithoutDiscovery(before), kubeadmConfigSpecWithoutDiscovery(after), "kubeadmConfigSpec")
+}
+
+// machineDeploymentTemplateChanged returns true if the machine template of a MachineDeployment changed, ignoring
+// the in-place mutable fields like the MachineDeployment controller does when selecting the MachineSet to be used.
+func machineDeploymentTemplateChanged(before, after *unstructured.Unstructured) bool {
+	beforeMD := &clusterv1.MachineDeployment{}
+	afterMD := &clusterv1.MachineDeployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(before.Object, beforeMD); err != nil {
+		return nestedFieldChanged(before, after, "spec", "template")
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(after.Object, afterMD); err != nil {
+		return nestedFieldChanged(before, after, "spec", "template")
+	}
+	return !mdutil.EqualMachineTemplate(&beforeMD.Spec.Template, &afterMD.Spec.Template)
+}
+
+// nestedFieldChanged returns true if the value of a nested field differs between two objects.
+func nestedFieldChanged(before, after *unstructured.Unstructured, fields ...string) bool {
+	beforeValue, _, _ := unstructured.NestedFieldNoCopy(before.Object, fields...)
+	afterValue, _, _ := unstructured.NestedFieldNoCopy(after.Object, fields...)
+	beforeJSON, err := json.Marshal(beforeValue)
+	if err != nil {
+		return true
+	}
+	afterJSON, err := json.Marshal(afterValue)
+	if err != nil {
+		return true
+	}
+	return !bytes.Equal(beforeJSON, afterJSON)
+}
+
+// editClusterClass gets a ClusterClass from the management cluster, edits it in place using the editor in
+// the input, and adds the edited ClusterClass to the input objects.
+func (t *topologyClient) editClusterClass(ctx context.Context, in *TopologyPlanInput, c client.Client) error {
+	if c == nil {
+		return errors.New("a management cluster with Cluster API installed is required")
+	}
+	if in.Editor == nil {
+		return errors.New("an editor is required")
+	}
+
+	namespace := in.TargetNamespace
+	if namespace == "" {
+		currentNamespace, err := t.proxy.CurrentNamespace()
+		if err != nil {
+			return errors.Wrap(err, "failed to get current namespace")
+		}
+		namespace = currentNamespace
+	}
+
+	clusterClass := &unstructured.Unstructured{}
+	clusterClass.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("ClusterClass"))
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: in.EditClusterClass}, clusterClass); err != nil {
+		return errors.Wrapf(err, "failed to get ClusterClass %s/%s", namespace, in.EditClusterClass)
+	}
+	clusterClass.SetManagedFields(nil)
+
+	original, err := utilyaml.FromUnstructured([]unstructured.Unstructured{*clusterClass})
+	if err != nil {
+		return errors.Wrap(err, "failed to convert ClusterClass to yaml")
+	}
+	edited, err := in.Editor(original)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(original, edited) {
+		return errors.New("edit cancelled, no changes made")
+	}
+
+	objs, err := utilyaml.ToUnstructured(edited)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert edited yaml to objects")
+	}
+	if len(objs) != 1 || objs[0].GroupVersionKind() != clusterClass.GroupVersionKind() ||
+		objs[0].GetName() != clusterClass.GetName() || objs[0].GetNamespace() != clusterClass.GetNamespace() {
+		return errors.Errorf("the edited yaml should contain only the ClusterClass %s/%s", namespace, in.EditClusterClass)
+	}
+	in.Objs = append(in.Objs, &objs[0])
+	return nil
 }
 
 // validateInput checks that the topology plan input does not violate any of the below expectations:
@@ -24,9 +24,11 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
 	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
@@ -47,6 +49,9 @@ var (
 	//go:embed assets/topology-test/existing-my-second-cluster.yaml
 	existingMySecondClusterYAML []byte
 
+	//go:embed assets/topology-test/existing-my-broken-cluster.yaml
+	existingMyBrokenClusterYAML []byte
+
 	// modifiedClusterYAML changes the control plane replicas from 1 to 3.
 	//go:embed assets/topology-test/modified-my-cluster.yaml
 	modifiedMyClusterYAML []byte
@@ -75,6 +80,8 @@ func Test_topologyClient_Plan(t *testing.T) {
 		created                []item
 		modified               []item
 		deleted                []item
+		rollouts               map[client.ObjectKey][]MachineRollout
+		failedClusters         []client.ObjectKey
 	}
 	tests := []struct {
 		name            string
@@ -114,6 +121,10 @@ func Test_topologyClient_Plan(t *testing.T) {
 					return []client.ObjectKey{cc}
 				}(),
 				reconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					// Creating a new Cluster does not trigger rollouts.
+					{Namespace: "default", Name: "my-cluster"}: {},
+				},
 			},
 			wantErr: false,
 		},
@@ -139,6 +150,10 @@ func Test_topologyClient_Plan(t *testing.T) {
 					{kind: "KubeadmControlPlane", namespace: "default", namePrefix: "my-cluster-"},
 				},
 				reconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					// Scaling the control plane does not trigger a rollout.
+					{Namespace: "default", Name: "my-cluster"}: {},
+				},
 			},
 			wantErr: false,
 		},
@@ -172,6 +187,11 @@ func Test_topologyClient_Plan(t *testing.T) {
 					{kind: "DockerMachineTemplate", namespace: "default", namePrefix: "my-cluster-control-plane-"},
 				},
 				reconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					{Namespace: "default", Name: "my-cluster"}: {
+						{Kind: "KubeadmControlPlane", Name: "my-cluster-fwbpf", Replicas: 1},
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -201,6 +221,15 @@ func Test_topologyClient_Plan(t *testing.T) {
 				modified:          []item{},
 				created:           []item{},
 				reconciledCluster: nil,
+				// Changes are computed for all the affected Clusters.
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					{Namespace: "default", Name: "my-cluster"}: {
+						{Kind: "KubeadmControlPlane", Name: "my-cluster-fwbpf", Replicas: 1},
+					},
+					{Namespace: "default", Name: "my-second-cluster"}: {
+						{Kind: "KubeadmControlPlane", Name: "my-second-cluster-fwbpf", Replicas: 1},
+					},
+				},
 			},
 			wantErr: false,
 		},
@@ -237,9 +266,126 @@ func Test_topologyClient_Plan(t *testing.T) {
 					{kind: "DockerMachineTemplate", namespace: "default", namePrefix: "my-cluster-control-plane-"},
 				},
 				reconciledCluster: &client.ObjectKey{Namespace: "default", Name: "my-cluster"},
+				// Changes are computed only for the target Cluster.
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					{Namespace: "default", Name: "my-cluster"}: {
+						{Kind: "KubeadmControlPlane", Name: "my-cluster-fwbpf", Replicas: 1},
+					},
+				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Editing an existing ClusterClass in place. Affects multiple clusters.",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterYAML,
+				existingMySecondClusterYAML,
+			),
+			args: args{
+				in: &TopologyPlanInput{
+					EditClusterClass: "my-cluster-class",
+					TargetNamespace:  "default",
+					// Adds a label to the control plane machines.
+					Editor: func(original []byte) ([]byte, error) {
+						return []byte(strings.Replace(string(original), "    metadata: {}\n", "    metadata:\n      labels:\n        foo: bar\n", 1)), nil
+					},
+				},
+			},
+			want: out{
+				affectedClusters: func() []client.ObjectKey {
+					cluster := client.ObjectKey{Namespace: "default", Name: "my-cluster"}
+					cluster2 := client.ObjectKey{Namespace: "default", Name: "my-second-cluster"}
+					return []client.ObjectKey{cluster, cluster2}
+				}(),
+				affectedClusterClasses: func() []client.ObjectKey {
+					cc := client.ObjectKey{Namespace: "default", Name: "my-cluster-class"}
+					return []client.ObjectKey{cc}
+				}(),
+				reconciledCluster: nil,
+				// Labels are propagated in place to the control plane machines, so they do not trigger a rollout.
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					{Namespace: "default", Name: "my-cluster"}:        {},
+					{Namespace: "default", Name: "my-second-cluster"}: {},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Editing an existing ClusterClass in place. Failures on one of the affected clusters are reported for that cluster.",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterYAML,
+				existingMyBrokenClusterYAML,
+			),
+			args: args{
+				in: &TopologyPlanInput{
+					EditClusterClass: "my-cluster-class",
+					TargetNamespace:  "default",
+					// Adds a label to the control plane machines.
+					Editor: func(original []byte) ([]byte, error) {
+						return []byte(strings.Replace(string(original), "    metadata: {}\n", "    metadata:\n      labels:\n        foo: bar\n", 1)), nil
+					},
+				},
+			},
+			want: out{
+				affectedClusters: func() []client.ObjectKey {
+					cluster := client.ObjectKey{Namespace: "default", Name: "my-cluster"}
+					cluster2 := client.ObjectKey{Namespace: "default", Name: "my-broken-cluster"}
+					return []client.ObjectKey{cluster, cluster2}
+				}(),
+				affectedClusterClasses: func() []client.ObjectKey {
+					cc := client.ObjectKey{Namespace: "default", Name: "my-cluster-class"}
+					return []client.ObjectKey{cc}
+				}(),
+				reconciledCluster: nil,
+				rollouts: map[client.ObjectKey][]MachineRollout{
+					{Namespace: "default", Name: "my-cluster"}:        {},
+					{Namespace: "default", Name: "my-broken-cluster"}: {},
+				},
+				failedClusters: []client.ObjectKey{
+					{Namespace: "default", Name: "my-broken-cluster"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Editing an existing ClusterClass in place without changes should return error",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterYAML,
+			),
+			args: args{
+				in: &TopologyPlanInput{
+					EditClusterClass: "my-cluster-class",
+					TargetNamespace:  "default",
+					Editor: func(original []byte) ([]byte, error) {
+						return original, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Editing a ClusterClass which does not exist should return error",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+			),
+			args: args{
+				in: &TopologyPlanInput{
+					EditClusterClass: "does-not-exist",
+					TargetNamespace:  "default",
+					Editor: func(original []byte) ([]byte, error) {
+						return original, nil
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Input with objects in different namespaces should return error",
 			args: args{
@@ -321,6 +467,140 @@ func Test_topologyClient_Plan(t *testing.T) {
 			for _, deleted := range tt.want.deleted {
 				g.Expect(res.Deleted).To(ContainElement(MatchTopologyPlanOutputItem(deleted.kind, deleted.namespace, deleted.namePrefix)))
 			}
+
+			// Check the changes and the rollouts for each of the reconciled clusters.
+			if tt.want.rollouts != nil {
+				g.Expect(res.ClusterChanges).To(HaveLen(len(tt.want.rollouts)))
+				for _, changes := range res.ClusterChanges {
+					g.Expect(tt.want.rollouts).To(HaveKey(changes.Cluster))
+					g.Expect(changes.Rollouts).To(ConsistOf(tt.want.rollouts[changes.Cluster]))
+				}
+			}
+
+			// Check the clusters for which the changes can't be computed.
+			failedClusters := []client.ObjectKey{}
+			for _, changes := range res.ClusterChanges {
+				if changes.Error != nil {
+					failedClusters = append(failedClusters, changes.Cluster)
+				}
+			}
+			g.Expect(failedClusters).To(ConsistOf(tt.want.failedClusters))
+		})
+	}
+}
+
+func Test_machineRollouts(t *testing.T) {
+	controlPlaneRef := &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: "cp"}
+	obj := func(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		u.SetAPIVersion(clusterv1.GroupVersion.String())
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+
+	tests := []struct {
+		name     string
+		modified []*PatchSummary
+		want     []MachineRollout
+	}{
+		{
+			name: "Control plane version change triggers a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("KubeadmControlPlane", "cp", map[string]interface{}{"version": "v1.23.0", "replicas": int64(3)}),
+				After:  obj("KubeadmControlPlane", "cp", map[string]interface{}{"version": "v1.24.0", "replicas": int64(3)}),
+			}},
+			want: []MachineRollout{{Kind: "KubeadmControlPlane", Name: "cp", Replicas: 3}},
+		},
+		{
+			name: "Control plane replicas and node drain timeout changes do not trigger a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("KubeadmControlPlane", "cp", map[string]interface{}{"replicas": int64(1), "machineTemplate": map[string]interface{}{"nodeDrainTimeout": "1s"}}),
+				After:  obj("KubeadmControlPlane", "cp", map[string]interface{}{"replicas": int64(3), "machineTemplate": map[string]interface{}{"nodeDrainTimeout": "2s"}}),
+			}},
+			want: []MachineRollout{},
+		},
+		{
+			name: "Control plane machine template metadata and node deletion timeout changes do not trigger a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("KubeadmControlPlane", "cp", map[string]interface{}{"machineTemplate": map[string]interface{}{"metadata": map[string]interface{}{}, "nodeDeletionTimeout": "1s"}}),
+				After:  obj("KubeadmControlPlane", "cp", map[string]interface{}{"machineTemplate": map[string]interface{}{"metadata": map[string]interface{}{"labels": map[string]interface{}{"foo": "bar"}}, "nodeDeletionTimeout": "2s"}}),
+			}},
+			want: []MachineRollout{},
+		},
+		{
+			name: "Control plane rollout and remediation strategy changes do not trigger a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("KubeadmControlPlane", "cp", map[string]interface{}{"rolloutStrategy": map[string]interface{}{"type": "RollingUpdate", "rollingUpdate": map[string]interface{}{"maxSurge": int64(1)}}}),
+				After: obj("KubeadmControlPlane", "cp", map[string]interface{}{
+					"rolloutStrategy":     map[string]interface{}{"type": "RollingUpdate", "rollingUpdate": map[string]interface{}{"maxSurge": int64(0)}},
+					"remediationStrategy": map[string]interface{}{"maxRetry": int64(3)},
+					"rolloutAfter":        "2022-01-01T00:00:00Z",
+				}),
+			}},
+			want: []MachineRollout{},
+		},
+		{
+			name: "Control plane infrastructure machine template change triggers a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("KubeadmControlPlane", "cp", map[string]interface{}{"machineTemplate": map[string]interface{}{"infrastructureRef": map[string]interface{}{"name": "cp-1"}}}),
+				After:  obj("KubeadmControlPlane", "cp", map[string]interface{}{"machineTemplate": map[string]interface{}{"infrastructureRef": map[string]interface{}{"name": "cp-2"}}}),
+			}},
+			want: []MachineRollout{{Kind: "KubeadmControlPlane", Name: "cp", Replicas: 1}},
+		},
+		{
+			name: "Control plane KubeadmConfigSpec change triggers a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("KubeadmControlPlane", "cp", map[string]interface{}{"kubeadmConfigSpec": map[string]interface{}{"preKubeadmCommands": []interface{}{"foo"}}}),
+				After:  obj("KubeadmControlPlane", "cp", map[string]interface{}{"kubeadmConfigSpec": map[string]interface{}{"preKubeadmCommands": []interface{}{"bar"}}}),
+			}},
+			want: []MachineRollout{{Kind: "KubeadmControlPlane", Name: "cp", Replicas: 1}},
+		},
+		{
+			name: "MachineDeployment template change triggers a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("MachineDeployment", "md", map[string]interface{}{"replicas": int64(2), "template": map[string]interface{}{"spec": map[string]interface{}{"version": "v1.23.0"}}}),
+				After:  obj("MachineDeployment", "md", map[string]interface{}{"replicas": int64(2), "template": map[string]interface{}{"spec": map[string]interface{}{"version": "v1.24.0"}}}),
+			}},
+			want: []MachineRollout{{Kind: "MachineDeployment", Name: "md", Replicas: 2}},
+		},
+		{
+			name: "MachineDeployment template in-place mutable fields changes do not trigger a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("MachineDeployment", "md", map[string]interface{}{"template": map[string]interface{}{
+					"metadata": map[string]interface{}{},
+					"spec":     map[string]interface{}{"clusterName": "c", "nodeDrainTimeout": "1s", "nodeDeletionTimeout": "1s"},
+				}}),
+				After: obj("MachineDeployment", "md", map[string]interface{}{"template": map[string]interface{}{
+					"metadata": map[string]interface{}{"labels": map[string]interface{}{"foo": "bar"}, "annotations": map[string]interface{}{"foo": "bar"}},
+					"spec":     map[string]interface{}{"clusterName": "c", "nodeDrainTimeout": "2s", "nodeDeletionTimeout": "2s"},
+				}}),
+			}},
+			want: []MachineRollout{},
+		},
+		{
+			name: "MachineDeployment replicas change does not trigger a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("MachineDeployment", "md", map[string]interface{}{"replicas": int64(2)}),
+				After:  obj("MachineDeployment", "md", map[string]interface{}{"replicas": int64(5)}),
+			}},
+			want: []MachineRollout{},
+		},
+		{
+			name: "Changes to other objects do not trigger a rollout",
+			modified: []*PatchSummary{{
+				Before: obj("DockerMachineTemplate", "cp", map[string]interface{}{"template": "a"}),
+				After:  obj("DockerMachineTemplate", "cp", map[string]interface{}{"template": "b"}),
+			}},
+			want: []MachineRollout{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := machineRollouts(&ChangeSummary{Modified: tt.modified}, controlPlaneRef)
+			g.Expect(got).To(Equal(tt.want))
 		})
 	}
 }
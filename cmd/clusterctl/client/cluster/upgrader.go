@@ -33,6 +33,7 @@ import (
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/util"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 )
 
@@ -44,10 +45,21 @@ type ProviderUpgrader interface {
 	Plan() ([]UpgradePlan, error)
 
 	// ApplyPlan executes an upgrade following an UpgradePlan generated by clusterctl.
-	ApplyPlan(clusterAPIVersion string) error
+	ApplyPlan(opts UpgradeOptions, clusterAPIVersion string) error
 
 	// ApplyCustomPlan plan executes an upgrade using the UpgradeItems provided by the user.
-	ApplyCustomPlan(providersToUpgrade ...UpgradeItem) error
+	ApplyCustomPlan(opts UpgradeOptions, providersToUpgrade ...UpgradeItem) error
+}
+
+// UpgradeOptions defines the options used to configure the upgrade.
+type UpgradeOptions struct {
+	// WaitProviders instructs the upgrade to wait till the upgraded providers are available;
+	// if a provider does not become available within WaitProviderTimeout, the previous versions of all the
+	// upgraded providers are restored.
+	WaitProviders bool
+
+	// WaitProviderTimeout sets the timeout per provider wait.
+	WaitProviderTimeout time.Duration
 }
 
 // UpgradePlan defines a list of possible upgrade targets for a management cluster.
@@ -149,7 +161,7 @@ func (u *providerUpgrader) Plan() ([]UpgradePlan, error) {
 	return ret, nil
 }
 
-func (u *providerUpgrader) ApplyPlan(contract string) error {
+func (u *providerUpgrader) ApplyPlan(opts UpgradeOptions, contract string) error {
 	if contract != clusterv1.GroupVersion.Version {
 		return errors.Errorf("current version of clusterctl could only upgrade to %s contract, requested %s", clusterv1.GroupVersion.Version, contract)
 	}
@@ -169,10 +181,10 @@ func (u *providerUpgrader) ApplyPlan(contract string) error {
 	}
 
 	// Do the upgrade
-	return u.doUpgrade(upgradePlan)
+	return u.doUpgrade(upgradePlan, opts)
 }
 
-func (u *providerUpgrader) ApplyCustomPlan(upgradeItems ...UpgradeItem) error {
+func (u *providerUpgrader) ApplyCustomPlan(opts UpgradeOptions, upgradeItems ...UpgradeItem) error {
 	log := logf.Log
 	log.Info("Performing upgrade...")
 
@@ -184,7 +196,7 @@ func (u *providerUpgrader) ApplyCustomPlan(upgradeItems ...UpgradeItem) error {
 	}
 
 	// Do the upgrade
-	return u.doUpgrade(upgradePlan)
+	return u.doUpgrade(upgradePlan, opts)
 }
 
 // getUpgradePlan returns the upgrade plan for a specific set of providers/contract
@@ -346,7 +358,11 @@ func (u *providerUpgrader) getUpgradeComponents(provider UpgradeItem) (repositor
 	return components, nil
 }
 
-func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
+// doUpgrade executes an upgrade plan.
+// The upgrade is transactional: a snapshot of the components and of the inventory of the providers is taken before
+// changing anything, and if the upgrade fails midway, or if the new versions of the providers do not become available,
+// the previous versions of the providers are restored.
+func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan, opts UpgradeOptions) error {
 	// Check for multiple instances of the same provider if current contract is v1alpha3.
 	if upgradePlan.Contract == clusterv1.GroupVersion.Version {
 		if err := u.providerInventory.CheckSingleProviderInstance(); err != nil {
@@ -360,6 +376,39 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 		return providers[a].GetProviderType().Order() < providers[b].GetProviderType().Order()
 	})
 
+	// Take a snapshot of all the providers to be upgraded, so it is possible to roll back if something goes wrong.
+	// NOTE: this is done before changing anything in the management cluster, so if the snapshot fails there is nothing to roll back.
+	snapshots := []*providerSnapshot{}
+	for _, upgradeItem := range providers {
+		// If there is not a specified next version, skip it (we are already up-to-date).
+		if upgradeItem.NextVersion == "" {
+			continue
+		}
+
+		snapshot, err := u.snapshotProvider(upgradeItem.Provider)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if err := u.upgradeProviders(providers, opts); err != nil {
+		return u.rollback(snapshots, err)
+	}
+
+	// Delete webhook namespace since it's not needed from v1alpha4.
+	if upgradePlan.Contract == clusterv1.GroupVersion.Version {
+		if err := u.providerComponents.DeleteWebhookNamespace(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// upgradeProviders replaces the providers with the next version defined in the upgrade items, and eventually waits
+// for the new versions to become available.
+func (u *providerUpgrader) upgradeProviders(providers []UpgradeItem, opts UpgradeOptions) error {
 	// Scale down all providers.
 	// This is done to ensure all Pods of all "old" provider Deployments have been deleted.
 	// Otherwise it can happen that a provider Pod survives the upgrade because we create
@@ -376,11 +425,12 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 
 		// Scale down provider.
 		if err := u.scaleDownProvider(upgradeItem.Provider); err != nil {
-			return err
+			return errors.Wrapf(err, "failed to upgrade provider %s", upgradeItem.InstanceName())
 		}
 	}
 
 	// Delete old providers and deploy new ones if necessary, i.e. there is a NextVersion.
+	installedComponents := map[string]repository.Components{}
 	for _, upgradeItem := range providers {
 		// If there is not a specified next version, skip it (we are already up-to-date).
 		if upgradeItem.NextVersion == "" {
@@ -390,7 +440,7 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 		// Gets the provider components for the target version.
 		components, err := u.getUpgradeComponents(upgradeItem)
 		if err != nil {
-			return err
+			return errors.Wrapf(err, "failed to upgrade provider %s to %s", upgradeItem.InstanceName(), upgradeItem.NextVersion)
 		}
 
 		// Delete the provider, preserving CRD, namespace and the inventory.
@@ -400,19 +450,35 @@ func (u *providerUpgrader) doUpgrade(upgradePlan *UpgradePlan) error {
 			IncludeCRDs:      false,
 			SkipInventory:    true,
 		}); err != nil {
-			return err
+			return errors.Wrapf(err, "failed to upgrade provider %s to %s", upgradeItem.InstanceName(), upgradeItem.NextVersion)
 		}
 
 		// Install the new version of the provider components.
 		if err := installComponentsAndUpdateInventory(components, u.providerComponents, u.providerInventory); err != nil {
-			return err
+			return errors.Wrapf(err, "failed to upgrade provider %s to %s", upgradeItem.InstanceName(), upgradeItem.NextVersion)
 		}
+		installedComponents[upgradeItem.InstanceName()] = components
 	}
 
-	// Delete webhook namespace since it's not needed from v1alpha4.
-	if upgradePlan.Contract == clusterv1.GroupVersion.Version {
-		if err := u.providerComponents.DeleteWebhookNamespace(); err != nil {
-			return err
+	if !opts.WaitProviders {
+		return nil
+	}
+
+	// Wait for the new versions of the providers to become available.
+	log := logf.Log
+	log.Info("Waiting for providers to be available...")
+	for _, upgradeItem := range providers {
+		components, ok := installedComponents[upgradeItem.InstanceName()]
+		if !ok {
+			continue
+		}
+		for _, obj := range components.Objs() {
+			if !util.IsDeploymentWithManager(obj) {
+				continue
+			}
+			if err := waitDeploymentReady(u.proxy, obj, opts.WaitProviderTimeout); err != nil {
+				return errors.Wrapf(err, "provider %s version %s did not become available", upgradeItem.InstanceName(), upgradeItem.NextVersion)
+			}
 		}
 	}
 
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	utilresource "sigs.k8s.io/cluster-api/util/resource"
+)
+
+const (
+	endpointsKind     = "Endpoints"
+	endpointSliceKind = "EndpointSlice"
+	serviceKind       = "Service"
+)
+
+// providerSnapshot is a copy of the components and of the inventory entry of a provider, taken before an upgrade
+// in order to restore the previous version of the provider if the upgrade fails.
+// NOTE: CRDs are not included in the snapshot, because after an API version bump the CRDs of the previous version
+// can't be applied anymore (the API server rejects CRDs not serving all the versions in status.storedVersions); the
+// upgraded CRDs are preserved instead, given that they are expected to serve the previous API versions too.
+type providerSnapshot struct {
+	provider clusterctlv1.Provider
+	objs     []unstructured.Unstructured
+}
+
+// snapshotProvider takes a snapshot of the components and of the inventory entry of a provider.
+func (u *providerUpgrader) snapshotProvider(provider clusterctlv1.Provider) (*providerSnapshot, error) {
+	log := logf.Log
+	log.V(1).Info("Taking a snapshot", "Provider", provider.Name, "Version", provider.Version, "Namespace", provider.Namespace)
+
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabelName: "",
+		clusterv1.ProviderLabelName:      provider.ManifestLabel(),
+	}
+	resources, err := u.proxy.ListResources(labels, provider.Namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to take a snapshot of provider %s", provider.InstanceName())
+	}
+
+	snapshot := &providerSnapshot{
+		provider: *provider.DeepCopy(),
+	}
+	snapshot.provider.ResourceVersion = ""
+
+	for i := range resources {
+		obj := resources[i]
+
+		// Skip the inventory entry, which is restored using the inventory client.
+		if obj.GroupVersionKind().GroupKind().String() == providerGroupKind {
+			continue
+		}
+		// Skip CRDs, which are preserved across the rollback.
+		if obj.GetKind() == customResourceDefinitionKind {
+			continue
+		}
+		// Skip objects generated by controllers, like ReplicaSets and Pods; they are generated again when the
+		// objects owning them are restored.
+		if len(obj.GetOwnerReferences()) > 0 || obj.GetKind() == endpointsKind || obj.GetKind() == endpointSliceKind {
+			continue
+		}
+
+		snapshot.objs = append(snapshot.objs, cleanupSnapshotObject(obj))
+	}
+	snapshot.objs = utilresource.SortForCreate(snapshot.objs)
+
+	return snapshot, nil
+}
+
+// cleanupSnapshotObject removes from an object the fields set by the API server, so the object can be created again.
+func cleanupSnapshotObject(obj unstructured.Unstructured) unstructured.Unstructured {
+	obj = *obj.DeepCopy()
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetGeneration(0)
+	obj.SetSelfLink("")
+	obj.SetManagedFields(nil)
+	obj.SetDeletionTimestamp(nil)
+	unstructured.RemoveNestedField(obj.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	// The cluster IP of a service can't be reused until the service being replaced is deleted, so let the API server
+	// allocate a new one.
+	if obj.GetKind() == serviceKind {
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+	}
+	return obj
+}
+
+// rollback restores the providers from the snapshots taken before the upgrade, and returns an error
+// reporting the upgrade failure and the result of the rollback.
+func (u *providerUpgrader) rollback(snapshots []*providerSnapshot, upgradeErr error) error {
+	log := logf.Log
+	log.Info("Upgrade failed, restoring the previous versions of the providers", "Error", upgradeErr.Error())
+
+	// Restore providers in the reverse order of the upgrade.
+	errList := []error{}
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if err := u.restoreProvider(snapshots[i]); err != nil {
+			errList = append(errList, err)
+		}
+	}
+
+	if len(errList) > 0 {
+		return errors.Errorf("%v; failed to restore the previous versions of the providers: %v", upgradeErr, kerrors.NewAggregate(errList))
+	}
+	return errors.Wrap(upgradeErr, "the previous versions of the providers have been restored")
+}
+
+// restoreProvider replaces the components of a provider with the ones from a snapshot.
+func (u *providerUpgrader) restoreProvider(snapshot *providerSnapshot) error {
+	provider := snapshot.provider
+
+	log := logf.Log
+	log.Info("Restoring", "Provider", provider.Name, "Version", provider.Version, "Namespace", provider.Namespace)
+
+	// Scale down and delete the components installed by the upgrade, if any, preserving CRD, namespace and the inventory.
+	if err := u.scaleDownProvider(provider); err != nil {
+		return errors.Wrapf(err, "failed to restore provider %s version %s", provider.InstanceName(), provider.Version)
+	}
+	if err := u.providerComponents.Delete(DeleteOptions{
+		Provider:         provider,
+		IncludeNamespace: false,
+		IncludeCRDs:      false,
+		SkipInventory:    true,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to restore provider %s version %s", provider.InstanceName(), provider.Version)
+	}
+
+	// Create the components from the snapshot, and restore the inventory entry.
+	if err := u.providerComponents.Create(snapshot.objs); err != nil {
+		return errors.Wrapf(err, "failed to restore provider %s version %s", provider.InstanceName(), provider.Version)
+	}
+	if err := u.providerInventory.Create(provider); err != nil {
+		return errors.Wrapf(err, "failed to restore the inventory of provider %s version %s", provider.InstanceName(), provider.Version)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_providerUpgrader_rollback(t *testing.T) {
+	g := NewWithT(t)
+
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabelName: "",
+		clusterv1.ProviderLabelName:      "infrastructure-infra",
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra-system", Name: "infra-config", Labels: labels},
+		Data:       map[string]string{"version": "v2.0.0"},
+	}
+	ownedConfigMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "infra-system",
+			Name:            "infra-owned",
+			Labels:          labels,
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "infra-config"}},
+		},
+	}
+
+	proxy := test.NewFakeProxy().
+		WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v2.0.0", "infra-system").
+		WithObjs(configMap, ownedConfigMap)
+	inventory := newInventoryClient(proxy, nil)
+	u := &providerUpgrader{
+		proxy:              proxy,
+		providerInventory:  inventory,
+		providerComponents: newComponentsClient(proxy),
+	}
+
+	providers, err := inventory.List()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providers.Items).To(HaveLen(1))
+
+	snapshot, err := u.snapshotProvider(providers.Items[0])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot.provider.Version).To(Equal("v2.0.0"))
+	// Only the ConfigMap not owned by other objects is included in the snapshot; the inventory is stored separately.
+	g.Expect(snapshot.objs).To(HaveLen(1))
+	g.Expect(snapshot.objs[0].GetName()).To(Equal("infra-config"))
+
+	// Simulate an upgrade failing after changing the provider.
+	c, err := proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(c.Delete(ctx, configMap)).To(Succeed())
+	upgradedProvider := providers.Items[0].DeepCopy()
+	upgradedProvider.Version = "v2.0.1"
+	g.Expect(inventory.Create(*upgradedProvider)).To(Succeed())
+
+	err = u.rollback([]*providerSnapshot{snapshot}, errors.New("provider infra-system/infrastructure-infra did not become available"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("did not become available"))
+	g.Expect(err.Error()).To(ContainSubstring("the previous versions of the providers have been restored"))
+
+	gotConfigMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(configMap), gotConfigMap)).To(Succeed())
+	g.Expect(gotConfigMap.Data).To(Equal(configMap.Data))
+
+	gotProvider := &clusterctlv1.Provider{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&providers.Items[0]), gotProvider)).To(Succeed())
+	g.Expect(gotProvider.Version).To(Equal("v2.0.0"))
+}
+
+func Test_providerUpgrader_rollbackAcrossAPIVersionBump(t *testing.T) {
+	g := NewWithT(t)
+
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabelName: "",
+		clusterv1.ProviderLabelName:      "infrastructure-infra",
+	}
+	crd := func(versions ...string) *apiextensionsv1.CustomResourceDefinition {
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition"},
+			ObjectMeta: metav1.ObjectMeta{Name: "infraclusters.infrastructure.cluster.x-k8s.io", Labels: labels},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "infrastructure.cluster.x-k8s.io",
+				Names: apiextensionsv1.CustomResourceDefinitionNames{Kind: "InfraCluster", Plural: "infraclusters"},
+				Scope: apiextensionsv1.NamespaceScoped,
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{StoredVersions: versions},
+		}
+		for i, v := range versions {
+			crd.Spec.Versions = append(crd.Spec.Versions, apiextensionsv1.CustomResourceDefinitionVersion{
+				Name:    v,
+				Served:  true,
+				Storage: i == len(versions)-1,
+			})
+		}
+		return crd
+	}
+	configMap := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "infra-system", Name: "infra-config", Labels: labels},
+		Data:       map[string]string{"version": "v1.0.0"},
+	}
+
+	proxy := test.NewFakeProxy().
+		WithProviderInventory("infra", clusterctlv1.InfrastructureProviderType, "v1.0.0", "infra-system").
+		WithObjs(crd("v1alpha4"), configMap)
+	inventory := newInventoryClient(proxy, nil)
+	u := &providerUpgrader{
+		proxy:              proxy,
+		providerInventory:  inventory,
+		providerComponents: newComponentsClient(proxy),
+	}
+
+	providers, err := inventory.List()
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(providers.Items).To(HaveLen(1))
+
+	// CRDs are not included in the snapshot.
+	snapshot, err := u.snapshotProvider(providers.Items[0])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(snapshot.objs).To(HaveLen(1))
+	g.Expect(snapshot.objs[0].GetName()).To(Equal("infra-config"))
+
+	// Simulate an upgrade bumping the API version of the provider, and then failing; after the upgrade
+	// the CRD stores objects in both the API versions, so the CRD of the previous version can't be applied anymore.
+	c, err := proxy.NewClient()
+	g.Expect(err).NotTo(HaveOccurred())
+	upgradedCRD := crd("v1alpha4", "v1beta1")
+	gotCRD := &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(upgradedCRD), gotCRD)).To(Succeed())
+	upgradedCRD.ResourceVersion = gotCRD.ResourceVersion
+	g.Expect(c.Update(ctx, upgradedCRD)).To(Succeed())
+	g.Expect(c.Delete(ctx, configMap)).To(Succeed())
+	upgradedProvider := providers.Items[0].DeepCopy()
+	upgradedProvider.Version = "v2.0.0"
+	g.Expect(inventory.Create(*upgradedProvider)).To(Succeed())
+
+	err = u.rollback([]*providerSnapshot{snapshot}, errors.New("provider infra-system/infrastructure-infra did not become available"))
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.Error()).To(ContainSubstring("the previous versions of the providers have been restored"))
+
+	// The upgraded CRD is preserved, while the other components and the inventory are restored.
+	gotCRD = &apiextensionsv1.CustomResourceDefinition{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(upgradedCRD), gotCRD)).To(Succeed())
+	g.Expect(gotCRD.Spec.Versions).To(HaveLen(2))
+	g.Expect(gotCRD.Status.StoredVersions).To(Equal([]string{"v1alpha4", "v1beta1"}))
+
+	gotConfigMap := &corev1.ConfigMap{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(configMap), gotConfigMap)).To(Succeed())
+	g.Expect(gotConfigMap.Data).To(Equal(configMap.Data))
+
+	gotProvider := &clusterctlv1.Provider{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(&providers.Items[0]), gotProvider)).To(Succeed())
+	g.Expect(gotProvider.Version).To(Equal("v1.0.0"))
+}
+
+func Test_cleanupSnapshotObject(t *testing.T) {
+	g := NewWithT(t)
+
+	obj := unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":              "webhook-service",
+			"namespace":         "infra-system",
+			"resourceVersion":   "42",
+			"uid":               "a-uid",
+			"creationTimestamp": "2022-01-01T00:00:00Z",
+		},
+		"spec": map[string]interface{}{
+			"clusterIP":  "10.0.0.1",
+			"clusterIPs": []interface{}{"10.0.0.1"},
+			"ports":      []interface{}{map[string]interface{}{"port": int64(443)}},
+		},
+		"status": map[string]interface{}{},
+	}}
+
+	got := cleanupSnapshotObject(obj)
+	g.Expect(got.Object).To(Equal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata": map[string]interface{}{
+			"name":      "webhook-service",
+			"namespace": "infra-system",
+		},
+		"spec": map[string]interface{}{
+			"ports": []interface{}{map[string]interface{}{"port": int64(443)}},
+		},
+	}))
+	// The original object is not modified.
+	g.Expect(obj.GetResourceVersion()).To(Equal("42"))
+}
@@ -976,7 +976,7 @@ func Test_providerUpgrader_ApplyPlan(t *testing.T) {
 				},
 				providerInventory: newInventoryClient(tt.fields.proxy, nil),
 			}
-			err := u.ApplyPlan(tt.contract)
+			err := u.ApplyPlan(UpgradeOptions{}, tt.contract)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).Should(ContainSubstring(tt.errorMsg))
@@ -1112,7 +1112,7 @@ func Test_providerUpgrader_ApplyCustomPlan(t *testing.T) {
 				},
 				providerInventory: newInventoryClient(tt.fields.proxy, nil),
 			}
-			err := u.ApplyCustomPlan(tt.providersToUpgrade...)
+			err := u.ApplyCustomPlan(UpgradeOptions{}, tt.providersToUpgrade...)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 				g.Expect(err.Error()).Should(ContainSubstring(tt.errorMsg))
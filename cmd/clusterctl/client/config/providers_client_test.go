@@ -40,6 +40,10 @@ func Test_providers_List(t *testing.T) {
 	})
 
 	defaultsAndZZZ := append(defaults, NewProvider("zzz", "https://zzz/infrastructure-components.yaml", "InfrastructureProvider"))
+	// AddonProviders are after InfrastructureProviders in the sorted list of providers.
+	sort.Slice(defaultsAndZZZ, func(i, j int) bool {
+		return defaultsAndZZZ[i].Less(defaultsAndZZZ[j])
+	})
 
 	defaultsWithOverride := append([]Provider{}, defaults...)
 	defaultsWithOverride[0] = NewProvider(defaults[0].Name(), "https://zzz/infrastructure-components.yaml", defaults[0].Type())
@@ -19,6 +19,9 @@ package config
 const (
 	// GitHubTokenVariable defines a variable hosting the GitHub access token.
 	GitHubTokenVariable = "github-token"
+
+	// GitLabAccessTokenVariable defines a variable hosting the GitLab access token.
+	GitLabAccessTokenVariable = "gitlab-access-token"
 )
 
 // VariablesClient has methods to work with environment variables and with variables defined in the clusterctl configuration file.
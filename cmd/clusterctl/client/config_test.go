@@ -81,6 +81,8 @@ func Test_clusterctlClient_GetProvidersConfig(t *testing.T) {
 				config.SideroProviderName,
 				config.VclusterProviderName,
 				config.VSphereProviderName,
+				config.InClusterIPAMProviderName,
+				config.HelmAddonProviderName,
 			},
 			wantErr: false,
 		},
@@ -118,6 +120,8 @@ func Test_clusterctlClient_GetProvidersConfig(t *testing.T) {
 				config.SideroProviderName,
 				config.VclusterProviderName,
 				config.VSphereProviderName,
+				config.InClusterIPAMProviderName,
+				config.HelmAddonProviderName,
 			},
 			wantErr: false,
 		},
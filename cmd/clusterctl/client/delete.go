@@ -45,6 +45,15 @@ type DeleteOptions struct {
 	// If unspecified, the kubeadm control plane provider latest release is used.
 	ControlPlaneProviders []string
 
+	// IPAMProviders and versions (e.g. infoblox:v0.0.1) to delete from the management cluster.
+	IPAMProviders []string
+
+	// RuntimeExtensionProviders and versions (e.g. test:v0.0.1) to delete from the management cluster.
+	RuntimeExtensionProviders []string
+
+	// AddonProviders and versions (e.g. helm:v0.1.0) to delete from the management cluster.
+	AddonProviders []string
+
 	// DeleteAll set for deletion of all the providers.
 	DeleteAll bool
 
@@ -109,6 +118,21 @@ func (c *clusterctlClient) Delete(options DeleteOptions) error {
 			return err
 		}
 
+		providers, err = appendProviders(providers, clusterctlv1.IPAMProviderType, options.IPAMProviders...)
+		if err != nil {
+			return err
+		}
+
+		providers, err = appendProviders(providers, clusterctlv1.RuntimeExtensionProviderType, options.RuntimeExtensionProviders...)
+		if err != nil {
+			return err
+		}
+
+		providers, err = appendProviders(providers, clusterctlv1.AddonProviderType, options.AddonProviders...)
+		if err != nil {
+			return err
+		}
+
 		for _, provider := range providers {
 			// Try to detect the namespace where the provider lives
 			provider.Namespace, err = clusterClient.ProviderInventory().GetProviderNamespace(provider.ProviderName, provider.GetProviderType())
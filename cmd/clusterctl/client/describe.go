@@ -98,3 +98,55 @@ func (c *clusterctlClient) DescribeCluster(options DescribeClusterOptions) (*tre
 		Grouping:                options.Grouping,
 	})
 }
+
+// DescribeClusterClassOptions carries the options supported by DescribeClusterClass.
+type DescribeClusterClassOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the ClusterClasses are located. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// ClusterClassName to be described. If empty, all the ClusterClasses in the namespace will be described.
+	ClusterClassName string
+
+	// Grouping groups Clusters in case the ready conditions
+	// have the same Status, Severity and Reason.
+	Grouping bool
+}
+
+// DescribeClusterClass returns an object tree for each ClusterClass, representing the Clusters using it
+// and whether they are in sync with it.
+func (c *clusterctlClient) DescribeClusterClass(options DescribeClusterClassOptions) ([]*tree.ObjectTree, error) {
+	// gets access to the management cluster
+	cluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure this command only runs against management clusters with the current Cluster API contract.
+	if err := cluster.ProviderInventory().CheckCAPIContract(); err != nil {
+		return nil, err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := cluster.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	// Fetch the Cluster client.
+	client, err := cluster.Proxy().NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	// Gets the object trees representing the ClusterClasses and the Clusters using them.
+	return tree.DiscoverClusterClasses(context.TODO(), client, options.Namespace, options.ClusterClassName, tree.DiscoverOptions{
+		Grouping: options.Grouping,
+	})
+}
@@ -53,6 +53,15 @@ type InitOptions struct {
 	// If unspecified, the kubeadm control plane provider latest release is used.
 	ControlPlaneProviders []string
 
+	// IPAMProviders and versions (e.g. infoblox:v0.0.1) to add to the management cluster.
+	IPAMProviders []string
+
+	// RuntimeExtensionProviders and versions (e.g. test:v0.0.1) to add to the management cluster.
+	RuntimeExtensionProviders []string
+
+	// AddonProviders and versions (e.g. helm:v0.1.0) to add to the management cluster.
+	AddonProviders []string
+
 	// TargetNamespace defines the namespace where the providers should be deployed. If unspecified, each provider
 	// will be installed in a provider's default namespace.
 	TargetNamespace string
@@ -220,6 +229,18 @@ func (c *clusterctlClient) setupInstaller(cluster cluster.Client, options InitOp
 		return nil, err
 	}
 
+	if err := c.addToInstaller(addOptions, clusterctlv1.IPAMProviderType, options.IPAMProviders...); err != nil {
+		return nil, err
+	}
+
+	if err := c.addToInstaller(addOptions, clusterctlv1.RuntimeExtensionProviderType, options.RuntimeExtensionProviders...); err != nil {
+		return nil, err
+	}
+
+	if err := c.addToInstaller(addOptions, clusterctlv1.AddonProviderType, options.AddonProviders...); err != nil {
+		return nil, err
+	}
+
 	return installer, nil
 }
 
@@ -214,6 +214,7 @@ func Test_clusterctlClient_Init(t *testing.T) {
 		bootstrapProvider      []string
 		controlPlaneProvider   []string
 		infrastructureProvider []string
+		ipamProvider           []string
 		targetNameSpace        string
 	}
 	type want struct {
@@ -405,6 +406,49 @@ func Test_clusterctlClient_Init(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Init (with an empty cluster) adds an IPAM provider/current contract",
+			field: field{
+				client: fakeEmptyClusterWithProviders(ipamProviderConfig),
+				hasCRD: false,
+			},
+			args: args{
+				coreProvider:           "",  // with an empty cluster, a core provider should be added automatically
+				bootstrapProvider:      nil, // with an empty cluster, a bootstrap provider should be added automatically
+				controlPlaneProvider:   nil, // with an empty cluster, a control plane provider should be added automatically
+				infrastructureProvider: []string{"infra"},
+				ipamProvider:           []string{config.InClusterIPAMProviderName},
+				targetNameSpace:        "",
+			},
+			want: []want{
+				{
+					provider:        capiProviderConfig,
+					version:         "v1.0.0",
+					targetNamespace: "ns1",
+				},
+				{
+					provider:        bootstrapProviderConfig,
+					version:         "v2.0.0",
+					targetNamespace: "ns2",
+				},
+				{
+					provider:        controlPlaneProviderConfig,
+					version:         "v2.0.0",
+					targetNamespace: "ns3",
+				},
+				{
+					provider:        infraProviderConfig,
+					version:         "v3.0.0",
+					targetNamespace: "ns4",
+				},
+				{
+					provider:        ipamProviderConfig,
+					version:         "v2.0.0",
+					targetNamespace: "ns2",
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "Fails when opting out from coreProvider automatic installation",
 			field: field{
@@ -547,6 +591,7 @@ func Test_clusterctlClient_Init(t *testing.T) {
 				BootstrapProviders:      tt.args.bootstrapProvider,
 				ControlPlaneProviders:   tt.args.controlPlaneProvider,
 				InfrastructureProviders: tt.args.infrastructureProvider,
+				IPAMProviders:           tt.args.ipamProvider,
 				TargetNamespace:         tt.args.targetNameSpace,
 			})
 			if tt.wantErr {
@@ -572,6 +617,7 @@ var (
 	bootstrapProviderConfig    = config.NewProvider(config.KubeadmBootstrapProviderName, "url", clusterctlv1.BootstrapProviderType)
 	controlPlaneProviderConfig = config.NewProvider(config.KubeadmControlPlaneProviderName, "url", clusterctlv1.ControlPlaneProviderType)
 	infraProviderConfig        = config.NewProvider("infra", "url", clusterctlv1.InfrastructureProviderType)
+	ipamProviderConfig         = config.NewProvider(config.InClusterIPAMProviderName, "url", clusterctlv1.IPAMProviderType)
 )
 
 // setup a cluster client and the fake configuration for testing.
@@ -612,6 +658,24 @@ func fakeEmptyCluster() *fakeClient {
 	return client
 }
 
+// clusterctl client for an empty management cluster with repositories for the base set of providers
+// plus the given additional providers.
+func fakeEmptyClusterWithProviders(providers ...config.Provider) *fakeClient {
+	config1 := fakeConfig(
+		append([]config.Provider{capiProviderConfig, bootstrapProviderConfig, controlPlaneProviderConfig, infraProviderConfig}, providers...),
+		map[string]string{"SOME_VARIABLE": "value"},
+	)
+
+	additionalProviders := make([]Provider, 0, len(providers))
+	for _, p := range providers {
+		additionalProviders = append(additionalProviders, p)
+	}
+	repositories := fakeRepositories(config1, additionalProviders)
+	cluster1 := fakeCluster(config1, repositories, newFakeCertManagerClient(nil, nil))
+
+	return fakeClusterCtlClient(config1, repositories, []*fakeClusterClient{cluster1})
+}
+
 func fakeConfig(providers []config.Provider, variables map[string]string) *fakeConfigClient {
 	config := newFakeConfig()
 	for _, p := range providers {
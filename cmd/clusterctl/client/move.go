@@ -18,10 +18,22 @@ package client
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/homedir"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 )
 
+// moveCheckpointFileName defines the name of the file, under the clusterctl config folder, where the progress of a move
+// is recorded if no other checkpoint file is specified.
+const moveCheckpointFileName = "move-checkpoint.json"
+
 // MoveOptions carries the options supported by move.
 type MoveOptions struct {
 	// FromKubeconfig defines the kubeconfig to use for accessing the source management cluster. If empty,
@@ -33,11 +45,32 @@ type MoveOptions struct {
 	ToKubeconfig Kubeconfig
 
 	// Namespace where the objects describing the workload cluster exists. If unspecified, the current
-	// namespace will be used.
+	// namespace will be used, unless ClusterSelector or Clusters are set; in this case Clusters from
+	// all the namespaces are considered.
 	Namespace string
 
+	// ClusterSelector is a label selector; if set, only the Clusters with matching labels and the objects
+	// they depend on are moved.
+	ClusterSelector string
+
+	// Clusters is the list of Clusters to be moved, together with the objects they depend on; each Cluster
+	// is defined as namespace/name, or as name only for Clusters in Namespace (or in the current namespace
+	// if Namespace is unspecified).
+	Clusters []string
+
 	// DryRun means the move action is a dry run, no real action will be performed
 	DryRun bool
+
+	// CheckpointFile defines the file where the progress of the move is recorded, so a failed move can be resumed
+	// or rolled back. If unspecified, $HOME/.cluster-api/move-checkpoint.json will be used.
+	CheckpointFile string
+
+	// Resume completes a failed move using the progress recorded in the CheckpointFile.
+	Resume bool
+
+	// Rollback reverts a failed move using the progress recorded in the CheckpointFile, by deleting the objects
+	// already created in the target management cluster and by resuming the Clusters in the source management cluster.
+	Rollback bool
 }
 
 // BackupOptions holds options supported by backup.
@@ -52,6 +85,17 @@ type BackupOptions struct {
 
 	// Directory defines the local directory to store the cluster objects
 	Directory string
+
+	// File defines the archive file to store the cluster objects into, together with a manifest
+	// describing the backup. It can't be combined with Directory.
+	File string
+
+	// Recipients defines the age public keys the archive File is encrypted for.
+	Recipients []string
+
+	// PassphraseFile defines a file containing the passphrase used to encrypt the archive File.
+	// It can't be combined with Recipients.
+	PassphraseFile string
 }
 
 // RestoreOptions holds options supported by restore.
@@ -62,9 +106,25 @@ type RestoreOptions struct {
 
 	// Directory defines the local directory to restore cluster objects from
 	Directory string
+
+	// File defines the archive file to restore cluster objects from. It can't be combined with Directory.
+	File string
+
+	// IdentityFile defines a file containing the age private keys used to decrypt the archive File.
+	IdentityFile string
+
+	// PassphraseFile defines a file containing the passphrase used to decrypt the archive File.
+	PassphraseFile string
 }
 
 func (c *clusterctlClient) Move(options MoveOptions) error {
+	if options.Resume && options.Rollback {
+		return errors.New("resume and rollback cannot be used together")
+	}
+	if (options.Resume || options.Rollback) && options.DryRun {
+		return errors.New("resume and rollback cannot be used with dry run")
+	}
+
 	// Get the client for interacting with the source management cluster.
 	fromCluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.FromKubeconfig})
 	if err != nil {
@@ -100,8 +160,26 @@ func (c *clusterctlClient) Move(options MoveOptions) error {
 		}
 	}
 
+	checkpointFile := options.CheckpointFile
+	if checkpointFile == "" {
+		checkpointFile = filepath.Join(homedir.HomeDir(), config.ConfigFolder, moveCheckpointFileName)
+	}
+
+	if options.Resume {
+		return fromCluster.ObjectMover().ResumeMove(toCluster, checkpointFile)
+	}
+	if options.Rollback {
+		return fromCluster.ObjectMover().RollbackMove(toCluster, checkpointFile)
+	}
+
+	selector, err := clusterSelector(fromCluster, options)
+	if err != nil {
+		return err
+	}
+
 	// If the option specifying the Namespace is empty, try to detect it.
-	if options.Namespace == "" {
+	// NOTE: When moving a selection of Clusters, Clusters from all the namespaces are considered.
+	if options.Namespace == "" && selector.IsEmpty() {
 		currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
 		if err != nil {
 			return err
@@ -109,10 +187,50 @@ func (c *clusterctlClient) Move(options MoveOptions) error {
 		options.Namespace = currentNamespace
 	}
 
-	return fromCluster.ObjectMover().Move(options.Namespace, toCluster, options.DryRun)
+	return fromCluster.ObjectMover().Move(options.Namespace, selector, toCluster, options.DryRun, checkpointFile)
+}
+
+// clusterSelector returns the ClusterSelector defined by the move options.
+func clusterSelector(fromCluster cluster.Client, options MoveOptions) (cluster.ClusterSelector, error) {
+	selector := cluster.ClusterSelector{}
+
+	if options.ClusterSelector != "" {
+		labelSelector, err := labels.Parse(options.ClusterSelector)
+		if err != nil {
+			return selector, errors.Wrapf(err, "invalid cluster selector %q", options.ClusterSelector)
+		}
+		selector.LabelSelector = labelSelector
+	}
+
+	for _, c := range options.Clusters {
+		key := types.NamespacedName{Namespace: options.Namespace, Name: c}
+		if parts := strings.Split(c, "/"); len(parts) == 2 {
+			key = types.NamespacedName{Namespace: parts[0], Name: parts[1]}
+		}
+		if key.Name == "" || strings.Contains(key.Name, "/") {
+			return selector, errors.Errorf("invalid cluster %q, it should be in the form namespace/name or name", c)
+		}
+		if key.Namespace == "" {
+			currentNamespace, err := fromCluster.Proxy().CurrentNamespace()
+			if err != nil {
+				return selector, err
+			}
+			key.Namespace = currentNamespace
+		}
+		selector.Clusters = append(selector.Clusters, key)
+	}
+
+	return selector, nil
 }
 
 func (c *clusterctlClient) Backup(options BackupOptions) error {
+	if err := validateBackupRestoreTarget(options.Directory, options.File); err != nil {
+		return err
+	}
+	if options.File == "" && (len(options.Recipients) > 0 || options.PassphraseFile != "") {
+		return errors.New("encryption is supported only when backing up to an archive file")
+	}
+
 	// Get the client for interacting with the source management cluster.
 	fromCluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.FromKubeconfig})
 	if err != nil {
@@ -138,6 +256,13 @@ func (c *clusterctlClient) Backup(options BackupOptions) error {
 		options.Namespace = currentNamespace
 	}
 
+	if options.File != "" {
+		return fromCluster.ObjectMover().BackupArchive(options.Namespace, options.File, cluster.ArchiveEncryption{
+			Recipients:     options.Recipients,
+			PassphraseFile: options.PassphraseFile,
+		})
+	}
+
 	if _, err := os.Stat(options.Directory); os.IsNotExist(err) {
 		return err
 	}
@@ -146,6 +271,13 @@ func (c *clusterctlClient) Backup(options BackupOptions) error {
 }
 
 func (c *clusterctlClient) Restore(options RestoreOptions) error {
+	if err := validateBackupRestoreTarget(options.Directory, options.File); err != nil {
+		return err
+	}
+	if options.File == "" && (options.IdentityFile != "" || options.PassphraseFile != "") {
+		return errors.New("decryption is supported only when restoring from an archive file")
+	}
+
 	// Get the client for interacting with the source management cluster.
 	toCluster, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.ToKubeconfig})
 	if err != nil {
@@ -162,9 +294,27 @@ func (c *clusterctlClient) Restore(options RestoreOptions) error {
 		return err
 	}
 
+	if options.File != "" {
+		return toCluster.ObjectMover().RestoreArchive(toCluster, options.File, cluster.ArchiveEncryption{
+			IdentityFile:   options.IdentityFile,
+			PassphraseFile: options.PassphraseFile,
+		})
+	}
+
 	if _, err := os.Stat(options.Directory); os.IsNotExist(err) {
 		return err
 	}
 
 	return toCluster.ObjectMover().Restore(toCluster, options.Directory)
 }
+
+// validateBackupRestoreTarget checks that exactly one between a directory and an archive file is specified.
+func validateBackupRestoreTarget(directory, file string) error {
+	if directory != "" && file != "" {
+		return errors.New("directory and file can't be used together")
+	}
+	if directory == "" && file == "" {
+		return errors.New("one of directory or file must be specified")
+	}
+	return nil
+}
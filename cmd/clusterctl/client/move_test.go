@@ -17,7 +17,9 @@ limitations under the License.
 package client
 
 import (
+	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
@@ -80,6 +82,92 @@ func Test_clusterctlClient_Move(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "does not return error if a cluster selector and a list of clusters are used",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					ClusterSelector: "tenant=foo",
+					Clusters:        []string{"ns1/cluster1", "cluster2"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "does not return error when resuming a move",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Resume:         true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if resume and rollback are used together",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Resume:         true,
+					Rollback:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if rollback is used with dry run",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					Rollback:       true,
+					DryRun:         true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the cluster selector is not valid",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					ClusterSelector: "tenant in (foo",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if a cluster is not valid",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: MoveOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					ToKubeconfig:   Kubeconfig{Path: "kubeconfig", Context: "worker-context"},
+					Clusters:       []string{"ns1/cluster1/foo"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -143,6 +231,60 @@ func Test_clusterctlClient_Backup(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "does not return error when backing up to an encrypted archive",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: BackupOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					File:           filepath.Join(dir, "backup.tar.gz.age"),
+					Recipients:     []string{"age1..."},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if both directory and file are specified",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: BackupOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					Directory:      dir,
+					File:           filepath.Join(dir, "backup.tar.gz"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if neither directory nor file are specified",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: BackupOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if encryption is requested when backing up to a directory",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: BackupOptions{
+					FromKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					Directory:      dir,
+					PassphraseFile: filepath.Join(dir, "passphrase"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -206,6 +348,48 @@ func Test_clusterctlClient_Restore(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "does not return error when restoring from an encrypted archive",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: RestoreOptions{
+					ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					File:         filepath.Join(dir, "backup.tar.gz.age"),
+					IdentityFile: filepath.Join(dir, "key.txt"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if both directory and file are specified",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: RestoreOptions{
+					ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					Directory:    dir,
+					File:         filepath.Join(dir, "backup.tar.gz"),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if decryption is requested when restoring from a directory",
+			fields: fields{
+				client: fakeClientForMove(), // core v1.0.0 (v1.0.1 available), infra v2.0.0 (v2.0.1 available)
+			},
+			args: args{
+				options: RestoreOptions{
+					ToKubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+					Directory:    dir,
+					IdentityFile: filepath.Join(dir, "key.txt"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -255,7 +439,15 @@ type fakeObjectMover struct {
 	restoerErr error
 }
 
-func (f *fakeObjectMover) Move(namespace string, toCluster cluster.Client, dryRun bool) error {
+func (f *fakeObjectMover) Move(namespace string, selector cluster.ClusterSelector, toCluster cluster.Client, dryRun bool, checkpointFile string) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) ResumeMove(toCluster cluster.Client, checkpointFile string) error {
+	return f.moveErr
+}
+
+func (f *fakeObjectMover) RollbackMove(toCluster cluster.Client, checkpointFile string) error {
 	return f.moveErr
 }
 
@@ -263,6 +455,18 @@ func (f *fakeObjectMover) Backup(namespace string, directory string) error {
 	return f.backupErr
 }
 
+func (f *fakeObjectMover) BackupArchive(namespace string, file string, encryption cluster.ArchiveEncryption) error {
+	return f.backupErr
+}
+
 func (f *fakeObjectMover) Restore(toCluster cluster.Client, directory string) error {
 	return f.restoerErr
 }
+
+func (f *fakeObjectMover) RestoreArchive(toCluster cluster.Client, file string, encryption cluster.ArchiveEncryption) error {
+	return f.restoerErr
+}
+
+func (f *fakeObjectMover) Snapshot(namespace string, w io.Writer, encryption cluster.ArchiveEncryption) error {
+	return f.backupErr
+}
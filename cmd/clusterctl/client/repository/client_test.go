@@ -73,6 +73,57 @@ func Test_newRepositoryClient_LocalFileSystemRepository(t *testing.T) {
 	}
 }
 
+func Test_newRepositoryClient_RemoteRepository(t *testing.T) {
+	configClient, err := config.New("", config.InjectReader(test.NewFakeReader()))
+	NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+	tests := []struct {
+		name     string
+		provider config.Provider
+		want     Repository
+		wantErr  bool
+	}{
+		{
+			name:     "successfully creates repository client with GitLab backend",
+			provider: config.NewProvider("foo", "https://gitlab.example.com/api/v4/projects/group%2Fproject/packages/generic/foo/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			want:     &gitLabRepository{},
+		},
+		{
+			name:     "successfully creates repository client with HTTP backend",
+			provider: config.NewProvider("foo", "https://artifacts.example.com/bootstrap-foo/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			want:     &httpRepository{},
+		},
+		{
+			name:     "successfully creates repository client with plain HTTP backend",
+			provider: config.NewProvider("foo", "http://artifacts.example.com/bootstrap-foo/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			want:     &httpRepository{},
+		},
+		{
+			name:     "successfully creates repository client with OCI backend",
+			provider: config.NewProvider("foo", "oci://registry.example.com/bootstrap-foo:v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			want:     &ociRepository{},
+		},
+		{
+			name:     "fails for unsupported schemes",
+			provider: config.NewProvider("foo", "ftp://artifacts.example.com/bootstrap-foo/v1.0.0/bootstrap-components.yaml", clusterctlv1.BootstrapProviderType),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gs := NewWithT(t)
+
+			repoClient, err := newRepositoryClient(tt.provider, configClient)
+			if tt.wantErr {
+				gs.Expect(err).To(HaveOccurred())
+				return
+			}
+			gs.Expect(err).NotTo(HaveOccurred())
+			gs.Expect(repoClient.repository).To(BeAssignableToTypeOf(tt.want))
+		})
+	}
+}
+
 func Test_newRepositoryClient_YamlProcessor(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -731,4 +731,5 @@ func resetCaches() {
 	cacheVersions = map[string][]string{}
 	cacheReleases = map[string]*github.RepositoryRelease{}
 	cacheFiles = map[string][]byte{}
+	cacheOCIManifests = map[string]*ociManifest{}
 }
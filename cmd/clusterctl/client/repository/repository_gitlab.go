@@ -0,0 +1,226 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	gitLabPackagesAPIPrefix = "api/v4/projects"
+	gitLabPackagesPageSize  = 100
+	gitLabTokenHeader       = "PRIVATE-TOKEN"
+)
+
+// gitLabPackage is a package as returned by the GitLab packages API.
+type gitLabPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// gitLabRepository provides support for providers hosted on GitLab.
+//
+// We support GitLab repositories that use the generic package registry to publish artifacts and versions, both
+// on gitlab.com and on self-hosted GitLab instances.
+// Repositories must use versioned packages, and the repository URL must be in the form
+// https://{host}/api/v4/projects/{projectSlug}/packages/generic/{packageName}/{latest|version}/{componentsPath}.
+type gitLabRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	host                  string
+	projectSlug           string
+	packageName           string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+	token                 string
+}
+
+var _ Repository = &gitLabRepository{}
+
+type gitLabRepositoryOption func(*gitLabRepository)
+
+func injectGitLabClient(c *http.Client) gitLabRepositoryOption {
+	return func(g *gitLabRepository) {
+		g.httpClient = c
+	}
+}
+
+// isGitLabRepositoryURL returns true if the URL points to a GitLab generic package.
+func isGitLabRepositoryURL(rURL *url.URL) bool {
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.EscapedPath(), "/"), "/")
+	return rURL.Scheme == httpsScheme &&
+		len(urlSplit) >= 9 &&
+		strings.Join(urlSplit[:3], "/") == gitLabPackagesAPIPrefix &&
+		urlSplit[4] == "packages" && urlSplit[5] == "generic"
+}
+
+// NewGitLabRepository returns a gitLabRepository implementation.
+func NewGitLabRepository(providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...gitLabRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	if !isGitLabRepositoryURL(rURL) {
+		return nil, errors.New("invalid url: a GitLab repository url should be in the form https://{host}/api/v4/projects/{projectSlug}/packages/generic/{packageName}/{latest|version}/{componentsClient.yaml}")
+	}
+
+	// Extract all the info from url split.
+	// NOTE: The escaped path is used so the project slug, e.g. group%2Fproject, is preserved.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.EscapedPath(), "/"), "/")
+	projectSlug := urlSplit[3]
+	packageName := urlSplit[6]
+	defaultVersion := urlSplit[7]
+	path := strings.Join(urlSplit[8:], "/")
+
+	// use path's directory as a rootPath
+	rootPath := filepath.Dir(path)
+	// use the file name (if any) as componentsPath
+	componentsPath := getComponentsPath(path, rootPath)
+
+	repo := &gitLabRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		host:                  rURL.Host,
+		projectSlug:           projectSlug,
+		packageName:           packageName,
+		defaultVersion:        defaultVersion,
+		rootPath:              rootPath,
+		componentsPath:        componentsPath,
+	}
+
+	// process gitLabRepositoryOptions
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if token, err := configVariablesClient.Get(config.GitLabAccessTokenVariable); err == nil {
+		repo.token = token
+	}
+
+	if defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get GitLab latest version")
+		}
+	}
+
+	return repo, nil
+}
+
+// DefaultVersion returns defaultVersion field of gitLabRepository struct.
+func (g *gitLabRepository) DefaultVersion() string {
+	return g.defaultVersion
+}
+
+// RootPath returns rootPath field of gitLabRepository struct.
+func (g *gitLabRepository) RootPath() string {
+	return g.rootPath
+}
+
+// ComponentsPath returns componentsPath field of gitLabRepository struct.
+func (g *gitLabRepository) ComponentsPath() string {
+	return g.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository.
+func (g *gitLabRepository) GetVersions() ([]string, error) {
+	cacheID := fmt.Sprintf("%s/%s/%s", g.host, g.projectSlug, g.packageName)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	// get all the packages, going through all the pages.
+	// NB. the package_name filter of the GitLab API matches package names fuzzily, so the name is checked again below.
+	versions := []string{}
+	for page := 1; ; page++ {
+		packagesURL := fmt.Sprintf("https://%s/%s/%s/packages?package_type=generic&package_name=%s&per_page=%d&page=%d",
+			g.host, gitLabPackagesAPIPrefix, g.projectSlug, url.QueryEscape(g.packageName), gitLabPackagesPageSize, page)
+		content, err := httpGet(g.httpClient, packagesURL, g.header())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get repository versions")
+		}
+
+		packages := []gitLabPackage{}
+		if err := json.Unmarshal(content, &packages); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the list of packages")
+		}
+
+		for _, p := range packages {
+			if p.Name != g.packageName {
+				continue
+			}
+			if _, err := version.ParseSemantic(p.Version); err != nil {
+				// Discard packages with versions that are not valid semantic versions (the user can point explicitly to such packages).
+				continue
+			}
+			versions = append(versions, p.Version)
+		}
+
+		if len(packages) < gitLabPackagesPageSize {
+			break
+		}
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (g *gitLabRepository) GetFile(version, path string) ([]byte, error) {
+	cacheID := fmt.Sprintf("%s/%s/%s:%s:%s", g.host, g.projectSlug, g.packageName, version, path)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	fileURL := fmt.Sprintf("https://%s/%s/%s/packages/generic/%s/%s/%s",
+		g.host, gitLabPackagesAPIPrefix, g.projectSlug, g.packageName, version, filepath.ToSlash(filepath.Join(g.rootPath, path)))
+	content, err := httpGet(g.httpClient, fileURL, g.header())
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download file %q from GitLab package %s %s", path, g.packageName, version)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// header returns the headers to be used when calling the GitLab API.
+func (g *gitLabRepository) header() http.Header {
+	header := http.Header{}
+	if g.token != "" {
+		header.Set(gitLabTokenHeader, g.token)
+	}
+	return header
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_gitLabRepository_newGitLabRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    *gitLabRepository
+		wantErr bool
+	}{
+		{
+			name: "can create a new GitLab repo",
+			url:  "https://gitlab.example.com/api/v4/projects/group%2Fproject/packages/generic/cluster-api/v1.1.0/core-components.yaml",
+			want: &gitLabRepository{
+				host:           "gitlab.example.com",
+				projectSlug:    "group%2Fproject",
+				packageName:    "cluster-api",
+				defaultVersion: "v1.1.0",
+				rootPath:       ".",
+				componentsPath: "core-components.yaml",
+			},
+		},
+		{
+			name: "can create a new GitLab repo with a project ID",
+			url:  "https://gitlab.com/api/v4/projects/1234/packages/generic/cluster-api/v1.1.0/core-components.yaml",
+			want: &gitLabRepository{
+				host:           "gitlab.com",
+				projectSlug:    "1234",
+				packageName:    "cluster-api",
+				defaultVersion: "v1.1.0",
+				rootPath:       ".",
+				componentsPath: "core-components.yaml",
+			},
+		},
+		{
+			name:    "provider url should be in https",
+			url:     "http://gitlab.example.com/api/v4/projects/group%2Fproject/packages/generic/cluster-api/v1.1.0/core-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url should point to a generic package",
+			url:     "https://gitlab.example.com/group/project/-/releases/v1.1.0/core-components.yaml",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewGitLabRepository(config.NewProvider("test", tt.url, clusterctlv1.CoreProviderType), test.NewFakeVariableClient())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			gitLab := repo.(*gitLabRepository)
+			g.Expect(gitLab.host).To(Equal(tt.want.host))
+			g.Expect(gitLab.projectSlug).To(Equal(tt.want.projectSlug))
+			g.Expect(gitLab.packageName).To(Equal(tt.want.packageName))
+			g.Expect(gitLab.DefaultVersion()).To(Equal(tt.want.defaultVersion))
+			g.Expect(gitLab.RootPath()).To(Equal(tt.want.rootPath))
+			g.Expect(gitLab.ComponentsPath()).To(Equal(tt.want.componentsPath))
+		})
+	}
+}
+
+func Test_gitLabRepository_getVersionsAndFiles(t *testing.T) {
+	retryableOperationInterval = 200 * time.Millisecond
+	retryableOperationTimeout = 1 * time.Second
+
+	// NOTE: ServeMux matches on the unescaped path, so the project slug group%2Fproject is matched as group/project.
+	mux := http.NewServeMux()
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/api/v4/projects/group/project/packages", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.Header.Get(gitLabTokenHeader) != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("package_name") != "cluster-api" || r.URL.Query().Get("package_type") != "generic" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		// Return a full first page, to check that the following page is requested.
+		if r.URL.Query().Get("page") == "1" {
+			packages := make([]string, 0, gitLabPackagesPageSize)
+			for i := 0; i < gitLabPackagesPageSize; i++ {
+				packages = append(packages, fmt.Sprintf(`{"name": "cluster-api", "version": "v0.%d.0"}`, i))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(packages, ","))
+			return
+		}
+		fmt.Fprint(w, `[`)
+		fmt.Fprint(w, `{"name": "cluster-api", "version": "v1.1.0"},`)
+		fmt.Fprint(w, `{"name": "cluster-api", "version": "foo"},`)          // no semantic version
+		fmt.Fprint(w, `{"name": "cluster-api-addons", "version": "v9.0.0"}`) // fuzzy match on package name
+		fmt.Fprint(w, `]`)
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/packages/generic/cluster-api/v1.1.0/metadata.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nkind: Metadata\nreleaseSeries:\n- major: 1\n  minor: 1\n  contract: v1beta1\n")
+	})
+	mux.HandleFunc("/api/v4/projects/group/project/packages/generic/cluster-api/v1.1.0/core-components.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "content")
+	})
+
+	g := NewWithT(t)
+	resetCaches()
+
+	configVariablesClient := test.NewFakeVariableClient().WithVar(config.GitLabAccessTokenVariable, "token")
+	providerURL := fmt.Sprintf("%s/api/v4/projects/group%%2Fproject/packages/generic/cluster-api/latest/core-components.yaml", server.URL)
+	repo, err := NewGitLabRepository(config.NewProvider("test", providerURL, clusterctlv1.CoreProviderType), configVariablesClient, injectGitLabClient(server.Client()))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	t.Run("latest is resolved using the package versions", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(repo.DefaultVersion()).To(Equal("v1.1.0"))
+	})
+
+	t.Run("versions are read from all the pages", func(t *testing.T) {
+		g := NewWithT(t)
+		got, err := repo.GetVersions()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(HaveLen(gitLabPackagesPageSize + 1))
+		g.Expect(got).To(ContainElements("v0.0.0", "v1.1.0"))
+	})
+
+	t.Run("files are read from the package", func(t *testing.T) {
+		g := NewWithT(t)
+		got, err := repo.GetFile("v1.1.0", "core-components.yaml")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal([]byte("content")))
+	})
+
+	t.Run("missing files return an error", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := repo.GetFile("v1.1.0", "404.yaml")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	httpScheme = "http"
+
+	// httpVersionsIndexFile is the name of the file listing the versions hosted in an HTTP repository.
+	httpVersionsIndexFile = "versions.yaml"
+)
+
+// httpVersionsIndex is the content of the versions index file of an HTTP repository.
+type httpVersionsIndex struct {
+	// Versions is the list of versions hosted in the repository.
+	Versions []string `json:"versions"`
+}
+
+// httpRepository provides support for providers hosted on a plain HTTP(S) server, e.g. a web server or an artifact store.
+//
+// The repository URL must be in the form {scheme}://{host}/{basePath}/{latest|version}/{componentsPath}; files for a
+// version are read from {basePath}/{version}, while the list of available versions is read from the
+// {basePath}/versions.yaml index file.
+type httpRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	httpClient            *http.Client
+	baseURL               *url.URL
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+}
+
+var _ Repository = &httpRepository{}
+
+type httpRepositoryOption func(*httpRepository)
+
+func injectHTTPClient(c *http.Client) httpRepositoryOption {
+	return func(h *httpRepository) {
+		h.httpClient = c
+	}
+}
+
+// NewHTTPRepository returns an httpRepository implementation.
+func NewHTTPRepository(providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...httpRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	if rURL.Scheme != httpScheme && rURL.Scheme != httpsScheme {
+		return nil, errors.New("invalid url: an HTTP repository url should start with http:// or https://")
+	}
+
+	// Check if the path is in the expected format,
+	// url's path has an extra leading slash at the end which we need to clean up before splitting.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if len(urlSplit) < 2 || urlSplit[len(urlSplit)-2] == "" || urlSplit[len(urlSplit)-1] == "" {
+		return nil, errors.New("invalid url: an HTTP repository url should be in the form {scheme}://{host}/{basePath}/{latest|version}/{componentsClient.yaml}")
+	}
+
+	baseURL := *rURL
+	baseURL.Path = "/" + strings.Join(urlSplit[:len(urlSplit)-2], "/")
+	baseURL.RawPath = ""
+	baseURL.RawQuery = ""
+	baseURL.Fragment = ""
+
+	repo := &httpRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		httpClient:            http.DefaultClient,
+		baseURL:               &baseURL,
+		defaultVersion:        urlSplit[len(urlSplit)-2],
+		rootPath:              ".",
+		componentsPath:        urlSplit[len(urlSplit)-1],
+	}
+
+	// process httpRepositoryOptions
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if repo.defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get latest version")
+		}
+	}
+
+	return repo, nil
+}
+
+// DefaultVersion returns defaultVersion field of httpRepository struct.
+func (h *httpRepository) DefaultVersion() string {
+	return h.defaultVersion
+}
+
+// RootPath returns rootPath field of httpRepository struct.
+func (h *httpRepository) RootPath() string {
+	return h.rootPath
+}
+
+// ComponentsPath returns componentsPath field of httpRepository struct.
+func (h *httpRepository) ComponentsPath() string {
+	return h.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository.
+func (h *httpRepository) GetVersions() ([]string, error) {
+	cacheID := h.url(httpVersionsIndexFile)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	content, err := httpGet(h.httpClient, cacheID, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get repository versions")
+	}
+
+	index := &httpVersionsIndex{}
+	if err := yaml.Unmarshal(content, index); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse versions index %q", cacheID)
+	}
+
+	versions := []string{}
+	for _, v := range index.Versions {
+		if _, err := version.ParseSemantic(v); err != nil {
+			// Discard versions that are not valid semantic versions (the user can point explicitly to such versions).
+			continue
+		}
+		versions = append(versions, v)
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (h *httpRepository) GetFile(version, fileName string) ([]byte, error) {
+	cacheID := h.url(version, h.rootPath, fileName)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	content, err := httpGet(h.httpClient, cacheID, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get file %q from version %q", fileName, version)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// url returns the URL of a file in the repository.
+func (h *httpRepository) url(elem ...string) string {
+	u := *h.baseURL
+	u.Path = path.Join(append([]string{u.Path}, elem...)...)
+	return u.String()
+}
+
+// httpGet downloads the content of the given URL, retrying in case of network or server errors.
+func httpGet(client *http.Client, rawURL string, header http.Header) ([]byte, error) {
+	var content []byte
+	var retryError error
+	_ = wait.PollImmediate(retryableOperationInterval, retryableOperationTimeout, func() (bool, error) {
+		req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, rawURL, http.NoBody)
+		if err != nil {
+			retryError = errors.Wrapf(err, "failed to create request for %q", rawURL)
+			return false, retryError
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		response, err := client.Do(req)
+		if err != nil {
+			retryError = errors.Wrapf(err, "failed to download %q", rawURL)
+			return false, nil
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode != http.StatusOK {
+			retryError = errors.Errorf("failed to download %q: %s", rawURL, response.Status)
+			// return immediately if the error is not transient
+			if response.StatusCode < http.StatusInternalServerError {
+				return false, retryError
+			}
+			return false, nil
+		}
+
+		content, err = io.ReadAll(response.Body)
+		if err != nil {
+			retryError = errors.Wrapf(err, "failed to read %q", rawURL)
+			return false, nil
+		}
+		retryError = nil
+		return true, nil
+	})
+	if retryError != nil {
+		return nil, retryError
+	}
+	return content, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_httpRepository_newHTTPRepository(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		wantBaseURL        string
+		wantDefaultVersion string
+		wantComponentsPath string
+		wantErr            bool
+	}{
+		{
+			name:               "can create a new HTTP repo",
+			url:                "https://example.com/artifacts/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantBaseURL:        "https://example.com/artifacts/infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+		},
+		{
+			name:               "can create a new HTTP repo with plain http and a port",
+			url:                "http://example.com:8080/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantBaseURL:        "http://example.com:8080/infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+		},
+		{
+			name:    "provider url should be http or https",
+			url:     "ftp://example.com/infrastructure-foo/v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url should include a version and a file",
+			url:     "https://example.com/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url should not end with a slash",
+			url:     "https://example.com/infrastructure-foo/v1.0.0/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewHTTPRepository(config.NewProvider("test", tt.url, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			h := repo.(*httpRepository)
+			g.Expect(h.baseURL.String()).To(Equal(tt.wantBaseURL))
+			g.Expect(h.DefaultVersion()).To(Equal(tt.wantDefaultVersion))
+			g.Expect(h.RootPath()).To(Equal("."))
+			g.Expect(h.ComponentsPath()).To(Equal(tt.wantComponentsPath))
+		})
+	}
+}
+
+func Test_httpRepository_getVersionsAndFiles(t *testing.T) {
+	retryableOperationInterval = 200 * time.Millisecond
+	retryableOperationTimeout = 1 * time.Second
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	versionsRequests := 0
+	mux.HandleFunc("/infrastructure-foo/versions.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		versionsRequests++
+		fmt.Fprint(w, "versions:\n- v1.0.0\n- v1.1.0\n- v1.2.0-alpha.0\n- foo\n")
+	})
+	mux.HandleFunc("/infrastructure-foo/v1.1.0/metadata.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nkind: Metadata\nreleaseSeries:\n- major: 1\n  minor: 1\n  contract: v1beta1\n")
+	})
+	mux.HandleFunc("/infrastructure-foo/v1.1.0/infrastructure-components.yaml", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, "content")
+	})
+	mux.HandleFunc("/infrastructure-foo/v1.1.0/broken.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	g := NewWithT(t)
+	resetCaches()
+
+	providerConfig := config.NewProvider("test", server.URL+"/infrastructure-foo/latest/infrastructure-components.yaml", clusterctlv1.InfrastructureProviderType)
+	repo, err := NewHTTPRepository(providerConfig, test.NewFakeVariableClient(), injectHTTPClient(server.Client()))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	t.Run("latest is resolved using the versions index", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(repo.DefaultVersion()).To(Equal("v1.1.0"))
+	})
+
+	t.Run("versions are read from the index and cached", func(t *testing.T) {
+		g := NewWithT(t)
+		got, err := repo.GetVersions()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(ConsistOf("v1.0.0", "v1.1.0", "v1.2.0-alpha.0"))
+		g.Expect(versionsRequests).To(Equal(1))
+	})
+
+	t.Run("files are read from the version folder", func(t *testing.T) {
+		g := NewWithT(t)
+		got, err := repo.GetFile("v1.1.0", "infrastructure-components.yaml")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(Equal([]byte("content")))
+	})
+
+	t.Run("missing files return an error", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := repo.GetFile("v1.1.0", "404.yaml")
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("server errors are retried and then return an error", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := repo.GetFile("v1.1.0", "broken.yaml")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
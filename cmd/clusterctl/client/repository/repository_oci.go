@@ -0,0 +1,261 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+const (
+	ociScheme = "oci"
+
+	// ociTitleAnnotation is the annotation used to store the file name of a layer, as set by OCI artifact tools like ORAS.
+	ociTitleAnnotation = "org.opencontainers.image.title"
+
+	ociManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+)
+
+// ociDescriptor describes a blob in an OCI registry.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest; only the fields used by clusterctl are defined.
+type ociManifest struct {
+	Layers []ociDescriptor `json:"layers"`
+}
+
+// ociTagList is the response of the OCI distribution API tag list endpoint.
+type ociTagList struct {
+	Name string   `json:"name"`
+	Tags []string `json:"tags"`
+}
+
+var (
+	// Cache used to limit the number of calls to OCI registries.
+	cacheOCIManifests = map[string]*ociManifest{}
+)
+
+// ociRepository provides support for providers published as OCI artifacts.
+//
+// The repository URL must be in the form oci://{registry}/{repository}:{latest|tag}/{componentsPath}; each version of
+// the provider is an artifact tagged with the version, and each file is stored in a layer annotated with
+// org.opencontainers.image.title set to the file name.
+// NOTE: "latest" is resolved to the latest release for the current contract, no matter if a tag named latest exists.
+type ociRepository struct {
+	providerConfig        config.Provider
+	configVariablesClient config.VariablesClient
+	client                *ociRegistryClient
+	repository            string
+	defaultVersion        string
+	rootPath              string
+	componentsPath        string
+}
+
+var _ Repository = &ociRepository{}
+
+type ociRepositoryOption func(*ociRepository)
+
+func injectOCIClient(c *http.Client) ociRepositoryOption {
+	return func(o *ociRepository) {
+		o.client.httpClient = c
+	}
+}
+
+// NewOCIRepository returns an ociRepository implementation.
+func NewOCIRepository(providerConfig config.Provider, configVariablesClient config.VariablesClient, opts ...ociRepositoryOption) (Repository, error) {
+	if configVariablesClient == nil {
+		return nil, errors.New("invalid arguments: configVariablesClient can't be nil")
+	}
+
+	rURL, err := url.Parse(providerConfig.URL())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid url")
+	}
+
+	if rURL.Scheme != ociScheme {
+		return nil, errors.New("invalid url: an OCI repository url should start with oci://")
+	}
+
+	// Check if the path is in the expected format,
+	// url's path has an extra leading slash at the end which we need to clean up before splitting.
+	urlSplit := strings.Split(strings.TrimPrefix(rURL.Path, "/"), "/")
+	if rURL.Host == "" || len(urlSplit) < 2 || urlSplit[len(urlSplit)-1] == "" {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}:{latest|tag}/{componentsClient.yaml}")
+	}
+	reference := strings.Join(urlSplit[:len(urlSplit)-1], "/")
+	i := strings.LastIndex(reference, ":")
+	if i <= 0 || i == len(reference)-1 {
+		return nil, errors.New("invalid url: an OCI repository url should be in the form oci://{registry}/{repository}:{latest|tag}/{componentsClient.yaml}")
+	}
+
+	repo := &ociRepository{
+		providerConfig:        providerConfig,
+		configVariablesClient: configVariablesClient,
+		client:                newOCIRegistryClient(rURL.Host),
+		repository:            reference[:i],
+		defaultVersion:        reference[i+1:],
+		rootPath:              ".",
+		componentsPath:        urlSplit[len(urlSplit)-1],
+	}
+
+	// process ociRepositoryOptions
+	for _, o := range opts {
+		o(repo)
+	}
+
+	if repo.defaultVersion == latestVersionTag {
+		repo.defaultVersion, err = latestContractRelease(repo, clusterv1.GroupVersion.Version)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get OCI latest version")
+		}
+	}
+
+	return repo, nil
+}
+
+// DefaultVersion returns defaultVersion field of ociRepository struct.
+func (o *ociRepository) DefaultVersion() string {
+	return o.defaultVersion
+}
+
+// RootPath returns rootPath field of ociRepository struct.
+func (o *ociRepository) RootPath() string {
+	return o.rootPath
+}
+
+// ComponentsPath returns componentsPath field of ociRepository struct.
+func (o *ociRepository) ComponentsPath() string {
+	return o.componentsPath
+}
+
+// GetVersions returns the list of versions that are available in a provider repository.
+func (o *ociRepository) GetVersions() ([]string, error) {
+	cacheID := fmt.Sprintf("%s/%s", o.client.registry, o.repository)
+	if versions, ok := cacheVersions[cacheID]; ok {
+		return versions, nil
+	}
+
+	// get all the tags, following pagination links if any.
+	versions := []string{}
+	next := fmt.Sprintf("/v2/%s/tags/list", o.repository)
+	for next != "" {
+		content, header, err := o.client.get(next, o.repository, "")
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get repository versions")
+		}
+
+		tagList := &ociTagList{}
+		if err := json.Unmarshal(content, tagList); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the list of tags")
+		}
+		for _, tag := range tagList.Tags {
+			if _, err := version.ParseSemantic(tag); err != nil {
+				// Discard tags that are not valid semantic versions (the user can point explicitly to such tags).
+				continue
+			}
+			versions = append(versions, tag)
+		}
+
+		next = nextLink(header.Get("Link"))
+	}
+
+	cacheVersions[cacheID] = versions
+	return versions, nil
+}
+
+// GetFile returns a file for a given provider version.
+func (o *ociRepository) GetFile(version, path string) ([]byte, error) {
+	cacheID := fmt.Sprintf("%s/%s:%s:%s", o.client.registry, o.repository, version, path)
+	if content, ok := cacheFiles[cacheID]; ok {
+		return content, nil
+	}
+
+	manifest, err := o.getManifest(version)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get OCI artifact %s:%s", o.repository, version)
+	}
+
+	// search for the file into the artifact layers, retrieving the layer digest.
+	var digest string
+	for _, layer := range manifest.Layers {
+		if layer.Annotations[ociTitleAnnotation] == path {
+			digest = layer.Digest
+			break
+		}
+	}
+	if digest == "" {
+		return nil, errors.Errorf("failed to get file %q from OCI artifact %s:%s", path, o.repository, version)
+	}
+
+	content, _, err := o.client.get(fmt.Sprintf("/v2/%s/blobs/%s", o.repository, digest), o.repository, "")
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download file %q from OCI artifact %s:%s", path, o.repository, version)
+	}
+
+	cacheFiles[cacheID] = content
+	return content, nil
+}
+
+// getManifest returns the manifest of the artifact with a specific tag.
+func (o *ociRepository) getManifest(tag string) (*ociManifest, error) {
+	cacheID := fmt.Sprintf("%s/%s:%s", o.client.registry, o.repository, tag)
+	if manifest, ok := cacheOCIManifests[cacheID]; ok {
+		return manifest, nil
+	}
+
+	content, _, err := o.client.get(fmt.Sprintf("/v2/%s/manifests/%s", o.repository, tag), o.repository, strings.Join([]string{ociManifestMediaType, dockerManifestMediaType}, ", "))
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(content, manifest); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse manifest")
+	}
+
+	cacheOCIManifests[cacheID] = manifest
+	return manifest, nil
+}
+
+// nextLink returns the URL of the next page from a Link header as defined by the OCI distribution spec,
+// e.g. </v2/foo/tags/list?n=100&last=v1.0.0>; rel="next".
+func nextLink(link string) string {
+	if link == "" || !strings.Contains(link, `rel="next"`) {
+		return ""
+	}
+	start := strings.Index(link, "<")
+	end := strings.Index(link, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return link[start+1 : end]
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/homedir"
+
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// ociRegistryClient is a minimal client for the OCI distribution API, supporting anonymous, basic and token
+// authentication using the credentials from the docker configuration file.
+type ociRegistryClient struct {
+	httpClient *http.Client
+	registry   string
+
+	// authorization is the value of the Authorization header, negotiated with the registry on the first
+	// unauthorized response and then reused for all the following requests.
+	authorization string
+
+	// credentials returns the username and password to be used for a registry.
+	credentials func(registry string) (string, string, error)
+}
+
+func newOCIRegistryClient(registry string) *ociRegistryClient {
+	return &ociRegistryClient{
+		httpClient:  http.DefaultClient,
+		registry:    registry,
+		credentials: dockerConfigCredentials,
+	}
+}
+
+// baseURL returns the base URL of the registry; plain HTTP is used only for registries on the local host.
+func (c *ociRegistryClient) baseURL() string {
+	host := c.registry
+	if h, _, err := net.SplitHostPort(c.registry); err == nil {
+		host = h
+	}
+	if host == "localhost" || net.ParseIP(host).IsLoopback() {
+		return fmt.Sprintf("%s://%s", httpScheme, c.registry)
+	}
+	return fmt.Sprintf("%s://%s", httpsScheme, c.registry)
+}
+
+// get returns the content and the headers of the response for a path in the registry, authenticating if required.
+func (c *ociRegistryClient) get(path, repository, accept string) ([]byte, http.Header, error) {
+	rawURL := path
+	if !strings.HasPrefix(path, httpScheme) {
+		rawURL = c.baseURL() + path
+	}
+
+	response, err := c.do(rawURL, accept)
+	if err != nil {
+		return nil, nil, err
+	}
+	if response.StatusCode == http.StatusUnauthorized {
+		challenge := response.Header.Get("WWW-Authenticate")
+		response.Body.Close()
+		if err := c.authenticate(challenge, repository); err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to authenticate to %s", c.registry)
+		}
+		if response, err = c.do(rawURL, accept); err != nil {
+			return nil, nil, err
+		}
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, errors.Errorf("failed to get %q: %s", rawURL, response.Status)
+	}
+	content, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to read %q", rawURL)
+	}
+	return content, response.Header, nil
+}
+
+func (c *ociRegistryClient) do(rawURL, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create request for %q", rawURL)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if c.authorization != "" {
+		req.Header.Set("Authorization", c.authorization)
+	}
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get %q", rawURL)
+	}
+	return response, nil
+}
+
+// authenticate sets the authorization to be used for the following requests according to the challenge
+// returned by the registry in the WWW-Authenticate header.
+func (c *ociRegistryClient) authenticate(challenge, repository string) error {
+	scheme, params := parseAuthChallenge(challenge)
+
+	username, password, err := c.credentials(c.registry)
+	if err != nil {
+		return errors.Wrap(err, "failed to get credentials")
+	}
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" && password == "" {
+			return errors.New("the registry requires credentials, but none are defined in the docker configuration file")
+		}
+		c.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+		return nil
+	case "bearer":
+		token, err := c.getToken(params, repository, username, password)
+		if err != nil {
+			return err
+		}
+		c.authorization = "Bearer " + token
+		return nil
+	default:
+		return errors.Errorf("unsupported authentication challenge %q", challenge)
+	}
+}
+
+// getToken gets a token from the authorization server defined in a bearer challenge.
+func (c *ociRegistryClient) getToken(params map[string]string, repository, username, password string) (string, error) {
+	realm, ok := params["realm"]
+	if !ok {
+		return "", errors.New("invalid bearer challenge: realm is missing")
+	}
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid bearer challenge: failed to parse realm %q", realm)
+	}
+
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", repository)
+	}
+	query := tokenURL.Query()
+	query.Set("scope", scope)
+	if service, ok := params["service"]; ok {
+		query.Set("service", service)
+	}
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, tokenURL.String(), http.NoBody)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create token request")
+	}
+	if username != "" || password != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	response, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get token")
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", errors.Errorf("failed to get token: %s", response.Status)
+	}
+
+	tokenResponse := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&tokenResponse); err != nil {
+		return "", errors.Wrapf(err, "failed to parse token response")
+	}
+	if tokenResponse.Token != "" {
+		return tokenResponse.Token, nil
+	}
+	if tokenResponse.AccessToken != "" {
+		return tokenResponse.AccessToken, nil
+	}
+	return "", errors.New("failed to get token: the token response is empty")
+}
+
+// parseAuthChallenge parses a WWW-Authenticate header, e.g. Bearer realm="https://auth.example.com/token",service="registry.example.com",
+// returning the authentication scheme and its parameters.
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+	challenge = strings.TrimSpace(challenge)
+	i := strings.Index(challenge, " ")
+	if i < 0 {
+		return challenge, params
+	}
+	scheme := challenge[:i]
+	for _, param := range splitAuthParams(challenge[i+1:]) {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+	}
+	return scheme, params
+}
+
+// splitAuthParams splits the parameters of an authentication challenge on commas not included in quoted values.
+func splitAuthParams(s string) []string {
+	params := []string{}
+	quoted := false
+	start := 0
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			params = append(params, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(params, s[start:])
+}
+
+// dockerConfig is the subset of the docker configuration file used to get registry credentials.
+type dockerConfig struct {
+	Auths       map[string]dockerAuthConfig `json:"auths,omitempty"`
+	CredsStore  string                      `json:"credsStore,omitempty"`
+	CredHelpers map[string]string           `json:"credHelpers,omitempty"`
+}
+
+// dockerAuthConfig contains the credentials for a registry.
+type dockerAuthConfig struct {
+	Auth     string `json:"auth,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// dockerConfigCredentials returns the credentials for a registry as defined in the docker configuration file,
+// read from $DOCKER_CONFIG/config.json or from ~/.docker/config.json.
+// Empty credentials are returned if the configuration file does not exist or does not define credentials for the registry;
+// a credential helper without credentials for the registry, or failing, is considered as not defining credentials, so
+// e.g. public registries can still be accessed anonymously when a credsStore is configured.
+func dockerConfigCredentials(registry string) (string, string, error) {
+	configDir := os.Getenv("DOCKER_CONFIG")
+	if configDir == "" {
+		configDir = filepath.Join(homedir.HomeDir(), ".docker")
+	}
+	content, err := os.ReadFile(filepath.Join(configDir, "config.json")) //nolint:gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", "", nil
+		}
+		return "", "", errors.Wrap(err, "failed to read the docker configuration file")
+	}
+
+	config := &dockerConfig{}
+	if err := json.Unmarshal(content, config); err != nil {
+		return "", "", errors.Wrap(err, "failed to parse the docker configuration file")
+	}
+
+	// Credential helpers take precedence over the credentials stored in the configuration file.
+	helper, ok := config.CredHelpers[registry]
+	if !ok {
+		helper = config.CredsStore
+	}
+	if helper != "" {
+		username, password, err := credentialHelperCredentials(helper, registry)
+		if err == nil && (username != "" || password != "") {
+			return username, password, nil
+		}
+		if err != nil {
+			logf.Log.V(5).Info("Ignoring the docker credential helper", "Registry", registry, "Error", err.Error())
+		}
+	}
+
+	for key, auth := range config.Auths {
+		if normalizeRegistry(key) != registry {
+			continue
+		}
+		if auth.Auth == "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", errors.Wrapf(err, "failed to decode the credentials for %s", registry)
+		}
+		userPass := strings.SplitN(string(decoded), ":", 2)
+		if len(userPass) != 2 {
+			return "", "", errors.Errorf("invalid credentials for %s: expected the username:password format", registry)
+		}
+		return userPass[0], userPass[1], nil
+	}
+	return "", "", nil
+}
+
+// normalizeRegistry returns the registry host from a key of the docker configuration file auths,
+// which can be defined as a URL, e.g. https://registry.example.com/v1/.
+func normalizeRegistry(key string) string {
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "http://")
+	if i := strings.Index(key, "/"); i >= 0 {
+		key = key[:i]
+	}
+	return key
+}
+
+// credentialHelperCredentials returns the credentials for a registry from a docker credential helper.
+func credentialHelperCredentials(helper, registry string) (string, string, error) {
+	cmd := exec.Command("docker-credential-"+helper, "get") //nolint:gosec
+	cmd.Stdin = strings.NewReader(registry)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", "", errors.Wrapf(err, "failed to get the credentials for %s from the docker-credential-%s helper", registry, helper)
+	}
+
+	credentials := struct {
+		Username string `json:"Username"`
+		Secret   string `json:"Secret"`
+	}{}
+	if err := json.Unmarshal(stdout.Bytes(), &credentials); err != nil {
+		return "", "", errors.Wrapf(err, "failed to parse the credentials from the docker-credential-%s helper", helper)
+	}
+	return credentials.Username, credentials.Secret, nil
+}
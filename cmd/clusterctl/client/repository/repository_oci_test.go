@@ -0,0 +1,337 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+const ociTestMetadata = "apiVersion: clusterctl.cluster.x-k8s.io/v1alpha3\nkind: Metadata\nreleaseSeries:\n- major: 1\n  minor: 1\n  contract: v1beta1\n"
+
+func Test_ociRepository_newOCIRepository(t *testing.T) {
+	tests := []struct {
+		name               string
+		url                string
+		wantRegistry       string
+		wantRepository     string
+		wantDefaultVersion string
+		wantComponentsPath string
+		wantErr            bool
+	}{
+		{
+			name:               "can create a new OCI repo",
+			url:                "oci://registry.example.com/infrastructure-foo:v1.0.0/infrastructure-components.yaml",
+			wantRegistry:       "registry.example.com",
+			wantRepository:     "infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+		},
+		{
+			name:               "can create a new OCI repo with a nested repository and a port",
+			url:                "oci://registry.example.com:5000/org/providers/infrastructure-foo:v1.0.0/infrastructure-components.yaml",
+			wantRegistry:       "registry.example.com:5000",
+			wantRepository:     "org/providers/infrastructure-foo",
+			wantDefaultVersion: "v1.0.0",
+			wantComponentsPath: "infrastructure-components.yaml",
+		},
+		{
+			name:    "provider url should use the oci scheme",
+			url:     "https://registry.example.com/infrastructure-foo:v1.0.0/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url should include a tag",
+			url:     "oci://registry.example.com/infrastructure-foo/infrastructure-components.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "provider url should include a file",
+			url:     "oci://registry.example.com/infrastructure-foo:v1.0.0/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			repo, err := NewOCIRepository(config.NewProvider("test", tt.url, clusterctlv1.InfrastructureProviderType), test.NewFakeVariableClient())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+
+			o := repo.(*ociRepository)
+			g.Expect(o.client.registry).To(Equal(tt.wantRegistry))
+			g.Expect(o.client.baseURL()).To(Equal("https://" + tt.wantRegistry))
+			g.Expect(o.repository).To(Equal(tt.wantRepository))
+			g.Expect(o.DefaultVersion()).To(Equal(tt.wantDefaultVersion))
+			g.Expect(o.RootPath()).To(Equal("."))
+			g.Expect(o.ComponentsPath()).To(Equal(tt.wantComponentsPath))
+		})
+	}
+}
+
+func Test_ociRepository_getVersionsAndFiles(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+
+	registry := test.NewFakeOCIRegistry().
+		WithArtifact("providers/infrastructure-foo", "v1.0.0", map[string][]byte{
+			"metadata.yaml":                  []byte(ociTestMetadata),
+			"infrastructure-components.yaml": []byte("v1.0.0 content"),
+		}).
+		WithArtifact("providers/infrastructure-foo", "v1.1.0", map[string][]byte{
+			"metadata.yaml":                  []byte(ociTestMetadata),
+			"infrastructure-components.yaml": []byte("v1.1.0 content"),
+			"cluster-template.yaml":          []byte("template"),
+			"clusterclass-dev.yaml":          []byte("clusterclass"),
+		}).
+		WithArtifact("providers/infrastructure-foo", "v1.2.0-alpha.0", map[string][]byte{
+			"metadata.yaml": []byte(ociTestMetadata),
+		}).
+		WithArtifact("providers/infrastructure-foo", "dev", map[string][]byte{})
+	defer registry.Close()
+
+	g := NewWithT(t)
+	resetCaches()
+
+	providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/providers/infrastructure-foo:latest/infrastructure-components.yaml", registry.Host()), clusterctlv1.InfrastructureProviderType)
+	repo, err := NewOCIRepository(providerConfig, test.NewFakeVariableClient())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	t.Run("latest is resolved using the repository tags", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(repo.DefaultVersion()).To(Equal("v1.1.0"))
+	})
+
+	t.Run("versions are the repository tags that are valid semantic versions", func(t *testing.T) {
+		g := NewWithT(t)
+		got, err := repo.GetVersions()
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(got).To(ConsistOf("v1.0.0", "v1.1.0", "v1.2.0-alpha.0"))
+	})
+
+	t.Run("files are read from the artifact layers", func(t *testing.T) {
+		g := NewWithT(t)
+		for file, want := range map[string]string{
+			"infrastructure-components.yaml": "v1.1.0 content",
+			"cluster-template.yaml":          "template",
+			"clusterclass-dev.yaml":          "clusterclass",
+		} {
+			got, err := repo.GetFile("v1.1.0", file)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal(want))
+		}
+
+		got, err := repo.GetFile("v1.0.0", "infrastructure-components.yaml")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(string(got)).To(Equal("v1.0.0 content"))
+	})
+
+	t.Run("files missing from the artifact return an error", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := repo.GetFile("v1.1.0", "404.yaml")
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("missing artifacts return an error", func(t *testing.T) {
+		g := NewWithT(t)
+		_, err := repo.GetFile("v9.9.9", "infrastructure-components.yaml")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func Test_ociRepository_authentication(t *testing.T) {
+	registry := test.NewFakeOCIRegistry().
+		WithTokenAuth("user", "pass").
+		WithArtifact("infrastructure-foo", "v1.0.0", map[string][]byte{
+			"infrastructure-components.yaml": []byte("content"),
+		})
+	defer registry.Close()
+
+	tests := []struct {
+		name         string
+		dockerConfig string
+		wantErr      bool
+	}{
+		{
+			name:         "authenticates using the auth field of the docker config",
+			dockerConfig: fmt.Sprintf(`{"auths":{"%s":{"auth":"%s"}}}`, registry.Host(), base64.StdEncoding.EncodeToString([]byte("user:pass"))),
+		},
+		{
+			name:         "authenticates using the username and password fields of the docker config",
+			dockerConfig: fmt.Sprintf(`{"auths":{"http://%s/v2/":{"username":"user","password":"pass"}}}`, registry.Host()),
+		},
+		{
+			name:         "fails with wrong credentials",
+			dockerConfig: fmt.Sprintf(`{"auths":{"%s":{"username":"user","password":"wrong"}}}`, registry.Host()),
+			wantErr:      true,
+		},
+		{
+			name:         "fails without credentials",
+			dockerConfig: `{"auths":{}}`,
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			dir := t.TempDir()
+			g.Expect(os.WriteFile(filepath.Join(dir, "config.json"), []byte(tt.dockerConfig), 0600)).To(Succeed())
+			t.Setenv("DOCKER_CONFIG", dir)
+
+			providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/infrastructure-foo:v1.0.0/infrastructure-components.yaml", registry.Host()), clusterctlv1.InfrastructureProviderType)
+			repo, err := NewOCIRepository(providerConfig, test.NewFakeVariableClient())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := repo.GetFile("v1.0.0", "infrastructure-components.yaml")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal("content"))
+		})
+	}
+}
+
+func Test_ociRepository_credentialHelpers(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake docker credential helpers are shell scripts")
+	}
+
+	privateRegistry := test.NewFakeOCIRegistry().
+		WithTokenAuth("user", "pass").
+		WithArtifact("infrastructure-foo", "v1.0.0", map[string][]byte{
+			"infrastructure-components.yaml": []byte("content"),
+		})
+	defer privateRegistry.Close()
+	publicRegistry := test.NewFakeOCIRegistry().
+		WithAnonymousTokenAuth().
+		WithArtifact("infrastructure-foo", "v1.0.0", map[string][]byte{
+			"infrastructure-components.yaml": []byte("content"),
+		})
+	defer publicRegistry.Close()
+
+	// The fake helpers behave as docker-credential-helpers do: "store" has credentials only for the private
+	// registry, and it fails with "credentials not found in native keychain" for any other registry.
+	helpersDir := t.TempDir()
+	storeHelper := fmt.Sprintf(`#!/bin/sh
+read registry
+if [ "$registry" = "%s" ]; then
+  echo '{"ServerURL":"%s","Username":"user","Secret":"pass"}'
+  exit 0
+fi
+echo "credentials not found in native keychain"
+exit 1
+`, privateRegistry.Host(), privateRegistry.Host())
+	g := NewWithT(t)
+	g.Expect(os.WriteFile(filepath.Join(helpersDir, "docker-credential-store"), []byte(storeHelper), 0700)).To(Succeed()) //nolint:gosec
+	t.Setenv("PATH", helpersDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	tests := []struct {
+		name         string
+		registry     *test.FakeOCIRegistry
+		dockerConfig string
+		wantErr      bool
+	}{
+		{
+			name:         "authenticates using the credentials from the credsStore",
+			registry:     privateRegistry,
+			dockerConfig: `{"credsStore":"store"}`,
+		},
+		{
+			name:         "authenticates using the credentials from the credHelpers",
+			registry:     privateRegistry,
+			dockerConfig: fmt.Sprintf(`{"credHelpers":{"%s":"store"}}`, privateRegistry.Host()),
+		},
+		{
+			name:         "authenticates anonymously when the credsStore has no entry for the registry",
+			registry:     publicRegistry,
+			dockerConfig: `{"credsStore":"store"}`,
+		},
+		{
+			name:         "authenticates anonymously when the credential helper is not installed",
+			registry:     publicRegistry,
+			dockerConfig: fmt.Sprintf(`{"credHelpers":{"%s":"missing"}}`, publicRegistry.Host()),
+		},
+		{
+			name:         "fails when the credential helper has no credentials for a registry requiring them",
+			registry:     privateRegistry,
+			dockerConfig: fmt.Sprintf(`{"credHelpers":{"%s":"missing"}}`, privateRegistry.Host()),
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			resetCaches()
+
+			dir := t.TempDir()
+			g.Expect(os.WriteFile(filepath.Join(dir, "config.json"), []byte(tt.dockerConfig), 0600)).To(Succeed())
+			t.Setenv("DOCKER_CONFIG", dir)
+
+			providerConfig := config.NewProvider("test", fmt.Sprintf("oci://%s/infrastructure-foo:v1.0.0/infrastructure-components.yaml", tt.registry.Host()), clusterctlv1.InfrastructureProviderType)
+			repo, err := NewOCIRepository(providerConfig, test.NewFakeVariableClient())
+			g.Expect(err).NotTo(HaveOccurred())
+
+			got, err := repo.GetFile("v1.0.0", "infrastructure-components.yaml")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(string(got)).To(Equal("content"))
+		})
+	}
+}
+
+func Test_parseAuthChallenge(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme, params := parseAuthChallenge(`Bearer realm="https://auth.example.com/token",service="registry.example.com",scope="repository:foo/bar:pull,push"`)
+	g.Expect(scheme).To(Equal("Bearer"))
+	g.Expect(params).To(Equal(map[string]string{
+		"realm":   "https://auth.example.com/token",
+		"service": "registry.example.com",
+		"scope":   "repository:foo/bar:pull,push",
+	}))
+}
+
+func Test_nextLink(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(nextLink(`</v2/foo/tags/list?n=100&last=v1.0.0>; rel="next"`)).To(Equal("/v2/foo/tags/list?n=100&last=v1.0.0"))
+	g.Expect(nextLink("")).To(BeEmpty())
+}
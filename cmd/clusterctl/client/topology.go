@@ -39,6 +39,13 @@ type TopologyPlanOptions struct {
 	// This namespace is used as default for objects with missing namespaces.
 	// If the namespace of any of the input objects conflicts with Namespace an error is returned.
 	Namespace string
+
+	// EditClusterClass is the name of a ClusterClass in the management cluster to be edited in place
+	// using Editor; the edited ClusterClass is then used as an additional input to the operation.
+	EditClusterClass string
+
+	// Editor edits the YAML of the ClusterClass to be edited in place, and returns the modified YAML.
+	Editor func(original []byte) ([]byte, error)
 }
 
 // TopologyPlanOutput defines the output of the topology plan operation.
@@ -56,6 +63,8 @@ func (c *clusterctlClient) TopologyPlan(options TopologyPlanOptions) (*TopologyP
 		Objs:              options.Objs,
 		TargetClusterName: options.Cluster,
 		TargetNamespace:   options.Namespace,
+		EditClusterClass:  options.EditClusterClass,
+		Editor:            options.Editor,
 	})
 
 	return out, err
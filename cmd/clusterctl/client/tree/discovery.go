@@ -18,17 +18,33 @@ package tree
 
 import (
 	"context"
+	"strings"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	// UnhealthyMachinesReason is the reason of the ready condition shown for a MachineHealthCheck
+	// when some of the machines it targets are unhealthy and waiting for remediation.
+	UnhealthyMachinesReason = "UnhealthyMachines"
+
+	// ClustersNotInSyncReason is the reason of the ready condition shown for a ClusterClass
+	// when some of the clusters using it are not in sync with it.
+	ClustersNotInSyncReason = "ClustersNotInSync"
 )
 
 // DiscoverOptions define options for the discovery process.
@@ -49,7 +65,8 @@ type DiscoverOptions struct {
 	// AddTemplateVirtualNode instructs the discovery process to group template under a virtual node.
 	AddTemplateVirtualNode bool
 
-	// Echo displays MachineInfrastructure or BootstrapConfig objects if the object's ready condition is true
+	// Echo displays MachineInfrastructure or BootstrapConfig objects if the object's ready condition is true;
+	// it also displays MachineHealthChecks and ExtensionConfigs whose ready condition is true.
 	Echo bool
 
 	// Grouping groups machine objects in case the ready conditions
@@ -162,6 +179,17 @@ func Discovery(ctx context.Context, c client.Client, namespace, name string, opt
 		addMachinePoolsToObjectTree(ctx, c, cluster.Namespace, workers, machinePoolList, tree)
 	}
 
+	// Adds the ClusterClass, if the cluster has a managed topology.
+	if cluster.Spec.Topology != nil {
+		addClusterClassToObjectTree(ctx, c, cluster, tree)
+	}
+
+	if err := addMachineHealthChecksToObjectTree(ctx, c, cluster, machinesList, tree, options); err != nil {
+		return nil, err
+	}
+
+	addExtensionConfigsToObjectTree(ctx, c, cluster, tree, options)
+
 	return tree, nil
 }
 
@@ -266,6 +294,11 @@ func addMachineDeploymentToObjectTree(ctx context.Context, c client.Client, clus
 func addMachinePoolsToObjectTree(ctx context.Context, c client.Client, namespace string, workers *unstructured.Unstructured, machinePoolList *expv1.MachinePoolList, tree *ObjectTree) {
 	for i := range machinePoolList.Items {
 		mp := &machinePoolList.Items[i]
+		// Enforce TypeMeta to make sure checks on GVK works properly.
+		mp.TypeMeta = metav1.TypeMeta{
+			Kind:       "MachinePool",
+			APIVersion: expv1.GroupVersion.String(),
+		}
 		_, visible := tree.Add(workers, mp)
 
 		if visible {
@@ -280,6 +313,177 @@ func addMachinePoolsToObjectTree(ctx context.Context, c client.Client, namespace
 	}
 }
 
+// addClusterClassToObjectTree adds the ClusterClass used by a cluster with a managed topology; the ClusterClass gets
+// a ready condition mirroring the cluster's TopologyReconciled condition, which reports if the cluster is in sync with it.
+func addClusterClassToObjectTree(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, tree *ObjectTree) {
+	clusterClass, err := getClusterClass(ctx, c, cluster.Namespace, cluster.Spec.Topology.Class)
+	if err != nil {
+		return
+	}
+
+	if topologyReconciled := conditions.Get(cluster, clusterv1.TopologyReconciledCondition); topologyReconciled != nil {
+		ready := topologyReconciled.DeepCopy()
+		ready.Type = clusterv1.ReadyCondition
+		setReadyCondition(clusterClass, ready)
+	}
+	tree.Add(cluster, clusterClass, ObjectMetaName("ClusterClass"))
+}
+
+// addMachineHealthChecksToObjectTree adds the MachineHealthChecks for a cluster, each one with a ready condition
+// reporting if remediation is allowed and if there are unhealthy machines; MachineHealthChecks whose ready condition
+// is true are added only if echo is enabled.
+func addMachineHealthChecksToObjectTree(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, machinesList *clusterv1.MachineList, tree *ObjectTree, options DiscoverOptions) error {
+	machineHealthCheckList, err := getMachineHealthChecksInCluster(ctx, c, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	var machineHealthCheckGroup client.Object
+	for i := range machineHealthCheckList.Items {
+		mhc := &machineHealthCheckList.Items[i]
+		// Enforce TypeMeta to make sure checks on GVK works properly.
+		mhc.TypeMeta = metav1.TypeMeta{
+			Kind:       "MachineHealthCheck",
+			APIVersion: clusterv1.GroupVersion.String(),
+		}
+
+		if ready := machineHealthCheckReadyCondition(mhc, machinesList); ready != nil {
+			setReadyCondition(mhc, ready)
+		}
+		if !options.Echo && conditions.IsTrue(mhc, clusterv1.ReadyCondition) {
+			continue
+		}
+
+		if machineHealthCheckGroup == nil {
+			machineHealthCheckGroup = VirtualObject(cluster.Namespace, "MachineHealthCheckGroup", "MachineHealthChecks")
+			tree.Add(cluster, machineHealthCheckGroup)
+		}
+		tree.Add(machineHealthCheckGroup, mhc)
+	}
+	return nil
+}
+
+// machineHealthCheckReadyCondition returns the ready condition shown for a MachineHealthCheck, which is:
+// - false, if remediation is not allowed, e.g. because there are too many unhealthy machines.
+// - false, if some of the target machines are unhealthy, and thus they are going to be remediated.
+// - true, if remediation is allowed and all the target machines are healthy.
+func machineHealthCheckReadyCondition(mhc *clusterv1.MachineHealthCheck, machinesList *clusterv1.MachineList) *clusterv1.Condition {
+	remediationAllowed := conditions.Get(mhc, clusterv1.RemediationAllowedCondition)
+	if remediationAllowed != nil && remediationAllowed.Status == corev1.ConditionFalse {
+		ready := remediationAllowed.DeepCopy()
+		ready.Type = clusterv1.ReadyCondition
+		return ready
+	}
+
+	targets := map[string]bool{}
+	for _, t := range mhc.Status.Targets {
+		targets[t] = true
+	}
+	var unhealthy []string
+	var lastTransitionTime metav1.Time
+	for i := range machinesList.Items {
+		m := &machinesList.Items[i]
+		if !targets[m.Name] || !conditions.IsFalse(m, clusterv1.MachineHealthCheckSucceededCondition) {
+			continue
+		}
+		unhealthy = append(unhealthy, m.Name)
+		if t := conditions.GetLastTransitionTime(m, clusterv1.MachineHealthCheckSucceededCondition); t != nil && (lastTransitionTime.IsZero() || t.Before(&lastTransitionTime)) {
+			lastTransitionTime = *t
+		}
+	}
+	if len(unhealthy) > 0 {
+		ready := conditions.FalseCondition(clusterv1.ReadyCondition, UnhealthyMachinesReason, clusterv1.ConditionSeverityWarning,
+			"%d of %d machines are unhealthy: %s", len(unhealthy), mhc.Status.ExpectedMachines, strings.Join(unhealthy, ", "))
+		ready.LastTransitionTime = lastTransitionTime
+		return ready
+	}
+
+	if remediationAllowed == nil {
+		return nil
+	}
+	ready := remediationAllowed.DeepCopy()
+	ready.Type = clusterv1.ReadyCondition
+	return ready
+}
+
+// addExtensionConfigsToObjectTree adds the ExtensionConfigs registering Runtime Extensions which are called for the cluster,
+// each one with a ready condition mirroring the Discovered condition; ExtensionConfigs whose ready condition
+// is true are added only if echo is enabled.
+// NOTE: ExtensionConfigs exist only if the RuntimeSDK feature is enabled, so they are added on a best effort basis.
+func addExtensionConfigsToObjectTree(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, tree *ObjectTree, options DiscoverOptions) {
+	extensionConfigList := &runtimev1.ExtensionConfigList{}
+	if err := c.List(ctx, extensionConfigList); err != nil {
+		return
+	}
+
+	// Gets the labels of the cluster's namespace, used for selecting the ExtensionConfigs that apply to the cluster.
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: cluster.Namespace}, namespace); err != nil {
+		namespace.Name = cluster.Namespace
+	}
+
+	var extensionConfigGroup client.Object
+	for i := range extensionConfigList.Items {
+		extensionConfig := &extensionConfigList.Items[i]
+		if !extensionConfigSelectsNamespace(extensionConfig, namespace) {
+			continue
+		}
+		// Enforce TypeMeta to make sure checks on GVK works properly.
+		extensionConfig.TypeMeta = metav1.TypeMeta{
+			Kind:       "ExtensionConfig",
+			APIVersion: runtimev1.GroupVersion.String(),
+		}
+
+		if discovered := conditions.Get(extensionConfig, runtimev1.RuntimeExtensionDiscoveredCondition); discovered != nil {
+			ready := discovered.DeepCopy()
+			ready.Type = clusterv1.ReadyCondition
+			setReadyCondition(extensionConfig, ready)
+		}
+		if !options.Echo && conditions.IsTrue(extensionConfig, clusterv1.ReadyCondition) {
+			continue
+		}
+
+		if extensionConfigGroup == nil {
+			extensionConfigGroup = VirtualObject(cluster.Namespace, "RuntimeExtensionGroup", "RuntimeExtensions")
+			tree.Add(cluster, extensionConfigGroup)
+		}
+		tree.Add(extensionConfigGroup, extensionConfig)
+	}
+}
+
+// extensionConfigSelectsNamespace returns true if the Runtime Extensions registered by the ExtensionConfig are called for
+// objects in the namespace; ExtensionConfigs without a namespace selector apply to all the namespaces.
+func extensionConfigSelectsNamespace(extensionConfig *runtimev1.ExtensionConfig, namespace *corev1.Namespace) bool {
+	if extensionConfig.Spec.NamespaceSelector == nil {
+		return true
+	}
+	selector, err := metav1.LabelSelectorAsSelector(extensionConfig.Spec.NamespaceSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(namespace.Labels))
+}
+
+// getClusterClass returns a ClusterClass as an unstructured object, so it can have conditions in the presentation layer.
+func getClusterClass(ctx context.Context, c client.Client, namespace, name string) (*unstructured.Unstructured, error) {
+	clusterClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, clusterClass); err != nil {
+		return nil, err
+	}
+	return clusterClassToUnstructured(clusterClass)
+}
+
+func clusterClassToUnstructured(clusterClass *clusterv1.ClusterClass) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(clusterClass)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to convert ClusterClass %s/%s to unstructured", clusterClass.Namespace, clusterClass.Name)
+	}
+	u := &unstructured.Unstructured{Object: content}
+	u.SetAPIVersion(clusterv1.GroupVersion.String())
+	u.SetKind("ClusterClass")
+	return u, nil
+}
+
 func getResourceSetBindingInCluster(ctx context.Context, c client.Client, namespace string, name string) (*addonsv1.ClusterResourceSetBinding, error) {
 	if name == "" {
 		return nil, nil
@@ -358,6 +562,21 @@ func getMachinePoolsInCluster(ctx context.Context, c client.Client, namespace, n
 	return machinePoolList, nil
 }
 
+func getMachineHealthChecksInCluster(ctx context.Context, c client.Client, namespace, name string) (*clusterv1.MachineHealthCheckList, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	machineHealthCheckList := &clusterv1.MachineHealthCheckList{}
+	labels := map[string]string{clusterv1.ClusterLabelName: name}
+
+	if err := c.List(ctx, machineHealthCheckList, client.InNamespace(namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+
+	return machineHealthCheckList, nil
+}
+
 func selectControlPlaneMachines(machineList *clusterv1.MachineList) []*clusterv1.Machine {
 	machines := []*clusterv1.Machine{}
 	for i := range machineList.Items {
@@ -0,0 +1,119 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// DiscoverClusterClasses returns an object tree for each ClusterClass in a namespace, or only for the ClusterClass with
+// the given name, if any; each tree lists the Clusters using the ClusterClass and whether they are in sync with it.
+func DiscoverClusterClasses(ctx context.Context, c client.Client, namespace, name string, options DiscoverOptions) ([]*ObjectTree, error) {
+	clusterClasses := []clusterv1.ClusterClass{}
+	if name != "" {
+		clusterClass := &clusterv1.ClusterClass{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, clusterClass); err != nil {
+			return nil, errors.Wrapf(err, "failed to get ClusterClass %s/%s", namespace, name)
+		}
+		clusterClasses = append(clusterClasses, *clusterClass)
+	} else {
+		clusterClassList := &clusterv1.ClusterClassList{}
+		if err := c.List(ctx, clusterClassList, client.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrapf(err, "failed to list ClusterClasses in namespace %s", namespace)
+		}
+		clusterClasses = clusterClassList.Items
+		sort.Slice(clusterClasses, func(i, j int) bool {
+			return clusterClasses[i].Name < clusterClasses[j].Name
+		})
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := c.List(ctx, clusterList, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Clusters in namespace %s", namespace)
+	}
+
+	trees := make([]*ObjectTree, 0, len(clusterClasses))
+	for i := range clusterClasses {
+		tree, err := discoverClusterClass(&clusterClasses[i], clusterList, options)
+		if err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+	}
+	return trees, nil
+}
+
+// discoverClusterClass returns the object tree for a ClusterClass and the Clusters using it; each Cluster gets
+// a ready condition mirroring its TopologyReconciled condition, and the ClusterClass gets a ready condition
+// summarizing if all the Clusters are in sync with it.
+func discoverClusterClass(clusterClass *clusterv1.ClusterClass, clusterList *clusterv1.ClusterList, options DiscoverOptions) (*ObjectTree, error) {
+	root, err := clusterClassToUnstructured(clusterClass)
+	if err != nil {
+		return nil, err
+	}
+	if options.Grouping {
+		addAnnotation(root, GroupingObjectAnnotation, "True")
+	}
+
+	var clusters []*clusterv1.Cluster
+	notInSync := 0
+	for i := range clusterList.Items {
+		cluster := &clusterList.Items[i]
+		if cluster.Spec.Topology == nil || cluster.Spec.Topology.Class != clusterClass.Name {
+			continue
+		}
+		// Enforce TypeMeta to make sure checks on GVK works properly.
+		cluster.TypeMeta = metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		}
+
+		// Replaces the cluster's ready condition with the TopologyReconciled condition, so the tree shows if the cluster is in sync.
+		if topologyReconciled := conditions.Get(cluster, clusterv1.TopologyReconciledCondition); topologyReconciled != nil {
+			ready := topologyReconciled.DeepCopy()
+			ready.Type = clusterv1.ReadyCondition
+			conditions.Set(cluster, ready)
+		} else {
+			conditions.Delete(cluster, clusterv1.ReadyCondition)
+		}
+		if !conditions.IsTrue(cluster, clusterv1.ReadyCondition) {
+			notInSync++
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	if notInSync > 0 {
+		setReadyCondition(root, conditions.FalseCondition(clusterv1.ReadyCondition, ClustersNotInSyncReason, clusterv1.ConditionSeverityWarning,
+			"%d of %d Clusters are not in sync with the ClusterClass", notInSync, len(clusters)))
+	} else {
+		setReadyCondition(root, conditions.TrueCondition(clusterv1.ReadyCondition))
+	}
+
+	tree := NewObjectTree(root, options.toObjectTreeOptions())
+	for _, cluster := range clusters {
+		tree.Add(root, cluster)
+	}
+	return tree, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func clusterObjectsWithTopology(name, class string, topologyReconciled *clusterv1.Condition) []client.Object {
+	objs := test.NewFakeCluster("ns1", name).WithTopologyClass(class).Objs()
+	for _, obj := range objs {
+		if cluster, ok := obj.(*clusterv1.Cluster); ok && topologyReconciled != nil {
+			conditions.Set(cluster, topologyReconciled)
+		}
+	}
+	return objs
+}
+
+func Test_DiscoverClusterClasses(t *testing.T) {
+	var objs []client.Object
+	objs = append(objs, test.NewFakeClusterClass("ns1", "class1").Objs()...)
+	objs = append(objs, test.NewFakeClusterClass("ns1", "class2").Objs()...)
+	objs = append(objs, clusterObjectsWithTopology("cluster1", "class1", conditions.TrueCondition(clusterv1.TopologyReconciledCondition))...)
+	objs = append(objs, clusterObjectsWithTopology("cluster2", "class1", conditions.FalseCondition(clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledControlPlaneUpgradePendingReason, clusterv1.ConditionSeverityInfo, ""))...)
+	objs = append(objs, clusterObjectsWithTopology("cluster3", "class2", conditions.TrueCondition(clusterv1.TopologyReconciledCondition))...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster4").Objs()...)
+
+	tests := []struct {
+		name          string
+		clusterClass  string
+		wantClasses   []string
+		wantClusters  map[string][]string
+		wantReady     map[string]corev1.ConditionStatus
+		wantNotInSync map[string]string
+		wantErr       bool
+	}{
+		{
+			name:         "Describe all the ClusterClasses in the namespace",
+			clusterClass: "",
+			wantClasses:  []string{"class1", "class2"},
+			wantClusters: map[string][]string{
+				"class1": {"cluster1", "cluster2"},
+				"class2": {"cluster3"},
+			},
+			wantReady: map[string]corev1.ConditionStatus{
+				"class1": corev1.ConditionFalse,
+				"class2": corev1.ConditionTrue,
+			},
+			wantNotInSync: map[string]string{
+				"class1": "1 of 2 Clusters are not in sync with the ClusterClass",
+			},
+		},
+		{
+			name:         "Describe a ClusterClass",
+			clusterClass: "class2",
+			wantClasses:  []string{"class2"},
+			wantClusters: map[string][]string{
+				"class2": {"cluster3"},
+			},
+			wantReady: map[string]corev1.ConditionStatus{
+				"class2": corev1.ConditionTrue,
+			},
+		},
+		{
+			name:         "Fails if the ClusterClass does not exist",
+			clusterClass: "class3",
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			client, err := test.NewFakeProxy().WithObjs(objs...).NewClient()
+			g.Expect(err).ToNot(HaveOccurred())
+
+			trees, err := DiscoverClusterClasses(context.TODO(), client, "ns1", tt.clusterClass, DiscoverOptions{})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(trees).To(HaveLen(len(tt.wantClasses)))
+
+			for i, tree := range trees {
+				root := tree.GetRoot()
+				g.Expect(root.GetName()).To(Equal(tt.wantClasses[i]))
+				g.Expect(root.GetObjectKind().GroupVersionKind().Kind).To(Equal("ClusterClass"))
+
+				ready := GetReadyCondition(root)
+				g.Expect(ready).ToNot(BeNil())
+				g.Expect(ready.Status).To(Equal(tt.wantReady[root.GetName()]))
+				if ready.Status == corev1.ConditionFalse {
+					g.Expect(ready.Reason).To(Equal(ClustersNotInSyncReason))
+					g.Expect(ready.Message).To(Equal(tt.wantNotInSync[root.GetName()]))
+				}
+
+				var gotClusters []string
+				for _, cluster := range tree.GetObjectsByParent(root.GetUID()) {
+					gotClusters = append(gotClusters, cluster.GetName())
+
+					// The cluster's ready condition should mirror its TopologyReconciled condition.
+					clusterReady := GetReadyCondition(cluster)
+					g.Expect(clusterReady).ToNot(BeNil())
+					g.Expect(clusterReady.Status).To(Equal(conditions.Get(cluster.(conditions.Getter), clusterv1.TopologyReconciledCondition).Status))
+				}
+				g.Expect(gotClusters).To(ConsistOf(tt.wantClusters[root.GetName()]))
+			}
+		})
+	}
+}
@@ -22,11 +22,15 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func clusterObjectsWithResourceSet() []client.Object {
@@ -62,6 +66,122 @@ func clusterObjectsWithResourceSet() []client.Object {
 	return append(clusterObjs, resourceSetObjs...)
 }
 
+func clusterObjectsWithTopologyAndHealthChecks() []client.Object {
+	namespace := "ns1"
+	clusterObjs := test.NewFakeCluster(namespace, "cluster1").
+		WithTopologyClass("class1").
+		WithControlPlane(
+			test.NewFakeControlPlane("cp").
+				WithMachines(
+					test.NewFakeMachine("cp1"),
+				),
+		).
+		WithMachineDeployments(
+			test.NewFakeMachineDeployment("md1").
+				WithMachineSets(
+					test.NewFakeMachineSet("ms1").
+						WithMachines(
+							test.NewFakeMachine("m1"),
+							test.NewFakeMachine("m2"),
+						),
+				),
+		).
+		Objs()
+
+	for _, obj := range clusterObjs {
+		switch o := obj.(type) {
+		case *clusterv1.Cluster:
+			conditions.MarkFalse(o, clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledControlPlaneUpgradePendingReason, clusterv1.ConditionSeverityInfo, "")
+		case *clusterv1.Machine:
+			if o.Name == "m1" {
+				conditions.MarkFalse(o, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeNotFoundReason, clusterv1.ConditionSeverityWarning, "")
+			}
+		}
+	}
+
+	// A MachineHealthCheck with an unhealthy machine, and one with all the machines healthy.
+	unhealthyMHC := &clusterv1.MachineHealthCheck{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineHealthCheck",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "mhc-workers",
+			UID:       "cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheck, ns1/mhc-workers",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "cluster1"},
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{ClusterName: "cluster1"},
+		Status: clusterv1.MachineHealthCheckStatus{
+			ExpectedMachines: 2,
+			Targets:          []string{"m1", "m2"},
+		},
+	}
+	conditions.MarkTrue(unhealthyMHC, clusterv1.RemediationAllowedCondition)
+
+	healthyMHC := &clusterv1.MachineHealthCheck{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "MachineHealthCheck",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      "mhc-cp",
+			UID:       "cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheck, ns1/mhc-cp",
+			Labels:    map[string]string{clusterv1.ClusterLabelName: "cluster1"},
+		},
+		Spec: clusterv1.MachineHealthCheckSpec{ClusterName: "cluster1"},
+		Status: clusterv1.MachineHealthCheckStatus{
+			ExpectedMachines: 1,
+			Targets:          []string{"cp1"},
+		},
+	}
+	conditions.MarkTrue(healthyMHC, clusterv1.RemediationAllowedCondition)
+
+	// An ExtensionConfig not yet discovered, and one selecting another namespace.
+	ns := &corev1.Namespace{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Namespace",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   namespace,
+			Labels: map[string]string{"env": "test"},
+		},
+	}
+	extensionConfig := &runtimev1.ExtensionConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ExtensionConfig",
+			APIVersion: runtimev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ext1",
+			UID:  "runtime.cluster.x-k8s.io/v1alpha1, Kind=ExtensionConfig, /ext1",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "test"}},
+		},
+	}
+	conditions.MarkFalse(extensionConfig, runtimev1.RuntimeExtensionDiscoveredCondition, runtimev1.DiscoveryFailedReason, clusterv1.ConditionSeverityError, "")
+	otherExtensionConfig := &runtimev1.ExtensionConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ExtensionConfig",
+			APIVersion: runtimev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ext2",
+			UID:  "runtime.cluster.x-k8s.io/v1alpha1, Kind=ExtensionConfig, /ext2",
+		},
+		Spec: runtimev1.ExtensionConfigSpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+		},
+	}
+	conditions.MarkFalse(otherExtensionConfig, runtimev1.RuntimeExtensionDiscoveredCondition, runtimev1.DiscoveryFailedReason, clusterv1.ConditionSeverityError, "")
+
+	objs := append(clusterObjs, test.NewFakeClusterClass(namespace, "class1").Objs()...)
+	return append(objs, unhealthyMHC, healthyMHC, ns, extensionConfig, otherExtensionConfig)
+}
+
 func Test_Discovery(t *testing.T) {
 	type nodeCheck func(*WithT, client.Object)
 	type args struct {
@@ -733,6 +853,104 @@ func Test_Discovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Discovery with machine pools",
+			args: args{
+				discoverOptions: DiscoverOptions{
+					Grouping: true,
+				},
+				objs: test.NewFakeCluster("ns1", "cluster1").
+					WithControlPlane(
+						test.NewFakeControlPlane("cp").
+							WithMachines(
+								test.NewFakeMachine("cp1"),
+							),
+					).
+					WithMachinePools(
+						test.NewFakeMachinePool("mp1"),
+					).
+					Objs(),
+			},
+			wantTree: map[string][]string{
+				// Cluster should be parent of InfrastructureCluster, ControlPlane, and WorkerNodes
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1": {
+					"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/cluster1",
+					"controlplane.cluster.x-k8s.io/v1beta1, Kind=GenericControlPlane, ns1/cp",
+					"virtual.cluster.x-k8s.io/v1beta1, Kind=WorkerGroup, ns1/Workers",
+				},
+				// Workers should have a machine pool
+				"virtual.cluster.x-k8s.io/v1beta1, Kind=WorkerGroup, ns1/Workers": {
+					"cluster.x-k8s.io/v1beta1, Kind=MachinePool, ns1/mp1",
+				},
+				// Machine pool should be leaf (no echo)
+				"cluster.x-k8s.io/v1beta1, Kind=MachinePool, ns1/mp1": {},
+			},
+			wantNodeCheck: map[string]nodeCheck{
+				// Workers should be a virtual node
+				"virtual.cluster.x-k8s.io/v1beta1, Kind=WorkerGroup, ns1/Workers": func(g *WithT, obj client.Object) {
+					g.Expect(IsVirtualObject(obj)).To(BeTrue())
+				},
+			},
+		},
+		{
+			name: "Discovery with cluster class, machine health checks and runtime extensions",
+			args: args{
+				discoverOptions: DiscoverOptions{
+					Grouping: true,
+				},
+				objs: clusterObjectsWithTopologyAndHealthChecks(),
+			},
+			wantTree: map[string][]string{
+				// Cluster should be parent of InfrastructureCluster, ControlPlane, WorkerGroup, ClusterClass, MachineHealthCheckGroup and RuntimeExtensionGroup
+				"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1": {
+					"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/cluster1",
+					"controlplane.cluster.x-k8s.io/v1beta1, Kind=GenericControlPlane, ns1/cp",
+					"virtual.cluster.x-k8s.io/v1beta1, Kind=WorkerGroup, ns1/Workers",
+					"cluster.x-k8s.io/v1beta1, Kind=ClusterClass, ns1/class1",
+					"virtual.cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheckGroup, ns1/MachineHealthChecks",
+					"virtual.cluster.x-k8s.io/v1beta1, Kind=RuntimeExtensionGroup, ns1/RuntimeExtensions",
+				},
+				// ClusterClass should be a leaf
+				"cluster.x-k8s.io/v1beta1, Kind=ClusterClass, ns1/class1": {},
+				// MachineHealthCheckGroup should only have the MachineHealthCheck with unhealthy machines (no echo)
+				"virtual.cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheckGroup, ns1/MachineHealthChecks": {
+					"cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheck, ns1/mhc-workers",
+				},
+				// RuntimeExtensionGroup should only have the ExtensionConfig selecting the cluster namespace
+				"virtual.cluster.x-k8s.io/v1beta1, Kind=RuntimeExtensionGroup, ns1/RuntimeExtensions": {
+					"runtime.cluster.x-k8s.io/v1alpha1, Kind=ExtensionConfig, /ext1",
+				},
+			},
+			wantNodeCheck: map[string]nodeCheck{
+				// ClusterClass should have a meta name and a ready condition mirroring the TopologyReconciled condition
+				"cluster.x-k8s.io/v1beta1, Kind=ClusterClass, ns1/class1": func(g *WithT, obj client.Object) {
+					g.Expect(GetMetaName(obj)).To(Equal("ClusterClass"))
+					ready := GetReadyCondition(obj)
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(corev1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(clusterv1.TopologyReconciledControlPlaneUpgradePendingReason))
+				},
+				// MachineHealthCheckGroup should be a virtual node
+				"virtual.cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheckGroup, ns1/MachineHealthChecks": func(g *WithT, obj client.Object) {
+					g.Expect(IsVirtualObject(obj)).To(BeTrue())
+				},
+				// MachineHealthCheck should have a ready condition reporting the unhealthy machines
+				"cluster.x-k8s.io/v1beta1, Kind=MachineHealthCheck, ns1/mhc-workers": func(g *WithT, obj client.Object) {
+					ready := GetReadyCondition(obj)
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(corev1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(UnhealthyMachinesReason))
+					g.Expect(ready.Message).To(Equal("1 of 2 machines are unhealthy: m1"))
+				},
+				// ExtensionConfig should have a ready condition mirroring the Discovered condition
+				"runtime.cluster.x-k8s.io/v1alpha1, Kind=ExtensionConfig, /ext1": func(g *WithT, obj client.Object) {
+					ready := GetReadyCondition(obj)
+					g.Expect(ready).ToNot(BeNil())
+					g.Expect(ready.Status).To(Equal(corev1.ConditionFalse))
+					g.Expect(ready.Reason).To(Equal(runtimev1.DiscoveryFailedReason))
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
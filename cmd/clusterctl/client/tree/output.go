@@ -0,0 +1,130 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ObjectTreeOutput is the representation of an ObjectTree used by machine-readable output formats.
+type ObjectTreeOutput struct {
+	// Options are the options used for building the ObjectTree.
+	Options ObjectTreeOptions `json:"options"`
+
+	// Root is the node for the root of the ObjectTree, usually a Cluster.
+	Root *ObjectNode `json:"root"`
+}
+
+// ObjectNode is the representation of an object in the ObjectTree used by machine-readable output formats.
+type ObjectNode struct {
+	// APIVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the object; group objects have the kind of the objects in the group with the Group suffix, e.g. MachineGroup.
+	Kind string `json:"kind"`
+
+	// Name of the object; it is empty for group objects.
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the object.
+	Namespace string `json:"namespace,omitempty"`
+
+	// MetaName is the name used for the object in the presentation layer, e.g. ControlPlane.
+	MetaName string `json:"metaName,omitempty"`
+
+	// Virtual is true if the object does not correspond to any real object, e.g. Workers.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// Group is true if the object represents a group of sibling objects with the same ready condition.
+	Group bool `json:"group,omitempty"`
+
+	// GroupItems are the names of the objects in the group.
+	GroupItems []string `json:"groupItems,omitempty"`
+
+	// Deleting is true if the object is being deleted.
+	Deleting bool `json:"deleting,omitempty"`
+
+	// Ready is the ready condition of the object, if defined.
+	Ready *clusterv1.Condition `json:"ready,omitempty"`
+
+	// Conditions are the other conditions of the object, sorted by type.
+	Conditions []*clusterv1.Condition `json:"conditions,omitempty"`
+
+	// Children are the nodes for the child objects, in the same order they are printed in the tree view.
+	Children []*ObjectNode `json:"children,omitempty"`
+}
+
+// GetOptions returns the options used for building the tree.
+func (od ObjectTree) GetOptions() ObjectTreeOptions { return od.options }
+
+// ToOutput returns the representation of the tree used by machine-readable output formats.
+// NOTE: Unlike the tree view, all the conditions are included for all the objects.
+func (od ObjectTree) ToOutput() *ObjectTreeOutput {
+	return &ObjectTreeOutput{
+		Options: od.options,
+		Root:    od.toObjectNode(od.root),
+	}
+}
+
+func (od ObjectTree) toObjectNode(obj client.Object) *ObjectNode {
+	node := &ObjectNode{
+		APIVersion: obj.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+		Kind:       obj.GetObjectKind().GroupVersionKind().Kind,
+		Name:       obj.GetName(),
+		Namespace:  obj.GetNamespace(),
+		MetaName:   GetMetaName(obj),
+		Virtual:    IsVirtualObject(obj),
+		Group:      IsGroupObject(obj),
+		Deleting:   !obj.GetDeletionTimestamp().IsZero(),
+		Ready:      GetReadyCondition(obj),
+		Conditions: GetOtherConditions(obj),
+	}
+
+	// The name of group objects is generated randomly, so it is replaced by the names of the objects in the group.
+	if node.Group {
+		node.Name = ""
+		node.GroupItems = strings.Split(GetGroupItems(obj), GroupItemsSeparator)
+	}
+
+	children := od.GetObjectsByParent(obj.GetUID())
+	sort.Slice(children, func(i, j int) bool {
+		if GetZOrder(children[i]) == GetZOrder(children[j]) {
+			return sortKey(children[i]) < sortKey(children[j])
+		}
+		return GetZOrder(children[i]) > GetZOrder(children[j])
+	})
+	for _, child := range children {
+		node.Children = append(node.Children, od.toObjectNode(child))
+	}
+	return node
+}
+
+// sortKey returns the key used for sorting objects with the same z-order.
+func sortKey(obj client.Object) string {
+	if IsGroupObject(obj) {
+		return obj.GetObjectKind().GroupVersionKind().Kind + "/" + GetGroupItems(obj)
+	}
+	if metaName := GetMetaName(obj); metaName != "" {
+		return metaName
+	}
+	return obj.GetObjectKind().GroupVersionKind().Kind + "/" + obj.GetName()
+}
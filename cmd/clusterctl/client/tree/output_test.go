@@ -0,0 +1,77 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tree
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_ToOutput(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := fakeCluster("cluster",
+		withClusterCondition(conditions.TrueCondition(clusterv1.ReadyCondition)),
+		withClusterCondition(conditions.FalseCondition(clusterv1.InfrastructureReadyCondition, "Reason", clusterv1.ConditionSeverityWarning, "")),
+	)
+	options := ObjectTreeOptions{Echo: true, Grouping: true}
+	tree := NewObjectTree(cluster, options)
+
+	workers := VirtualObject("ns", "WorkerGroup", "Workers")
+	tree.Add(cluster, workers, GroupingObject(true))
+	tree.Add(workers, fakeMachine("machine-2", withMachineCondition(conditions.TrueCondition(clusterv1.ReadyCondition))))
+	tree.Add(workers, fakeMachine("machine-3", withMachineCondition(conditions.TrueCondition(clusterv1.ReadyCondition))))
+
+	deleting := fakeMachine("machine-1", withMachineCondition(conditions.FalseCondition(clusterv1.ReadyCondition, "Deleting", clusterv1.ConditionSeverityInfo, "")))
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	tree.Add(cluster, deleting, ObjectMetaName("Deleted"), ZOrder(1))
+
+	out := tree.ToOutput()
+	g.Expect(out.Options).To(Equal(options))
+
+	root := out.Root
+	g.Expect(root.Kind).To(Equal("Cluster"))
+	g.Expect(root.Name).To(Equal("cluster"))
+	g.Expect(root.Namespace).To(Equal("ns"))
+	g.Expect(root.Ready.Status).To(BeEquivalentTo("True"))
+	g.Expect(root.Conditions).To(HaveLen(1))
+	g.Expect(root.Conditions[0].Type).To(Equal(clusterv1.InfrastructureReadyCondition))
+
+	// Children are sorted by z-order first.
+	g.Expect(root.Children).To(HaveLen(2))
+	g.Expect(root.Children[0].Name).To(Equal("machine-1"))
+	g.Expect(root.Children[0].MetaName).To(Equal("Deleted"))
+	g.Expect(root.Children[0].Deleting).To(BeTrue())
+
+	g.Expect(root.Children[1].Name).To(Equal("Workers"))
+	g.Expect(root.Children[1].Virtual).To(BeTrue())
+	g.Expect(root.Children[1].Children).To(HaveLen(1))
+
+	group := root.Children[1].Children[0]
+	g.Expect(group.Kind).To(Equal("MachineGroup"))
+	g.Expect(group.Name).To(BeEmpty())
+	g.Expect(group.Group).To(BeTrue())
+	g.Expect(group.Virtual).To(BeTrue())
+	g.Expect(group.GroupItems).To(Equal([]string{"machine-2", "machine-3"}))
+	g.Expect(group.Ready.Status).To(BeEquivalentTo("True"))
+}
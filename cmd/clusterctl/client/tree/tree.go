@@ -36,27 +36,27 @@ import (
 type ObjectTreeOptions struct {
 	// ShowOtherConditions is a list of comma separated kind or kind/name for which we should add   the ShowObjectConditionsAnnotation
 	// to signal to the presentation layer to show all the conditions for the objects.
-	ShowOtherConditions string
+	ShowOtherConditions string `json:"showOtherConditions,omitempty"`
 
 	// ShowMachineSets instructs the discovery process to include machine sets in the ObjectTree.
-	ShowMachineSets bool
+	ShowMachineSets bool `json:"showMachineSets,omitempty"`
 
 	// ShowClusterResourceSets instructs the discovery process to include cluster resource sets in the ObjectTree.
-	ShowClusterResourceSets bool
+	ShowClusterResourceSets bool `json:"showClusterResourceSets,omitempty"`
 
 	// ShowTemplates instructs the discovery process to include infrastructure and bootstrap config templates in the ObjectTree.
-	ShowTemplates bool
+	ShowTemplates bool `json:"showTemplates,omitempty"`
 
 	// AddTemplateVirtualNode instructs the discovery process to group template under a virtual node.
-	AddTemplateVirtualNode bool
+	AddTemplateVirtualNode bool `json:"addTemplateVirtualNode,omitempty"`
 
 	// Echo displays objects if the object's ready condition has the
 	// same Status, Severity and Reason of the parent's object ready condition (it is an echo)
-	Echo bool
+	Echo bool `json:"echo"`
 
 	// Grouping groups sibling object in case the ready conditions
 	// have the same Status, Severity and Reason
-	Grouping bool
+	Grouping bool `json:"grouping"`
 }
 
 // ObjectTree defines an object tree representing the status of a Cluster API cluster.
@@ -28,6 +28,9 @@ type backupOptions struct {
 	fromKubeconfigContext string
 	namespace             string
 	directory             string
+	file                  string
+	recipients            []string
+	passphraseFile        string
 }
 
 var buo = &backupOptions{}
@@ -40,7 +43,13 @@ var backupCmd = &cobra.Command{
 
 	Example: Examples(`
 		Backup Cluster API objects and all dependencies from a management cluster.
-		clusterctl backup --directory=/tmp/backup-directory`),
+		clusterctl backup --directory=/tmp/backup-directory
+
+		Backup Cluster API objects and all dependencies into an archive encrypted for an age public key.
+		clusterctl backup --file=/tmp/backup.tar.gz.age --recipient=age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+
+		Backup Cluster API objects and all dependencies into an archive encrypted with a passphrase read from a file.
+		clusterctl backup --file=/tmp/backup.tar.gz.age --passphrase-file=/path/to/passphrase`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runBackup()
@@ -56,13 +65,19 @@ func init() {
 		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
 	backupCmd.Flags().StringVar(&buo.directory, "directory", "",
 		"The directory to save Cluster API objects to as yaml files")
+	backupCmd.Flags().StringVar(&buo.file, "file", "",
+		"The archive file to save Cluster API objects to, together with a manifest describing the backup")
+	backupCmd.Flags().StringSliceVar(&buo.recipients, "recipient", nil,
+		"The age public key to encrypt the archive file for. Can be specified multiple times")
+	backupCmd.Flags().StringVar(&buo.passphraseFile, "passphrase-file", "",
+		"Path to a file containing the passphrase to encrypt the archive file with")
 
 	RootCmd.AddCommand(backupCmd)
 }
 
 func runBackup() error {
-	if buo.directory == "" {
-		return errors.New("please specify a directory to backup cluster API objects to using the --directory flag")
+	if buo.directory == "" && buo.file == "" {
+		return errors.New("please specify a directory or an archive file to backup cluster API objects to using the --directory or the --file flag")
 	}
 
 	c, err := client.New(cfgFile)
@@ -74,5 +89,8 @@ func runBackup() error {
 		FromKubeconfig: client.Kubeconfig{Path: buo.fromKubeconfig, Context: buo.fromKubeconfigContext},
 		Namespace:      buo.namespace,
 		Directory:      buo.directory,
+		File:           buo.file,
+		Recipients:     buo.recipients,
+		PassphraseFile: buo.passphraseFile,
 	})
 }
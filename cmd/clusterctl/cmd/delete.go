@@ -24,15 +24,18 @@ import (
 )
 
 type deleteOptions struct {
-	kubeconfig              string
-	kubeconfigContext       string
-	coreProvider            string
-	bootstrapProviders      []string
-	controlPlaneProviders   []string
-	infrastructureProviders []string
-	includeNamespace        bool
-	includeCRDs             bool
-	deleteAll               bool
+	kubeconfig                string
+	kubeconfigContext         string
+	coreProvider              string
+	bootstrapProviders        []string
+	controlPlaneProviders     []string
+	infrastructureProviders   []string
+	ipamProviders             []string
+	runtimeExtensionProviders []string
+	addonProviders            []string
+	includeNamespace          bool
+	includeCRDs               bool
+	deleteAll                 bool
 }
 
 var dd = &deleteOptions{}
@@ -102,6 +105,12 @@ func init() {
 		"Bootstrap providers and versions (e.g. kubeadm:v0.3.0) to delete from the management cluster")
 	deleteCmd.Flags().StringSliceVarP(&dd.controlPlaneProviders, "control-plane", "c", nil,
 		"ControlPlane providers and versions (e.g. kubeadm:v0.3.0) to delete from the management cluster")
+	deleteCmd.Flags().StringSliceVar(&dd.ipamProviders, "ipam", nil,
+		"IPAM providers and versions (e.g. infoblox:v0.0.1) to delete from the management cluster")
+	deleteCmd.Flags().StringSliceVar(&dd.runtimeExtensionProviders, "runtime-extension", nil,
+		"Runtime extension providers and versions (e.g. test:v0.0.1) to delete from the management cluster")
+	deleteCmd.Flags().StringSliceVar(&dd.addonProviders, "addon", nil,
+		"Add-on providers and versions (e.g. helm:v0.1.0) to delete from the management cluster")
 
 	deleteCmd.Flags().BoolVar(&dd.deleteAll, "all", false,
 		"Force deletion of all the providers")
@@ -118,24 +127,30 @@ func runDelete() error {
 	hasProviderNames := (dd.coreProvider != "") ||
 		(len(dd.bootstrapProviders) > 0) ||
 		(len(dd.controlPlaneProviders) > 0) ||
-		(len(dd.infrastructureProviders) > 0)
+		(len(dd.infrastructureProviders) > 0) ||
+		(len(dd.ipamProviders) > 0) ||
+		(len(dd.runtimeExtensionProviders) > 0) ||
+		(len(dd.addonProviders) > 0)
 
 	if dd.deleteAll && hasProviderNames {
-		return errors.New("The --all flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure")
+		return errors.New("The --all flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon")
 	}
 
 	if !dd.deleteAll && !hasProviderNames {
-		return errors.New("At least one of --core, --bootstrap, --control-plane, --infrastructure should be specified or the --all flag should be set")
+		return errors.New("At least one of --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon should be specified or the --all flag should be set")
 	}
 
 	return c.Delete(client.DeleteOptions{
-		Kubeconfig:              client.Kubeconfig{Path: dd.kubeconfig, Context: dd.kubeconfigContext},
-		IncludeNamespace:        dd.includeNamespace,
-		IncludeCRDs:             dd.includeCRDs,
-		CoreProvider:            dd.coreProvider,
-		BootstrapProviders:      dd.bootstrapProviders,
-		InfrastructureProviders: dd.infrastructureProviders,
-		ControlPlaneProviders:   dd.controlPlaneProviders,
-		DeleteAll:               dd.deleteAll,
+		Kubeconfig:                client.Kubeconfig{Path: dd.kubeconfig, Context: dd.kubeconfigContext},
+		IncludeNamespace:          dd.includeNamespace,
+		IncludeCRDs:               dd.includeCRDs,
+		CoreProvider:              dd.coreProvider,
+		BootstrapProviders:        dd.bootstrapProviders,
+		InfrastructureProviders:   dd.infrastructureProviders,
+		ControlPlaneProviders:     dd.controlPlaneProviders,
+		IPAMProviders:             dd.ipamProviders,
+		RuntimeExtensionProviders: dd.runtimeExtensionProviders,
+		AddonProviders:            dd.addonProviders,
+		DeleteAll:                 dd.deleteAll,
 	})
 }
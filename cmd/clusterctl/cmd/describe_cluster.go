@@ -17,8 +17,13 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"sort"
 	"strings"
 	"time"
@@ -26,10 +31,12 @@ import (
 	"github.com/fatih/color"
 	"github.com/gobuffalo/flect"
 	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
@@ -41,8 +48,24 @@ const (
 	lastElemPrefix  = `└─`
 	indent          = "  "
 	pipe            = `│ `
+
+	// describeClusterOutputText prints the cluster status as a tree view.
+	describeClusterOutputText = "text"
+	// describeClusterOutputJSON prints the cluster status in json format.
+	describeClusterOutputJSON = "json"
+	// describeClusterOutputYaml prints the cluster status in yaml format.
+	describeClusterOutputYaml = "yaml"
+
+	// describeClusterWatchInterval is the interval between two checks for changes when watching the cluster status.
+	describeClusterWatchInterval = 2 * time.Second
+
+	// clearScreen is the escape sequence which clears the terminal and moves the cursor to the top left.
+	clearScreen = "\033[H\033[2J"
 )
 
+// describeClusterOutputs is the list of valid describe cluster outputs.
+var describeClusterOutputs = []string{describeClusterOutputText, describeClusterOutputJSON, describeClusterOutputYaml}
+
 var (
 	gray   = color.New(color.FgHiBlack)
 	red    = color.New(color.FgRed)
@@ -64,6 +87,8 @@ type describeClusterOptions struct {
 	disableNoEcho           bool
 	grouping                bool
 	disableGrouping         bool
+	output                  string
+	watch                   bool
 }
 
 var dc = &describeClusterOptions{}
@@ -92,11 +117,18 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 disabling automatic echo suppression
         # e.g. show the infrastructure machine objects, no matter if the current state is already reported by the machine's Ready condition.
-		clusterctl describe cluster test-1 --disable-no-echo`),
+		clusterctl describe cluster test-1 --disable-no-echo
+
+		# Describe the cluster named test-1 in json format, e.g. for checking its status in scripts.
+		# NOTE: all the conditions are included for all the objects.
+		clusterctl describe cluster test-1 -o json
+
+		# Describe the cluster named test-1, and print it again whenever the status of its objects changes.
+		clusterctl describe cluster test-1 --watch`),
 
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runDescribeCluster(args[0])
+		return runDescribeCluster(os.Stdout, args[0])
 	},
 }
 
@@ -130,6 +162,11 @@ func init() {
 	_ = describeClusterClusterCmd.Flags().MarkDeprecated("disable-grouping",
 		"use --grouping instead.")
 
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", describeClusterOutputText,
+		fmt.Sprintf("Output format. Valid values: %v.", describeClusterOutputs))
+	describeClusterClusterCmd.Flags().BoolVarP(&dc.watch, "watch", "w", false,
+		"Watch the cluster status, and print it again whenever it changes. In json and yaml format, each status is printed as a separate document.")
+
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
 		describeClusterClusterCmd.Flags().Lookup("kubeconfig"),
@@ -142,36 +179,115 @@ func init() {
 	describeCmd.AddCommand(describeClusterClusterCmd)
 }
 
-func runDescribeCluster(name string) error {
+func runDescribeCluster(out io.Writer, name string) error {
+	if !isValidDescribeClusterOutput(dc.output) {
+		return errors.Errorf("invalid output format %q, valid values: %v", dc.output, describeClusterOutputs)
+	}
+
 	c, err := client.New(cfgFile)
 	if err != nil {
 		return err
 	}
 
-	tree, err := c.DescribeCluster(client.DescribeClusterOptions{
-		Kubeconfig:              client.Kubeconfig{Path: dc.kubeconfig, Context: dc.kubeconfigContext},
-		Namespace:               dc.namespace,
-		ClusterName:             name,
-		ShowOtherConditions:     dc.showOtherConditions,
-		ShowClusterResourceSets: dc.showClusterResourceSets,
-		ShowTemplates:           dc.showTemplates,
-		ShowMachineSets:         dc.showMachineSets,
-		AddTemplateVirtualNode:  true,
-		Echo:                    dc.echo || dc.disableNoEcho,
-		Grouping:                dc.grouping && !dc.disableGrouping,
-	})
+	describe := func() (*tree.ObjectTree, error) {
+		return c.DescribeCluster(client.DescribeClusterOptions{
+			Kubeconfig:              client.Kubeconfig{Path: dc.kubeconfig, Context: dc.kubeconfigContext},
+			Namespace:               dc.namespace,
+			ClusterName:             name,
+			ShowOtherConditions:     dc.showOtherConditions,
+			ShowClusterResourceSets: dc.showClusterResourceSets,
+			ShowTemplates:           dc.showTemplates,
+			ShowMachineSets:         dc.showMachineSets,
+			AddTemplateVirtualNode:  true,
+			Echo:                    dc.echo || dc.disableNoEcho,
+			Grouping:                dc.grouping && !dc.disableGrouping,
+		})
+	}
+
+	if dc.watch {
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		return watchDescribeCluster(ctx, out, describe, dc.output, describeClusterWatchInterval)
+	}
+
+	tree, err := describe()
 	if err != nil {
 		return err
 	}
+	return printDescribeClusterOutput(out, tree, dc.output)
+}
 
-	printObjectTree(tree)
+func isValidDescribeClusterOutput(output string) bool {
+	for _, o := range describeClusterOutputs {
+		if output == o {
+			return true
+		}
+	}
+	return false
+}
+
+// watchDescribeCluster prints the cluster status every time it changes, until the context is canceled.
+// NOTE: Changes are detected by comparing the machine-readable representation of the object tree, which
+// does not include the age of conditions; as a consequence, in text format the SINCE column is updated only
+// when the cluster status changes.
+func watchDescribeCluster(ctx context.Context, out io.Writer, describe func() (*tree.ObjectTree, error), output string, interval time.Duration) error {
+	var last []byte
+	for {
+		objectTree, err := describe()
+		if err != nil {
+			return err
+		}
+
+		current, err := json.Marshal(objectTree.ToOutput())
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the cluster status")
+		}
+		if !bytes.Equal(current, last) {
+			switch {
+			case output == describeClusterOutputText:
+				fmt.Fprint(out, clearScreen)
+			case output == describeClusterOutputYaml && last != nil:
+				fmt.Fprintln(out, "---")
+			}
+			if err := printDescribeClusterOutput(out, objectTree, output); err != nil {
+				return err
+			}
+			last = current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// printDescribeClusterOutput prints the cluster status in the given output format.
+func printDescribeClusterOutput(out io.Writer, objectTree *tree.ObjectTree, output string) error {
+	switch output {
+	case describeClusterOutputJSON:
+		b, err := json.MarshalIndent(objectTree.ToOutput(), "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the cluster status to json")
+		}
+		fmt.Fprintln(out, string(b))
+	case describeClusterOutputYaml:
+		b, err := yaml.Marshal(objectTree.ToOutput())
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the cluster status to yaml")
+		}
+		fmt.Fprint(out, string(b))
+	default:
+		printObjectTree(out, objectTree)
+	}
 	return nil
 }
 
-// printObjectTree prints the cluster status to stdout.
-func printObjectTree(tree *tree.ObjectTree) {
+// printObjectTree prints the cluster status to out.
+func printObjectTree(out io.Writer, tree *tree.ObjectTree) {
 	// Creates the output table
-	tbl := tablewriter.NewWriter(os.Stdout)
+	tbl := tablewriter.NewWriter(out)
 	tbl.SetHeader([]string{"NAME", "READY", "SEVERITY", "REASON", "SINCE", "MESSAGE"})
 
 	formatTableTree(tbl)
@@ -18,9 +18,12 @@ package cmd
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
@@ -29,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
@@ -294,6 +298,85 @@ func Test_TreePrefix(t *testing.T) {
 	}
 }
 
+func Test_printDescribeClusterOutput(t *testing.T) {
+	objectTree := func() *tree.ObjectTree {
+		root := fakeObject("root", withCondition(conditions.TrueCondition(clusterv1.ReadyCondition)))
+		objectTree := tree.NewObjectTree(root, tree.ObjectTreeOptions{Grouping: true})
+		objectTree.Add(root, fakeObject("child1", withCondition(conditions.FalseCondition("C1.1", "Reason", clusterv1.ConditionSeverityError, "message"))))
+		return objectTree
+	}
+
+	tests := []struct {
+		name      string
+		output    string
+		unmarshal func([]byte, interface{}) error
+	}{
+		{
+			name:      "json",
+			output:    describeClusterOutputJSON,
+			unmarshal: json.Unmarshal,
+		},
+		{
+			name:   "yaml",
+			output: describeClusterOutputYaml,
+			unmarshal: func(b []byte, v interface{}) error {
+				return yaml.Unmarshal(b, v)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var output bytes.Buffer
+
+			g.Expect(printDescribeClusterOutput(&output, objectTree(), tt.output)).To(Succeed())
+
+			got := &tree.ObjectTreeOutput{}
+			g.Expect(tt.unmarshal(output.Bytes(), got)).To(Succeed())
+			g.Expect(got.Options.Grouping).To(BeTrue())
+			g.Expect(got.Root.Name).To(Equal("root"))
+			g.Expect(got.Root.Ready.Status).To(BeEquivalentTo("True"))
+			g.Expect(got.Root.Children).To(HaveLen(1))
+			g.Expect(got.Root.Children[0].Name).To(Equal("child1"))
+			g.Expect(got.Root.Children[0].Conditions).To(HaveLen(1))
+			g.Expect(got.Root.Children[0].Conditions[0].Reason).To(Equal("Reason"))
+		})
+	}
+}
+
+func Test_watchDescribeCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The cluster status changes only in the third call, then the watch is stopped.
+	calls := 0
+	describe := func() (*tree.ObjectTree, error) {
+		calls++
+		name := "child1"
+		if calls >= 3 {
+			name = "child2"
+		}
+		if calls == 4 {
+			cancel()
+		}
+		root := fakeObject("root")
+		objectTree := tree.NewObjectTree(root, tree.ObjectTreeOptions{})
+		objectTree.Add(root, fakeObject(name))
+		return objectTree, nil
+	}
+
+	var output bytes.Buffer
+	g.Expect(watchDescribeCluster(ctx, &output, describe, describeClusterOutputYaml, time.Millisecond)).To(Succeed())
+	g.Expect(calls).To(BeNumerically(">=", 4))
+
+	documents := strings.Split(output.String(), "---\n")
+	g.Expect(documents).To(HaveLen(2))
+	g.Expect(documents[0]).To(ContainSubstring("name: child1"))
+	g.Expect(documents[1]).To(ContainSubstring("name: child2"))
+}
+
 type objectOption func(object ctrlclient.Object)
 
 func fakeObject(name string, options ...objectOption) ctrlclient.Object {
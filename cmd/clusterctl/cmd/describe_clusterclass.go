@@ -0,0 +1,156 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+)
+
+type describeClusterClassOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	grouping          bool
+	output            string
+}
+
+var dcc = &describeClusterClassOptions{}
+
+var describeClusterClassCmd = &cobra.Command{
+	Use:   "clusterclass [name]",
+	Short: "Describe ClusterClasses.",
+	Long: LongDesc(`
+		Provide an "at glance" view of the Clusters using a ClusterClass, designed to help the user in quickly
+		understanding which Clusters are affected by changes to the ClusterClass and if they are in sync with it.
+		.`),
+
+	Example: Examples(`
+		# Describe all the ClusterClasses in the current namespace.
+		clusterctl describe clusterclass
+
+		# Describe the ClusterClass named quick-start.
+		clusterctl describe clusterclass quick-start
+
+		# Describe the ClusterClass named quick-start disabling automatic grouping of Clusters with the same ready condition.
+		clusterctl describe clusterclass quick-start --grouping=false
+
+		# Describe the ClusterClass named quick-start in json format.
+		clusterctl describe clusterclass quick-start -o json`),
+
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		return runDescribeClusterClass(os.Stdout, name)
+	},
+}
+
+func init() {
+	describeClusterClassCmd.Flags().StringVar(&dcc.kubeconfig, "kubeconfig", "",
+		"Path to a kubeconfig file to use for the management cluster. If empty, default discovery rules apply.")
+	describeClusterClassCmd.Flags().StringVar(&dcc.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	describeClusterClassCmd.Flags().StringVarP(&dcc.namespace, "namespace", "n", "",
+		"The namespace where the ClusterClasses are located. If unspecified, the current namespace will be used.")
+
+	describeClusterClassCmd.Flags().BoolVar(&dcc.grouping, "grouping", true,
+		"Groups Clusters when ready condition has the same Status, Severity and Reason.")
+	describeClusterClassCmd.Flags().StringVarP(&dcc.output, "output", "o", describeClusterOutputText,
+		fmt.Sprintf("Output format. Valid values: %v.", describeClusterOutputs))
+
+	// completions
+	describeClusterClassCmd.ValidArgsFunction = resourceNameCompletionFunc(
+		describeClusterClassCmd.Flags().Lookup("kubeconfig"),
+		describeClusterClassCmd.Flags().Lookup("kubeconfig-context"),
+		describeClusterClassCmd.Flags().Lookup("namespace"),
+		clusterv1.GroupVersion.String(),
+		"clusterclass",
+	)
+
+	describeCmd.AddCommand(describeClusterClassCmd)
+}
+
+func runDescribeClusterClass(out io.Writer, name string) error {
+	if !isValidDescribeClusterOutput(dcc.output) {
+		return errors.Errorf("invalid output format %q, valid values: %v", dcc.output, describeClusterOutputs)
+	}
+
+	c, err := client.New(cfgFile)
+	if err != nil {
+		return err
+	}
+
+	trees, err := c.DescribeClusterClass(client.DescribeClusterClassOptions{
+		Kubeconfig:       client.Kubeconfig{Path: dcc.kubeconfig, Context: dcc.kubeconfigContext},
+		Namespace:        dcc.namespace,
+		ClusterClassName: name,
+		Grouping:         dcc.grouping,
+	})
+	if err != nil {
+		return err
+	}
+	return printDescribeClusterClassOutput(out, trees, dcc.output)
+}
+
+// printDescribeClusterClassOutput prints the ClusterClasses and the Clusters using them in the given output format;
+// in json and yaml format, the object trees are printed as a list.
+func printDescribeClusterClassOutput(out io.Writer, objectTrees []*tree.ObjectTree, output string) error {
+	outputs := make([]*tree.ObjectTreeOutput, 0, len(objectTrees))
+	for _, objectTree := range objectTrees {
+		outputs = append(outputs, objectTree.ToOutput())
+	}
+
+	switch output {
+	case describeClusterOutputJSON:
+		b, err := json.MarshalIndent(outputs, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the ClusterClasses status to json")
+		}
+		fmt.Fprintln(out, string(b))
+	case describeClusterOutputYaml:
+		b, err := yaml.Marshal(outputs)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal the ClusterClasses status to yaml")
+		}
+		fmt.Fprint(out, string(b))
+	default:
+		if len(objectTrees) == 0 {
+			fmt.Fprintln(out, "No ClusterClasses found.")
+			return nil
+		}
+		for i, objectTree := range objectTrees {
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			printObjectTree(out, objectTree)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func Test_printDescribeClusterClassOutput(t *testing.T) {
+	objectTree := func(name string) *tree.ObjectTree {
+		root := fakeObject(name, withCondition(conditions.TrueCondition(clusterv1.ReadyCondition)))
+		objectTree := tree.NewObjectTree(root, tree.ObjectTreeOptions{})
+		objectTree.Add(root, fakeObject(name+"-cluster", withCondition(conditions.TrueCondition(clusterv1.ReadyCondition))))
+		return objectTree
+	}
+	objectTrees := []*tree.ObjectTree{objectTree("class1"), objectTree("class2")}
+
+	t.Run("json", func(t *testing.T) {
+		g := NewWithT(t)
+		var output bytes.Buffer
+
+		g.Expect(printDescribeClusterClassOutput(&output, objectTrees, describeClusterOutputJSON)).To(Succeed())
+
+		got := []tree.ObjectTreeOutput{}
+		g.Expect(json.Unmarshal(output.Bytes(), &got)).To(Succeed())
+		g.Expect(got).To(HaveLen(2))
+		g.Expect(got[0].Root.Name).To(Equal("class1"))
+		g.Expect(got[0].Root.Children).To(HaveLen(1))
+		g.Expect(got[0].Root.Children[0].Name).To(Equal("class1-cluster"))
+		g.Expect(got[1].Root.Name).To(Equal("class2"))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		g := NewWithT(t)
+		var output bytes.Buffer
+
+		g.Expect(printDescribeClusterClassOutput(&output, objectTrees, describeClusterOutputText)).To(Succeed())
+		g.Expect(output.String()).To(ContainSubstring("Object/class1"))
+		g.Expect(output.String()).To(ContainSubstring("└─Object/class2-cluster"))
+	})
+
+	t.Run("text without ClusterClasses", func(t *testing.T) {
+		g := NewWithT(t)
+		var output bytes.Buffer
+
+		g.Expect(printDescribeClusterClassOutput(&output, nil, describeClusterOutputText)).To(Succeed())
+		g.Expect(output.String()).To(Equal("No ClusterClasses found.\n"))
+	})
+}
@@ -25,13 +25,16 @@ import (
 )
 
 type generateProvidersOptions struct {
-	coreProvider           string
-	bootstrapProvider      string
-	controlPlaneProvider   string
-	infrastructureProvider string
-	targetNamespace        string
-	textOutput             bool
-	raw                    bool
+	coreProvider             string
+	bootstrapProvider        string
+	controlPlaneProvider     string
+	infrastructureProvider   string
+	ipamProvider             string
+	runtimeExtensionProvider string
+	addonProvider            string
+	targetNamespace          string
+	textOutput               bool
+	raw                      bool
 }
 
 var gpo = &generateProvidersOptions{}
@@ -82,6 +85,12 @@ func init() {
 		"Bootstrap provider and version (e.g. kubeadm:v0.3.0)")
 	generateProviderCmd.Flags().StringVarP(&gpo.controlPlaneProvider, "control-plane", "c", "",
 		"ControlPlane provider and version (e.g. kubeadm:v0.3.0)")
+	generateProviderCmd.Flags().StringVar(&gpo.ipamProvider, "ipam", "",
+		"IPAM provider and version (e.g. infoblox:v0.0.1)")
+	generateProviderCmd.Flags().StringVar(&gpo.runtimeExtensionProvider, "runtime-extension", "",
+		"Runtime extension provider and version (e.g. test:v0.0.1)")
+	generateProviderCmd.Flags().StringVar(&gpo.addonProvider, "addon", "",
+		"Add-on provider and version (e.g. helm:v0.1.0)")
 	generateProviderCmd.Flags().StringVarP(&gpo.targetNamespace, "target-namespace", "n", "",
 		"The target namespace where the provider should be deployed. If unspecified, the components default namespace is used.")
 	generateProviderCmd.Flags().BoolVar(&gpo.textOutput, "describe", false,
@@ -121,31 +130,32 @@ func runGenerateProviderComponents() error {
 
 // parseProvider parses command line flags and returns the provider name and type.
 func parseProvider() (string, clusterctlv1.ProviderType, error) {
+	const providerFlags = "--core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon"
+
 	providerName := gpo.coreProvider
 	providerType := clusterctlv1.CoreProviderType
-	if gpo.bootstrapProvider != "" {
-		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure should be set")
-		}
-		providerName = gpo.bootstrapProvider
-		providerType = clusterctlv1.BootstrapProviderType
-	}
-	if gpo.controlPlaneProvider != "" {
-		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure should be set")
+	for _, p := range []struct {
+		name         string
+		providerType clusterctlv1.ProviderType
+	}{
+		{name: gpo.bootstrapProvider, providerType: clusterctlv1.BootstrapProviderType},
+		{name: gpo.controlPlaneProvider, providerType: clusterctlv1.ControlPlaneProviderType},
+		{name: gpo.infrastructureProvider, providerType: clusterctlv1.InfrastructureProviderType},
+		{name: gpo.ipamProvider, providerType: clusterctlv1.IPAMProviderType},
+		{name: gpo.runtimeExtensionProvider, providerType: clusterctlv1.RuntimeExtensionProviderType},
+		{name: gpo.addonProvider, providerType: clusterctlv1.AddonProviderType},
+	} {
+		if p.name == "" {
+			continue
 		}
-		providerName = gpo.controlPlaneProvider
-		providerType = clusterctlv1.ControlPlaneProviderType
-	}
-	if gpo.infrastructureProvider != "" {
 		if providerName != "" {
-			return "", "", errors.New("only one of --core, --bootstrap, --control-plane, --infrastructure should be set")
+			return "", "", errors.Errorf("only one of %s should be set", providerFlags)
 		}
-		providerName = gpo.infrastructureProvider
-		providerType = clusterctlv1.InfrastructureProviderType
+		providerName = p.name
+		providerType = p.providerType
 	}
 	if providerName == "" {
-		return "", "", errors.New("at least one of --core, --bootstrap, --control-plane, --infrastructure should be set")
+		return "", "", errors.Errorf("at least one of %s should be set", providerFlags)
 	}
 
 	return providerName, providerType, nil
@@ -26,16 +26,19 @@ import (
 )
 
 type initOptions struct {
-	kubeconfig              string
-	kubeconfigContext       string
-	coreProvider            string
-	bootstrapProviders      []string
-	controlPlaneProviders   []string
-	infrastructureProviders []string
-	targetNamespace         string
-	listImages              bool
-	waitProviders           bool
-	waitProviderTimeout     int
+	kubeconfig                string
+	kubeconfigContext         string
+	coreProvider              string
+	bootstrapProviders        []string
+	controlPlaneProviders     []string
+	infrastructureProviders   []string
+	ipamProviders             []string
+	runtimeExtensionProviders []string
+	addonProviders            []string
+	targetNamespace           string
+	listImages                bool
+	waitProviders             bool
+	waitProviderTimeout       int
 }
 
 var initOpts = &initOptions{}
@@ -76,6 +79,9 @@ var initCmd = &cobra.Command{
 		# Initialize a management cluster with multiple infrastructure providers.
 		clusterctl init --infrastructure=aws,vsphere
 
+		# Initialize a management cluster with an IPAM provider and an addon provider.
+		clusterctl init --infrastructure=vsphere --ipam=in-cluster --addon=helm
+
 		# Initialize a management cluster with a custom target namespace for the provider resources.
 		clusterctl init --infrastructure aws --target-namespace foo
 
@@ -102,6 +108,12 @@ func init() {
 		"Bootstrap providers and versions (e.g. kubeadm:v0.3.0) to add to the management cluster. If unspecified, Kubeadm bootstrap provider's latest release is used.")
 	initCmd.Flags().StringSliceVarP(&initOpts.controlPlaneProviders, "control-plane", "c", nil,
 		"Control plane providers and versions (e.g. kubeadm:v0.3.0) to add to the management cluster. If unspecified, the Kubeadm control plane provider's latest release is used.")
+	initCmd.Flags().StringSliceVar(&initOpts.ipamProviders, "ipam", nil,
+		"IPAM providers and versions (e.g. infoblox:v0.0.1) to add to the management cluster.")
+	initCmd.Flags().StringSliceVar(&initOpts.runtimeExtensionProviders, "runtime-extension", nil,
+		"Runtime extension providers and versions (e.g. test:v0.0.1) to add to the management cluster.")
+	initCmd.Flags().StringSliceVar(&initOpts.addonProviders, "addon", nil,
+		"Add-on providers and versions (e.g. helm:v0.1.0) to add to the management cluster.")
 	initCmd.Flags().StringVarP(&initOpts.targetNamespace, "target-namespace", "n", "",
 		"The target namespace where the providers should be deployed. If unspecified, the provider components' default namespace is used.")
 	initCmd.Flags().BoolVar(&initOpts.waitProviders, "wait-providers", false,
@@ -123,15 +135,18 @@ func runInit() error {
 	}
 
 	options := client.InitOptions{
-		Kubeconfig:              client.Kubeconfig{Path: initOpts.kubeconfig, Context: initOpts.kubeconfigContext},
-		CoreProvider:            initOpts.coreProvider,
-		BootstrapProviders:      initOpts.bootstrapProviders,
-		ControlPlaneProviders:   initOpts.controlPlaneProviders,
-		InfrastructureProviders: initOpts.infrastructureProviders,
-		TargetNamespace:         initOpts.targetNamespace,
-		LogUsageInstructions:    true,
-		WaitProviders:           initOpts.waitProviders,
-		WaitProviderTimeout:     time.Duration(initOpts.waitProviderTimeout) * time.Second,
+		Kubeconfig:                client.Kubeconfig{Path: initOpts.kubeconfig, Context: initOpts.kubeconfigContext},
+		CoreProvider:              initOpts.coreProvider,
+		BootstrapProviders:        initOpts.bootstrapProviders,
+		ControlPlaneProviders:     initOpts.controlPlaneProviders,
+		InfrastructureProviders:   initOpts.infrastructureProviders,
+		IPAMProviders:             initOpts.ipamProviders,
+		RuntimeExtensionProviders: initOpts.runtimeExtensionProviders,
+		AddonProviders:            initOpts.addonProviders,
+		TargetNamespace:           initOpts.targetNamespace,
+		LogUsageInstructions:      true,
+		WaitProviders:             initOpts.waitProviders,
+		WaitProviderTimeout:       time.Duration(initOpts.waitProviderTimeout) * time.Second,
 	}
 
 	if initOpts.listImages {
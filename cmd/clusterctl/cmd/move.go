@@ -29,7 +29,12 @@ type moveOptions struct {
 	toKubeconfig          string
 	toKubeconfigContext   string
 	namespace             string
+	clusterSelector       string
+	clusters              []string
 	dryRun                bool
+	checkpointFile        string
+	resume                bool
+	rollback              bool
 }
 
 var mo = &moveOptions{}
@@ -40,11 +45,26 @@ var moveCmd = &cobra.Command{
 	Long: LongDesc(`
 		Move Cluster API objects and all dependencies between management clusters.
 
-		Note: The destination cluster MUST have the required provider components installed.`),
+		Note: The destination cluster MUST have the required provider components installed.
+
+		The progress of the move is recorded in a checkpoint file; in case of failures, the move can be
+		completed using the --resume flag, or reverted using the --rollback flag.`),
 
 	Example: Examples(`
 		Move Cluster API objects and all dependencies between management clusters.
-		clusterctl move --to-kubeconfig=target-kubeconfig.yaml`),
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml
+
+		Move only the Clusters with the tenant=foo label, from all the namespaces, and the objects they depend on.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster-selector tenant=foo
+
+		Move only the Clusters cluster1 in namespace ns1 and cluster2 in namespace ns2, and the objects they depend on.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --cluster ns1/cluster1 --cluster ns2/cluster2
+
+		Complete a failed move.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --resume
+
+		Revert a failed move, deleting the objects created in the target management cluster and resuming the source Clusters.
+		clusterctl move --to-kubeconfig=target-kubeconfig.yaml --rollback`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runMove()
@@ -61,9 +81,19 @@ func init() {
 	moveCmd.Flags().StringVar(&mo.toKubeconfigContext, "to-kubeconfig-context", "",
 		"Context to be used within the kubeconfig file for the destination management cluster. If empty, current context will be used.")
 	moveCmd.Flags().StringVarP(&mo.namespace, "namespace", "n", "",
-		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used.")
+		"The namespace where the workload cluster is hosted. If unspecified, the current context's namespace is used, unless --cluster-selector or --cluster are set.")
+	moveCmd.Flags().StringVar(&mo.clusterSelector, "cluster-selector", "",
+		"Label selector for the Clusters to be moved together with the objects they depend on. If --namespace is unspecified, Clusters from all the namespaces are considered.")
+	moveCmd.Flags().StringSliceVar(&mo.clusters, "cluster", nil,
+		"Cluster to be moved together with the objects it depends on, in the form namespace/name or name. Can be repeated.")
 	moveCmd.Flags().BoolVar(&mo.dryRun, "dry-run", false,
 		"Enable dry run, don't really perform the move actions")
+	moveCmd.Flags().StringVar(&mo.checkpointFile, "checkpoint-file", "",
+		"Path to the file where the progress of the move is recorded. If unspecified, $HOME/.cluster-api/move-checkpoint.json is used.")
+	moveCmd.Flags().BoolVar(&mo.resume, "resume", false,
+		"Complete a failed move using the progress recorded in the checkpoint file.")
+	moveCmd.Flags().BoolVar(&mo.rollback, "rollback", false,
+		"Revert a failed move using the progress recorded in the checkpoint file, deleting the objects created in the destination management cluster and resuming the source Clusters.")
 
 	RootCmd.AddCommand(moveCmd)
 }
@@ -80,9 +110,14 @@ func runMove() error {
 	}
 
 	return c.Move(client.MoveOptions{
-		FromKubeconfig: client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
-		ToKubeconfig:   client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
-		Namespace:      mo.namespace,
-		DryRun:         mo.dryRun,
+		FromKubeconfig:  client.Kubeconfig{Path: mo.fromKubeconfig, Context: mo.fromKubeconfigContext},
+		ToKubeconfig:    client.Kubeconfig{Path: mo.toKubeconfig, Context: mo.toKubeconfigContext},
+		Namespace:       mo.namespace,
+		ClusterSelector: mo.clusterSelector,
+		Clusters:        mo.clusters,
+		DryRun:          mo.dryRun,
+		CheckpointFile:  mo.checkpointFile,
+		Resume:          mo.resume,
+		Rollback:        mo.rollback,
 	})
 }
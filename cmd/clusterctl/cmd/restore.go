@@ -27,6 +27,9 @@ type restoreOptions struct {
 	toKubeconfig        string
 	toKubeconfigContext string
 	directory           string
+	file                string
+	identityFile        string
+	passphraseFile      string
 }
 
 var ro = &restoreOptions{}
@@ -39,7 +42,10 @@ var restoreCmd = &cobra.Command{
 		or in the provided directory.`),
 	Example: Examples(`
 		Restore Cluster API objects from file by glob. Object files are searched in config directory.
-		clusterctl restore my-cluster`),
+		clusterctl restore my-cluster
+
+		Restore Cluster API objects from an archive encrypted for an age public key.
+		clusterctl restore --file=/tmp/backup.tar.gz.age --identity-file=/path/to/key.txt`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runRestore()
@@ -53,13 +59,19 @@ func init() {
 		"Context to be used within the kubeconfig file for the target management cluster. If empty, current context will be used.")
 	restoreCmd.Flags().StringVar(&ro.directory, "directory", "",
 		"The directory to target when restoring Cluster API object yaml files")
+	restoreCmd.Flags().StringVar(&ro.file, "file", "",
+		"The archive file to restore Cluster API objects from")
+	restoreCmd.Flags().StringVar(&ro.identityFile, "identity-file", "",
+		"Path to a file containing the age private keys to decrypt the archive file with")
+	restoreCmd.Flags().StringVar(&ro.passphraseFile, "passphrase-file", "",
+		"Path to a file containing the passphrase to decrypt the archive file with")
 
 	RootCmd.AddCommand(restoreCmd)
 }
 
 func runRestore() error {
-	if ro.directory == "" {
-		return errors.New("please specify a directory to restore cluster API objects from using the --directory flag")
+	if ro.directory == "" && ro.file == "" {
+		return errors.New("please specify a directory or an archive file to restore cluster API objects from using the --directory or the --file flag")
 	}
 
 	c, err := client.New(cfgFile)
@@ -68,7 +80,10 @@ func runRestore() error {
 	}
 
 	return c.Restore(client.RestoreOptions{
-		ToKubeconfig: client.Kubeconfig{Path: ro.toKubeconfig, Context: ro.toKubeconfigContext},
-		Directory:    ro.directory,
+		ToKubeconfig:   client.Kubeconfig{Path: ro.toKubeconfig, Context: ro.toKubeconfigContext},
+		Directory:      ro.directory,
+		File:           ro.file,
+		IdentityFile:   ro.identityFile,
+		PassphraseFile: ro.passphraseFile,
 	})
 }
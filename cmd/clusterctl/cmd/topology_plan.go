@@ -17,16 +17,21 @@ limitations under the License.
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
 	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/kubectl/pkg/cmd/util/editor"
 	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
@@ -41,6 +46,8 @@ type topologyPlanOptions struct {
 	cluster           string
 	namespace         string
 	outDir            string
+	editClusterClass  string
+	report            string
 }
 
 var tp = &topologyPlanOptions{}
@@ -51,6 +58,12 @@ var topologyPlanCmd = &cobra.Command{
 	Long: LongDesc(`
 		Provide the list of objects that would be created, modified and deleted when an input file is applied.
 		The input can be a file with a new/modified cluster, new/modified ClusterClass, new/modified templates.
+		As an alternative, a ClusterClass in the management cluster can be edited in place using --edit-clusterclass.
+
+		The changes are computed for each of the affected clusters, or only for the cluster passed using --cluster;
+		for each cluster, the command prints a diff of the objects that will be created, modified and deleted and
+		the number of machines that will be rolled out. A machine-readable report of the changes can be written to
+		the path passed using --report, e.g. for gating changes in CI.
 		Details about the objects that will be created and modified will be stored in a path passed using --output-directory.
 
 		This command can also be run without a real cluster. In such cases, the input should contain all the objects needed.
@@ -76,6 +89,13 @@ var topologyPlanCmd = &cobra.Command{
 
 		# List the clusters and ClusterClasses impacted by a template change.
 		clusterctl alpha topology plan -f modified-template.yaml -o output/
+
+		# Edit the ClusterClass "quick-start" in the management cluster, and list the changes to the clusters using it,
+		# without applying the edited ClusterClass.
+		clusterctl alpha topology plan --edit-clusterclass quick-start
+
+		# List the changes when a ClusterClass is changed, and write a report with the changes and the machines rolled out.
+		clusterctl alpha topology plan -f modified-cluster-class.yaml --report report.json
 	`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -84,27 +104,26 @@ var topologyPlanCmd = &cobra.Command{
 }
 
 func init() {
-	topologyPlanCmd.Flags().StringVar(&initOpts.kubeconfig, "kubeconfig", "",
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfig, "kubeconfig", "",
 		"Path to the kubeconfig for the management cluster. If unspecified, default discovery rules apply.")
-	topologyPlanCmd.Flags().StringVar(&initOpts.kubeconfigContext, "kubeconfig-context", "",
+	topologyPlanCmd.Flags().StringVar(&tp.kubeconfigContext, "kubeconfig-context", "",
 		"Context to be used within the kubeconfig file. If empty, current context will be used.")
 
 	topologyPlanCmd.Flags().StringArrayVarP(&tp.files, "file", "f", nil, "path to the file with new or modified resources to be applied; the file should not contain more than one Cluster or more than one ClusterClass")
-	topologyPlanCmd.Flags().StringVarP(&tp.cluster, "cluster", "c", "", "name of the target cluster; this parameter is required when more than one cluster is affected")
+	topologyPlanCmd.Flags().StringVarP(&tp.cluster, "cluster", "c", "", "name of the target cluster; if specified, changes are computed only for this cluster")
 	topologyPlanCmd.Flags().StringVarP(&tp.namespace, "namespace", "n", "", "target namespace for the operation. If specified, it is used as default namespace for objects with missing namespace")
-	topologyPlanCmd.Flags().StringVarP(&tp.outDir, "output-directory", "o", "", "output directory to write details about created/modified objects")
-
-	if err := topologyPlanCmd.MarkFlagRequired("file"); err != nil {
-		panic(err)
-	}
-	if err := topologyPlanCmd.MarkFlagRequired("output-directory"); err != nil {
-		panic(err)
-	}
+	topologyPlanCmd.Flags().StringVarP(&tp.outDir, "output-directory", "o", "", "output directory to write details about created/modified objects of the target cluster")
+	topologyPlanCmd.Flags().StringVar(&tp.editClusterClass, "edit-clusterclass", "", "name of a ClusterClass in the management cluster to edit in place with the editor defined by the KUBE_EDITOR or EDITOR environment variables; the edited ClusterClass is not applied")
+	topologyPlanCmd.Flags().StringVar(&tp.report, "report", "", "path to the file to write a json report of the changes and of the machines rolled out for each cluster")
 
 	topologyCmd.AddCommand(topologyPlanCmd)
 }
 
 func runTopologyPlan() error {
+	if len(tp.files) == 0 && tp.editClusterClass == "" {
+		return errors.New("at least one of --file or --edit-clusterclass must be specified")
+	}
+
 	c, err := client.New(cfgFile)
 	if err != nil {
 		return err
@@ -124,33 +143,72 @@ func runTopologyPlan() error {
 	}
 
 	out, err := c.TopologyPlan(client.TopologyPlanOptions{
-		Kubeconfig: client.Kubeconfig{Path: tp.kubeconfig, Context: tp.kubeconfigContext},
-		Objs:       convertToPtrSlice(objs),
-		Cluster:    tp.cluster,
-		Namespace:  tp.namespace,
+		Kubeconfig:       client.Kubeconfig{Path: tp.kubeconfig, Context: tp.kubeconfigContext},
+		Objs:             convertToPtrSlice(objs),
+		Cluster:          tp.cluster,
+		Namespace:        tp.namespace,
+		EditClusterClass: tp.editClusterClass,
+		Editor:           editInEditor,
 	})
 	if err != nil {
 		return err
 	}
-	return printTopologyPlanOutput(out, tp.outDir)
+	return printTopologyPlanOutput(out, tp.outDir, tp.report)
 }
 
-func printTopologyPlanOutput(out *cluster.TopologyPlanOutput, outdir string) error {
+// editInEditor opens the original content in the editor defined by the KUBE_EDITOR or EDITOR environment variables,
+// and returns the edited content.
+func editInEditor(original []byte) ([]byte, error) {
+	e := editor.NewDefaultEditor([]string{"KUBE_EDITOR", "EDITOR"})
+	edited, file, err := e.LaunchTempFile("clusterctl-edit-", ".yaml", bytes.NewReader(original))
+	if file != "" {
+		defer os.Remove(file)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to launch the editor")
+	}
+	return edited, nil
+}
+
+func printTopologyPlanOutput(out *cluster.TopologyPlanOutput, outdir, report string) error {
 	printAffectedClusterClasses(out)
 	printAffectedClusters(out)
 	if len(out.Clusters) == 0 {
 		// No affected clusters. Return early.
 		return nil
 	}
+	failedClusters := []string{}
+	for _, changes := range out.ClusterChanges {
+		if changes.Error != nil {
+			fmt.Printf("Failed to compute the changes for Cluster %q: %v\n\n", changes.Cluster.String(), changes.Error)
+			failedClusters = append(failedClusters, changes.Cluster.String())
+			continue
+		}
+		printChangeSummary(changes.Cluster, changes.ChangeSummary)
+		if err := printChangeDiffs(changes.ChangeSummary); err != nil {
+			return err
+		}
+	}
+	printRollouts(out)
 	if out.ReconciledCluster == nil {
-		fmt.Printf("No target cluster identified. Use --cluster to specify a target cluster to get detailed changes.")
-	} else {
-		printChangeSummary(out)
+		if outdir != "" {
+			fmt.Printf("No target cluster identified. Use --cluster to specify a target cluster to write details about created/modified objects.\n")
+		}
+	} else if outdir != "" {
 		if err := writeOutputFiles(out, outdir); err != nil {
 			return errors.Wrap(err, "failed to write output files of target cluster changes")
 		}
 	}
+	if report != "" {
+		if err := writeTopologyPlanReport(out, report); err != nil {
+			return errors.Wrap(err, "failed to write the report")
+		}
+		fmt.Printf("Report written to %q\n", report)
+	}
 	fmt.Printf("\n")
+	if len(failedClusters) > 0 {
+		return errors.Errorf("failed to compute the changes for Clusters %s", strings.Join(failedClusters, ", "))
+	}
 	return nil
 }
 
@@ -180,13 +238,13 @@ func printAffectedClusters(out *cluster.TopologyPlanOutput) {
 	fmt.Printf("\n")
 }
 
-func printChangeSummary(out *cluster.TopologyPlanOutput) {
+func printChangeSummary(clusterKey crclient.ObjectKey, out *cluster.ChangeSummary) {
 	if len(out.Created) == 0 && len(out.Modified) == 0 && len(out.Deleted) == 0 {
-		fmt.Printf("No changes detected for Cluster %q.\n", fmt.Sprintf("%s/%s", out.ReconciledCluster.Namespace, out.ReconciledCluster.Name))
+		fmt.Printf("No changes detected for Cluster %q.\n\n", clusterKey.String())
 		return
 	}
 
-	fmt.Printf("Changes for Cluster %q: \n", fmt.Sprintf("%s/%s", out.ReconciledCluster.Namespace, out.ReconciledCluster.Name))
+	fmt.Printf("Changes for Cluster %q: \n", clusterKey.String())
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"Namespace", "Kind", "Name", "Action"})
 	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
@@ -218,6 +276,208 @@ func printChangeSummary(out *cluster.TopologyPlanOutput) {
 	fmt.Printf("\n")
 }
 
+// printChangeDiffs prints a colored unified diff for each of the objects created, modified and deleted.
+func printChangeDiffs(out *cluster.ChangeSummary) error {
+	printDiff := func(before, after *unstructured.Unstructured) error {
+		diff, err := objectDiff(before, after)
+		if err != nil {
+			return err
+		}
+		for _, line := range strings.SplitAfter(diff, "\n") {
+			switch {
+			case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+				fmt.Print(line)
+			case strings.HasPrefix(line, "+"):
+				fmt.Print(green.Sprint(line))
+			case strings.HasPrefix(line, "-"):
+				fmt.Print(red.Sprint(line))
+			case strings.HasPrefix(line, "@@"):
+				fmt.Print(cyan.Sprint(line))
+			default:
+				fmt.Print(line)
+			}
+		}
+		fmt.Printf("\n")
+		return nil
+	}
+
+	for _, c := range out.Created {
+		if err := printDiff(nil, c); err != nil {
+			return err
+		}
+	}
+	for _, m := range out.Modified {
+		if err := printDiff(m.Before, m.After); err != nil {
+			return err
+		}
+	}
+	for _, d := range out.Deleted {
+		if err := printDiff(d, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectDiff returns the unified diff between the yaml of the initial and of the final state of an object;
+// the initial state is nil for created objects, while the final state is nil for deleted objects.
+// NOTE: Fields which are changed by every write operation, e.g. the resourceVersion, are not included in the diff.
+func objectDiff(before, after *unstructured.Unstructured) (string, error) {
+	toYAMLLines := func(obj *unstructured.Unstructured) ([]string, error) {
+		if obj == nil {
+			return nil, nil
+		}
+		obj = obj.DeepCopy()
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+		yaml, err := utilyaml.FromUnstructured([]unstructured.Unstructured{*obj})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s %s/%s to yaml", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+		return difflib.SplitLines(string(yaml)), nil
+	}
+
+	a, err := toYAMLLines(before)
+	if err != nil {
+		return "", err
+	}
+	b, err := toYAMLLines(after)
+	if err != nil {
+		return "", err
+	}
+
+	objectPath := func(obj *unstructured.Unstructured) string {
+		return fmt.Sprintf("%s/%s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+	fromFile, toFile := "/dev/null", "/dev/null"
+	if before != nil {
+		fromFile = "a/" + objectPath(before)
+	}
+	if after != nil {
+		toFile = "b/" + objectPath(after)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        a,
+		B:        b,
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+// printRollouts prints the clusters which are going to roll out machines, and how many.
+func printRollouts(out *cluster.TopologyPlanOutput) {
+	var clusters []*cluster.ClusterChanges
+	for _, changes := range out.ClusterChanges {
+		if len(changes.Rollouts) > 0 {
+			clusters = append(clusters, changes)
+		}
+	}
+	if len(clusters) == 0 {
+		fmt.Printf("No Clusters will roll out machines.\n")
+		return
+	}
+
+	fmt.Printf("The following Clusters will roll out machines:\n")
+	for _, changes := range clusters {
+		rollouts := []string{}
+		for _, r := range changes.Rollouts {
+			rollouts = append(rollouts, fmt.Sprintf("%s/%s: %d", r.Kind, r.Name, r.Replicas))
+		}
+		fmt.Printf(" ＊ %s: %d machine(s) (%s)\n", changes.Cluster.String(), rolloutMachines(changes.Rollouts), strings.Join(rollouts, ", "))
+	}
+	fmt.Printf("\n")
+}
+
+func rolloutMachines(rollouts []cluster.MachineRollout) int64 {
+	var machines int64
+	for _, r := range rollouts {
+		machines += r.Replicas
+	}
+	return machines
+}
+
+// topologyPlanReport is a machine-readable report of the changes, e.g. for gating changes in CI.
+type topologyPlanReport struct {
+	// ClusterClasses is the list of affected ClusterClasses.
+	ClusterClasses []string `json:"clusterClasses"`
+	// Clusters is the list of affected Clusters.
+	Clusters []string `json:"clusters"`
+	// Changes is the list of changes for each of the reconciled Clusters.
+	Changes []topologyPlanClusterReport `json:"changes"`
+	// RolloutMachines is the total number of machines rolled out across all the reconciled Clusters.
+	RolloutMachines int64 `json:"rolloutMachines"`
+}
+
+// topologyPlanClusterReport reports the changes for a Cluster.
+type topologyPlanClusterReport struct {
+	Cluster         string                   `json:"cluster"`
+	Created         []string                 `json:"created"`
+	Modified        []string                 `json:"modified"`
+	Deleted         []string                 `json:"deleted"`
+	Rollouts        []cluster.MachineRollout `json:"rollouts"`
+	RolloutMachines int64                    `json:"rolloutMachines"`
+	// Error is the error which prevented computing the changes for the Cluster, if any.
+	Error string `json:"error,omitempty"`
+}
+
+func newTopologyPlanReport(out *cluster.TopologyPlanOutput) *topologyPlanReport {
+	objectKeys := func(keys []crclient.ObjectKey) []string {
+		res := []string{}
+		for _, k := range keys {
+			res = append(res, k.String())
+		}
+		sort.Strings(res)
+		return res
+	}
+	objectNames := func(objs []*unstructured.Unstructured) []string {
+		res := []string{}
+		for _, o := range objs {
+			res = append(res, fmt.Sprintf("%s/%s", o.GetKind(), o.GetName()))
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	report := &topologyPlanReport{
+		ClusterClasses: objectKeys(out.ClusterClasses),
+		Clusters:       objectKeys(out.Clusters),
+		Changes:        []topologyPlanClusterReport{},
+	}
+	for _, changes := range out.ClusterChanges {
+		modified := []*unstructured.Unstructured{}
+		for _, m := range changes.Modified {
+			modified = append(modified, m.After)
+		}
+		clusterReport := topologyPlanClusterReport{
+			Cluster:         changes.Cluster.String(),
+			Created:         objectNames(changes.Created),
+			Modified:        objectNames(modified),
+			Deleted:         objectNames(changes.Deleted),
+			Rollouts:        changes.Rollouts,
+			RolloutMachines: rolloutMachines(changes.Rollouts),
+		}
+		if changes.Error != nil {
+			clusterReport.Error = changes.Error.Error()
+		}
+		report.Changes = append(report.Changes, clusterReport)
+		report.RolloutMachines += clusterReport.RolloutMachines
+	}
+	return report
+}
+
+func writeTopologyPlanReport(out *cluster.TopologyPlanOutput, path string) error {
+	b, err := json.MarshalIndent(newTopologyPlanReport(out), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the report to json")
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write report to file %q", path)
+	}
+	return nil
+}
+
 func writeOutputFiles(out *cluster.TopologyPlanOutput, outDir string) error {
 	if _, err := os.Stat(outDir); os.IsNotExist(err) {
 		return fmt.Errorf("output directory %q does not exist", outDir)
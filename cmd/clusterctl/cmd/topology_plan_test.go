@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+func fakeUnstructured(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	u.SetAPIVersion("test.cluster.x-k8s.io/v1beta1")
+	u.SetKind(kind)
+	u.SetNamespace("ns")
+	u.SetName(name)
+	return u
+}
+
+func Test_objectDiff(t *testing.T) {
+	before := fakeUnstructured("Object", "o1", map[string]interface{}{"replicas": int64(1), "version": "v1.23.0"})
+	before.SetResourceVersion("1")
+	after := fakeUnstructured("Object", "o1", map[string]interface{}{"replicas": int64(1), "version": "v1.24.0"})
+	after.SetResourceVersion("2")
+
+	tests := []struct {
+		name           string
+		before         *unstructured.Unstructured
+		after          *unstructured.Unstructured
+		wantContain    []string
+		wantNotContain []string
+	}{
+		{
+			name:   "Diff of a modified object",
+			before: before,
+			after:  after,
+			wantContain: []string{
+				"--- a/Object/ns/o1\n",
+				"+++ b/Object/ns/o1\n",
+				"-  version: v1.23.0\n",
+				"+  version: v1.24.0\n",
+				"   replicas: 1\n",
+			},
+			// The resourceVersion changes on every write, so it is not included in the diff.
+			wantNotContain: []string{"resourceVersion"},
+		},
+		{
+			name:   "Diff of a created object",
+			before: nil,
+			after:  after,
+			wantContain: []string{
+				"--- /dev/null\n",
+				"+++ b/Object/ns/o1\n",
+				"+  version: v1.24.0\n",
+			},
+		},
+		{
+			name:   "Diff of a deleted object",
+			before: before,
+			after:  nil,
+			wantContain: []string{
+				"--- a/Object/ns/o1\n",
+				"+++ /dev/null\n",
+				"-  version: v1.23.0\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := objectDiff(tt.before, tt.after)
+			g.Expect(err).ToNot(HaveOccurred())
+			for _, s := range tt.wantContain {
+				g.Expect(got).To(ContainSubstring(s))
+			}
+			for _, s := range tt.wantNotContain {
+				g.Expect(got).ToNot(ContainSubstring(s))
+			}
+		})
+	}
+}
+
+func Test_newTopologyPlanReport(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster1 := crclient.ObjectKey{Namespace: "ns", Name: "cluster1"}
+	cluster2 := crclient.ObjectKey{Namespace: "ns", Name: "cluster2"}
+	cluster3 := crclient.ObjectKey{Namespace: "ns", Name: "cluster3"}
+	out := &cluster.TopologyPlanOutput{
+		Clusters:       []crclient.ObjectKey{cluster2, cluster3, cluster1},
+		ClusterClasses: []crclient.ObjectKey{{Namespace: "ns", Name: "class1"}},
+		ClusterChanges: []*cluster.ClusterChanges{
+			{
+				Cluster: cluster1,
+				ChangeSummary: &cluster.ChangeSummary{
+					Created: []*unstructured.Unstructured{fakeUnstructured("DockerMachineTemplate", "cp-2", nil)},
+					Modified: []*cluster.PatchSummary{
+						{
+							Before: fakeUnstructured("MachineDeployment", "md", nil),
+							After:  fakeUnstructured("MachineDeployment", "md", nil),
+						},
+						{
+							Before: fakeUnstructured("KubeadmControlPlane", "cp", nil),
+							After:  fakeUnstructured("KubeadmControlPlane", "cp", nil),
+						},
+					},
+				},
+				Rollouts: []cluster.MachineRollout{
+					{Kind: "KubeadmControlPlane", Name: "cp", Replicas: 3},
+					{Kind: "MachineDeployment", Name: "md", Replicas: 2},
+				},
+			},
+			{
+				Cluster:       cluster2,
+				ChangeSummary: &cluster.ChangeSummary{},
+			},
+			{
+				Cluster:       cluster3,
+				ChangeSummary: &cluster.ChangeSummary{},
+				Error:         errors.New("failed to dry run the topology controller"),
+			},
+		},
+	}
+
+	report := newTopologyPlanReport(out)
+	g.Expect(report.ClusterClasses).To(Equal([]string{"ns/class1"}))
+	g.Expect(report.Clusters).To(Equal([]string{"ns/cluster1", "ns/cluster2", "ns/cluster3"}))
+	g.Expect(report.RolloutMachines).To(Equal(int64(5)))
+	g.Expect(report.Changes).To(HaveLen(3))
+
+	g.Expect(report.Changes[0].Cluster).To(Equal("ns/cluster1"))
+	g.Expect(report.Changes[0].Created).To(Equal([]string{"DockerMachineTemplate/cp-2"}))
+	g.Expect(report.Changes[0].Modified).To(Equal([]string{"KubeadmControlPlane/cp", "MachineDeployment/md"}))
+	g.Expect(report.Changes[0].Deleted).To(BeEmpty())
+	g.Expect(report.Changes[0].RolloutMachines).To(Equal(int64(5)))
+	g.Expect(report.Changes[0].Error).To(BeEmpty())
+
+	g.Expect(report.Changes[1].Cluster).To(Equal("ns/cluster2"))
+	g.Expect(report.Changes[1].Rollouts).To(BeEmpty())
+	g.Expect(report.Changes[1].RolloutMachines).To(BeZero())
+
+	g.Expect(report.Changes[2].Cluster).To(Equal("ns/cluster3"))
+	g.Expect(report.Changes[2].Error).To(Equal("failed to dry run the topology controller"))
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package cmd
 
 import (
+	"time"
+
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
@@ -24,13 +26,18 @@ import (
 )
 
 type upgradeApplyOptions struct {
-	kubeconfig              string
-	kubeconfigContext       string
-	contract                string
-	coreProvider            string
-	bootstrapProviders      []string
-	controlPlaneProviders   []string
-	infrastructureProviders []string
+	kubeconfig                string
+	kubeconfigContext         string
+	contract                  string
+	coreProvider              string
+	bootstrapProviders        []string
+	controlPlaneProviders     []string
+	infrastructureProviders   []string
+	ipamProviders             []string
+	runtimeExtensionProviders []string
+	addonProviders            []string
+	waitProviders             bool
+	waitProviderTimeout       int
 }
 
 var ua = &upgradeApplyOptions{}
@@ -50,7 +57,11 @@ var upgradeApplyCmd = &cobra.Command{
 		clusterctl upgrade apply --contract v1alpha4
 
 		# Upgrades only the capa-system/aws provider to the v0.5.0 version.
-		clusterctl upgrade apply --infrastructure capa-system/aws:v0.5.0`),
+		clusterctl upgrade apply --infrastructure capa-system/aws:v0.5.0
+
+		# Upgrades without waiting for the new versions of the providers to become available;
+		# the previous versions are restored only if installing the new versions fails.
+		clusterctl upgrade apply --contract v1alpha4 --wait-providers=false`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runUpgradeApply()
@@ -73,6 +84,16 @@ func init() {
 		"Bootstrap providers instance and versions (e.g. capi-kubeadm-bootstrap-system/kubeadm:v0.3.0) to upgrade to. This flag can be used as alternative to --contract.")
 	upgradeApplyCmd.Flags().StringSliceVarP(&ua.controlPlaneProviders, "control-plane", "c", nil,
 		"ControlPlane providers instance and versions (e.g. capi-kubeadm-control-plane-system/kubeadm:v0.3.0) to upgrade to. This flag can be used as alternative to --contract.")
+	upgradeApplyCmd.Flags().StringSliceVar(&ua.ipamProviders, "ipam", nil,
+		"IPAM providers instance and versions (e.g. ipam-system/infoblox:v0.0.1) to upgrade to. This flag can be used as alternative to --contract.")
+	upgradeApplyCmd.Flags().StringSliceVar(&ua.runtimeExtensionProviders, "runtime-extension", nil,
+		"Runtime extension providers instance and versions (e.g. runtime-extension-system/test:v0.0.1) to upgrade to. This flag can be used as alternative to --contract.")
+	upgradeApplyCmd.Flags().StringSliceVar(&ua.addonProviders, "addon", nil,
+		"Add-on providers instance and versions (e.g. caaph-system/helm:v0.1.0) to upgrade to. This flag can be used as alternative to --contract.")
+	upgradeApplyCmd.Flags().BoolVar(&ua.waitProviders, "wait-providers", true,
+		"Wait for providers to be available after the upgrade, and restore the previous versions of the providers if they do not become available.")
+	upgradeApplyCmd.Flags().IntVar(&ua.waitProviderTimeout, "wait-provider-timeout", 5*60,
+		"Wait timeout per provider upgrade in seconds. This value is ignored if --wait-providers is false")
 }
 
 func runUpgradeApply() error {
@@ -84,21 +105,29 @@ func runUpgradeApply() error {
 	hasProviderNames := (ua.coreProvider != "") ||
 		(len(ua.bootstrapProviders) > 0) ||
 		(len(ua.controlPlaneProviders) > 0) ||
-		(len(ua.infrastructureProviders) > 0)
+		(len(ua.infrastructureProviders) > 0) ||
+		(len(ua.ipamProviders) > 0) ||
+		(len(ua.runtimeExtensionProviders) > 0) ||
+		(len(ua.addonProviders) > 0)
 
 	if ua.contract == "" && !hasProviderNames {
-		return errors.New("Either the --contract flag or at least one of the following flags has to be set: --core, --bootstrap, --control-plane, --infrastructure")
+		return errors.New("Either the --contract flag or at least one of the following flags has to be set: --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon")
 	}
 	if ua.contract != "" && hasProviderNames {
-		return errors.New("The --contract flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure")
+		return errors.New("The --contract flag can't be used in combination with --core, --bootstrap, --control-plane, --infrastructure, --ipam, --runtime-extension, --addon")
 	}
 
 	return c.ApplyUpgrade(client.ApplyUpgradeOptions{
-		Kubeconfig:              client.Kubeconfig{Path: ua.kubeconfig, Context: ua.kubeconfigContext},
-		Contract:                ua.contract,
-		CoreProvider:            ua.coreProvider,
-		BootstrapProviders:      ua.bootstrapProviders,
-		ControlPlaneProviders:   ua.controlPlaneProviders,
-		InfrastructureProviders: ua.infrastructureProviders,
+		Kubeconfig:                client.Kubeconfig{Path: ua.kubeconfig, Context: ua.kubeconfigContext},
+		Contract:                  ua.contract,
+		CoreProvider:              ua.coreProvider,
+		BootstrapProviders:        ua.bootstrapProviders,
+		ControlPlaneProviders:     ua.controlPlaneProviders,
+		InfrastructureProviders:   ua.infrastructureProviders,
+		IPAMProviders:             ua.ipamProviders,
+		RuntimeExtensionProviders: ua.runtimeExtensionProviders,
+		AddonProviders:            ua.addonProviders,
+		WaitProviders:             ua.waitProviders,
+		WaitProviderTimeout:       time.Duration(ua.waitProviderTimeout) * time.Second,
 	})
 }
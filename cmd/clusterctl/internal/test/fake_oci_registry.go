@@ -0,0 +1,164 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+)
+
+// FakeOCIRegistry is an in-process stand-in for an OCI registry, serving the subset of the OCI distribution API
+// used to pull artifacts; each file of an artifact is stored in a layer annotated with its file name.
+type FakeOCIRegistry struct {
+	server    *httptest.Server
+	artifacts map[string]map[string]fakeOCIArtifact
+	blobs     map[string][]byte
+	username  string
+	password  string
+	token     string
+	anonymous bool
+}
+
+type fakeOCIArtifact struct {
+	manifest []byte
+}
+
+// NewFakeOCIRegistry starts a FakeOCIRegistry; use Close to stop it.
+func NewFakeOCIRegistry() *FakeOCIRegistry {
+	r := &FakeOCIRegistry{
+		artifacts: map[string]map[string]fakeOCIArtifact{},
+		blobs:     map[string][]byte{},
+	}
+	r.server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+// WithArtifact adds an artifact with the given files to a repository of the registry.
+func (r *FakeOCIRegistry) WithArtifact(repository, tag string, files map[string][]byte) *FakeOCIRegistry {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	layers := []map[string]interface{}{}
+	for _, name := range names {
+		digest := fmt.Sprintf("sha256:%x", sha256.Sum256(files[name]))
+		r.blobs[digest] = files[name]
+		layers = append(layers, map[string]interface{}{
+			"mediaType":   "application/vnd.oci.image.layer.v1.tar",
+			"digest":      digest,
+			"size":        len(files[name]),
+			"annotations": map[string]string{"org.opencontainers.image.title": name},
+		})
+	}
+	manifest, _ := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"mediaType":     "application/vnd.oci.image.manifest.v1+json",
+		"layers":        layers,
+	})
+
+	if _, ok := r.artifacts[repository]; !ok {
+		r.artifacts[repository] = map[string]fakeOCIArtifact{}
+	}
+	r.artifacts[repository][tag] = fakeOCIArtifact{manifest: manifest}
+	return r
+}
+
+// WithTokenAuth requires clients to authenticate with a bearer token, issued by the registry token endpoint
+// to clients providing the given credentials.
+func (r *FakeOCIRegistry) WithTokenAuth(username, password string) *FakeOCIRegistry {
+	r.username = username
+	r.password = password
+	r.token = "fake-token"
+	return r
+}
+
+// WithAnonymousTokenAuth requires clients to authenticate with a bearer token, issued by the registry token endpoint
+// to any client, as public registries do.
+func (r *FakeOCIRegistry) WithAnonymousTokenAuth() *FakeOCIRegistry {
+	r.anonymous = true
+	r.token = "fake-token"
+	return r
+}
+
+// Host returns the host of the registry, to be used in oci:// URLs.
+func (r *FakeOCIRegistry) Host() string {
+	return strings.TrimPrefix(r.server.URL, "http://")
+}
+
+// Close stops the registry.
+func (r *FakeOCIRegistry) Close() {
+	r.server.Close()
+}
+
+func (r *FakeOCIRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if username, password, ok := req.BasicAuth(); !r.anonymous && (!ok || username != r.username || password != r.password) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": r.token})
+		return
+	}
+
+	if r.token != "" && req.Header.Get("Authorization") != "Bearer "+r.token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake-registry"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.HasSuffix(path, "/tags/list"):
+		repository := strings.TrimSuffix(path, "/tags/list")
+		artifacts, ok := r.artifacts[repository]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		tags := make([]string, 0, len(artifacts))
+		for tag := range artifacts {
+			tags = append(tags, tag)
+		}
+		sort.Strings(tags)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"name": repository, "tags": tags})
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		artifact, ok := r.artifacts[path[:i]][path[i+len("/manifests/"):]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		_, _ = w.Write(artifact.manifest)
+	case strings.Contains(path, "/blobs/"):
+		blob, ok := r.blobs[path[strings.LastIndex(path, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(blob)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
@@ -32,6 +32,7 @@ import (
 	clustertopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/cluster"
 	machinedeploymenttopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/machinedeployment"
 	machinesettopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/machineset"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
 )
 
 // Following types provides access to reconcilers implemented in internal/controllers, thus
@@ -62,14 +63,22 @@ type MachineReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// NodeLabelSyncPatterns are the patterns used to select the Machine labels to be kept in sync onto the Node.
+	NodeLabelSyncPatterns []string
+
+	// NodeAnnotationSyncPatterns are the patterns used to select the Machine annotations to be kept in sync onto the Node.
+	NodeAnnotationSyncPatterns []string
 }
 
 func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return (&machinecontroller.Reconciler{
-		Client:           r.Client,
-		APIReader:        r.APIReader,
-		Tracker:          r.Tracker,
-		WatchFilterValue: r.WatchFilterValue,
+		Client:                     r.Client,
+		APIReader:                  r.APIReader,
+		Tracker:                    r.Tracker,
+		WatchFilterValue:           r.WatchFilterValue,
+		NodeLabelSyncPatterns:      r.NodeLabelSyncPatterns,
+		NodeAnnotationSyncPatterns: r.NodeAnnotationSyncPatterns,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -139,6 +148,9 @@ type ClusterTopologyReconciler struct {
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects in a managed topology.
 	UnstructuredCachingClient client.Client
+
+	// RuntimeClient is used to call the lifecycle hooks implemented by Runtime Extensions.
+	RuntimeClient runtimeclient.Client
 }
 
 func (r *ClusterTopologyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -146,6 +158,7 @@ func (r *ClusterTopologyReconciler) SetupWithManager(ctx context.Context, mgr ct
 		Client:                    r.Client,
 		APIReader:                 r.APIReader,
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
+		RuntimeClient:             r.RuntimeClient,
 		WatchFilterValue:          r.WatchFilterValue,
 	}).SetupWithManager(ctx, mgr, options)
 }
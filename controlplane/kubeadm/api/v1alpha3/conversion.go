@@ -42,7 +42,11 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.MachineTemplate.NodeDeletionTimeout
 	dst.Spec.KubeadmConfigSpec.Files = restored.Spec.KubeadmConfigSpec.Files
 	dst.Spec.KubeadmConfigSpec.Users = restored.Spec.KubeadmConfigSpec.Users
+	dst.Spec.RemediationStrategy = restored.Spec.RemediationStrategy
+	dst.Spec.EtcdDefragmentation = restored.Spec.EtcdDefragmentation
+	dst.Spec.RolloutBefore = restored.Spec.RolloutBefore
 	dst.Status.Version = restored.Status.Version
+	dst.Status.LastRemediation = restored.Status.LastRemediation
 
 	if restored.Spec.KubeadmConfigSpec.Users != nil {
 		for i := range restored.Spec.KubeadmConfigSpec.Users {
@@ -73,6 +77,8 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.KubeadmConfigSpec.InitConfiguration.Patches = restored.Spec.KubeadmConfigSpec.InitConfiguration.Patches
 		dst.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases
+		dst.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = restored.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts
+		dst.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.KubeadmConfigSpec.JoinConfiguration != nil {
 		if dst.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -80,6 +86,15 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.Patches = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Patches
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases
+		dst.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts
+		dst.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.KubeadmConfigSpec.ClusterConfiguration != nil {
+		if dst.Spec.KubeadmConfigSpec.ClusterConfiguration == nil {
+			dst.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.Proxy = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.Proxy
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.DNS.Disabled = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.DNS.Disabled
 	}
 
 	return nil
@@ -120,7 +135,7 @@ func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha3_KubeadmControlPlaneSpec
 }
 
 func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apiconversion.Scope) error {
-	// NOTE: custom conversion func is required because status.Version does not exist in v1alpha3.
+	// NOTE: custom conversion func is required because status.Version and status.LastRemediation do not exist in v1alpha3.
 	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha3_KubeadmControlPlaneStatus(in, out, s)
 }
 
@@ -57,6 +57,8 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.KubeadmConfigSpec.InitConfiguration.Patches = restored.Spec.KubeadmConfigSpec.InitConfiguration.Patches
 		dst.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases
+		dst.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = restored.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts
+		dst.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.KubeadmConfigSpec.JoinConfiguration != nil {
 		if dst.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -64,9 +66,22 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.Patches = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Patches
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases
+		dst.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts
+		dst.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.KubeadmConfigSpec.ClusterConfiguration != nil {
+		if dst.Spec.KubeadmConfigSpec.ClusterConfiguration == nil {
+			dst.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.Proxy = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.Proxy
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.DNS.Disabled = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.DNS.Disabled
 	}
 
 	dst.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.MachineTemplate.NodeDeletionTimeout
+	dst.Spec.RemediationStrategy = restored.Spec.RemediationStrategy
+	dst.Spec.EtcdDefragmentation = restored.Spec.EtcdDefragmentation
+	dst.Spec.RolloutBefore = restored.Spec.RolloutBefore
+	dst.Status.LastRemediation = restored.Status.LastRemediation
 
 	return nil
 }
@@ -126,6 +141,8 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Patches = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Patches
 		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases
+		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts
+		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.NodeRegistration.ImagePullSerial
 	}
 	if restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration != nil {
 		if dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -133,12 +150,24 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Patches = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Patches
 		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases
+		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts
+		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullSerial = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullSerial
+	}
+	if restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration != nil {
+		if dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration == nil {
+			dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{}
+		}
+		dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Proxy = restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Proxy
+		dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.DNS.Disabled = restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.DNS.Disabled
 	}
 	if dst.Spec.Template.Spec.MachineTemplate == nil {
 		dst.Spec.Template.Spec.MachineTemplate = restored.Spec.Template.Spec.MachineTemplate
 	} else if restored.Spec.Template.Spec.MachineTemplate != nil {
 		dst.Spec.Template.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.Template.Spec.MachineTemplate.NodeDeletionTimeout
 	}
+	dst.Spec.Template.Spec.RemediationStrategy = restored.Spec.Template.Spec.RemediationStrategy
+	dst.Spec.Template.Spec.EtcdDefragmentation = restored.Spec.Template.Spec.EtcdDefragmentation
+	dst.Spec.Template.Spec.RolloutBefore = restored.Spec.Template.Spec.RolloutBefore
 
 	return nil
 }
@@ -226,3 +255,13 @@ func Convert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmContr
 	// .NodeDrainTimeout was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in, out, s)
 }
+
+func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in *controlplanev1.KubeadmControlPlaneSpec, out *KubeadmControlPlaneSpec, s apiconversion.Scope) error {
+	// .RemediationStrategy, .EtcdDefragmentation and .RolloutBefore were added in v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in, out, s)
+}
+
+func Convert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in *controlplanev1.KubeadmControlPlaneStatus, out *KubeadmControlPlaneStatus, s apiconversion.Scope) error {
+	// .LastRemediation was added in v1beta1.
+	return autoConvert_v1beta1_KubeadmControlPlaneStatus_To_v1alpha4_KubeadmControlPlaneStatus(in, out, s)
+}
@@ -132,6 +132,18 @@ const (
 	// EtcdMemberUnhealthyReason (Severity=Error) documents a Machine's etcd member is unhealthy.
 	EtcdMemberUnhealthyReason = "EtcdMemberUnhealthy"
 
+	// MachineEtcdMemberVotingCondition reports whether the machine's etcd member is a voting member of the etcd cluster;
+	// etcd members joining the cluster as learners are promoted to voting members once they are in sync with the leader.
+	// NOTE: This conditions exists only if a stacked etcd cluster is used and the EtcdLearnerMode feature gate is enabled.
+	MachineEtcdMemberVotingCondition clusterv1.ConditionType = "EtcdMemberVoting"
+
+	// EtcdMemberLearnerReason (Severity=Info) documents a Machine's etcd member being a learner not yet in sync with the leader.
+	EtcdMemberLearnerReason = "EtcdMemberLearner"
+
+	// EtcdMemberPromotionFailedReason (Severity=Warning) documents a failure in promoting a Machine's etcd member
+	// to a voting member.
+	EtcdMemberPromotionFailedReason = "EtcdMemberPromotionFailed"
+
 	// MachinesCreatedCondition documents that the machines controlled by the KubeadmControlPlane are created.
 	// When this condition is false, it indicates that there was an error when cloning the infrastructure/bootstrap template or
 	// when generating the machine object.
@@ -149,3 +161,33 @@ const (
 	// generate a machine object.
 	MachineGenerationFailedReason = "MachineGenerationFailed"
 )
+
+const (
+	// EtcdSnapshotRestoredCondition documents the restore of the EtcdSnapshot referenced by the RestoreEtcdSnapshotAnnotation
+	// when the control plane is initialized.
+	// NOTE: This condition exists only if the control plane has been initialized from an EtcdSnapshot.
+	EtcdSnapshotRestoredCondition clusterv1.ConditionType = "EtcdSnapshotRestored"
+
+	// EtcdSnapshotRestoringReason (Severity=Info) documents a KubeadmControlPlane waiting for the first control plane
+	// machine to restore the EtcdSnapshot and to initialize the control plane.
+	EtcdSnapshotRestoringReason = "EtcdSnapshotRestoring"
+)
+
+// Conditions and condition Reasons for the EtcdSnapshot object.
+
+const (
+	// EtcdSnapshotTakenCondition documents that the etcd snapshot has been taken and stored in the data Secrets.
+	EtcdSnapshotTakenCondition clusterv1.ConditionType = "SnapshotTaken"
+
+	// WaitingForControlPlaneInitializedReason (Severity=Info) documents an EtcdSnapshot waiting for the
+	// control plane of the Cluster to be initialized.
+	WaitingForControlPlaneInitializedReason = "WaitingForControlPlaneInitialized"
+
+	// EtcdSnapshotFailedReason (Severity=Warning) documents an EtcdSnapshot controller failing to take or store
+	// the snapshot; those kind of errors are usually temporary and the controller retries.
+	EtcdSnapshotFailedReason = "SnapshotFailed"
+
+	// EtcdSnapshotUnsupportedReason (Severity=Error) documents an EtcdSnapshot that can't be taken, because the Cluster
+	// does not have a KubeadmControlPlane managing a stacked etcd cluster, or because the snapshot exceeds the maximum size of a snapshot.
+	EtcdSnapshotUnsupportedReason = "SnapshotUnsupported"
+)
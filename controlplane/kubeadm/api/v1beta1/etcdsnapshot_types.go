@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// EtcdSnapshotDataKey is the key of the data Secret entry storing a chunk of the etcd snapshot.
+	// The snapshot is gzip compressed and split across the data Secrets, each one storing at most
+	// the maximum size of a Secret; the snapshot is the concatenation of the chunks in the order
+	// listed in the EtcdSnapshot status.
+	EtcdSnapshotDataKey = "snapshot"
+)
+
+// EtcdSnapshotSpec defines the desired state of EtcdSnapshot.
+type EtcdSnapshotSpec struct {
+	// ClusterName is the name of the Cluster this snapshot is taken from.
+	// The Cluster must have a KubeadmControlPlane managing a stacked etcd cluster.
+	// +kubebuilder:validation:MinLength=1
+	ClusterName string `json:"clusterName"`
+}
+
+// EtcdSnapshotStatus defines the observed state of EtcdSnapshot.
+type EtcdSnapshotStatus struct {
+	// Ready indicates the snapshot has been taken and stored in the data Secrets.
+	// +optional
+	Ready bool `json:"ready"`
+
+	// DataSecretNames are the names of the Secrets storing the chunks of the snapshot, in order.
+	// +optional
+	DataSecretNames []string `json:"dataSecretNames,omitempty"`
+
+	// Size is the size in bytes of the etcd snapshot, before compression.
+	// +optional
+	Size int64 `json:"size,omitempty"`
+
+	// CompletionTime is the time the snapshot has been stored.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions defines current service state of the EtcdSnapshot.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=etcdsnapshots,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
+// +kubebuilder:printcolumn:name="Ready",type=boolean,JSONPath=".status.ready",description="Snapshot has been taken and stored"
+// +kubebuilder:printcolumn:name="Size",type=integer,JSONPath=".status.size",description="Size in bytes of the etcd snapshot"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of EtcdSnapshot"
+
+// EtcdSnapshot is the Schema for the etcdsnapshots API.
+// An EtcdSnapshot takes a snapshot of the etcd cluster of a KubeadmControlPlane once, and stores it in Secrets;
+// the snapshot can then be restored when the control plane is initialized again by annotating the
+// KubeadmControlPlane with RestoreEtcdSnapshotAnnotation.
+type EtcdSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EtcdSnapshotSpec   `json:"spec,omitempty"`
+	Status EtcdSnapshotStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (in *EtcdSnapshot) GetConditions() clusterv1.Conditions {
+	return in.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (in *EtcdSnapshot) SetConditions(conditions clusterv1.Conditions) {
+	in.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// EtcdSnapshotList contains a list of EtcdSnapshot.
+type EtcdSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EtcdSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EtcdSnapshot{}, &EtcdSnapshotList{})
+}
@@ -126,6 +126,7 @@ const (
 	scheduler            = "scheduler"
 	ntp                  = "ntp"
 	ignition             = "ignition"
+	timeouts             = "timeouts"
 )
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -145,8 +146,10 @@ func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
 		{spec, kubeadmConfigSpec, clusterConfiguration, scheduler, "*"},
 		{spec, kubeadmConfigSpec, initConfiguration, nodeRegistration, "*"},
 		{spec, kubeadmConfigSpec, initConfiguration, patches, directory},
+		{spec, kubeadmConfigSpec, initConfiguration, timeouts, "*"},
 		{spec, kubeadmConfigSpec, joinConfiguration, nodeRegistration, "*"},
 		{spec, kubeadmConfigSpec, joinConfiguration, patches, directory},
+		{spec, kubeadmConfigSpec, joinConfiguration, timeouts, "*"},
 		{spec, kubeadmConfigSpec, preKubeadmCommands},
 		{spec, kubeadmConfigSpec, postKubeadmCommands},
 		{spec, kubeadmConfigSpec, files},
@@ -163,7 +166,10 @@ func (in *KubeadmControlPlane) ValidateUpdate(old runtime.Object) error {
 		{spec, "replicas"},
 		{spec, "version"},
 		{spec, "rolloutAfter"},
+		{spec, "rolloutBefore", "*"},
 		{spec, "rolloutStrategy", "*"},
+		{spec, "remediationStrategy", "*"},
+		{spec, "etcdDefragmentation", "*"},
 	}
 
 	allErrs := validateKubeadmControlPlaneSpec(in.Spec, in.Namespace, field.NewPath("spec"))
@@ -313,7 +319,31 @@ func validateKubeadmControlPlaneSpec(s KubeadmControlPlaneSpec, namespace string
 		allErrs = append(allErrs, field.Invalid(pathPrefix.Child("version"), s.Version, "must be a valid semantic version"))
 	}
 
+	allErrs = append(allErrs, validateRolloutBefore(s.RolloutBefore, pathPrefix.Child("rolloutBefore"))...)
 	allErrs = append(allErrs, validateRolloutStrategy(s.RolloutStrategy, s.Replicas, pathPrefix.Child("rolloutStrategy"))...)
+	allErrs = append(allErrs, validateRemediationStrategy(s.RemediationStrategy, pathPrefix.Child("remediationStrategy"))...)
+	allErrs = append(allErrs, validateEtcdDefragmentation(s.EtcdDefragmentation, pathPrefix.Child("etcdDefragmentation"))...)
+
+	return allErrs
+}
+
+func validateRolloutBefore(rolloutBefore *RolloutBefore, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if rolloutBefore == nil {
+		return allErrs
+	}
+
+	if rolloutBefore.CertificatesExpiryDays != nil && *rolloutBefore.CertificatesExpiryDays < 7 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("certificatesExpiryDays"),
+				*rolloutBefore.CertificatesExpiryDays,
+				"must be greater than or equal to 7",
+			),
+		)
+	}
 
 	return allErrs
 }
@@ -361,6 +391,81 @@ func validateRolloutStrategy(rolloutStrategy *RolloutStrategy, replicas *int32,
 	return allErrs
 }
 
+func validateRemediationStrategy(remediationStrategy *RemediationStrategy, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if remediationStrategy == nil {
+		return allErrs
+	}
+
+	if remediationStrategy.MaxRetry != nil && *remediationStrategy.MaxRetry < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("maxRetry"),
+				*remediationStrategy.MaxRetry,
+				"must be greater than or equal to 0",
+			),
+		)
+	}
+
+	if remediationStrategy.RetryPeriod.Duration < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("retryPeriod"),
+				remediationStrategy.RetryPeriod.String(),
+				"must be greater than or equal to 0",
+			),
+		)
+	}
+
+	if remediationStrategy.MinHealthyPeriod != nil && remediationStrategy.MinHealthyPeriod.Duration < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("minHealthyPeriod"),
+				remediationStrategy.MinHealthyPeriod.String(),
+				"must be greater than or equal to 0",
+			),
+		)
+	}
+
+	return allErrs
+}
+
+func validateEtcdDefragmentation(etcdDefragmentation *EtcdDefragmentation, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if etcdDefragmentation == nil {
+		return allErrs
+	}
+
+	if etcdDefragmentation.MinDBSize != nil && etcdDefragmentation.MinDBSize.Sign() < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("minDBSize"),
+				etcdDefragmentation.MinDBSize.String(),
+				"must be greater than or equal to 0",
+			),
+		)
+	}
+
+	if etcdDefragmentation.MinFragmentationPercent != nil && (*etcdDefragmentation.MinFragmentationPercent < 1 || *etcdDefragmentation.MinFragmentationPercent > 100) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("minFragmentationPercent"),
+				*etcdDefragmentation.MinFragmentationPercent,
+				"must be between 1 and 100",
+			),
+		)
+	}
+
+	return allErrs
+}
+
 func validateClusterConfiguration(newClusterConfiguration, oldClusterConfiguration *bootstrapv1.ClusterConfiguration, pathPrefix *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -22,6 +22,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -103,6 +104,54 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	invalidMaxSurge := valid.DeepCopy()
 	invalidMaxSurge.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntVal = int32(3)
 
+	validRemediationStrategy := valid.DeepCopy()
+	validRemediationStrategy.Spec.RemediationStrategy = &RemediationStrategy{
+		MaxRetry:         pointer.Int32Ptr(3),
+		RetryPeriod:      metav1.Duration{Duration: 5 * time.Minute},
+		MinHealthyPeriod: &metav1.Duration{Duration: 2 * time.Hour},
+	}
+
+	invalidRemediationMaxRetry := valid.DeepCopy()
+	invalidRemediationMaxRetry.Spec.RemediationStrategy = &RemediationStrategy{
+		MaxRetry: pointer.Int32Ptr(-1),
+	}
+
+	invalidRemediationRetryPeriod := valid.DeepCopy()
+	invalidRemediationRetryPeriod.Spec.RemediationStrategy = &RemediationStrategy{
+		RetryPeriod: metav1.Duration{Duration: -1 * time.Minute},
+	}
+
+	invalidRemediationMinHealthyPeriod := valid.DeepCopy()
+	invalidRemediationMinHealthyPeriod.Spec.RemediationStrategy = &RemediationStrategy{
+		MinHealthyPeriod: &metav1.Duration{Duration: -1 * time.Minute},
+	}
+
+	validEtcdDefragmentation := valid.DeepCopy()
+	validEtcdDefragmentation.Spec.EtcdDefragmentation = &EtcdDefragmentation{
+		MinDBSize:               resource.NewQuantity(200*1024*1024, resource.BinarySI),
+		MinFragmentationPercent: pointer.Int32Ptr(30),
+	}
+
+	invalidEtcdDefragmentationMinDBSize := valid.DeepCopy()
+	invalidEtcdDefragmentationMinDBSize.Spec.EtcdDefragmentation = &EtcdDefragmentation{
+		MinDBSize: resource.NewQuantity(-1, resource.BinarySI),
+	}
+
+	invalidEtcdDefragmentationMinFragmentationPercent := valid.DeepCopy()
+	invalidEtcdDefragmentationMinFragmentationPercent.Spec.EtcdDefragmentation = &EtcdDefragmentation{
+		MinFragmentationPercent: pointer.Int32Ptr(0),
+	}
+
+	validRolloutBefore := valid.DeepCopy()
+	validRolloutBefore.Spec.RolloutBefore = &RolloutBefore{
+		CertificatesExpiryDays: pointer.Int32Ptr(21),
+	}
+
+	invalidRolloutBeforeCertificatesExpiryDays := valid.DeepCopy()
+	invalidRolloutBeforeCertificatesExpiryDays.Spec.RolloutBefore = &RolloutBefore{
+		CertificatesExpiryDays: pointer.Int32Ptr(5),
+	}
+
 	invalidNamespace := valid.DeepCopy()
 	invalidNamespace.Spec.MachineTemplate.InfrastructureRef.Namespace = "bar"
 
@@ -204,6 +253,51 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: true,
 			kcp:       invalidMaxSurge,
 		},
+		{
+			name:      "should succeed when given a valid remediation strategy",
+			expectErr: false,
+			kcp:       validRemediationStrategy,
+		},
+		{
+			name:      "should succeed when given a valid etcd defragmentation",
+			expectErr: false,
+			kcp:       validEtcdDefragmentation,
+		},
+		{
+			name:      "should return error when etcd defragmentation minDBSize is negative",
+			expectErr: true,
+			kcp:       invalidEtcdDefragmentationMinDBSize,
+		},
+		{
+			name:      "should return error when etcd defragmentation minFragmentationPercent is 0",
+			expectErr: true,
+			kcp:       invalidEtcdDefragmentationMinFragmentationPercent,
+		},
+		{
+			name:      "should succeed when given a valid rolloutBefore",
+			expectErr: false,
+			kcp:       validRolloutBefore,
+		},
+		{
+			name:      "should return error when rolloutBefore certificatesExpiryDays is less than 7",
+			expectErr: true,
+			kcp:       invalidRolloutBeforeCertificatesExpiryDays,
+		},
+		{
+			name:      "should return error when remediation maxRetry is negative",
+			expectErr: true,
+			kcp:       invalidRemediationMaxRetry,
+		},
+		{
+			name:      "should return error when remediation retryPeriod is negative",
+			expectErr: true,
+			kcp:       invalidRemediationRetryPeriod,
+		},
+		{
+			name:      "should return error when remediation minHealthyPeriod is negative",
+			expectErr: true,
+			kcp:       invalidRemediationMinHealthyPeriod,
+		},
 		{
 			name:                  "should return error when Ignition configuration is invalid",
 			enableIgnitionFeature: true,
@@ -382,6 +476,16 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	validUpdate.Spec.Replicas = pointer.Int32Ptr(5)
 	now := metav1.NewTime(time.Now())
 	validUpdate.Spec.RolloutAfter = &now
+	validUpdate.Spec.RemediationStrategy = &RemediationStrategy{
+		MaxRetry:    pointer.Int32Ptr(5),
+		RetryPeriod: metav1.Duration{Duration: 10 * time.Minute},
+	}
+	validUpdate.Spec.EtcdDefragmentation = &EtcdDefragmentation{
+		MinFragmentationPercent: pointer.Int32Ptr(30),
+	}
+	validUpdate.Spec.RolloutBefore = &RolloutBefore{
+		CertificatesExpiryDays: pointer.Int32Ptr(14),
+	}
 	validUpdate.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
 
 	scaleToZero := before.DeepCopy()
@@ -618,6 +722,16 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		Directory: "/tmp/patches",
 	}
 
+	updateInitConfigurationTimeouts := before.DeepCopy()
+	updateInitConfigurationTimeouts.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = &bootstrapv1.Timeouts{
+		ControlPlaneComponentHealthCheck: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
+	updateJoinConfigurationTimeouts := before.DeepCopy()
+	updateJoinConfigurationTimeouts.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = &bootstrapv1.Timeouts{
+		Discovery: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
 	tests := []struct {
 		name                  string
 		enableIgnitionFeature bool
@@ -936,6 +1050,18 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       updateJoinConfigurationPatches,
 		},
+		{
+			name:      "should allow changes to initConfiguration.timeouts",
+			expectErr: false,
+			before:    before,
+			kcp:       updateInitConfigurationTimeouts,
+		},
+		{
+			name:      "should allow changes to joinConfiguration.timeouts",
+			expectErr: false,
+			before:    before,
+			kcp:       updateJoinConfigurationTimeouts,
+		},
 		{
 			name:                  "should return error when Ignition configuration is invalid",
 			enableIgnitionFeature: true,
@@ -102,5 +102,9 @@ func (r *KubeadmControlPlaneTemplate) ValidateDelete() error {
 // validateKubeadmControlPlaneTemplateResourceSpec is a copy of validateKubeadmControlPlaneSpec which
 // only validates the fields in KubeadmControlPlaneTemplateResourceSpec we care about.
 func validateKubeadmControlPlaneTemplateResourceSpec(s KubeadmControlPlaneTemplateResourceSpec, pathPrefix *field.Path) field.ErrorList {
-	return validateRolloutStrategy(s.RolloutStrategy, nil, pathPrefix.Child("rolloutStrategy"))
+	allErrs := validateRolloutStrategy(s.RolloutStrategy, nil, pathPrefix.Child("rolloutStrategy"))
+	allErrs = append(allErrs, validateRolloutBefore(s.RolloutBefore, pathPrefix.Child("rolloutBefore"))...)
+	allErrs = append(allErrs, validateRemediationStrategy(s.RemediationStrategy, pathPrefix.Child("remediationStrategy"))...)
+	allErrs = append(allErrs, validateEtcdDefragmentation(s.EtcdDefragmentation, pathPrefix.Child("etcdDefragmentation"))...)
+	return allErrs
 }
@@ -38,13 +38,39 @@ type KubeadmControlPlaneReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// ManagementClusterEndpoint is the endpoint of the management cluster API server the first control plane machine
+	// fetches the EtcdSnapshot being restored from.
+	ManagementClusterEndpoint string
 }
 
 // SetupWithManager sets up the reconciler with the Manager.
 func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return (&kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
+		Client:                    r.Client,
+		APIReader:                 r.APIReader,
+		Tracker:                   r.Tracker,
+		EtcdDialTimeout:           r.EtcdDialTimeout,
+		WatchFilterValue:          r.WatchFilterValue,
+		ManagementClusterEndpoint: r.ManagementClusterEndpoint,
+	}).SetupWithManager(ctx, mgr, options)
+}
+
+// EtcdSnapshotReconciler reconciles an EtcdSnapshot object.
+type EtcdSnapshotReconciler struct {
+	Client  client.Client
+	Tracker *remote.ClusterCacheTracker
+
+	EtcdDialTimeout time.Duration
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+// SetupWithManager sets up the reconciler with the Manager.
+func (r *EtcdSnapshotReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&kubeadmcontrolplanecontrollers.EtcdSnapshotReconciler{
 		Client:           r.Client,
-		APIReader:        r.APIReader,
 		Tracker:          r.Tracker,
 		EtcdDialTimeout:  r.EtcdDialTimeout,
 		WatchFilterValue: r.WatchFilterValue,
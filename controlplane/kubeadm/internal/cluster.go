@@ -152,6 +152,7 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.O
 		Client:              c,
 		CoreDNSMigrator:     &CoreDNSMigrator{},
 		etcdClientGenerator: NewEtcdClientGenerator(restConfig, tlsConfig, m.EtcdDialTimeout),
+		restConfig:          restConfig,
 	}, nil
 }
 
@@ -263,6 +263,8 @@ func (c *ControlPlane) MachinesNeedingRollout() collections.Machines {
 	return machines.AnyFilter(
 		// Machines that are scheduled for rollout (KCP.Spec.RolloutAfter set, the RolloutAfter deadline is expired, and the machine was created before the deadline).
 		collections.ShouldRolloutAfter(&c.reconciliationTime, c.KCP.Spec.RolloutAfter),
+		// Machines whose certificates are about to expire.
+		collections.ShouldRolloutBefore(&c.reconciliationTime, c.KCP.Spec.RolloutBefore),
 		// Machines that do not match with KCP config.
 		collections.Not(MatchesMachineSpec(c.infraResources, c.kubeadmConfigs, c.KCP)),
 	)
@@ -274,6 +276,8 @@ func (c *ControlPlane) UpToDateMachines() collections.Machines {
 	return c.Machines.Filter(
 		// Machines that shouldn't be rolled out after the deadline has expired.
 		collections.Not(collections.ShouldRolloutAfter(&c.reconciliationTime, c.KCP.Spec.RolloutAfter)),
+		// Machines whose certificates are not about to expire.
+		collections.Not(collections.ShouldRolloutBefore(&c.reconciliationTime, c.KCP.Spec.RolloutBefore)),
 		// Machines that match with KCP config.
 		MatchesMachineSpec(c.infraResources, c.kubeadmConfigs, c.KCP),
 	)
@@ -315,6 +319,18 @@ func getKubeadmConfigs(ctx context.Context, cl client.Client, machines collectio
 	return result, nil
 }
 
+// InfrastructureResource returns the infrastructure object of the given machine, if any.
+func (c *ControlPlane) InfrastructureResource(machineName string) (*unstructured.Unstructured, bool) {
+	infraObj, ok := c.infraResources[machineName]
+	return infraObj, ok
+}
+
+// KubeadmConfig returns the KubeadmConfig of the given machine, if any.
+func (c *ControlPlane) KubeadmConfig(machineName string) (*bootstrapv1.KubeadmConfig, bool) {
+	kubeadmConfig, ok := c.kubeadmConfigs[machineName]
+	return kubeadmConfig, ok
+}
+
 // IsEtcdManaged returns true if the control plane relies on a managed etcd.
 func (c *ControlPlane) IsEtcdManaged() bool {
 	return c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration == nil || c.KCP.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External == nil
@@ -347,6 +363,7 @@ func (c *ControlPlane) PatchMachines(ctx context.Context) error {
 				controlplanev1.MachineSchedulerPodHealthyCondition,
 				controlplanev1.MachineEtcdPodHealthyCondition,
 				controlplanev1.MachineEtcdMemberHealthyCondition,
+				controlplanev1.MachineEtcdMemberVotingCondition,
 			}}); err != nil {
 				errList = append(errList, errors.Wrapf(err, "failed to patch machine %s", machine.Name))
 			}
@@ -30,4 +30,11 @@ const (
 	// dependentCertRequeueAfter is how long to wait before checking again to see if
 	// dependent certificates have been created.
 	dependentCertRequeueAfter = 30 * time.Second
+
+	// etcdDefragmentationRequeueAfter is how long to wait before checking again
+	// if there are etcd members to defragment after an etcd member has been defragmented.
+	etcdDefragmentationRequeueAfter = 20 * time.Second
+
+	// etcdDefragmentationMinInterval is the minimum time between two defragmentations of the same etcd member.
+	etcdDefragmentationMinInterval = 1 * time.Hour
 )
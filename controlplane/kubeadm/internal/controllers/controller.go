@@ -54,7 +54,9 @@ import (
 )
 
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
-// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=create;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=create;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;create;update;patch;delete
@@ -72,6 +74,10 @@ type KubeadmControlPlaneReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// ManagementClusterEndpoint is the endpoint of the management cluster API server the first control plane machine
+	// fetches the EtcdSnapshot being restored from; it must be reachable from the machines of the workload clusters.
+	ManagementClusterEndpoint string
+
 	managementCluster         internal.ManagementCluster
 	managementClusterUncached internal.ManagementCluster
 }
@@ -234,6 +240,7 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.MachinesReadyCondition,
 			controlplanev1.AvailableCondition,
 			controlplanev1.CertificatesAvailableCondition,
+			controlplanev1.EtcdSnapshotRestoredCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
@@ -309,6 +316,12 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return ctrl.Result{}, err
 	}
 
+	// Propagate the in-place mutable fields from the KCP machine template to the existing Machines,
+	// InfrastructureMachines and KubeadmConfigs, without triggering a rollout.
+	if err := r.syncMachines(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to sync Machines")
+	}
+
 	// Aggregate the operational state of all the machines; while aggregating we are adding the
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	conditions.SetAggregate(controlPlane.KCP, controlplanev1.MachinesReadyCondition, ownedMachines.ConditionGetters(), conditions.AddSourceRef(), conditions.WithStepCounterIf(false))
@@ -331,6 +344,16 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return result, err
 	}
 
+	// Reconcile certificate expiry for machines that don't have the expiry annotation on KubeadmConfig yet.
+	if result, err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// Clean up the credentials used to restore an etcd snapshot once the control plane is initialized.
+	if err := r.reconcileEtcdSnapshotRestore(ctx, controlPlane); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to clean up etcd snapshot restore")
+	}
+
 	// Control plane machines rollout due to configuration changes (e.g. upgrades) takes precedence over other operations.
 	needRollout := controlPlane.MachinesNeedingRollout()
 	switch {
@@ -400,7 +423,9 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, cluster *
 		return ctrl.Result{}, errors.Wrap(err, "failed to update CoreDNS deployment")
 	}
 
-	return ctrl.Result{}, nil
+	// Defragment etcd members and disarm etcd alarms; this happens only when the control plane
+	// is not rolling out nor scaling, given that a member can't serve requests while being defragmented.
+	return r.reconcileEtcdDefragmentation(ctx, controlPlane, workloadCluster)
 }
 
 // reconcileDelete handles KubeadmControlPlane deletion.
@@ -511,6 +536,11 @@ func (r *KubeadmControlPlaneReconciler) reconcileControlPlaneConditions(ctx cont
 	workloadCluster.UpdateStaticPodConditions(ctx, controlPlane)
 	workloadCluster.UpdateEtcdConditions(ctx, controlPlane)
 
+	// Promote the etcd members that joined as learners, once they are in sync with the leader.
+	if feature.Gates.Enabled(feature.EtcdLearnerMode) && controlPlane.IsEtcdManaged() {
+		workloadCluster.PromoteEtcdLearners(ctx, controlPlane)
+	}
+
 	// Patch machines with the updated conditions.
 	if err := controlPlane.PatchMachines(ctx); err != nil {
 		return ctrl.Result{}, err
@@ -576,6 +606,81 @@ func (r *KubeadmControlPlaneReconciler) reconcileEtcdMembers(ctx context.Context
 	return ctrl.Result{}, nil
 }
 
+// reconcileCertificateExpiries records the expiry of the certificates of each machine as an annotation on its KubeadmConfig,
+// so the Machine controller can surface it in the Machine status.
+// NOTE: The expiry is read only once, given that certificates are not renewed on existing machines; machines are replaced instead.
+func (r *KubeadmControlPlaneReconciler) reconcileCertificateExpiries(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "cluster", controlPlane.Cluster.Name)
+
+	// Return if there are no KCP-owned control-plane machines.
+	if controlPlane.Machines.Len() == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	// Return if KCP is not yet initialized (no API server to contact for checking certificate expiration).
+	if !controlPlane.KCP.Status.Initialized {
+		return ctrl.Result{}, nil
+	}
+
+	// Ignore machines which are being deleted.
+	machines := controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp))
+
+	var workloadCluster internal.WorkloadCluster
+	for _, m := range machines {
+		log := log.WithValues("machine", m.Name)
+
+		kubeadmConfig, ok := controlPlane.KubeadmConfig(m.Name)
+		if !ok {
+			// Skip if the Machine doesn't have a KubeadmConfig.
+			continue
+		}
+
+		if _, ok := kubeadmConfig.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation]; ok {
+			// Skip if the KubeadmConfig already has the certificates expiry date annotation.
+			continue
+		}
+
+		// Skip if the Machine doesn't have a Node.
+		if m.Status.NodeRef == nil {
+			continue
+		}
+		nodeName := m.Status.NodeRef.Name
+
+		// Get the workload cluster client only once, when there is at least one machine to check.
+		if workloadCluster == nil {
+			var err error
+			workloadCluster, err = r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(controlPlane.Cluster))
+			if err != nil {
+				return ctrl.Result{}, errors.Wrap(err, "failed to reconcile certificate expiries: cannot get remote client to workload cluster")
+			}
+		}
+
+		log.V(3).Info("Reconciling certificate expiry")
+		certificateExpiry, err := workloadCluster.GetAPIServerCertificateExpiry(ctx, kubeadmConfig, nodeName)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificate expiry for Machine/%s", m.Name)
+		}
+		expiry := certificateExpiry.Format(time.RFC3339)
+
+		log.V(2).Info("Setting certificate expiry", "expiry", expiry)
+		patchHelper, err := patch.NewHelper(kubeadmConfig, r.Client)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificate expiry for Machine/%s", m.Name)
+		}
+
+		if kubeadmConfig.Annotations == nil {
+			kubeadmConfig.Annotations = map[string]string{}
+		}
+		kubeadmConfig.Annotations[clusterv1.MachineCertificatesExpiryDateAnnotation] = expiry
+
+		if err := patchHelper.Patch(ctx, kubeadmConfig); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificate expiry for Machine/%s", m.Name)
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func (r *KubeadmControlPlaneReconciler) adoptMachines(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane, machines collections.Machines, cluster *clusterv1.Cluster) error {
 	// We do an uncached full quorum read against the KCP to avoid re-adopting Machines the garbage collector just intentionally orphaned
 	// See https://github.com/kubernetes/kubernetes/issues/42639
@@ -1373,6 +1373,215 @@ func TestKubeadmControlPlaneReconciler_reconcileDelete(t *testing.T) {
 
 // test utils.
 
+func TestKubeadmControlPlaneReconciler_reconcileCertificateExpiries(t *testing.T) {
+	g := NewWithT(t)
+
+	preExistingExpiry := time.Now().Add(5 * 24 * time.Hour)
+	detectedExpiry := time.Now().Add(25 * 24 * time.Hour)
+
+	cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+	kcp := &controlplanev1.KubeadmControlPlane{
+		Status: controlplanev1.KubeadmControlPlaneStatus{Initialized: true},
+	}
+
+	machineWithoutExpiryAnnotation := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutExpiryAnnotation",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithoutExpiryAnnotation-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       "machineWithoutExpiryAnnotation-bootstrap",
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithoutExpiryAnnotation",
+			},
+		},
+	}
+	machineWithoutExpiryAnnotationKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutExpiryAnnotation-bootstrap",
+			Namespace: cluster.Namespace,
+		},
+	}
+
+	machineWithExpiryAnnotation := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithExpiryAnnotation",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithExpiryAnnotation-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       "machineWithExpiryAnnotation-bootstrap",
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithExpiryAnnotation",
+			},
+		},
+	}
+	machineWithExpiryAnnotationKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithExpiryAnnotation-bootstrap",
+			Namespace: cluster.Namespace,
+			Annotations: map[string]string{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: preExistingExpiry.Format(time.RFC3339),
+			},
+		},
+	}
+
+	machineWithDeletionTimestamp := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "machineWithDeletionTimestamp",
+			Namespace:         cluster.Namespace,
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithDeletionTimestamp-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       "machineWithDeletionTimestamp-bootstrap",
+				},
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithDeletionTimestamp",
+			},
+		},
+	}
+	machineWithDeletionTimestampKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithDeletionTimestamp-bootstrap",
+			Namespace: cluster.Namespace,
+		},
+	}
+
+	machineWithoutNodeRef := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutNodeRef",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithoutNodeRef-infra",
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       "machineWithoutNodeRef-bootstrap",
+				},
+			},
+		},
+	}
+	machineWithoutNodeRefKubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutNodeRef-bootstrap",
+			Namespace: cluster.Namespace,
+		},
+	}
+
+	machineWithoutKubeadmConfig := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machineWithoutKubeadmConfig",
+			Namespace: cluster.Namespace,
+		},
+		Spec: clusterv1.MachineSpec{
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       "GenericInfrastructureMachine",
+				APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+				Name:       "machineWithoutKubeadmConfig-infra",
+			},
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{
+				Name: "machineWithoutKubeadmConfig",
+			},
+		},
+	}
+
+	fakeClient := newFakeClient(
+		machineWithoutExpiryAnnotationKubeadmConfig.DeepCopy(),
+		machineWithExpiryAnnotationKubeadmConfig.DeepCopy(),
+		machineWithDeletionTimestampKubeadmConfig.DeepCopy(),
+		machineWithoutNodeRefKubeadmConfig.DeepCopy(),
+	)
+	controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.FromMachines(
+		machineWithoutExpiryAnnotation,
+		machineWithExpiryAnnotation,
+		machineWithDeletionTimestamp,
+		machineWithoutNodeRef,
+		machineWithoutKubeadmConfig,
+	))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &KubeadmControlPlaneReconciler{
+		Client: fakeClient,
+		managementCluster: &fakeManagementCluster{
+			Workload: fakeWorkloadCluster{
+				APIServerCertificateExpiry: &detectedExpiry,
+			},
+		},
+	}
+
+	_, err = r.reconcileCertificateExpiries(ctx, controlPlane)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	// Verify machineWithoutExpiryAnnotation has detectedExpiry.
+	actualKubeadmConfig := bootstrapv1.KubeadmConfig{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithoutExpiryAnnotationKubeadmConfig), &actualKubeadmConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actualKubeadmConfig.Annotations).To(HaveKeyWithValue(clusterv1.MachineCertificatesExpiryDateAnnotation, detectedExpiry.Format(time.RFC3339)))
+
+	// Verify machineWithExpiryAnnotation has still preExistingExpiry.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithExpiryAnnotationKubeadmConfig), &actualKubeadmConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actualKubeadmConfig.Annotations).To(HaveKeyWithValue(clusterv1.MachineCertificatesExpiryDateAnnotation, preExistingExpiry.Format(time.RFC3339)))
+
+	// Verify machineWithDeletionTimestamp has still no expiry annotation.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithDeletionTimestampKubeadmConfig), &actualKubeadmConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actualKubeadmConfig.Annotations).ToNot(HaveKey(clusterv1.MachineCertificatesExpiryDateAnnotation))
+
+	// Verify machineWithoutNodeRef has still no expiry annotation.
+	actualKubeadmConfig = bootstrapv1.KubeadmConfig{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(machineWithoutNodeRefKubeadmConfig), &actualKubeadmConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(actualKubeadmConfig.Annotations).ToNot(HaveKey(clusterv1.MachineCertificatesExpiryDateAnnotation))
+}
+
 func newFakeClient(initObjs ...client.Object) client.Client {
 	return &fakeClient{
 		startTime: time.Now(),
@@ -0,0 +1,167 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+const (
+	// etcdQuotaBackendBytesArg is the etcd argument defining the size of the database above which etcd raises a NOSPACE alarm.
+	etcdQuotaBackendBytesArg = "quota-backend-bytes"
+
+	// defaultEtcdQuotaBackendBytes is the value etcd uses when the quota-backend-bytes argument is not set.
+	defaultEtcdQuotaBackendBytes = 2 * 1024 * 1024 * 1024
+)
+
+// reconcileEtcdDefragmentation defragments the etcd members with too much space not in use, one at a time,
+// and disarms the NOSPACE alarms of the members with enough space available.
+// NOTE: A member can't serve any request while it is being defragmented, so this must be called only
+// when the control plane is stable, i.e. it is not rolling out nor scaling.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdDefragmentation(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx, "cluster", controlPlane.Cluster.Name)
+
+	// If defragmentation is not enabled or etcd is not managed by KCP this is a no-op.
+	if controlPlane.KCP.Spec.EtcdDefragmentation == nil || !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	// If there are machines being deleted, wait for the deletion to complete.
+	if controlPlane.HasDeletingMachine() {
+		return ctrl.Result{}, nil
+	}
+
+	input, err := etcdDefragmentationInput(controlPlane.KCP)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Collect all the node names, and when the corresponding etcd members have been last defragmented.
+	nodeNames := []string{}
+	machinesByNodeName := map[string]*clusterv1.Machine{}
+	for _, machine := range controlPlane.Machines {
+		if machine.Status.NodeRef == nil {
+			// If there are provisioning machines (machines without a node yet), return.
+			return ctrl.Result{}, nil
+		}
+		nodeName := machine.Status.NodeRef.Name
+		nodeNames = append(nodeNames, nodeName)
+		machinesByNodeName[nodeName] = machine
+
+		if defragmentedAt, ok := machine.Annotations[controlplanev1.EtcdDefragmentedAtAnnotation]; ok {
+			lastDefragmentation, err := time.Parse(time.RFC3339, defragmentedAt)
+			if err != nil {
+				// NOTE: An invalid annotation is ignored, and it is replaced when the member is defragmented again.
+				log.Error(err, "Failed to parse etcd defragmentation time annotation", "machine", machine.Name)
+				continue
+			}
+			input.LastDefragmentations[nodeName] = lastDefragmentation
+		}
+	}
+
+	result, err := workloadCluster.DefragmentEtcd(ctx, nodeNames, input)
+	if result != nil {
+		if result.DefragmentedMember != "" {
+			log.Info("Etcd member defragmented", "member", result.DefragmentedMember)
+			r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "EtcdMemberDefragmented", "Defragmented etcd member %s", result.DefragmentedMember)
+
+			if err := r.setEtcdDefragmentedAt(ctx, machinesByNodeName[result.DefragmentedMember]); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		if len(result.DisarmedMembers) > 0 {
+			log.Info("Etcd NOSPACE alarms disarmed", "members", result.DisarmedMembers)
+			r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, "EtcdAlarmDisarmed", "Disarmed NOSPACE alarm for etcd members %v", result.DisarmedMembers)
+		}
+	}
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed attempt to defragment etcd members")
+	}
+
+	// If a member has been defragmented, check again soon if there are other members to be defragmented.
+	if result.DefragmentedMember != "" {
+		return ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// setEtcdDefragmentedAt records on the machine that its etcd member has been defragmented now.
+func (r *KubeadmControlPlaneReconciler) setEtcdDefragmentedAt(ctx context.Context, machine *clusterv1.Machine) error {
+	if machine == nil {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		return errors.Wrapf(err, "failed to record etcd defragmentation for Machine/%s", machine.Name)
+	}
+	if machine.Annotations == nil {
+		machine.Annotations = map[string]string{}
+	}
+	machine.Annotations[controlplanev1.EtcdDefragmentedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := patchHelper.Patch(ctx, machine); err != nil {
+		return errors.Wrapf(err, "failed to record etcd defragmentation for Machine/%s", machine.Name)
+	}
+	return nil
+}
+
+// etcdDefragmentationInput returns the thresholds for etcd defragmentation defined in the KubeadmControlPlane,
+// falling back to the defaults for the values not set.
+func etcdDefragmentationInput(kcp *controlplanev1.KubeadmControlPlane) (internal.EtcdDefragmentationInput, error) {
+	input := internal.EtcdDefragmentationInput{
+		MinDBSize:               controlplanev1.DefaultEtcdDefragmentationMinDBSize,
+		MinFragmentationPercent: controlplanev1.DefaultEtcdDefragmentationMinFragmentationPercent,
+		QuotaBackendBytes:       defaultEtcdQuotaBackendBytes,
+		MinInterval:             etcdDefragmentationMinInterval,
+		LastDefragmentations:    map[string]time.Time{},
+	}
+
+	if spec := kcp.Spec.EtcdDefragmentation; spec != nil {
+		if spec.MinDBSize != nil {
+			input.MinDBSize = spec.MinDBSize.Value()
+		}
+		if spec.MinFragmentationPercent != nil {
+			input.MinFragmentationPercent = *spec.MinFragmentationPercent
+		}
+	}
+
+	if clusterConfiguration := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration; clusterConfiguration != nil && clusterConfiguration.Etcd.Local != nil {
+		if quota, ok := clusterConfiguration.Etcd.Local.ExtraArgs[etcdQuotaBackendBytesArg]; ok {
+			quotaBackendBytes, err := strconv.ParseInt(quota, 10, 64)
+			if err != nil {
+				return input, errors.Wrapf(err, "failed to parse etcd %s argument %q", etcdQuotaBackendBytesArg, quota)
+			}
+			// NOTE: etcd uses the default quota when the argument is set to 0.
+			if quotaBackendBytes > 0 {
+				input.QuotaBackendBytes = quotaBackendBytes
+			}
+		}
+	}
+
+	return input, nil
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
+)
+
+func TestReconcileEtcdDefragmentation(t *testing.T) {
+	machineWithNode := func(name string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: metav1.NamespaceDefault},
+			Status: clusterv1.MachineStatus{
+				NodeRef: &corev1.ObjectReference{Name: name},
+			},
+		}
+	}
+	deletingMachine := machineWithNode("m3")
+	now := metav1.Now()
+	deletingMachine.DeletionTimestamp = &now
+	defragmentedAt := time.Now().Add(-10 * time.Minute).UTC().Truncate(time.Second)
+	defragmentedMachine := machineWithNode("m1")
+	defragmentedMachine.Annotations = map[string]string{
+		controlplanev1.EtcdDefragmentedAtAnnotation: defragmentedAt.Format(time.RFC3339),
+	}
+	invalidDefragmentedMachine := machineWithNode("m1")
+	invalidDefragmentedMachine.Annotations = map[string]string{
+		controlplanev1.EtcdDefragmentedAtAnnotation: "invalid",
+	}
+
+	tests := []struct {
+		name                        string
+		defrag                      *controlplanev1.EtcdDefragmentation
+		externalEtcd                bool
+		machines                    []*clusterv1.Machine
+		workload                    fakeWorkloadCluster
+		expectErr                   bool
+		expectResult                ctrl.Result
+		expectedEvents              []string
+		expectLastDefragmentations  map[string]time.Time
+		expectDefragmentedAtMachine string
+	}{
+		{
+			name:     "does nothing if etcd defragmentation is not enabled",
+			machines: []*clusterv1.Machine{machineWithNode("m1")},
+			workload: fakeWorkloadCluster{EtcdDefragmentationErr: errors.New("should not be called")},
+		},
+		{
+			name:         "does nothing if etcd is not managed by KCP",
+			defrag:       &controlplanev1.EtcdDefragmentation{},
+			externalEtcd: true,
+			machines:     []*clusterv1.Machine{machineWithNode("m1")},
+			workload:     fakeWorkloadCluster{EtcdDefragmentationErr: errors.New("should not be called")},
+		},
+		{
+			name:     "does nothing if there are machines without a node",
+			defrag:   &controlplanev1.EtcdDefragmentation{},
+			machines: []*clusterv1.Machine{machineWithNode("m1"), {ObjectMeta: metav1.ObjectMeta{Name: "m2"}}},
+			workload: fakeWorkloadCluster{EtcdDefragmentationErr: errors.New("should not be called")},
+		},
+		{
+			name:     "does nothing if there are machines being deleted",
+			defrag:   &controlplanev1.EtcdDefragmentation{},
+			machines: []*clusterv1.Machine{machineWithNode("m1"), deletingMachine},
+			workload: fakeWorkloadCluster{EtcdDefragmentationErr: errors.New("should not be called")},
+		},
+		{
+			name:     "requeues after an etcd member has been defragmented",
+			defrag:   &controlplanev1.EtcdDefragmentation{},
+			machines: []*clusterv1.Machine{machineWithNode("m1"), machineWithNode("m2")},
+			workload: fakeWorkloadCluster{EtcdDefragmentationResult: &internal.EtcdDefragmentationResult{
+				DefragmentedMember: "m2",
+				DisarmedMembers:    []string{"m2"},
+			}},
+			expectResult: ctrl.Result{RequeueAfter: etcdDefragmentationRequeueAfter},
+			expectedEvents: []string{
+				"Normal EtcdMemberDefragmented Defragmented etcd member m2",
+				"Normal EtcdAlarmDisarmed Disarmed NOSPACE alarm for etcd members [m2]",
+			},
+			expectLastDefragmentations:  map[string]time.Time{},
+			expectDefragmentedAtMachine: "m2",
+		},
+		{
+			name:                       "passes the last etcd defragmentation times to the workload cluster",
+			defrag:                     &controlplanev1.EtcdDefragmentation{},
+			machines:                   []*clusterv1.Machine{defragmentedMachine, machineWithNode("m2")},
+			workload:                   fakeWorkloadCluster{},
+			expectLastDefragmentations: map[string]time.Time{"m1": defragmentedAt},
+		},
+		{
+			name:                       "ignores invalid etcd defragmentation time annotations",
+			defrag:                     &controlplanev1.EtcdDefragmentation{},
+			machines:                   []*clusterv1.Machine{invalidDefragmentedMachine, machineWithNode("m2")},
+			workload:                   fakeWorkloadCluster{},
+			expectLastDefragmentations: map[string]time.Time{},
+		},
+		{
+			name:     "does not requeue if no etcd member has been defragmented",
+			defrag:   &controlplanev1.EtcdDefragmentation{},
+			machines: []*clusterv1.Machine{machineWithNode("m1"), machineWithNode("m2")},
+			workload: fakeWorkloadCluster{},
+		},
+		{
+			name:      "returns error if the etcd defragmentation fails",
+			defrag:    &controlplanev1.EtcdDefragmentation{},
+			machines:  []*clusterv1.Machine{machineWithNode("m1"), machineWithNode("m2")},
+			workload:  fakeWorkloadCluster{EtcdDefragmentationErr: errors.New("failed")},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdDefragmentation: tt.defrag,
+				},
+			}
+			if tt.externalEtcd {
+				kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+				}
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP:      kcp,
+				Cluster:  &clusterv1.Cluster{},
+				Machines: collections.FromMachines(tt.machines...),
+			}
+			objs := []client.Object{}
+			for _, m := range tt.machines {
+				objs = append(objs, m.DeepCopy())
+			}
+			fakeClient := newFakeClient(objs...)
+			recorder := record.NewFakeRecorder(32)
+			r := &KubeadmControlPlaneReconciler{
+				Client:   fakeClient,
+				recorder: recorder,
+			}
+			input := &internal.EtcdDefragmentationInput{}
+			tt.workload.EtcdDefragmentationInput = input
+
+			result, err := r.reconcileEtcdDefragmentation(ctx, controlPlane, tt.workload)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(Equal(tt.expectResult))
+			if tt.expectLastDefragmentations != nil {
+				g.Expect(input.MinInterval).To(Equal(etcdDefragmentationMinInterval))
+				g.Expect(input.LastDefragmentations).To(Equal(tt.expectLastDefragmentations))
+			}
+			for _, m := range tt.machines {
+				gotMachine := &clusterv1.Machine{}
+				g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(m), gotMachine)).To(Succeed())
+				if m.Name == tt.expectDefragmentedAtMachine {
+					g.Expect(gotMachine.Annotations).To(HaveKey(controlplanev1.EtcdDefragmentedAtAnnotation))
+					defragmentedAt, err := time.Parse(time.RFC3339, gotMachine.Annotations[controlplanev1.EtcdDefragmentedAtAnnotation])
+					g.Expect(err).ToNot(HaveOccurred())
+					g.Expect(defragmentedAt).To(BeTemporally("~", time.Now(), time.Minute))
+					continue
+				}
+				g.Expect(gotMachine.Annotations).To(Equal(m.Annotations))
+			}
+
+			close(recorder.Events)
+			events := []string{}
+			for e := range recorder.Events {
+				events = append(events, e)
+			}
+			g.Expect(events).To(ConsistOf(tt.expectedEvents))
+		})
+	}
+}
+
+func TestEtcdDefragmentationInput(t *testing.T) {
+	minDBSize := resource.MustParse("1Gi")
+
+	tests := []struct {
+		name      string
+		kcp       *controlplanev1.KubeadmControlPlane
+		expected  internal.EtcdDefragmentationInput
+		expectErr bool
+	}{
+		{
+			name: "uses defaults if thresholds are not set",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdDefragmentation: &controlplanev1.EtcdDefragmentation{},
+				},
+			},
+			expected: internal.EtcdDefragmentationInput{
+				MinDBSize:               controlplanev1.DefaultEtcdDefragmentationMinDBSize,
+				MinFragmentationPercent: controlplanev1.DefaultEtcdDefragmentationMinFragmentationPercent,
+				QuotaBackendBytes:       defaultEtcdQuotaBackendBytes,
+				MinInterval:             etcdDefragmentationMinInterval,
+				LastDefragmentations:    map[string]time.Time{},
+			},
+		},
+		{
+			name: "uses thresholds from the KCP and the etcd quota from the ClusterConfiguration",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdDefragmentation: &controlplanev1.EtcdDefragmentation{
+						MinDBSize:               &minDBSize,
+						MinFragmentationPercent: pointer.Int32Ptr(20),
+					},
+					KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+						ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+							Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{
+								ExtraArgs: map[string]string{"quota-backend-bytes": "8589934592"},
+							}},
+						},
+					},
+				},
+			},
+			expected: internal.EtcdDefragmentationInput{
+				MinDBSize:               1024 * 1024 * 1024,
+				MinFragmentationPercent: 20,
+				QuotaBackendBytes:       8 * 1024 * 1024 * 1024,
+				MinInterval:             etcdDefragmentationMinInterval,
+				LastDefragmentations:    map[string]time.Time{},
+			},
+		},
+		{
+			name: "returns error if the etcd quota is not a number",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdDefragmentation: &controlplanev1.EtcdDefragmentation{},
+					KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+						ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+							Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{
+								ExtraArgs: map[string]string{"quota-backend-bytes": "8Gi"},
+							}},
+						},
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			input, err := etcdDefragmentationInput(tt.kcp)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(input).To(Equal(tt.expected))
+		})
+	}
+}
@@ -0,0 +1,521 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+const (
+	// etcdSnapshotRestorePath is the path the etcd snapshot is written to on the first control plane machine
+	// when restoring an EtcdSnapshot.
+	etcdSnapshotRestorePath = "/etc/kubernetes/etcd-snapshot.db"
+
+	// etcdSnapshotRestoreCredentialsDir is the directory the credentials to fetch the etcd snapshot from the management
+	// cluster are written to on the first control plane machine when restoring an EtcdSnapshot.
+	etcdSnapshotRestoreCredentialsDir = "/etc/kubernetes/etcd-snapshot-restore"
+
+	// defaultEtcdDataDir is the default data directory of the etcd member managed by kubeadm.
+	defaultEtcdDataDir = "/var/lib/etcd"
+
+	// maxEtcdSnapshotSecrets is the maximum number of Secrets a compressed etcd snapshot is split across;
+	// together with etcdSnapshotChunkSize it defines the maximum size of a snapshot, i.e. 128MiB.
+	maxEtcdSnapshotSecrets = 128
+)
+
+// etcdSnapshotChunkSize is the size of the chunks a compressed etcd snapshot is split into, one for each Secret.
+// NOTE: This is a variable so it can be changed in tests.
+var etcdSnapshotChunkSize = corev1.MaxSecretSize
+
+// EtcdSnapshotReconciler reconciles an EtcdSnapshot object.
+type EtcdSnapshotReconciler struct {
+	Client          client.Client
+	Tracker         *remote.ClusterCacheTracker
+	EtcdDialTimeout time.Duration
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	managementCluster internal.ManagementCluster
+}
+
+func (r *EtcdSnapshotReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&controlplanev1.EtcdSnapshot{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	if r.managementCluster == nil {
+		if r.Tracker == nil {
+			return errors.New("cluster cache tracker is nil, cannot create the internal management cluster resource")
+		}
+		r.managementCluster = &internal.Management{
+			Client:          r.Client,
+			Tracker:         r.Tracker,
+			EtcdDialTimeout: r.EtcdDialTimeout,
+		}
+	}
+
+	return nil
+}
+
+func (r *EtcdSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the EtcdSnapshot instance.
+	snapshot := &controlplanev1.EtcdSnapshot{}
+	if err := r.Client.Get(ctx, req.NamespacedName, snapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// A snapshot is taken only once; snapshots that can't be taken are not retried either.
+	if snapshot.Status.Ready || conditions.GetReason(snapshot, controlplanev1.EtcdSnapshotTakenCondition) == controlplanev1.EtcdSnapshotUnsupportedReason {
+		return ctrl.Result{}, nil
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetClusterByName(ctx, r.Client, snapshot.Namespace, snapshot.Spec.ClusterName)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	log = log.WithValues("cluster", cluster.Name)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	if annotations.IsPaused(cluster, snapshot) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(snapshot, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the EtcdSnapshot object and status after each reconciliation.
+		if err := patchEtcdSnapshot(ctx, patchHelper, snapshot); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// Label the snapshot with the name of the Cluster, so snapshots can be easily selected.
+	if snapshot.Labels == nil {
+		snapshot.Labels = map[string]string{}
+	}
+	snapshot.Labels[clusterv1.ClusterLabelName] = cluster.Name
+
+	return r.reconcile(ctx, cluster, snapshot)
+}
+
+// reconcile takes the etcd snapshot and stores it in the data Secrets.
+func (r *EtcdSnapshotReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, snapshot *controlplanev1.EtcdSnapshot) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if cluster.Spec.ControlPlaneRef == nil || cluster.Spec.ControlPlaneRef.Kind != "KubeadmControlPlane" {
+		conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.EtcdSnapshotUnsupportedReason, clusterv1.ConditionSeverityError,
+			"Cluster %s does not have a KubeadmControlPlane", cluster.Name)
+		return ctrl.Result{}, nil
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.ControlPlaneRef.Name}, kcp); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get KubeadmControlPlane for Cluster %s", cluster.Name)
+	}
+
+	if !(&internal.ControlPlane{KCP: kcp}).IsEtcdManaged() {
+		conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.EtcdSnapshotUnsupportedReason, clusterv1.ConditionSeverityError,
+			"KubeadmControlPlane %s uses an external etcd cluster", kcp.Name)
+		return ctrl.Result{}, nil
+	}
+
+	if !kcp.Status.Initialized {
+		log.Info("Waiting for the control plane to be initialized")
+		conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.WaitingForControlPlaneInitializedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{RequeueAfter: 20 * time.Second}, nil
+	}
+
+	workloadCluster, err := r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.EtcdSnapshotFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, errors.Wrap(err, "cannot get remote client to workload cluster")
+	}
+
+	data, size, err := takeEtcdSnapshot(ctx, workloadCluster)
+	if err != nil {
+		if errors.Is(err, errEtcdSnapshotTooLarge) {
+			conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.EtcdSnapshotUnsupportedReason, clusterv1.ConditionSeverityError,
+				"The compressed snapshot exceeds the maximum size of %d bytes", maxEtcdSnapshotSecrets*etcdSnapshotChunkSize)
+			return ctrl.Result{}, nil
+		}
+		conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.EtcdSnapshotFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Split the snapshot across as many Secrets as required, given that the size of each Secret is limited.
+	secretNames := []string{}
+	for i := 0; i*etcdSnapshotChunkSize < len(data); i++ {
+		chunk := data[i*etcdSnapshotChunkSize:]
+		if len(chunk) > etcdSnapshotChunkSize {
+			chunk = chunk[:etcdSnapshotChunkSize]
+		}
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%d", snapshot.Name, i),
+				Namespace: snapshot.Namespace,
+			},
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+			if secret.Labels == nil {
+				secret.Labels = map[string]string{}
+			}
+			secret.Labels[clusterv1.ClusterLabelName] = cluster.Name
+			secret.Type = clusterv1.ClusterSecretType
+			secret.Data = map[string][]byte{controlplanev1.EtcdSnapshotDataKey: chunk}
+			return controllerutil.SetControllerReference(snapshot, secret, r.Client.Scheme())
+		}); err != nil {
+			conditions.MarkFalse(snapshot, controlplanev1.EtcdSnapshotTakenCondition, controlplanev1.EtcdSnapshotFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return ctrl.Result{}, errors.Wrapf(err, "failed to store etcd snapshot in Secret %s", secret.Name)
+		}
+		secretNames = append(secretNames, secret.Name)
+	}
+
+	log.Info("Etcd snapshot stored", "Secrets", secretNames, "size", size)
+	now := metav1.Now()
+	snapshot.Status.Ready = true
+	snapshot.Status.DataSecretNames = secretNames
+	snapshot.Status.Size = size
+	snapshot.Status.CompletionTime = &now
+	conditions.MarkTrue(snapshot, controlplanev1.EtcdSnapshotTakenCondition)
+	return ctrl.Result{}, nil
+}
+
+// errEtcdSnapshotTooLarge is returned when the compressed etcd snapshot exceeds the maximum size of a snapshot.
+var errEtcdSnapshotTooLarge = errors.New("etcd snapshot is too large")
+
+// limitedBuffer is a bytes.Buffer failing writes when it grows over limit bytes,
+// so a snapshot exceeding the maximum size of a snapshot is not read entirely in memory.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errEtcdSnapshotTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// takeEtcdSnapshot takes a snapshot of the etcd cluster of the workload cluster, and returns it gzip compressed,
+// together with the size of the snapshot before compression.
+func takeEtcdSnapshot(ctx context.Context, workloadCluster internal.WorkloadCluster) ([]byte, int64, error) {
+	data := &limitedBuffer{limit: maxEtcdSnapshotSecrets * etcdSnapshotChunkSize}
+	compressor := gzip.NewWriter(data)
+
+	size, err := workloadCluster.SnapshotEtcd(ctx, compressor)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to take etcd snapshot")
+	}
+	if err := compressor.Close(); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to compress etcd snapshot")
+	}
+	return data.Bytes(), size, nil
+}
+
+func patchEtcdSnapshot(ctx context.Context, patchHelper *patch.Helper, snapshot *controlplanev1.EtcdSnapshot) error {
+	// Always update the readyCondition by summarizing the state of other conditions.
+	conditions.SetSummary(snapshot,
+		conditions.WithConditions(
+			controlplanev1.EtcdSnapshotTakenCondition,
+		),
+	)
+
+	// Patch the object, ignoring conflicts on the conditions owned by this controller.
+	return patchHelper.Patch(
+		ctx,
+		snapshot,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			clusterv1.ReadyCondition,
+			controlplanev1.EtcdSnapshotTakenCondition,
+		}},
+	)
+}
+
+// restoreEtcdSnapshot changes the bootstrap spec of the first control plane machine so the EtcdSnapshot referenced
+// by the RestoreEtcdSnapshotAnnotation, if any, is restored before running kubeadm init.
+func (r *KubeadmControlPlaneReconciler) restoreEtcdSnapshot(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, bootstrapSpec *bootstrapv1.KubeadmConfigSpec) error {
+	name, ok := kcp.Annotations[controlplanev1.RestoreEtcdSnapshotAnnotation]
+	if !ok {
+		return nil
+	}
+
+	snapshot := &controlplanev1.EtcdSnapshot{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: name}, snapshot); err != nil {
+		return errors.Wrapf(err, "failed to get EtcdSnapshot %s to restore", name)
+	}
+	if snapshot.Spec.ClusterName != cluster.Name {
+		return errors.Errorf("EtcdSnapshot %s has been taken from Cluster %s, it can't be restored to Cluster %s", name, snapshot.Spec.ClusterName, cluster.Name)
+	}
+	if !snapshot.Status.Ready || len(snapshot.Status.DataSecretNames) == 0 {
+		return errors.Errorf("EtcdSnapshot %s is not ready to be restored", name)
+	}
+	if bootstrapSpec.ClusterConfiguration != nil && bootstrapSpec.ClusterConfiguration.Etcd.External != nil {
+		return errors.Errorf("EtcdSnapshot %s can't be restored to an external etcd cluster", name)
+	}
+	// NOTE: The control plane of an existing Cluster is never initialized again, even if all the control plane
+	// machines have been lost; the Cluster and the KubeadmControlPlane must be recreated to restore a snapshot.
+	if kcp.Status.Initialized {
+		return errors.Errorf("EtcdSnapshot %s can't be restored, KubeadmControlPlane %s has already been initialized", name, kcp.Name)
+	}
+	if r.ManagementClusterEndpoint == "" {
+		return errors.Errorf("EtcdSnapshot %s can't be restored, the management cluster endpoint the machine fetches the snapshot from is not set", name)
+	}
+
+	if err := r.createEtcdSnapshotRestoreCredentials(ctx, cluster, kcp, snapshot.Status.DataSecretNames); err != nil {
+		return errors.Wrapf(err, "failed to create the credentials to fetch EtcdSnapshot %s", name)
+	}
+
+	kubeconfig, err := etcdSnapshotRestoreKubeconfig(r.ManagementClusterEndpoint)
+	if err != nil {
+		return errors.Wrapf(err, "failed to generate the kubeconfig to fetch EtcdSnapshot %s", name)
+	}
+	addEtcdSnapshotRestore(bootstrapSpec, kcp.Namespace, snapshot.Status.DataSecretNames, etcdSnapshotRestoreCredentialsName(kcp), kubeconfig)
+
+	// Record the restore being in progress, so the credentials are cleaned up once the control plane is initialized.
+	conditions.MarkFalse(kcp, controlplanev1.EtcdSnapshotRestoredCondition, controlplanev1.EtcdSnapshotRestoringReason, clusterv1.ConditionSeverityInfo,
+		"Restoring EtcdSnapshot %s", name)
+	return nil
+}
+
+// etcdSnapshotRestoreCredentialsName returns the name of the ServiceAccount, Role, RoleBinding and token Secret
+// used by the first control plane machine of a KubeadmControlPlane to fetch the EtcdSnapshot being restored.
+func etcdSnapshotRestoreCredentialsName(kcp *controlplanev1.KubeadmControlPlane) string {
+	return fmt.Sprintf("%s-etcd-snapshot-restore", kcp.Name)
+}
+
+// createEtcdSnapshotRestoreCredentials creates a ServiceAccount allowed to get only the data Secrets of the EtcdSnapshot
+// being restored, and a token Secret for it; the token is populated by the management cluster, and it is written
+// to the first control plane machine, so the machine can fetch the snapshot at boot.
+// NOTE: The credentials are owned by the KubeadmControlPlane, and they are deleted once the control plane is initialized.
+func (r *KubeadmControlPlaneReconciler) createEtcdSnapshotRestoreCredentials(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane, dataSecretNames []string) error {
+	name := etcdSnapshotRestoreCredentialsName(kcp)
+	objectMeta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       kcp.Namespace,
+			Labels:          map[string]string{clusterv1.ClusterLabelName: cluster.Name},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane"))},
+		}
+	}
+
+	serviceAccount := &corev1.ServiceAccount{ObjectMeta: objectMeta()}
+	role := &rbacv1.Role{
+		ObjectMeta: objectMeta(),
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups:     []string{""},
+			Resources:     []string{"secrets"},
+			Verbs:         []string{"get"},
+			ResourceNames: dataSecretNames,
+		}},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: objectMeta(),
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      name,
+			Namespace: kcp.Namespace,
+		}},
+	}
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: objectMeta(),
+		Type:       corev1.SecretTypeServiceAccountToken,
+	}
+	tokenSecret.Annotations = map[string]string{corev1.ServiceAccountNameKey: name}
+
+	for _, obj := range []client.Object{serviceAccount, role, roleBinding, tokenSecret} {
+		if err := r.Client.Create(ctx, obj); err != nil {
+			if !apierrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "failed to create %T %s", obj, name)
+			}
+			// The credentials have been created by a previous attempt to initialize the control plane,
+			// possibly to restore another snapshot.
+			if err := r.Client.Patch(ctx, obj, client.Merge); err != nil {
+				return errors.Wrapf(err, "failed to patch %T %s", obj, name)
+			}
+		}
+	}
+	return nil
+}
+
+// deleteEtcdSnapshotRestoreCredentials deletes the credentials used by the first control plane machine to fetch
+// the EtcdSnapshot being restored.
+func (r *KubeadmControlPlaneReconciler) deleteEtcdSnapshotRestoreCredentials(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) error {
+	objectMeta := metav1.ObjectMeta{
+		Name:      etcdSnapshotRestoreCredentialsName(kcp),
+		Namespace: kcp.Namespace,
+	}
+	for _, obj := range []client.Object{&corev1.Secret{ObjectMeta: objectMeta}, &rbacv1.RoleBinding{ObjectMeta: objectMeta}, &rbacv1.Role{ObjectMeta: objectMeta}, &corev1.ServiceAccount{ObjectMeta: objectMeta}} {
+		if err := r.Client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete %T %s", obj, objectMeta.Name)
+		}
+	}
+	return nil
+}
+
+// reconcileEtcdSnapshotRestore deletes the credentials used to fetch the EtcdSnapshot being restored, and removes
+// the RestoreEtcdSnapshotAnnotation, once a control plane initialized from the snapshot is initialized; this prevents
+// the snapshot from being restored again if the Cluster is recreated later.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdSnapshotRestore(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	kcp := controlPlane.KCP
+	if !kcp.Status.Initialized || conditions.GetReason(kcp, controlplanev1.EtcdSnapshotRestoredCondition) != controlplanev1.EtcdSnapshotRestoringReason {
+		return nil
+	}
+
+	if err := r.deleteEtcdSnapshotRestoreCredentials(ctx, kcp); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Etcd snapshot restored", "EtcdSnapshot", kcp.Annotations[controlplanev1.RestoreEtcdSnapshotAnnotation])
+	conditions.MarkTrue(kcp, controlplanev1.EtcdSnapshotRestoredCondition)
+	delete(kcp.Annotations, controlplanev1.RestoreEtcdSnapshotAnnotation)
+	return nil
+}
+
+// etcdSnapshotRestoreKubeconfig returns a kubeconfig for the management cluster at endpoint, authenticating with the
+// token of the ServiceAccount created by createEtcdSnapshotRestoreCredentials once written to the machine.
+func etcdSnapshotRestoreKubeconfig(endpoint string) ([]byte, error) {
+	const name = "management-cluster"
+	return clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			name: {
+				Server:               endpoint,
+				CertificateAuthority: path.Join(etcdSnapshotRestoreCredentialsDir, corev1.ServiceAccountRootCAKey),
+			},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			name: {
+				TokenFile: path.Join(etcdSnapshotRestoreCredentialsDir, corev1.ServiceAccountTokenKey),
+			},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			name: {
+				Cluster:  name,
+				AuthInfo: name,
+			},
+		},
+		CurrentContext: name,
+	})
+}
+
+// addEtcdSnapshotRestore adds to a bootstrap spec for kubeadm init the credentials to fetch the snapshot stored in the
+// dataSecretNames Secrets from the management cluster, and a command fetching and restoring it into the etcd data
+// directory before kubeadm init runs. The snapshot is fetched at boot, so its size is not limited by the size of the
+// bootstrap data.
+// NOTE: The restored etcd member is named after the hostname and advertises the address of the default route,
+// which matches the kubeadm defaults.
+func addEtcdSnapshotRestore(bootstrapSpec *bootstrapv1.KubeadmConfigSpec, namespace string, dataSecretNames []string, credentialsSecretName string, kubeconfig []byte) {
+	dataDir := defaultEtcdDataDir
+	if bootstrapSpec.ClusterConfiguration != nil && bootstrapSpec.ClusterConfiguration.Etcd.Local != nil && bootstrapSpec.ClusterConfiguration.Etcd.Local.DataDir != "" {
+		dataDir = bootstrapSpec.ClusterConfiguration.Etcd.Local.DataDir
+	}
+
+	kubeconfigPath := path.Join(etcdSnapshotRestoreCredentialsDir, "kubeconfig")
+	bootstrapSpec.Files = append(bootstrapSpec.Files,
+		bootstrapv1.File{
+			Path:        kubeconfigPath,
+			Owner:       "root:root",
+			Permissions: "0600",
+			Content:     string(kubeconfig),
+		},
+		bootstrapv1.File{
+			Path:        path.Join(etcdSnapshotRestoreCredentialsDir, corev1.ServiceAccountRootCAKey),
+			Owner:       "root:root",
+			Permissions: "0600",
+			ContentFrom: &bootstrapv1.FileSource{
+				Secret: bootstrapv1.SecretFileSource{Name: credentialsSecretName, Key: corev1.ServiceAccountRootCAKey},
+			},
+		},
+		bootstrapv1.File{
+			Path:        path.Join(etcdSnapshotRestoreCredentialsDir, corev1.ServiceAccountTokenKey),
+			Owner:       "root:root",
+			Permissions: "0600",
+			ContentFrom: &bootstrapv1.FileSource{
+				Secret: bootstrapv1.SecretFileSource{Name: credentialsSecretName, Key: corev1.ServiceAccountTokenKey},
+			},
+		},
+	)
+
+	// The chunks are fetched in order and concatenated; gunzip fails if any chunk is missing or corrupted,
+	// given that the gzip trailer has the checksum and the size of the snapshot.
+	bootstrapSpec.PreKubeadmCommands = append(bootstrapSpec.PreKubeadmCommands, fmt.Sprintf(
+		`for s in %[1]s; do kubectl --kubeconfig %[2]s --namespace %[3]s get secret "${s}" -o jsonpath='{.data.%[4]s}' | base64 -d; done | gunzip > %[5]s && `+
+			`rm -rf %[6]s && `+
+			`ETCD_NAME="$(hostname)" && ETCD_PEER_URL="https://$(ip -4 route get 1 | sed -n 's/.* src \([^ ]*\).*/\1/p'):2380" && `+
+			`etcdutl snapshot restore %[5]s --data-dir %[7]s --name "${ETCD_NAME}" --initial-cluster "${ETCD_NAME}=${ETCD_PEER_URL}" --initial-advertise-peer-urls "${ETCD_PEER_URL}" && `+
+			`rm -f %[5]s`,
+		strings.Join(dataSecretNames, " "), kubeconfigPath, namespace, controlplanev1.EtcdSnapshotDataKey, etcdSnapshotRestorePath, etcdSnapshotRestoreCredentialsDir, dataDir))
+
+	// kubeadm init requires the etcd data directory to be empty, unless the preflight check is ignored.
+	if bootstrapSpec.InitConfiguration == nil {
+		bootstrapSpec.InitConfiguration = &bootstrapv1.InitConfiguration{}
+	}
+	bootstrapSpec.InitConfiguration.NodeRegistration.IgnorePreflightErrors = append(bootstrapSpec.InitConfiguration.NodeRegistration.IgnorePreflightErrors,
+		"DirAvailable-"+strings.ReplaceAll(dataDir, "/", "-"))
+}
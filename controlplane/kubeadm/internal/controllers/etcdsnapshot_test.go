@@ -0,0 +1,508 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestEtcdSnapshotReconciler_reconcile(t *testing.T) {
+	newSnapshot := func(clusterName string) *controlplanev1.EtcdSnapshot {
+		return &controlplanev1.EtcdSnapshot{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: controlplanev1.GroupVersion.String(),
+				Kind:       "EtcdSnapshot",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "snapshot",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: controlplanev1.EtcdSnapshotSpec{
+				ClusterName: clusterName,
+			},
+		}
+	}
+
+	t.Run("stores the snapshot in Secrets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defer func(chunkSize int) { etcdSnapshotChunkSize = chunkSize }(etcdSnapshotChunkSize)
+		etcdSnapshotChunkSize = 16
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Status.Initialized = true
+		snapshot := newSnapshot(cluster.Name)
+		fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), snapshot.DeepCopy())
+
+		r := &EtcdSnapshotReconciler{
+			Client: fakeClient,
+			managementCluster: &fakeManagementCluster{
+				Workload: fakeWorkloadCluster{EtcdSnapshotResult: []byte("snapshot")},
+			},
+		}
+
+		result, err := r.reconcile(ctx, cluster, snapshot)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(snapshot.Status.Ready).To(BeTrue())
+		g.Expect(snapshot.Status.Size).To(BeEquivalentTo(8))
+		g.Expect(snapshot.Status.CompletionTime).NotTo(BeNil())
+		g.Expect(conditions.IsTrue(snapshot, controlplanev1.EtcdSnapshotTakenCondition)).To(BeTrue())
+
+		// The compressed snapshot is split across more Secrets than one, given the chunk size.
+		g.Expect(len(snapshot.Status.DataSecretNames)).To(BeNumerically(">", 1))
+		compressed := []byte{}
+		for i, name := range snapshot.Status.DataSecretNames {
+			g.Expect(name).To(Equal(fmt.Sprintf("%s-%d", snapshot.Name, i)))
+
+			secret := &corev1.Secret{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: snapshot.Namespace, Name: name}, secret)).To(Succeed())
+			g.Expect(secret.Type).To(Equal(clusterv1.ClusterSecretType))
+			g.Expect(secret.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, cluster.Name))
+			g.Expect(secret.OwnerReferences).To(HaveLen(1))
+			g.Expect(secret.OwnerReferences[0].Kind).To(Equal("EtcdSnapshot"))
+			g.Expect(len(secret.Data[controlplanev1.EtcdSnapshotDataKey])).To(BeNumerically("<=", etcdSnapshotChunkSize))
+			compressed = append(compressed, secret.Data[controlplanev1.EtcdSnapshotDataKey]...)
+		}
+
+		// The snapshot is the concatenation of the chunks, gzip compressed.
+		decompressor, err := gzip.NewReader(bytes.NewReader(compressed))
+		g.Expect(err).NotTo(HaveOccurred())
+		data, err := io.ReadAll(decompressor)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(string(data)).To(Equal("snapshot"))
+	})
+
+	t.Run("does not support snapshots exceeding the maximum size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		defer func(chunkSize int) { etcdSnapshotChunkSize = chunkSize }(etcdSnapshotChunkSize)
+		etcdSnapshotChunkSize = 16
+
+		// Random data can't be compressed, so the compressed snapshot is bigger than maxEtcdSnapshotSecrets chunks.
+		data := make([]byte, 2*maxEtcdSnapshotSecrets*etcdSnapshotChunkSize)
+		_, err := rand.Read(data)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Status.Initialized = true
+		snapshot := newSnapshot(cluster.Name)
+		fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), snapshot.DeepCopy())
+
+		r := &EtcdSnapshotReconciler{
+			Client: fakeClient,
+			managementCluster: &fakeManagementCluster{
+				Workload: fakeWorkloadCluster{EtcdSnapshotResult: data},
+			},
+		}
+
+		result, err := r.reconcile(ctx, cluster, snapshot)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(snapshot.Status.Ready).To(BeFalse())
+		g.Expect(snapshot.Status.DataSecretNames).To(BeEmpty())
+		g.Expect(conditions.GetReason(snapshot, controlplanev1.EtcdSnapshotTakenCondition)).To(Equal(controlplanev1.EtcdSnapshotUnsupportedReason))
+
+		secrets := &corev1.SecretList{}
+		g.Expect(fakeClient.List(ctx, secrets, client.InNamespace(snapshot.Namespace))).To(Succeed())
+		g.Expect(secrets.Items).To(BeEmpty())
+	})
+
+	t.Run("waits for the control plane to be initialized", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		snapshot := newSnapshot(cluster.Name)
+		fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), snapshot.DeepCopy())
+
+		r := &EtcdSnapshotReconciler{
+			Client:            fakeClient,
+			managementCluster: &fakeManagementCluster{},
+		}
+
+		result, err := r.reconcile(ctx, cluster, snapshot)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.RequeueAfter).NotTo(BeZero())
+		g.Expect(snapshot.Status.Ready).To(BeFalse())
+		g.Expect(conditions.GetReason(snapshot, controlplanev1.EtcdSnapshotTakenCondition)).To(Equal(controlplanev1.WaitingForControlPlaneInitializedReason))
+	})
+
+	t.Run("does not support external etcd", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		kcp.Status.Initialized = true
+		kcp.Spec.KubeadmConfigSpec.ClusterConfiguration = &bootstrapv1.ClusterConfiguration{
+			Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{Endpoints: []string{"https://etcd:2379"}}},
+		}
+		snapshot := newSnapshot(cluster.Name)
+		fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy(), snapshot.DeepCopy())
+
+		r := &EtcdSnapshotReconciler{
+			Client:            fakeClient,
+			managementCluster: &fakeManagementCluster{},
+		}
+
+		result, err := r.reconcile(ctx, cluster, snapshot)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result).To(Equal(ctrl.Result{}))
+		g.Expect(snapshot.Status.Ready).To(BeFalse())
+		g.Expect(conditions.GetReason(snapshot, controlplanev1.EtcdSnapshotTakenCondition)).To(Equal(controlplanev1.EtcdSnapshotUnsupportedReason))
+	})
+
+	t.Run("does not support clusters without a KubeadmControlPlane", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster, _, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+		cluster.Spec.ControlPlaneRef = nil
+		snapshot := newSnapshot(cluster.Name)
+		fakeClient := newFakeClient(cluster.DeepCopy(), snapshot.DeepCopy())
+
+		r := &EtcdSnapshotReconciler{
+			Client:            fakeClient,
+			managementCluster: &fakeManagementCluster{},
+		}
+
+		_, err := r.reconcile(ctx, cluster, snapshot)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(conditions.GetReason(snapshot, controlplanev1.EtcdSnapshotTakenCondition)).To(Equal(controlplanev1.EtcdSnapshotUnsupportedReason))
+	})
+}
+
+func TestKubeadmControlPlaneReconciler_initializeControlPlaneWithEtcdSnapshot(t *testing.T) {
+	tests := []struct {
+		name                      string
+		snapshot                  func(clusterName string) *controlplanev1.EtcdSnapshot
+		managementClusterEndpoint string
+		initialized               bool
+		expectErr                 bool
+	}{
+		{
+			name: "restores a ready snapshot",
+			snapshot: func(clusterName string) *controlplanev1.EtcdSnapshot {
+				return &controlplanev1.EtcdSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: metav1.NamespaceDefault},
+					Spec:       controlplanev1.EtcdSnapshotSpec{ClusterName: clusterName},
+					Status:     controlplanev1.EtcdSnapshotStatus{Ready: true, DataSecretNames: []string{"snapshot-0", "snapshot-1"}},
+				}
+			},
+			managementClusterEndpoint: "https://management:6443",
+		},
+		{
+			name: "fails if the management cluster endpoint is not set",
+			snapshot: func(clusterName string) *controlplanev1.EtcdSnapshot {
+				return &controlplanev1.EtcdSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: metav1.NamespaceDefault},
+					Spec:       controlplanev1.EtcdSnapshotSpec{ClusterName: clusterName},
+					Status:     controlplanev1.EtcdSnapshotStatus{Ready: true, DataSecretNames: []string{"snapshot-0", "snapshot-1"}},
+				}
+			},
+			expectErr: true,
+		},
+		{
+			name: "fails if the KubeadmControlPlane has already been initialized",
+			snapshot: func(clusterName string) *controlplanev1.EtcdSnapshot {
+				return &controlplanev1.EtcdSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: metav1.NamespaceDefault},
+					Spec:       controlplanev1.EtcdSnapshotSpec{ClusterName: clusterName},
+					Status:     controlplanev1.EtcdSnapshotStatus{Ready: true, DataSecretNames: []string{"snapshot-0", "snapshot-1"}},
+				}
+			},
+			managementClusterEndpoint: "https://management:6443",
+			initialized:               true,
+			expectErr:                 true,
+		},
+		{
+			name: "fails if the snapshot is not ready",
+			snapshot: func(clusterName string) *controlplanev1.EtcdSnapshot {
+				return &controlplanev1.EtcdSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: metav1.NamespaceDefault},
+					Spec:       controlplanev1.EtcdSnapshotSpec{ClusterName: clusterName},
+				}
+			},
+			managementClusterEndpoint: "https://management:6443",
+			expectErr:                 true,
+		},
+		{
+			name: "fails if the snapshot has been taken from another cluster",
+			snapshot: func(_ string) *controlplanev1.EtcdSnapshot {
+				return &controlplanev1.EtcdSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: metav1.NamespaceDefault},
+					Spec:       controlplanev1.EtcdSnapshotSpec{ClusterName: "another-cluster"},
+					Status:     controlplanev1.EtcdSnapshotStatus{Ready: true, DataSecretNames: []string{"snapshot-0"}},
+				}
+			},
+			managementClusterEndpoint: "https://management:6443",
+			expectErr:                 true,
+		},
+		{
+			name: "fails if the snapshot does not exist",
+			snapshot: func(_ string) *controlplanev1.EtcdSnapshot {
+				return nil
+			},
+			managementClusterEndpoint: "https://management:6443",
+			expectErr:                 true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster, kcp, genericMachineTemplate := createClusterWithControlPlane(metav1.NamespaceDefault)
+			kcp.Annotations = map[string]string{controlplanev1.RestoreEtcdSnapshotAnnotation: "snapshot"}
+			kcp.Status.Initialized = tt.initialized
+
+			objs := []client.Object{cluster.DeepCopy(), kcp.DeepCopy(), genericMachineTemplate.DeepCopy()}
+			if snapshot := tt.snapshot(cluster.Name); snapshot != nil {
+				objs = append(objs, snapshot)
+			}
+			fakeClient := newFakeClient(objs...)
+
+			r := &KubeadmControlPlaneReconciler{
+				Client:                    fakeClient,
+				ManagementClusterEndpoint: tt.managementClusterEndpoint,
+				recorder:                  record.NewFakeRecorder(32),
+				managementClusterUncached: &fakeManagementCluster{
+					Management: &internal.Management{Client: fakeClient},
+					Workload:   fakeWorkloadCluster{},
+				},
+			}
+			controlPlane := &internal.ControlPlane{
+				Cluster: cluster,
+				KCP:     kcp,
+			}
+
+			_, err := r.initializeControlPlane(ctx, cluster, kcp, controlPlane)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(conditions.Has(kcp, controlplanev1.EtcdSnapshotRestoredCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(conditions.GetReason(kcp, controlplanev1.EtcdSnapshotRestoredCondition)).To(Equal(controlplanev1.EtcdSnapshotRestoringReason))
+
+			credentialsName := etcdSnapshotRestoreCredentialsName(kcp)
+			kubeadmConfigs := &bootstrapv1.KubeadmConfigList{}
+			g.Expect(fakeClient.List(ctx, kubeadmConfigs, client.InNamespace(cluster.Namespace))).To(Succeed())
+			g.Expect(kubeadmConfigs.Items).To(HaveLen(1))
+			g.Expect(kubeadmConfigs.Items[0].Spec.Files).To(ContainElement(HaveField("ContentFrom.Secret.Name", credentialsName)))
+			g.Expect(kubeadmConfigs.Items[0].Spec.PreKubeadmCommands).To(HaveLen(1))
+			g.Expect(kubeadmConfigs.Items[0].Spec.PreKubeadmCommands[0]).To(ContainSubstring("for s in snapshot-0 snapshot-1;"))
+
+			// The first control plane machine can get only the data Secrets of the snapshot.
+			role := &rbacv1.Role{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: credentialsName}, role)).To(Succeed())
+			g.Expect(role.Rules).To(Equal([]rbacv1.PolicyRule{{
+				APIGroups:     []string{""},
+				Resources:     []string{"secrets"},
+				Verbs:         []string{"get"},
+				ResourceNames: []string{"snapshot-0", "snapshot-1"},
+			}}))
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: credentialsName}, &rbacv1.RoleBinding{})).To(Succeed())
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: credentialsName}, &corev1.ServiceAccount{})).To(Succeed())
+			tokenSecret := &corev1.Secret{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: credentialsName}, tokenSecret)).To(Succeed())
+			g.Expect(tokenSecret.Type).To(Equal(corev1.SecretTypeServiceAccountToken))
+			g.Expect(tokenSecret.Annotations).To(HaveKeyWithValue(corev1.ServiceAccountNameKey, credentialsName))
+			g.Expect(tokenSecret.OwnerReferences).To(HaveLen(1))
+			g.Expect(tokenSecret.OwnerReferences[0].Kind).To(Equal("KubeadmControlPlane"))
+		})
+	}
+}
+
+func TestKubeadmControlPlaneReconciler_reconcileEtcdSnapshotRestore(t *testing.T) {
+	tests := []struct {
+		name              string
+		restoring         bool
+		initialized       bool
+		expectAnnotation  bool
+		expectCredentials bool
+	}{
+		{
+			name:              "keeps the credentials until the control plane is initialized",
+			restoring:         true,
+			initialized:       false,
+			expectAnnotation:  true,
+			expectCredentials: true,
+		},
+		{
+			name:              "deletes the credentials and the annotation once the control plane is initialized",
+			restoring:         true,
+			initialized:       true,
+			expectAnnotation:  false,
+			expectCredentials: false,
+		},
+		{
+			name:              "ignores the annotation on a control plane initialized without restoring a snapshot",
+			restoring:         false,
+			initialized:       true,
+			expectAnnotation:  true,
+			expectCredentials: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster, kcp, _ := createClusterWithControlPlane(metav1.NamespaceDefault)
+			kcp.Annotations = map[string]string{controlplanev1.RestoreEtcdSnapshotAnnotation: "snapshot"}
+			kcp.Status.Initialized = tt.initialized
+			if tt.restoring {
+				conditions.MarkFalse(kcp, controlplanev1.EtcdSnapshotRestoredCondition, controlplanev1.EtcdSnapshotRestoringReason, clusterv1.ConditionSeverityInfo, "")
+			}
+			fakeClient := newFakeClient(cluster.DeepCopy(), kcp.DeepCopy())
+
+			r := &KubeadmControlPlaneReconciler{Client: fakeClient}
+			g.Expect(r.createEtcdSnapshotRestoreCredentials(ctx, cluster, kcp, []string{"snapshot-0"})).To(Succeed())
+
+			controlPlane := &internal.ControlPlane{
+				Cluster: cluster,
+				KCP:     kcp,
+			}
+			g.Expect(r.reconcileEtcdSnapshotRestore(ctx, controlPlane)).To(Succeed())
+
+			if tt.expectAnnotation {
+				g.Expect(kcp.Annotations).To(HaveKey(controlplanev1.RestoreEtcdSnapshotAnnotation))
+			} else {
+				g.Expect(kcp.Annotations).NotTo(HaveKey(controlplanev1.RestoreEtcdSnapshotAnnotation))
+			}
+
+			switch {
+			case !tt.restoring:
+				g.Expect(conditions.Has(kcp, controlplanev1.EtcdSnapshotRestoredCondition)).To(BeFalse())
+			case tt.initialized:
+				g.Expect(conditions.IsTrue(kcp, controlplanev1.EtcdSnapshotRestoredCondition)).To(BeTrue())
+			default:
+				g.Expect(conditions.GetReason(kcp, controlplanev1.EtcdSnapshotRestoredCondition)).To(Equal(controlplanev1.EtcdSnapshotRestoringReason))
+			}
+
+			key := client.ObjectKey{Namespace: kcp.Namespace, Name: etcdSnapshotRestoreCredentialsName(kcp)}
+			for _, obj := range []client.Object{&corev1.Secret{}, &rbacv1.RoleBinding{}, &rbacv1.Role{}, &corev1.ServiceAccount{}} {
+				err := fakeClient.Get(ctx, key, obj)
+				if tt.expectCredentials {
+					g.Expect(err).NotTo(HaveOccurred())
+				} else {
+					g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				}
+			}
+		})
+	}
+}
+
+func Test_addEtcdSnapshotRestore(t *testing.T) {
+	tests := []struct {
+		name                string
+		bootstrapSpec       *bootstrapv1.KubeadmConfigSpec
+		wantDataDir         string
+		wantPreflightErrors []string
+	}{
+		{
+			name:                "restores into the default data dir",
+			bootstrapSpec:       &bootstrapv1.KubeadmConfigSpec{},
+			wantDataDir:         "/var/lib/etcd",
+			wantPreflightErrors: []string{"DirAvailable--var-lib-etcd"},
+		},
+		{
+			name: "restores into a custom data dir",
+			bootstrapSpec: &bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{Local: &bootstrapv1.LocalEtcd{DataDir: "/mnt/etcd"}},
+				},
+				InitConfiguration: &bootstrapv1.InitConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{IgnorePreflightErrors: []string{"Swap"}},
+				},
+			},
+			wantDataDir:         "/mnt/etcd",
+			wantPreflightErrors: []string{"Swap", "DirAvailable--mnt-etcd"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			addEtcdSnapshotRestore(tt.bootstrapSpec, metav1.NamespaceDefault, []string{"snapshot-0", "snapshot-1"}, "credentials", []byte("kubeconfig"))
+
+			g.Expect(tt.bootstrapSpec.Files).To(Equal([]bootstrapv1.File{
+				{
+					Path:        etcdSnapshotRestoreCredentialsDir + "/kubeconfig",
+					Owner:       "root:root",
+					Permissions: "0600",
+					Content:     "kubeconfig",
+				},
+				{
+					Path:        etcdSnapshotRestoreCredentialsDir + "/ca.crt",
+					Owner:       "root:root",
+					Permissions: "0600",
+					ContentFrom: &bootstrapv1.FileSource{
+						Secret: bootstrapv1.SecretFileSource{Name: "credentials", Key: "ca.crt"},
+					},
+				},
+				{
+					Path:        etcdSnapshotRestoreCredentialsDir + "/token",
+					Owner:       "root:root",
+					Permissions: "0600",
+					ContentFrom: &bootstrapv1.FileSource{
+						Secret: bootstrapv1.SecretFileSource{Name: "credentials", Key: "token"},
+					},
+				},
+			}))
+			g.Expect(tt.bootstrapSpec.PreKubeadmCommands).To(HaveLen(1))
+			g.Expect(tt.bootstrapSpec.PreKubeadmCommands[0]).To(HavePrefix("for s in snapshot-0 snapshot-1; do kubectl --kubeconfig " + etcdSnapshotRestoreCredentialsDir + "/kubeconfig --namespace default get secret"))
+			g.Expect(tt.bootstrapSpec.PreKubeadmCommands[0]).To(ContainSubstring("| gunzip > " + etcdSnapshotRestorePath))
+			g.Expect(tt.bootstrapSpec.PreKubeadmCommands[0]).To(ContainSubstring("etcdutl snapshot restore " + etcdSnapshotRestorePath + " --data-dir " + tt.wantDataDir))
+			g.Expect(tt.bootstrapSpec.InitConfiguration.NodeRegistration.IgnorePreflightErrors).To(Equal(tt.wantPreflightErrors))
+		})
+	}
+}
+
+func Test_etcdSnapshotRestoreKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+
+	data, err := etcdSnapshotRestoreKubeconfig("https://management:6443")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	config, err := clientcmd.Load(data)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(config.Clusters).To(HaveLen(1))
+	g.Expect(config.Clusters[config.Contexts[config.CurrentContext].Cluster].Server).To(Equal("https://management:6443"))
+	g.Expect(config.Clusters[config.Contexts[config.CurrentContext].Cluster].CertificateAuthority).To(Equal(etcdSnapshotRestoreCredentialsDir + "/ca.crt"))
+	g.Expect(config.AuthInfos[config.Contexts[config.CurrentContext].AuthInfo].TokenFile).To(Equal(etcdSnapshotRestoreCredentialsDir + "/token"))
+}
@@ -18,11 +18,14 @@ package controllers
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/blang/semver"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/collections"
@@ -65,8 +68,16 @@ func (f *fakeManagementCluster) GetMachinePoolsForCluster(c context.Context, clu
 
 type fakeWorkloadCluster struct {
 	*internal.Workload
-	Status            internal.ClusterStatus
-	EtcdMembersResult []string
+	Status             internal.ClusterStatus
+	EtcdMembersResult  []string
+	EtcdSnapshotResult []byte
+
+	EtcdDefragmentationResult *internal.EtcdDefragmentationResult
+	EtcdDefragmentationErr    error
+	// EtcdDefragmentationInput, if set, records the input of the last DefragmentEtcd call.
+	EtcdDefragmentationInput *internal.EtcdDefragmentationInput
+
+	APIServerCertificateExpiry *time.Time
 }
 
 func (f fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, _ *clusterv1.Machine) error {
@@ -77,6 +88,24 @@ func (f fakeWorkloadCluster) ReconcileEtcdMembers(ctx context.Context, nodeNames
 	return nil, nil
 }
 
+func (f fakeWorkloadCluster) DefragmentEtcd(_ context.Context, _ []string, input internal.EtcdDefragmentationInput) (*internal.EtcdDefragmentationResult, error) {
+	if f.EtcdDefragmentationInput != nil {
+		*f.EtcdDefragmentationInput = input
+	}
+	if f.EtcdDefragmentationResult == nil {
+		return &internal.EtcdDefragmentationResult{}, f.EtcdDefragmentationErr
+	}
+	return f.EtcdDefragmentationResult, f.EtcdDefragmentationErr
+}
+
+func (f fakeWorkloadCluster) EnableEtcdLearnerModeInKubeadmConfigMap(_ context.Context, _ semver.Version) error {
+	return nil
+}
+
+func (f fakeWorkloadCluster) GetAPIServerCertificateExpiry(_ context.Context, _ *bootstrapv1.KubeadmConfig, _ string) (*time.Time, error) {
+	return f.APIServerCertificateExpiry, nil
+}
+
 func (f fakeWorkloadCluster) ClusterStatus(_ context.Context) (internal.ClusterStatus, error) {
 	return f.Status, nil
 }
@@ -117,6 +146,11 @@ func (f fakeWorkloadCluster) EtcdMembers(_ context.Context) ([]string, error) {
 	return f.EtcdMembersResult, nil
 }
 
+func (f fakeWorkloadCluster) SnapshotEtcd(_ context.Context, snapshot io.Writer) (int64, error) {
+	n, err := snapshot.Write(f.EtcdSnapshotResult)
+	return int64(n), err
+}
+
 type fakeMigrator struct {
 	migrateCalled    bool
 	migrateErr       error
@@ -29,6 +29,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apiserver/pkg/storage/names"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -36,10 +37,12 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
+	"sigs.k8s.io/cluster-api/util/labels"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
@@ -307,8 +310,83 @@ func (r *KubeadmControlPlaneReconciler) generateMachine(ctx context.Context, kcp
 	}
 	machine.Annotations[controlplanev1.KubeadmClusterConfigurationAnnotation] = string(clusterConfig)
 
+	// In case this machine is being created as a replacement of a remediated machine, link it to the
+	// remediation in progress; this is required in order to track remediation retries.
+	if remediationData, ok := kcp.Annotations[controlplanev1.RemediationInProgressAnnotation]; ok {
+		machine.Annotations[controlplanev1.RemediationForAnnotation] = remediationData
+	}
+
 	if err := r.Client.Create(ctx, machine); err != nil {
 		return errors.Wrap(err, "failed to create machine")
 	}
+
+	// The remediation in progress, if any, is completed now that the replacement machine has been created.
+	delete(kcp.Annotations, controlplanev1.RemediationInProgressAnnotation)
 	return nil
 }
+
+// syncMachines propagates the in-place mutable fields from the KCP machine template to the existing Machines,
+// and the labels and annotations to their InfrastructureMachines and KubeadmConfigs.
+// In-place mutable fields are labels, annotations, NodeDrainTimeout and NodeDeletionTimeout.
+// Labels and annotations removed from the KCP machine template are removed from the existing objects too; the keys
+// originated from the template are tracked in the LabelsFromTemplateAnnotation and AnnotationsFromTemplateAnnotation
+// annotations.
+func (r *KubeadmControlPlaneReconciler) syncMachines(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	kcp := controlPlane.KCP
+	desiredLabels := internal.ControlPlaneMachineLabelsForCluster(kcp, controlPlane.Cluster.Name)
+	desiredAnnotations := kcp.Spec.MachineTemplate.ObjectMeta.Annotations
+
+	var errs []error
+	for machineName := range controlPlane.Machines {
+		m := controlPlane.Machines[machineName]
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(m, r.Client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		labels.SyncLabels(m, desiredLabels)
+		annotations.SyncAnnotations(m, desiredAnnotations)
+		m.Spec.NodeDrainTimeout = kcp.Spec.MachineTemplate.NodeDrainTimeout
+		if kcp.Spec.MachineTemplate.NodeDeletionTimeout != nil {
+			m.Spec.NodeDeletionTimeout = kcp.Spec.MachineTemplate.NodeDeletionTimeout
+		}
+		if err := patchHelper.Patch(ctx, m); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to update Machine %s", m.Name))
+			continue
+		}
+
+		// NOTE: InfrastructureMachines and KubeadmConfigs are already read when initializing the control plane,
+		// so syncing them only requires a patch when their metadata is not up to date.
+		if infraObj, ok := controlPlane.InfrastructureResource(machineName); ok {
+			if err := r.syncObjectMetadata(ctx, infraObj, desiredLabels, desiredAnnotations); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to update InfrastructureMachine for Machine %s", m.Name))
+				continue
+			}
+		}
+		if kubeadmConfig, ok := controlPlane.KubeadmConfig(machineName); ok {
+			if err := r.syncObjectMetadata(ctx, kubeadmConfig, desiredLabels, desiredAnnotations); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to update KubeadmConfig for Machine %s", m.Name))
+				continue
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// syncObjectMetadata syncs the given labels and annotations on the object.
+func (r *KubeadmControlPlaneReconciler) syncObjectMetadata(ctx context.Context, obj client.Object, desiredLabels, desiredAnnotations map[string]string) error {
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return err
+	}
+	labelsChanged := labels.SyncLabels(obj, desiredLabels)
+	annotationsChanged := annotations.SyncAnnotations(obj, desiredAnnotations)
+	if !labelsChanged && !annotationsChanged {
+		return nil
+	}
+	return patchHelper.Patch(ctx, obj)
+}
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
@@ -33,6 +34,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/secret"
@@ -559,6 +561,58 @@ func TestKubeadmControlPlaneReconciler_generateMachine(t *testing.T) {
 	g.Expect(kcp.Spec.MachineTemplate.ObjectMeta.Annotations).NotTo(HaveKey(controlplanev1.KubeadmClusterConfigurationAnnotation))
 }
 
+func TestKubeadmControlPlaneReconciler_generateMachineForRemediation(t *testing.T) {
+	g := NewWithT(t)
+	fakeClient := newFakeClient()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testCluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	remediationData := `{"machine":"m1","timestamp":"2022-06-01T10:00:00Z","retryCount":1}`
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testControlPlane",
+			Namespace: cluster.Namespace,
+			Annotations: map[string]string{
+				controlplanev1.RemediationInProgressAnnotation: remediationData,
+			},
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.16.6",
+		},
+	}
+
+	infraRef := &corev1.ObjectReference{
+		Kind:       "InfraKind",
+		APIVersion: "infrastructure.cluster.x-k8s.io/v1beta1",
+		Name:       "infra",
+		Namespace:  cluster.Namespace,
+	}
+	bootstrapRef := &corev1.ObjectReference{
+		Kind:       "BootstrapKind",
+		APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+		Name:       "bootstrap",
+		Namespace:  cluster.Namespace,
+	}
+	r := &KubeadmControlPlaneReconciler{
+		Client:            fakeClient,
+		managementCluster: &internal.Management{Client: fakeClient},
+		recorder:          record.NewFakeRecorder(32),
+	}
+	g.Expect(r.generateMachine(ctx, kcp, cluster, infraRef, bootstrapRef, nil)).To(Succeed())
+
+	machineList := &clusterv1.MachineList{}
+	g.Expect(fakeClient.List(ctx, machineList, client.InNamespace(cluster.Namespace))).To(Succeed())
+	g.Expect(machineList.Items).To(HaveLen(1))
+
+	// Verify that the replacement machine is linked to the remediation in progress, and that the remediation is now completed.
+	g.Expect(machineList.Items[0].Annotations).To(HaveKeyWithValue(controlplanev1.RemediationForAnnotation, remediationData))
+	g.Expect(kcp.Annotations).ToNot(HaveKey(controlplanev1.RemediationInProgressAnnotation))
+}
+
 func TestKubeadmControlPlaneReconciler_generateKubeadmConfig(t *testing.T) {
 	g := NewWithT(t)
 	fakeClient := newFakeClient()
@@ -606,3 +660,145 @@ func TestKubeadmControlPlaneReconciler_generateKubeadmConfig(t *testing.T) {
 	g.Expect(bootstrapConfig.OwnerReferences).To(ContainElement(expectedOwner))
 	g.Expect(bootstrapConfig.Spec).To(Equal(spec))
 }
+
+func TestKubeadmControlPlaneReconciler_syncMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testCluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testControlPlane",
+			Namespace: cluster.Namespace,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.16.6",
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels: map[string]string{
+						"machineTemplateLabel": "machineTemplateLabelValue",
+					},
+					Annotations: map[string]string{
+						"machineTemplateAnnotation": "machineTemplateAnnotationValue",
+					},
+				},
+				NodeDrainTimeout:    &metav1.Duration{Duration: 10 * time.Second},
+				NodeDeletionTimeout: &metav1.Duration{Duration: 20 * time.Second},
+			},
+		},
+	}
+
+	infraMachine := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "GenericInfrastructureMachine",
+			"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+			"metadata": map[string]interface{}{
+				"name":      "infra",
+				"namespace": cluster.Namespace,
+			},
+		},
+	}
+	kubeadmConfig := &bootstrapv1.KubeadmConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "bootstrap",
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				"existingLabel": "existingLabelValue",
+			},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				"existingLabel": "existingLabelValue",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: cluster.Name,
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       infraMachine.GetKind(),
+				APIVersion: infraMachine.GetAPIVersion(),
+				Name:       infraMachine.GetName(),
+				Namespace:  cluster.Namespace,
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       "KubeadmConfig",
+					APIVersion: bootstrapv1.GroupVersion.String(),
+					Name:       kubeadmConfig.Name,
+					Namespace:  cluster.Namespace,
+				},
+			},
+		},
+	}
+
+	fakeClient := newFakeClient(machine.DeepCopy(), infraMachine.DeepCopy(), kubeadmConfig.DeepCopy())
+	controlPlane, err := internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.FromMachines(machine.DeepCopy()))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &KubeadmControlPlaneReconciler{
+		Client:   fakeClient,
+		recorder: record.NewFakeRecorder(32),
+	}
+	g.Expect(r.syncMachines(ctx, controlPlane)).To(Succeed())
+
+	// Verify that labels, annotations and timeouts have been propagated to the Machine,
+	// preserving the existing labels.
+	gotMachine := &clusterv1.Machine{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("machineTemplateLabel", "machineTemplateLabelValue"))
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, cluster.Name))
+	g.Expect(gotMachine.Annotations).To(HaveKeyWithValue("machineTemplateAnnotation", "machineTemplateAnnotationValue"))
+	g.Expect(gotMachine.Spec.NodeDrainTimeout).To(Equal(kcp.Spec.MachineTemplate.NodeDrainTimeout))
+	g.Expect(gotMachine.Spec.NodeDeletionTimeout).To(Equal(kcp.Spec.MachineTemplate.NodeDeletionTimeout))
+
+	// Verify that labels and annotations have been propagated to the InfrastructureMachine.
+	gotInfraMachine := &unstructured.Unstructured{}
+	gotInfraMachine.SetGroupVersionKind(infraMachine.GroupVersionKind())
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infraMachine), gotInfraMachine)).To(Succeed())
+	g.Expect(gotInfraMachine.GetLabels()).To(HaveKeyWithValue("machineTemplateLabel", "machineTemplateLabelValue"))
+	g.Expect(gotInfraMachine.GetAnnotations()).To(HaveKeyWithValue("machineTemplateAnnotation", "machineTemplateAnnotationValue"))
+
+	// Verify that labels and annotations have been propagated to the KubeadmConfig.
+	gotKubeadmConfig := &bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kubeadmConfig), gotKubeadmConfig)).To(Succeed())
+	g.Expect(gotKubeadmConfig.Labels).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotKubeadmConfig.Labels).To(HaveKeyWithValue("machineTemplateLabel", "machineTemplateLabelValue"))
+	g.Expect(gotKubeadmConfig.Annotations).To(HaveKeyWithValue("machineTemplateAnnotation", "machineTemplateAnnotationValue"))
+
+	// Remove the label and the annotation from the KCP machine template.
+	kcp.Spec.MachineTemplate.ObjectMeta.Labels = nil
+	kcp.Spec.MachineTemplate.ObjectMeta.Annotations = nil
+	controlPlane, err = internal.NewControlPlane(ctx, fakeClient, cluster, kcp, collections.FromMachines(gotMachine))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.syncMachines(ctx, controlPlane)).To(Succeed())
+
+	// Verify that the label and the annotation have been removed from the Machine, the InfrastructureMachine
+	// and the KubeadmConfig, preserving the labels which are not originated from the KCP machine template.
+	gotMachine = &clusterv1.Machine{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, cluster.Name))
+	g.Expect(gotMachine.Labels).ToNot(HaveKey("machineTemplateLabel"))
+	g.Expect(gotMachine.Annotations).ToNot(HaveKey("machineTemplateAnnotation"))
+	g.Expect(gotMachine.Annotations).ToNot(HaveKey(clusterv1.AnnotationsFromTemplateAnnotation))
+
+	gotInfraMachine = &unstructured.Unstructured{}
+	gotInfraMachine.SetGroupVersionKind(infraMachine.GroupVersionKind())
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infraMachine), gotInfraMachine)).To(Succeed())
+	g.Expect(gotInfraMachine.GetLabels()).ToNot(HaveKey("machineTemplateLabel"))
+	g.Expect(gotInfraMachine.GetAnnotations()).ToNot(HaveKey("machineTemplateAnnotation"))
+
+	gotKubeadmConfig = &bootstrapv1.KubeadmConfig{}
+	g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(kubeadmConfig), gotKubeadmConfig)).To(Succeed())
+	g.Expect(gotKubeadmConfig.Labels).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotKubeadmConfig.Labels).ToNot(HaveKey("machineTemplateLabel"))
+	g.Expect(gotKubeadmConfig.Annotations).ToNot(HaveKey("machineTemplateAnnotation"))
+}
@@ -18,10 +18,14 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/blang/semver"
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,6 +42,7 @@ import (
 // based on the process described in https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20191017-kubeadm-based-control-plane.md#remediation-using-delete-and-recreate
 func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.Context, controlPlane *internal.ControlPlane) (ret ctrl.Result, retErr error) {
 	log := ctrl.LoggerFrom(ctx)
+	reconciliationTime := time.Now().UTC()
 
 	// Cleanup pending remediation actions not completed for any reasons (e.g. number of current replicas is less or equal to 1)
 	// if the underlying machine is now back to healthy / not deleting.
@@ -66,6 +71,23 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		return ctrl.Result{}, kerrors.NewAggregate(errList)
 	}
 
+	// Cleanup the remediation in progress annotation if it is stale, which happens when a machine has been created
+	// after the remediation started but the annotation has not been removed, e.g. because patching KCP failed.
+	// NOTE: the remediation is completed as soon as the replacement machine is created (see generateMachine).
+	if value, ok := controlPlane.KCP.Annotations[controlplanev1.RemediationInProgressAnnotation]; ok {
+		remediationInProgressData, err := RemediationDataFromAnnotation(value)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for _, m := range controlPlane.Machines {
+			if m.CreationTimestamp.After(remediationInProgressData.Timestamp.Time) {
+				log.Info("Removing stale remediation in progress annotation", "Machine", remediationInProgressData.Machine)
+				delete(controlPlane.KCP.Annotations, controlplanev1.RemediationInProgressAnnotation)
+				break
+			}
+		}
+	}
+
 	// Gets all machines that have `MachineHealthCheckSucceeded=False` (indicating a problem was detected on the machine)
 	// and `MachineOwnerRemediated` present, indicating that this controller is responsible for performing remediation.
 	unhealthyMachines := controlPlane.UnhealthyMachines()
@@ -75,6 +97,13 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		return ctrl.Result{}, nil
 	}
 
+	// Return early if another remediation is in progress, which means that an unhealthy machine has been deleted
+	// and its replacement has not been created yet.
+	if _, ok := controlPlane.KCP.Annotations[controlplanev1.RemediationInProgressAnnotation]; ok {
+		log.Info("Another remediation is already in progress. Skipping remediation")
+		return ctrl.Result{}, nil
+	}
+
 	// Select the machine to be remediated, which is the oldest machine marked as unhealthy.
 	//
 	// NOTE: The current solution is considered acceptable for the most frequent use case (only one unhealthy machine),
@@ -131,6 +160,17 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		return ctrl.Result{}, nil
 	}
 
+	// Remediation MUST respect the retry limits defined in the remediation strategy. This rule prevents endless
+	// delete/create loops, e.g. when every replacement machine fails because of a broken image.
+	remediationInProgressData, canRemediate, err := r.checkRetryLimits(log, machineToBeRemediated, controlPlane, reconciliationTime)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !canRemediate {
+		// NOTE: log lines and conditions surfacing why it is not possible to remediate are set by checkRetryLimits.
+		return ctrl.Result{}, nil
+	}
+
 	// Remediation MUST preserve etcd quorum. This rule ensures that we will not remove a member that would result in etcd
 	// losing a majority of members and thus become unable to field new requests.
 	if controlPlane.IsEtcdManaged() {
@@ -180,11 +220,131 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		return ctrl.Result{}, errors.Wrapf(err, "failed to delete unhealthy machine %s", machineToBeRemediated.Name)
 	}
 
-	log.Info("Remediating unhealthy machine", "UnhealthyMachine", machineToBeRemediated.Name)
+	log.Info("Remediating unhealthy machine", "UnhealthyMachine", machineToBeRemediated.Name, "RetryCount", remediationInProgressData.RetryCount)
 	conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.RemediationInProgressReason, clusterv1.ConditionSeverityWarning, "")
+
+	// Track the remediation in progress on KCP, so it can be picked up by the replacement machine created by the
+	// scale up operation that completes the remediation.
+	remediationInProgressValue, err := remediationInProgressData.Marshal()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if controlPlane.KCP.Annotations == nil {
+		controlPlane.KCP.Annotations = map[string]string{}
+	}
+	controlPlane.KCP.Annotations[controlplanev1.RemediationInProgressAnnotation] = remediationInProgressValue
+	controlPlane.KCP.Status.LastRemediation = remediationInProgressData.ToStatus()
+
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// checkRetryLimits checks if KCP is allowed to remediate the given machine considering the retry limits defined
+// in the KCP remediation strategy, and returns the remediation data to be tracked for the remediation.
+//
+// A remediation is considered a retry when the machine to be remediated has been created as a replacement of a
+// machine remediated less than MinHealthyPeriod before; in this case the retry count is carried over and incremented,
+// otherwise a new sequence of retries starts from zero.
+func (r *KubeadmControlPlaneReconciler) checkRetryLimits(log logr.Logger, machineToBeRemediated *clusterv1.Machine, controlPlane *internal.ControlPlane, reconciliationTime time.Time) (*RemediationData, bool, error) {
+	remediationInProgressData := &RemediationData{
+		Machine:    machineToBeRemediated.Name,
+		Timestamp:  metav1.Time{Time: reconciliationTime},
+		RetryCount: 0,
+	}
+
+	// If there is no last remediation, this is the first try of a new retry sequence.
+	value, ok := machineToBeRemediated.Annotations[controlplanev1.RemediationForAnnotation]
+	if !ok {
+		return remediationInProgressData, true, nil
+	}
+	lastRemediationData, err := RemediationDataFromAnnotation(value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Gets MinHealthyPeriod and RetryPeriod from the remediation strategy, or use defaults.
+	minHealthyPeriod := controlplanev1.DefaultMinHealthyPeriod
+	retryPeriod := time.Duration(0)
+	var maxRetry *int32
+	if strategy := controlPlane.KCP.Spec.RemediationStrategy; strategy != nil {
+		if strategy.MinHealthyPeriod != nil {
+			minHealthyPeriod = strategy.MinHealthyPeriod.Duration
+		}
+		retryPeriod = strategy.RetryPeriod.Duration
+		maxRetry = strategy.MaxRetry
+	}
+
+	// If the last remediation happened more than MinHealthyPeriod ago, the current failure is considered unrelated
+	// to it, and thus this is the first try of a new retry sequence.
+	lastRemediationTime := lastRemediationData.Timestamp.Time
+	if !lastRemediationTime.Add(minHealthyPeriod).After(reconciliationTime) {
+		return remediationInProgressData, true, nil
+	}
+
+	// Otherwise the remediation is a retry for the same failure, so carry over the retry count.
+	log = log.WithValues("RemediationRetryFor", lastRemediationData.Machine)
+	remediationInProgressData.RetryCount = lastRemediationData.RetryCount
+
+	// Check if remediation can happen because RetryPeriod is expired.
+	if lastRemediationTime.Add(retryPeriod).After(reconciliationTime) {
+		log.Info(fmt.Sprintf("A control plane machine needs remediation, but the operation already failed in the latest %s. Skipping remediation", retryPeriod), "UnhealthyMachine", machineToBeRemediated.Name)
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "KCP can't remediate this machine because the operation already failed in the latest %s (RetryPeriod)", retryPeriod)
+		return remediationInProgressData, false, nil
+	}
+
+	// Check if remediation can happen because MaxRetry is not reached yet, if defined.
+	if maxRetry != nil && remediationInProgressData.RetryCount >= *maxRetry {
+		log.Info(fmt.Sprintf("A control plane machine needs remediation, but the operation already failed %d times (MaxRetry %d). Skipping remediation", remediationInProgressData.RetryCount, *maxRetry), "UnhealthyMachine", machineToBeRemediated.Name)
+		conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.WaitingForRemediationReason, clusterv1.ConditionSeverityWarning, "KCP can't remediate this machine because the operation already failed %d times (MaxRetry)", *maxRetry)
+		return remediationInProgressData, false, nil
+	}
+
+	// All the checks passed, increase the remediation retry count.
+	remediationInProgressData.RetryCount++
+
+	return remediationInProgressData, true, nil
+}
+
+// RemediationData is used to keep track of information stored in the RemediationInProgressAnnotation on KCP
+// during remediation, and then in the RemediationForAnnotation on the replacement machine once it is created.
+type RemediationData struct {
+	// Machine is the machine name of the latest machine being remediated.
+	Machine string `json:"machine"`
+
+	// Timestamp is when last remediation happened. It is represented in RFC3339 form and is in UTC.
+	Timestamp metav1.Time `json:"timestamp"`
+
+	// RetryCount used to keep track of remediation retry for the last remediated machine.
+	// A retry happens when a machine that was created as a replacement for an unhealthy machine also fails.
+	RetryCount int32 `json:"retryCount"`
+}
+
+// RemediationDataFromAnnotation gets RemediationData from an annotation value.
+func RemediationDataFromAnnotation(value string) (*RemediationData, error) {
+	ret := &RemediationData{}
+	if err := json.Unmarshal([]byte(value), ret); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal remediation data %q", value)
+	}
+	return ret, nil
+}
+
+// Marshal a RemediationData into an annotation value.
+func (r *RemediationData) Marshal() (string, error) {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal remediation data")
+	}
+	return string(b), nil
+}
+
+// ToStatus converts a RemediationData into a LastRemediationStatus struct.
+func (r *RemediationData) ToStatus() *controlplanev1.LastRemediationStatus {
+	return &controlplanev1.LastRemediationStatus{
+		Machine:    r.Machine,
+		Timestamp:  r.Timestamp,
+		RetryCount: r.RetryCount,
+	}
+}
+
 // canSafelyRemoveEtcdMember assess if it is possible to remove the member hosted on the machine to be remediated
 // without loosing etcd quorum.
 //
@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	utilpointer "k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -108,6 +109,71 @@ func TestReconcileUnhealthyMachines(t *testing.T) {
 		g.Expect(ret.IsZero()).To(BeTrue()) // Remediation skipped
 		g.Expect(err).ToNot(HaveOccurred())
 	})
+	t.Run("Remediation does not happen if another remediation is in progress", func(t *testing.T) {
+		g := NewWithT(t)
+
+		m := createMachine(ctx, g, ns.Name, "m1-unhealthy-", withMachineHealthCheckFailed())
+		controlPlane := &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						controlplanev1.RemediationInProgressAnnotation: mustMarshalRemediationData(&RemediationData{
+							Machine:    "foo",
+							Timestamp:  metav1.Time{Time: time.Now().UTC()},
+							RetryCount: 0,
+						}),
+					},
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Replicas: utilpointer.Int32Ptr(3),
+				},
+			},
+			Cluster:  &clusterv1.Cluster{},
+			Machines: collections.FromMachines(m),
+		}
+		ret, err := r.reconcileUnhealthyMachines(context.TODO(), controlPlane)
+
+		g.Expect(ret.IsZero()).To(BeTrue()) // Remediation skipped
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(env.Cleanup(ctx, m)).To(Succeed())
+	})
+	t.Run("Remediation cleans up a stale remediation in progress annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		// The replacement machine has been created after the remediation started, but the annotation
+		// tracking the remediation in progress has not been removed.
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "m1-replacement",
+				Namespace:         ns.Name,
+				CreationTimestamp: metav1.Now(),
+			},
+		}
+		controlPlane := &internal.ControlPlane{
+			KCP: &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						controlplanev1.RemediationInProgressAnnotation: mustMarshalRemediationData(&RemediationData{
+							Machine:    "m1-unhealthy",
+							Timestamp:  metav1.Time{Time: time.Now().Add(-time.Hour).UTC()},
+							RetryCount: 0,
+						}),
+					},
+				},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Replicas: utilpointer.Int32Ptr(3),
+				},
+			},
+			Cluster:  &clusterv1.Cluster{},
+			Machines: collections.FromMachines(m),
+		}
+		ret, err := r.reconcileUnhealthyMachines(context.TODO(), controlPlane)
+
+		g.Expect(ret.IsZero()).To(BeTrue()) // Remediation skipped
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(controlPlane.KCP.Annotations).ToNot(HaveKey(controlplanev1.RemediationInProgressAnnotation))
+	})
 	t.Run("Remediation does not happen if desired replicas <= 1", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -330,6 +396,12 @@ func TestReconcileUnhealthyMachines(t *testing.T) {
 
 		assertMachineCondition(ctx, g, m1, clusterv1.MachineOwnerRemediatedCondition, corev1.ConditionFalse, clusterv1.RemediationInProgressReason, clusterv1.ConditionSeverityWarning, "")
 
+		remediationData, err := RemediationDataFromAnnotation(controlPlane.KCP.Annotations[controlplanev1.RemediationInProgressAnnotation])
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(remediationData.Machine).To(Equal(m1.Name))
+		g.Expect(remediationData.RetryCount).To(Equal(int32(0)))
+		g.Expect(controlPlane.KCP.Status.LastRemediation).To(Equal(remediationData.ToStatus()))
+
 		err = env.Get(ctx, client.ObjectKey{Namespace: m1.Namespace, Name: m1.Name}, m1)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(m1.ObjectMeta.DeletionTimestamp.IsZero()).To(BeFalse())
@@ -767,6 +839,152 @@ func TestCanSafelyRemoveEtcdMember(t *testing.T) {
 	})
 }
 
+func TestCheckRetryLimits(t *testing.T) {
+	reconciliationTime := time.Now().UTC()
+
+	tests := []struct {
+		name                string
+		remediationStrategy *controlplanev1.RemediationStrategy
+		lastRemediation     *RemediationData
+		wantCanRemediate    bool
+		wantRetryCount      int32
+	}{
+		{
+			name:             "first remediation when there is no previous remediation",
+			lastRemediation:  nil,
+			wantCanRemediate: true,
+			wantRetryCount:   0,
+		},
+		{
+			name: "first remediation of a new sequence when the previous remediation is older than the default MinHealthyPeriod",
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-2 * controlplanev1.DefaultMinHealthyPeriod)},
+				RetryCount: 3,
+			},
+			wantCanRemediate: true,
+			wantRetryCount:   0,
+		},
+		{
+			name: "first remediation of a new sequence when the previous remediation is older than MinHealthyPeriod",
+			remediationStrategy: &controlplanev1.RemediationStrategy{
+				MinHealthyPeriod: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-20 * time.Minute)},
+				RetryCount: 3,
+			},
+			wantCanRemediate: true,
+			wantRetryCount:   0,
+		},
+		{
+			name: "retry when the previous remediation happened within MinHealthyPeriod",
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-10 * time.Minute)},
+				RetryCount: 1,
+			},
+			wantCanRemediate: true,
+			wantRetryCount:   2,
+		},
+		{
+			name: "no retry before RetryPeriod is expired",
+			remediationStrategy: &controlplanev1.RemediationStrategy{
+				RetryPeriod: metav1.Duration{Duration: 20 * time.Minute},
+			},
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-10 * time.Minute)},
+				RetryCount: 1,
+			},
+			wantCanRemediate: false,
+			wantRetryCount:   1,
+		},
+		{
+			name: "retry after RetryPeriod is expired",
+			remediationStrategy: &controlplanev1.RemediationStrategy{
+				RetryPeriod: metav1.Duration{Duration: 5 * time.Minute},
+			},
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-10 * time.Minute)},
+				RetryCount: 1,
+			},
+			wantCanRemediate: true,
+			wantRetryCount:   2,
+		},
+		{
+			name: "no retry when MaxRetry is reached",
+			remediationStrategy: &controlplanev1.RemediationStrategy{
+				MaxRetry: utilpointer.Int32Ptr(2),
+			},
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-10 * time.Minute)},
+				RetryCount: 2,
+			},
+			wantCanRemediate: false,
+			wantRetryCount:   2,
+		},
+		{
+			name: "retry when MaxRetry is not reached yet",
+			remediationStrategy: &controlplanev1.RemediationStrategy{
+				MaxRetry: utilpointer.Int32Ptr(2),
+			},
+			lastRemediation: &RemediationData{
+				Machine:    "m0",
+				Timestamp:  metav1.Time{Time: reconciliationTime.Add(-10 * time.Minute)},
+				RetryCount: 1,
+			},
+			wantCanRemediate: true,
+			wantRetryCount:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "m1",
+				},
+			}
+			if tt.lastRemediation != nil {
+				m.Annotations = map[string]string{
+					controlplanev1.RemediationForAnnotation: mustMarshalRemediationData(tt.lastRemediation),
+				}
+			}
+			controlPlane := &internal.ControlPlane{
+				KCP: &controlplanev1.KubeadmControlPlane{
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						RemediationStrategy: tt.remediationStrategy,
+					},
+				},
+			}
+
+			r := &KubeadmControlPlaneReconciler{}
+			remediationData, canRemediate, err := r.checkRetryLimits(ctrl.Log, m, controlPlane, reconciliationTime)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(canRemediate).To(Equal(tt.wantCanRemediate))
+			g.Expect(remediationData.Machine).To(Equal(m.Name))
+			g.Expect(remediationData.Timestamp.Time).To(Equal(reconciliationTime))
+			g.Expect(remediationData.RetryCount).To(Equal(tt.wantRetryCount))
+			if !tt.wantCanRemediate {
+				g.Expect(conditions.IsFalse(m, clusterv1.MachineOwnerRemediatedCondition)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func mustMarshalRemediationData(r *RemediationData) string {
+	value, err := r.Marshal()
+	if err != nil {
+		panic("failed to marshal remediation data")
+	}
+	return value
+}
+
 func nodes(machines collections.Machines) []string {
 	nodes := make([]string, 0, machines.Len())
 	for _, m := range machines {
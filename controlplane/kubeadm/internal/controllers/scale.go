@@ -30,6 +30,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -53,6 +54,11 @@ func (r *KubeadmControlPlaneReconciler) initializeControlPlane(ctx context.Conte
 	}
 
 	bootstrapSpec := controlPlane.InitialControlPlaneConfig()
+	if err := r.restoreEtcdSnapshot(ctx, cluster, kcp, bootstrapSpec); err != nil {
+		logger.Error(err, "Failed to restore etcd snapshot")
+		r.recorder.Eventf(kcp, corev1.EventTypeWarning, "FailedInitialization", "Failed to restore etcd snapshot for cluster %s/%s control plane: %v", cluster.Namespace, cluster.Name, err)
+		return ctrl.Result{}, err
+	}
 	fd := controlPlane.NextFailureDomainForScaleUp()
 	if err := r.cloneConfigsAndGenerateMachine(ctx, cluster, kcp, bootstrapSpec, fd); err != nil {
 		logger.Error(err, "Failed to create initial control plane Machine")
@@ -72,6 +78,14 @@ func (r *KubeadmControlPlaneReconciler) scaleUpControlPlane(ctx context.Context,
 		return result, err
 	}
 
+	// Make the new etcd member join the etcd cluster as a learner, so it does not count for quorum
+	// until it is in sync with the leader.
+	if feature.Gates.Enabled(feature.EtcdLearnerMode) && controlPlane.IsEtcdManaged() {
+		if err := r.enableEtcdLearnerMode(ctx, cluster, kcp); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Create the bootstrap configuration
 	bootstrapSpec := controlPlane.JoinControlPlaneConfig()
 	fd := controlPlane.NextFailureDomainForScaleUp()
@@ -85,6 +99,25 @@ func (r *KubeadmControlPlaneReconciler) scaleUpControlPlane(ctx context.Context,
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// enableEtcdLearnerMode enables the kubeadm EtcdLearnerMode feature gate in the kubeadm config map, so kubeadm join
+// adds new etcd members as learners; learners are then promoted to voting members while reconciling conditions.
+func (r *KubeadmControlPlaneReconciler) enableEtcdLearnerMode(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane) error {
+	workloadCluster, err := r.managementCluster.GetWorkloadCluster(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return errors.Wrap(err, "failed to get remote client for workload cluster")
+	}
+
+	parsedVersion, err := semver.ParseTolerant(kcp.Spec.Version)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse kubernetes version %q", kcp.Spec.Version)
+	}
+
+	if err := workloadCluster.EnableEtcdLearnerModeInKubeadmConfigMap(ctx, parsedVersion); err != nil {
+		return errors.Wrap(err, "failed to enable etcd learner mode in the kubeadm config map")
+	}
+	return nil
+}
+
 func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 	ctx context.Context,
 	cluster *clusterv1.Cluster,
@@ -186,6 +219,9 @@ func (r *KubeadmControlPlaneReconciler) preflightChecks(_ context.Context, contr
 			controlplanev1.MachineEtcdPodHealthyCondition,
 			controlplanev1.MachineEtcdMemberHealthyCondition,
 		)
+		if feature.Gates.Enabled(feature.EtcdLearnerMode) {
+			allMachineHealthConditions = append(allMachineHealthConditions, controlplanev1.MachineEtcdMemberVotingCondition)
+		}
 	}
 	machineErrors := []error{}
 
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -32,6 +33,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 )
@@ -406,10 +408,11 @@ func TestSelectMachineForScaleDown(t *testing.T) {
 
 func TestPreflightChecks(t *testing.T) {
 	testCases := []struct {
-		name         string
-		kcp          *controlplanev1.KubeadmControlPlane
-		machines     []*clusterv1.Machine
-		expectResult ctrl.Result
+		name            string
+		etcdLearnerMode bool
+		kcp             *controlplanev1.KubeadmControlPlane
+		machines        []*clusterv1.Machine
+		expectResult    ctrl.Result
 	}{
 		{
 			name:         "control plane without machines (not initialized) should pass",
@@ -471,12 +474,54 @@ func TestPreflightChecks(t *testing.T) {
 			},
 			expectResult: ctrl.Result{},
 		},
+		{
+			name:            "control plane with an etcd learner should requeue",
+			etcdLearnerMode: true,
+			kcp:             &controlplanev1.KubeadmControlPlane{},
+			machines: []*clusterv1.Machine{
+				{
+					Status: clusterv1.MachineStatus{
+						Conditions: clusterv1.Conditions{
+							*conditions.TrueCondition(controlplanev1.MachineAPIServerPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineControllerManagerPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineSchedulerPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineEtcdPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineEtcdMemberHealthyCondition),
+							*conditions.FalseCondition(controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberLearnerReason, clusterv1.ConditionSeverityInfo, ""),
+						},
+					},
+				},
+			},
+			expectResult: ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+		},
+		{
+			name:            "control plane with only voting etcd members should pass",
+			etcdLearnerMode: true,
+			kcp:             &controlplanev1.KubeadmControlPlane{},
+			machines: []*clusterv1.Machine{
+				{
+					Status: clusterv1.MachineStatus{
+						Conditions: clusterv1.Conditions{
+							*conditions.TrueCondition(controlplanev1.MachineAPIServerPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineControllerManagerPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineSchedulerPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineEtcdPodHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineEtcdMemberHealthyCondition),
+							*conditions.TrueCondition(controlplanev1.MachineEtcdMemberVotingCondition),
+						},
+					},
+				},
+			},
+			expectResult: ctrl.Result{},
+		},
 	}
 
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.EtcdLearnerMode, tt.etcdLearnerMode)()
+
 			r := &KubeadmControlPlaneReconciler{
 				recorder: record.NewFakeRecorder(32),
 			}
@@ -52,6 +52,16 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, kcp *c
 	}
 	kcp.Status.UpdatedReplicas = int32(len(controlPlane.UpToDateMachines()))
 
+	// Surface the last remediation, which is the remediation currently in progress, if any, or the most
+	// recent of the remediations tracked on machines.
+	lastRemediation, err := lastRemediationData(kcp, ownedMachines)
+	if err != nil {
+		return err
+	}
+	if lastRemediation != nil {
+		kcp.Status.LastRemediation = lastRemediation.ToStatus()
+	}
+
 	replicas := int32(len(ownedMachines))
 	desiredReplicas := *kcp.Spec.Replicas
 
@@ -118,3 +128,27 @@ func (r *KubeadmControlPlaneReconciler) updateStatus(ctx context.Context, kcp *c
 
 	return nil
 }
+
+// lastRemediationData returns the remediation currently in progress, if any, or the most recent of the
+// remediations tracked on machines.
+func lastRemediationData(kcp *controlplanev1.KubeadmControlPlane, machines collections.Machines) (*RemediationData, error) {
+	if value, ok := kcp.Annotations[controlplanev1.RemediationInProgressAnnotation]; ok {
+		return RemediationDataFromAnnotation(value)
+	}
+
+	var lastRemediation *RemediationData
+	for _, m := range machines {
+		value, ok := m.Annotations[controlplanev1.RemediationForAnnotation]
+		if !ok {
+			continue
+		}
+		remediationData, err := RemediationDataFromAnnotation(value)
+		if err != nil {
+			return nil, err
+		}
+		if lastRemediation == nil || lastRemediation.Timestamp.Before(&remediationData.Timestamp) {
+			lastRemediation = remediationData
+		}
+	}
+	return lastRemediation, nil
+}
@@ -19,11 +19,13 @@ package etcd
 import (
 	"context"
 	"crypto/tls"
+	"io"
 	"net"
 	"time"
 
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"google.golang.org/grpc"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -31,19 +33,26 @@ import (
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 )
 
+// ErrLearnerNotReady signals that an etcd learner can't be promoted because it is not in sync with the leader yet.
+var ErrLearnerNotReady = errors.New("etcd learner is not in sync with the leader yet")
+
 // GRPCDial is a function that creates a connection to a given endpoint.
 type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
+	MemberPromote(ctx context.Context, id uint64) (*clientv3.MemberPromoteResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
 	MemberUpdate(ctx context.Context, id uint64, peerURLs []string) (*clientv3.MemberUpdateResponse, error)
 	MoveLeader(ctx context.Context, id uint64) (*clientv3.MoveLeaderResponse, error)
+	Snapshot(ctx context.Context) (io.ReadCloser, error)
 	Status(ctx context.Context, endpoint string) (*clientv3.StatusResponse, error)
 }
 
@@ -85,6 +94,18 @@ var AlarmTypeName = map[AlarmType]string{
 	AlarmCorrupt: "CORRUPT",
 }
 
+// MemberStatus represents the status of the etcd member a client is connected to.
+type MemberStatus struct {
+	// ID is the ID of the member.
+	ID uint64
+
+	// DBSize is the size of the backend database of the member, in bytes, including the space not in use.
+	DBSize int64
+
+	// DBSizeInUse is the size of the backend database of the member, in bytes, that is actually in use.
+	DBSizeInUse int64
+}
+
 // Adapted from kubeadm.
 
 // Member struct defines an etcd member; it is used to avoid spreading
@@ -224,6 +245,16 @@ func (c *Client) RemoveMember(ctx context.Context, id uint64) error {
 	return errors.Wrapf(err, "failed to remove member: %v", id)
 }
 
+// PromoteMember promotes a learner member to a voting member.
+// If the learner is not in sync with the leader yet, ErrLearnerNotReady is returned.
+func (c *Client) PromoteMember(ctx context.Context, id uint64) error {
+	_, err := c.EtcdClient.MemberPromote(ctx, id)
+	if errors.Is(err, rpctypes.ErrMemberLearnerNotReady) {
+		return ErrLearnerNotReady
+	}
+	return errors.Wrapf(err, "failed to promote member: %v", id)
+}
+
 // UpdateMemberPeerURLs updates the list of peer URLs.
 func (c *Client) UpdateMemberPeerURLs(ctx context.Context, id uint64, peerURLs []string) ([]*Member, error) {
 	response, err := c.EtcdClient.MemberUpdate(ctx, id, peerURLs)
@@ -256,3 +287,49 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// Snapshot streams a point-in-time snapshot of the etcd database of the member the client is connected to into w,
+// and returns the number of bytes written.
+func (c *Client) Snapshot(ctx context.Context, w io.Writer) (int64, error) {
+	rc, err := c.EtcdClient.Snapshot(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to start etcd snapshot")
+	}
+	defer rc.Close()
+
+	n, err := io.Copy(w, rc)
+	if err != nil {
+		return n, errors.Wrap(err, "failed to read etcd snapshot")
+	}
+	return n, nil
+}
+
+// Status retrieves the status of the member the client is connected to.
+func (c *Client) Status(ctx context.Context) (*MemberStatus, error) {
+	status, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get etcd member status")
+	}
+
+	return &MemberStatus{
+		ID:          status.Header.GetMemberId(),
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
+	}, nil
+}
+
+// Defragment defragments the backend database of the member the client is connected to, releasing the space not in use.
+// NOTE: The member can't serve any request while it is being defragmented.
+func (c *Client) Defragment(ctx context.Context) error {
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrapf(err, "failed to defragment etcd member: %v", c.Endpoint)
+}
+
+// DisarmAlarm disarms an alarm of the given type raised for a member.
+func (c *Client) DisarmAlarm(ctx context.Context, memberID uint64, alarm AlarmType) error {
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: memberID,
+		Alarm:    etcdserverpb.AlarmType(alarm),
+	})
+	return errors.Wrapf(err, "failed to disarm %s alarm for member: %v", AlarmTypeName[alarm], memberID)
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package etcd
 
 import (
+	"bytes"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -61,6 +63,23 @@ func TestEtcdMembers_WithErrors(t *testing.T) {
 
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).To(HaveOccurred())
+
+	_, err = client.Snapshot(ctx, &bytes.Buffer{})
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.Defragment(ctx)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.DisarmAlarm(ctx, 1234, AlarmNoSpace)
+	g.Expect(err).To(HaveOccurred())
+
+	err = client.PromoteMember(ctx, 1234)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.Is(err, ErrLearnerNotReady)).To(BeFalse())
+
+	fakeEtcdClient.ErrorResponse = rpctypes.ErrMemberLearnerNotReady
+	err = client.PromoteMember(ctx, 1234)
+	g.Expect(errors.Is(err, ErrLearnerNotReady)).To(BeTrue())
 }
 
 func TestEtcdMembers_WithSuccess(t *testing.T) {
@@ -81,9 +100,17 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 				{ID: 1234, Name: "foo", PeerURLs: []string{"https://1.2.3.4:2000", "https://4.5.6.7:2000"}},
 			},
 		},
-		MemberRemoveResponse: &clientv3.MemberRemoveResponse{},
-		AlarmResponse:        &clientv3.AlarmResponse{},
-		StatusResponse:       &clientv3.StatusResponse{},
+		MemberRemoveResponse:  &clientv3.MemberRemoveResponse{},
+		MemberPromoteResponse: &clientv3.MemberPromoteResponse{},
+		AlarmResponse:         &clientv3.AlarmResponse{},
+		AlarmDisarmResponse:   &clientv3.AlarmResponse{},
+		DefragmentResponse:    &clientv3.DefragmentResponse{},
+		StatusResponse: &clientv3.StatusResponse{
+			Header:      &etcdserverpb.ResponseHeader{MemberId: 1234},
+			DbSize:      200,
+			DbSizeInUse: 50,
+		},
+		SnapshotResponse: []byte("snapshot"),
 	}
 
 	client, err := newEtcdClient(ctx, fakeEtcdClient)
@@ -99,8 +126,30 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	err = client.RemoveMember(ctx, 1234)
 	g.Expect(err).NotTo(HaveOccurred())
 
+	err = client.PromoteMember(ctx, 1234)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fakeEtcdClient.PromotedMember).To(BeEquivalentTo(1234))
+
 	updatedMembers, err := client.UpdateMemberPeerURLs(ctx, 1234, []string{"https://4.5.6.7:2000"})
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(len(updatedMembers[0].PeerURLs)).To(Equal(2))
 	g.Expect(updatedMembers[0].PeerURLs).To(Equal([]string{"https://1.2.3.4:2000", "https://4.5.6.7:2000"}))
+
+	snapshot := &bytes.Buffer{}
+	n, err := client.Snapshot(ctx, snapshot)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(n).To(BeEquivalentTo(8))
+	g.Expect(snapshot.String()).To(Equal("snapshot"))
+
+	status, err := client.Status(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(status).To(Equal(&MemberStatus{ID: 1234, DBSize: 200, DBSizeInUse: 50}))
+
+	err = client.Defragment(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fakeEtcdClient.DefragmentedEndpoint).To(Equal("https://etcd-instance:2379"))
+
+	err = client.DisarmAlarm(ctx, 1234, AlarmNoSpace)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(fakeEtcdClient.DisarmedAlarm).To(Equal(&clientv3.AlarmMember{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}))
 }
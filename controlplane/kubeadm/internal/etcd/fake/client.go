@@ -18,22 +18,31 @@ limitations under the License.
 package fake
 
 import (
+	"bytes"
 	"context"
+	"io"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 )
 
 type FakeEtcdClient struct { //nolint:revive
-	AlarmResponse        *clientv3.AlarmResponse
-	EtcdEndpoints        []string
-	MemberListResponse   *clientv3.MemberListResponse
-	MemberRemoveResponse *clientv3.MemberRemoveResponse
-	MemberUpdateResponse *clientv3.MemberUpdateResponse
-	MoveLeaderResponse   *clientv3.MoveLeaderResponse
-	StatusResponse       *clientv3.StatusResponse
-	ErrorResponse        error
-	MovedLeader          uint64
-	RemovedMember        uint64
+	AlarmResponse         *clientv3.AlarmResponse
+	AlarmDisarmResponse   *clientv3.AlarmResponse
+	DefragmentResponse    *clientv3.DefragmentResponse
+	EtcdEndpoints         []string
+	MemberListResponse    *clientv3.MemberListResponse
+	MemberPromoteResponse *clientv3.MemberPromoteResponse
+	MemberRemoveResponse  *clientv3.MemberRemoveResponse
+	MemberUpdateResponse  *clientv3.MemberUpdateResponse
+	MoveLeaderResponse    *clientv3.MoveLeaderResponse
+	StatusResponse        *clientv3.StatusResponse
+	SnapshotResponse      []byte
+	ErrorResponse         error
+	MovedLeader           uint64
+	RemovedMember         uint64
+	PromotedMember        uint64
+	DisarmedAlarm         *clientv3.AlarmMember
+	DefragmentedEndpoint  string
 }
 
 func (c *FakeEtcdClient) Endpoints() []string {
@@ -49,13 +58,27 @@ func (c *FakeEtcdClient) Close() error {
 	return nil
 }
 
+func (c *FakeEtcdClient) AlarmDisarm(_ context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	c.DisarmedAlarm = m
+	return c.AlarmDisarmResponse, c.ErrorResponse
+}
+
 func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse, error) {
 	return c.AlarmResponse, c.ErrorResponse
 }
 
+func (c *FakeEtcdClient) Defragment(_ context.Context, endpoint string) (*clientv3.DefragmentResponse, error) {
+	c.DefragmentedEndpoint = endpoint
+	return c.DefragmentResponse, c.ErrorResponse
+}
+
 func (c *FakeEtcdClient) MemberList(_ context.Context) (*clientv3.MemberListResponse, error) {
 	return c.MemberListResponse, c.ErrorResponse
 }
+func (c *FakeEtcdClient) MemberPromote(_ context.Context, i uint64) (*clientv3.MemberPromoteResponse, error) {
+	c.PromotedMember = i
+	return c.MemberPromoteResponse, c.ErrorResponse
+}
 func (c *FakeEtcdClient) MemberRemove(_ context.Context, i uint64) (*clientv3.MemberRemoveResponse, error) {
 	c.RemovedMember = i
 	return c.MemberRemoveResponse, c.ErrorResponse
@@ -63,6 +86,9 @@ func (c *FakeEtcdClient) MemberRemove(_ context.Context, i uint64) (*clientv3.Me
 func (c *FakeEtcdClient) MemberUpdate(_ context.Context, _ uint64, _ []string) (*clientv3.MemberUpdateResponse, error) {
 	return c.MemberUpdateResponse, c.ErrorResponse
 }
+func (c *FakeEtcdClient) Snapshot(_ context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(c.SnapshotResponse)), c.ErrorResponse
+}
 func (c *FakeEtcdClient) Status(_ context.Context, _ string) (*clientv3.StatusResponse, error) {
 	return c.StatusResponse, nil
 }
@@ -0,0 +1,118 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	capimetrics "sigs.k8s.io/cluster-api/internal/metrics"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+const (
+	etcdMemberHealthy   = "healthy"
+	etcdMemberUnhealthy = "unhealthy"
+	etcdMemberUnknown   = "unknown"
+)
+
+var etcdMembersDesc = prometheus.NewDesc(
+	"capi_kubeadmcontrolplane_etcd_members",
+	"The number of etcd members of a KubeadmControlPlane with managed etcd, grouped by health as reported by the control plane Machines.",
+	[]string{"namespace", "name", "health"}, nil,
+)
+
+// Collector collects metrics about KubeadmControlPlane objects at scrape time.
+// NOTE: Objects are read using the client provided by the manager, so metrics are computed from
+// the cache without additional calls to the API server or to the workload clusters.
+type Collector struct {
+	client client.Reader
+}
+
+// NewCollector returns a new Collector reading objects using the given client.
+func NewCollector(c client.Reader) *Collector {
+	return &Collector{client: c}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- etcdMembersDesc
+	ch <- capimetrics.ConditionDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	if err := c.collect(context.Background(), ch); err != nil {
+		ctrl.Log.WithName("metrics").Error(err, "failed to collect KubeadmControlPlane metrics")
+	}
+}
+
+func (c *Collector) collect(ctx context.Context, ch chan<- prometheus.Metric) error {
+	kcps := &controlplanev1.KubeadmControlPlaneList{}
+	if err := c.client.List(ctx, kcps); err != nil {
+		return err
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := c.client.List(ctx, machineList, client.HasLabels{clusterv1.MachineControlPlaneLabelName}); err != nil {
+		return err
+	}
+	machines := collections.FromMachineList(machineList)
+
+	for i := range kcps.Items {
+		kcp := &kcps.Items[i]
+		capimetrics.CollectConditions(ch, "KubeadmControlPlane", kcp)
+
+		// If etcd is not managed by KCP there are no etcd members to report.
+		if kcp.Spec.KubeadmConfigSpec.ClusterConfiguration != nil && kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.External != nil {
+			continue
+		}
+
+		members := map[string]int{
+			etcdMemberHealthy:   0,
+			etcdMemberUnhealthy: 0,
+			etcdMemberUnknown:   0,
+		}
+		for _, machine := range machines.Filter(collections.OwnedMachines(kcp)) {
+			members[etcdMemberHealth(machine)]++
+		}
+		for health, count := range members {
+			ch <- prometheus.MustNewConstMetric(etcdMembersDesc, prometheus.GaugeValue, float64(count),
+				kcp.Namespace, kcp.Name, health,
+			)
+		}
+	}
+	return nil
+}
+
+// etcdMemberHealth returns the health of the etcd member hosted on a control plane Machine.
+func etcdMemberHealth(machine *clusterv1.Machine) string {
+	switch {
+	case conditions.IsTrue(machine, controlplanev1.MachineEtcdMemberHealthyCondition):
+		return etcdMemberHealthy
+	case conditions.IsFalse(machine, controlplanev1.MachineEtcdMemberHealthyCondition):
+		return etcdMemberUnhealthy
+	default:
+		return etcdMemberUnknown
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestCollector(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = controlplanev1.AddToScheme(scheme)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta:   metav1.TypeMeta{APIVersion: controlplanev1.GroupVersion.String(), Kind: "KubeadmControlPlane"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "kcp", UID: "kcp-uid"},
+	}
+	kcpExternalEtcd := &controlplanev1.KubeadmControlPlane{
+		TypeMeta:   metav1.TypeMeta{APIVersion: controlplanev1.GroupVersion.String(), Kind: "KubeadmControlPlane"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "kcp-external-etcd", UID: "kcp-external-etcd-uid"},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+				},
+			},
+		},
+	}
+
+	newMachine := func(name string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns1",
+				Name:      name,
+				Labels:    map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+				OwnerReferences: []metav1.OwnerReference{
+					{APIVersion: controlplanev1.GroupVersion.String(), Kind: "KubeadmControlPlane", Name: kcp.Name, UID: kcp.UID},
+				},
+			},
+		}
+	}
+	healthy1 := newMachine("healthy-1")
+	conditions.MarkTrue(healthy1, controlplanev1.MachineEtcdMemberHealthyCondition)
+	healthy2 := newMachine("healthy-2")
+	conditions.MarkTrue(healthy2, controlplanev1.MachineEtcdMemberHealthyCondition)
+	unhealthy := newMachine("unhealthy")
+	conditions.MarkFalse(unhealthy, controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberUnhealthyReason, clusterv1.ConditionSeverityError, "")
+	provisioning := newMachine("provisioning")
+
+	c := NewCollector(fake.NewClientBuilder().WithScheme(scheme).WithObjects(kcp, kcpExternalEtcd, healthy1, healthy2, unhealthy, provisioning).Build())
+
+	expected := `
+# HELP capi_kubeadmcontrolplane_etcd_members The number of etcd members of a KubeadmControlPlane with managed etcd, grouped by health as reported by the control plane Machines.
+# TYPE capi_kubeadmcontrolplane_etcd_members gauge
+capi_kubeadmcontrolplane_etcd_members{health="healthy",name="kcp",namespace="ns1"} 2
+capi_kubeadmcontrolplane_etcd_members{health="unhealthy",name="kcp",namespace="ns1"} 1
+capi_kubeadmcontrolplane_etcd_members{health="unknown",name="kcp",namespace="ns1"} 1
+`
+	g.Expect(testutil.CollectAndCompare(c, strings.NewReader(expected), "capi_kubeadmcontrolplane_etcd_members")).To(Succeed())
+}
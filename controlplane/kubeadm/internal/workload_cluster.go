@@ -25,6 +25,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"math/big"
 	"reflect"
 	"time"
@@ -37,6 +38,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -45,6 +47,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/certs"
 	containerutil "sigs.k8s.io/cluster-api/util/container"
@@ -81,6 +84,12 @@ var (
 	// NOTE: The following assumes that kubeadm version equals to Kubernetes version.
 	minVerUnversionedKubeletConfig = semver.MustParse("1.24.0")
 
+	// Starting from v1.27.0 kubeadm supports the EtcdLearnerMode feature gate, which makes
+	// kubeadm join add new etcd members as learners.
+	//
+	// NOTE: The following assumes that kubeadm version equals to Kubernetes version.
+	minVerEtcdLearnerMode = semver.MustParse("1.27.0")
+
 	// ErrControlPlaneMinNodes signals that a cluster doesn't meet the minimum required nodes
 	// to remove an etcd member.
 	ErrControlPlaneMinNodes = errors.New("cluster has fewer than 2 control plane nodes; removing an etcd member is not supported")
@@ -103,6 +112,7 @@ type WorkloadCluster interface {
 	UpdateImageRepositoryInKubeadmConfigMap(ctx context.Context, imageRepository string, version semver.Version) error
 	UpdateEtcdVersionInKubeadmConfigMap(ctx context.Context, imageRepository, imageTag string, version semver.Version) error
 	UpdateEtcdExtraArgsInKubeadmConfigMap(ctx context.Context, extraArgs map[string]string, version semver.Version) error
+	EnableEtcdLearnerModeInKubeadmConfigMap(ctx context.Context, version semver.Version) error
 	UpdateAPIServerInKubeadmConfigMap(ctx context.Context, apiServer bootstrapv1.APIServer, version semver.Version) error
 	UpdateControllerManagerInKubeadmConfigMap(ctx context.Context, controllerManager bootstrapv1.ControlPlaneComponent, version semver.Version) error
 	UpdateSchedulerInKubeadmConfigMap(ctx context.Context, scheduler bootstrapv1.ControlPlaneComponent, version semver.Version) error
@@ -114,9 +124,15 @@ type WorkloadCluster interface {
 	RemoveNodeFromKubeadmConfigMap(ctx context.Context, nodeName string, version semver.Version) error
 	ForwardEtcdLeadership(ctx context.Context, machine *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error
 	AllowBootstrapTokensToGetNodes(ctx context.Context) error
+	GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error)
 
 	// State recovery tasks.
 	ReconcileEtcdMembers(ctx context.Context, nodeNames []string, version semver.Version) ([]string, error)
+	PromoteEtcdLearners(ctx context.Context, controlPlane *ControlPlane)
+	DefragmentEtcd(ctx context.Context, nodeNames []string, input EtcdDefragmentationInput) (*EtcdDefragmentationResult, error)
+
+	// Backup tasks.
+	SnapshotEtcd(ctx context.Context, snapshot io.Writer) (int64, error)
 }
 
 // Workload defines operations on workload clusters.
@@ -124,6 +140,7 @@ type Workload struct {
 	Client              ctrlclient.Client
 	CoreDNSMigrator     coreDNSMigrator
 	etcdClientGenerator etcdClientFor
+	restConfig          *rest.Config
 }
 
 var _ WorkloadCluster = &Workload{}
@@ -469,6 +486,60 @@ func newClientCert(caCert *x509.Certificate, key *rsa.PrivateKey, caKey crypto.S
 	return c, errors.WithStack(err)
 }
 
+// GetAPIServerCertificateExpiry returns the certificate expiry of the apiserver on the given node.
+func (w *Workload) GetAPIServerCertificateExpiry(ctx context.Context, kubeadmConfig *bootstrapv1.KubeadmConfig, nodeName string) (*time.Time, error) {
+	// Create a proxy to the kube-apiserver Pod running on the node.
+	p := proxy.Proxy{
+		Kind:       "pods",
+		Namespace:  metav1.NamespaceSystem,
+		KubeConfig: w.restConfig,
+		Port:       int(calculateAPIServerPort(kubeadmConfig)),
+	}
+
+	dialer, err := proxy.NewDialer(p)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get certificate expiry for kube-apiserver on Node/%s: failed to create dialer", nodeName)
+	}
+
+	rawConn, err := dialer.DialContextWithAddr(ctx, staticPodName("kube-apiserver", nodeName))
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get certificate expiry for kube-apiserver on Node/%s: unable to dial to kube-apiserver", nodeName)
+	}
+
+	// Execute a TLS handshake over the connection to the kube-apiserver, so we can read the certificate it is serving.
+	// NOTE: The certificate is not verified because we are only interested in its expiry.
+	conn := tls.Client(rawConn, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec
+	if err := conn.HandshakeContext(ctx); err != nil {
+		_ = rawConn.Close()
+		return nil, errors.Wrapf(err, "unable to get certificate expiry for kube-apiserver on Node/%s: TLS handshake with the kube-apiserver failed", nodeName)
+	}
+	defer conn.Close()
+
+	// Return the expiry of the peer certificate with cn=kube-apiserver, which is the one generated by kubeadm.
+	for _, cert := range conn.ConnectionState().PeerCertificates {
+		if cert.Subject.CommonName == "kube-apiserver" {
+			return &cert.NotAfter, nil
+		}
+	}
+	return nil, errors.Errorf("unable to get certificate expiry for kube-apiserver on Node/%s: couldn't get peer certificate with cn=kube-apiserver", nodeName)
+}
+
+// calculateAPIServerPort returns the port the kube-apiserver is listening on, as configured in the KubeadmConfig.
+func calculateAPIServerPort(config *bootstrapv1.KubeadmConfig) int32 {
+	if config.Spec.InitConfiguration != nil &&
+		config.Spec.InitConfiguration.LocalAPIEndpoint.BindPort != 0 {
+		return config.Spec.InitConfiguration.LocalAPIEndpoint.BindPort
+	}
+
+	if config.Spec.JoinConfiguration != nil &&
+		config.Spec.JoinConfiguration.ControlPlane != nil &&
+		config.Spec.JoinConfiguration.ControlPlane.LocalAPIEndpoint.BindPort != 0 {
+		return config.Spec.JoinConfiguration.ControlPlane.LocalAPIEndpoint.BindPort
+	}
+
+	return 6443
+}
+
 func staticPodName(component, nodeName string) string {
 	return fmt.Sprintf("%s-%s", component, nodeName)
 }
@@ -18,6 +18,9 @@ package internal
 
 import (
 	"context"
+	"io"
+	"sort"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -25,10 +28,15 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	etcdutil "sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
+// etcdLearnerModeFeatureGate is the kubeadm feature gate making kubeadm join add new etcd members as learners.
+const etcdLearnerModeFeatureGate = "EtcdLearnerMode"
+
 type etcdClientFor interface {
 	forFirstAvailableNode(ctx context.Context, nodeNames []string) (*etcd.Client, error)
 	forLeader(ctx context.Context, nodeNames []string) (*etcd.Client, error)
@@ -111,6 +119,21 @@ func (w *Workload) UpdateEtcdExtraArgsInKubeadmConfigMap(ctx context.Context, ex
 	}, version)
 }
 
+// EnableEtcdLearnerModeInKubeadmConfigMap enables the kubeadm EtcdLearnerMode feature gate in the kubeadm config map,
+// so new control plane nodes join the etcd cluster as learners.
+// This is a no-op for Kubernetes versions older than v1.27.0, given that kubeadm does not support this feature gate.
+func (w *Workload) EnableEtcdLearnerModeInKubeadmConfigMap(ctx context.Context, version semver.Version) error {
+	if version.LT(minVerEtcdLearnerMode) {
+		return nil
+	}
+	return w.updateClusterConfiguration(ctx, func(c *bootstrapv1.ClusterConfiguration) {
+		if c.FeatureGates == nil {
+			c.FeatureGates = map[string]bool{}
+		}
+		c.FeatureGates[etcdLearnerModeFeatureGate] = true
+	}, version)
+}
+
 // RemoveEtcdMemberForMachine removes the etcd member from the target cluster's etcd cluster.
 // Removing the last remaining member of the cluster is not supported.
 func (w *Workload) RemoveEtcdMemberForMachine(ctx context.Context, machine *clusterv1.Machine) error {
@@ -210,6 +233,68 @@ func (w *Workload) ForwardEtcdLeadership(ctx context.Context, machine *clusterv1
 	return nil
 }
 
+// PromoteEtcdLearners promotes the etcd members that joined the cluster as learners to voting members,
+// once they are in sync with the leader, and reports whether the etcd member of each machine is a voting member
+// using the MachineEtcdMemberVotingCondition.
+func (w *Workload) PromoteEtcdLearners(ctx context.Context, controlPlane *ControlPlane) {
+	// Collect the machines with a node; the others are still provisioning, so they can't have an etcd member yet.
+	machines := []*clusterv1.Machine{}
+	nodeNames := []string{}
+	for _, machine := range controlPlane.Machines {
+		if machine.Status.NodeRef == nil || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		machines = append(machines, machine)
+		nodeNames = append(nodeNames, machine.Status.NodeRef.Name)
+	}
+	if len(machines) == 0 {
+		return
+	}
+
+	// NOTE: Using the leader given that it is the one deciding if a learner is in sync.
+	etcdClient, err := w.etcdClientGenerator.forLeader(ctx, nodeNames)
+	if err != nil {
+		for _, machine := range machines {
+			conditions.MarkUnknown(machine, controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberInspectionFailedReason, "Failed to connect to the etcd leader: %s", err)
+		}
+		return
+	}
+	defer etcdClient.Close()
+
+	members, err := etcdClient.Members(ctx)
+	if err != nil {
+		for _, machine := range machines {
+			conditions.MarkUnknown(machine, controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberInspectionFailedReason, "Failed to list etcd members: %s", err)
+		}
+		return
+	}
+
+	for _, machine := range machines {
+		// NOTE: A learner has an empty name until its etcd Pod starts.
+		member := etcdutil.MemberForName(members, machine.Status.NodeRef.Name)
+		if member == nil {
+			conditions.MarkUnknown(machine, controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberInspectionFailedReason, "Failed to get the etcd member for the %s node", machine.Status.NodeRef.Name)
+			continue
+		}
+
+		if !member.IsLearner {
+			conditions.MarkTrue(machine, controlplanev1.MachineEtcdMemberVotingCondition)
+			continue
+		}
+
+		// Etcd refuses to promote a learner which is not in sync with the leader, so it is safe to try.
+		err := etcdClient.PromoteMember(ctx, member.ID)
+		switch {
+		case err == nil:
+			conditions.MarkTrue(machine, controlplanev1.MachineEtcdMemberVotingCondition)
+		case errors.Is(err, etcd.ErrLearnerNotReady):
+			conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberLearnerReason, clusterv1.ConditionSeverityInfo, "Etcd member is a learner catching up with the leader")
+		default:
+			conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberPromotionFailedReason, clusterv1.ConditionSeverityWarning, "Failed to promote the etcd member: %s", err)
+		}
+	}
+}
+
 // EtcdMemberStatus contains status information for a single etcd member.
 type EtcdMemberStatus struct {
 	Name       string
@@ -247,3 +332,177 @@ func (w *Workload) EtcdMembers(ctx context.Context) ([]string, error) {
 	}
 	return names, nil
 }
+
+// SnapshotEtcd streams a snapshot of the etcd database into w, and returns the size of the snapshot.
+// The snapshot is taken from the etcd leader, given that it is guaranteed to have the most recent data.
+func (w *Workload) SnapshotEtcd(ctx context.Context, snapshot io.Writer) (int64, error) {
+	nodes, err := w.getControlPlaneNodes(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to list control plane nodes")
+	}
+	nodeNames := make([]string, 0, len(nodes.Items))
+	for _, node := range nodes.Items {
+		nodeNames = append(nodeNames, node.Name)
+	}
+	etcdClient, err := w.etcdClientGenerator.forLeader(ctx, nodeNames)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.Snapshot(ctx, snapshot)
+}
+
+// EtcdDefragmentationInput defines when etcd members are defragmented and when their NOSPACE alarms are disarmed.
+type EtcdDefragmentationInput struct {
+	// MinDBSize is the size of the database, in bytes, above which a member is considered for defragmentation.
+	MinDBSize int64
+
+	// MinFragmentationPercent is the minimum percentage of the database not in use for a member to be defragmented.
+	MinFragmentationPercent int32
+
+	// QuotaBackendBytes is the size of the database, in bytes, above which etcd raises a NOSPACE alarm.
+	QuotaBackendBytes int64
+
+	// MinInterval is the minimum time between two defragmentations of the same member.
+	MinInterval time.Duration
+
+	// LastDefragmentations are the times the members have been last defragmented, by node name.
+	LastDefragmentations map[string]time.Time
+}
+
+// EtcdDefragmentationResult reports the changes applied to the etcd cluster by DefragmentEtcd.
+type EtcdDefragmentationResult struct {
+	// DefragmentedMember is the name of the member that has been defragmented, if any.
+	DefragmentedMember string
+
+	// DisarmedMembers are the names of the members whose NOSPACE alarm has been disarmed.
+	DisarmedMembers []string
+}
+
+// etcdMemberDefragmentationStatus is the status of an etcd member, as used to decide whether it should be defragmented.
+type etcdMemberDefragmentationStatus struct {
+	nodeName string
+	client   *etcd.Client
+	member   *etcd.Member
+	status   *etcd.MemberStatus
+}
+
+func (s *etcdMemberDefragmentationStatus) hasAlarm(alarm etcd.AlarmType) bool {
+	for _, a := range s.member.Alarms {
+		if a == alarm {
+			return true
+		}
+	}
+	return false
+}
+
+// DefragmentEtcd defragments at most one etcd member, and then disarms the NOSPACE alarms of the members whose database is back under quota.
+// A member is defragmented when its database is bigger than MinDBSize and at least MinFragmentationPercent of it is not in use,
+// or when it has a NOSPACE alarm and defragmentation brings its database back under quota; members defragmented less
+// than MinInterval ago are skipped, members are considered in node name order, and the etcd leader is defragmented
+// only if there are no other members to defragment.
+//
+// NOTE: A member can't serve any request while it is being defragmented, so this func refuses to operate if any member can't be reached;
+// it is up to the caller to ensure the control plane is stable, e.g. not rolling out, before calling it.
+func (w *Workload) DefragmentEtcd(ctx context.Context, nodeNames []string, input EtcdDefragmentationInput) (*EtcdDefragmentationResult, error) {
+	nodeNames = append([]string{}, nodeNames...)
+	sort.Strings(nodeNames)
+
+	// Create an etcd Client for each etcd Pod and get the status of the corresponding member.
+	members := make([]*etcdMemberDefragmentationStatus, 0, len(nodeNames))
+	defer func() {
+		for _, m := range members {
+			m.client.Close()
+		}
+	}()
+	for _, nodeName := range nodeNames {
+		etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create etcd client for node %q", nodeName)
+		}
+		members = append(members, &etcdMemberDefragmentationStatus{nodeName: nodeName, client: etcdClient})
+
+		status, err := etcdClient.Status(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get etcd member status for node %q", nodeName)
+		}
+		members[len(members)-1].status = status
+	}
+	if len(members) == 0 {
+		return &EtcdDefragmentationResult{}, nil
+	}
+
+	// List etcd members, including their alarms, and match them with the nodes.
+	etcdMembers, err := members[0].client.Members(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list etcd members using etcd client")
+	}
+	var leader *etcdMemberDefragmentationStatus
+	for _, m := range members {
+		m.member = etcdutil.MemberForName(etcdMembers, m.nodeName)
+		if m.member == nil || m.member.ID != m.status.ID {
+			return nil, errors.Errorf("failed to get etcd member for node %q", m.nodeName)
+		}
+		if m.member.ID == members[0].client.LeaderID {
+			leader = m
+		}
+	}
+
+	// Defragment the first member in need, leaving the leader as the last option.
+	result := &EtcdDefragmentationResult{}
+	candidates := make([]*etcdMemberDefragmentationStatus, 0, len(members))
+	for _, m := range members {
+		if m != leader {
+			candidates = append(candidates, m)
+		}
+	}
+	if leader != nil {
+		candidates = append(candidates, leader)
+	}
+	for _, m := range candidates {
+		if !needsDefragmentation(m, input) {
+			continue
+		}
+		if err := m.client.Defragment(ctx); err != nil {
+			return nil, err
+		}
+		result.DefragmentedMember = m.nodeName
+
+		status, err := m.client.Status(ctx)
+		if err != nil {
+			return result, errors.Wrapf(err, "failed to get etcd member status for node %q", m.nodeName)
+		}
+		m.status = status
+		break
+	}
+
+	// Disarm the NOSPACE alarms of the members with enough space available.
+	for _, m := range members {
+		if !m.hasAlarm(etcd.AlarmNoSpace) || m.status.DBSize >= input.QuotaBackendBytes {
+			continue
+		}
+		if err := m.client.DisarmAlarm(ctx, m.member.ID, etcd.AlarmNoSpace); err != nil {
+			return result, err
+		}
+		result.DisarmedMembers = append(result.DisarmedMembers, m.nodeName)
+	}
+
+	return result, nil
+}
+
+func needsDefragmentation(m *etcdMemberDefragmentationStatus, input EtcdDefragmentationInput) bool {
+	if last, ok := input.LastDefragmentations[m.nodeName]; ok && time.Since(last) < input.MinInterval {
+		return false
+	}
+	reclaimable := m.status.DBSize - m.status.DBSizeInUse
+	if reclaimable <= 0 {
+		return false
+	}
+	// A member with a NOSPACE alarm is defragmented only if this brings its database back under quota,
+	// otherwise the alarm can't be disarmed anyway and the usual thresholds apply.
+	if m.hasAlarm(etcd.AlarmNoSpace) && m.status.DBSizeInUse < input.QuotaBackendBytes {
+		return true
+	}
+	return m.status.DBSize >= input.MinDBSize && reclaimable*100 >= int64(input.MinFragmentationPercent)*m.status.DBSize
+}
@@ -17,14 +17,17 @@ limitations under the License.
 package internal
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -32,8 +35,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	fake2 "sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd/fake"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/yaml"
 )
 
@@ -200,6 +206,80 @@ func TestUpdateEtcdExtraArgsInKubeadmConfigMap(t *testing.T) {
 	}
 }
 
+func TestEnableEtcdLearnerModeInKubeadmConfigMap(t *testing.T) {
+	tests := []struct {
+		name                     string
+		version                  semver.Version
+		clusterConfigurationData string
+		wantClusterConfiguration string
+	}{
+		{
+			name:    "it should enable the EtcdLearnerMode feature gate",
+			version: semver.MustParse("1.27.1"),
+			clusterConfigurationData: yaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration
+				featureGates:
+				  foo: true
+				`),
+			wantClusterConfiguration: yaml.Raw(`
+				apiServer: {}
+				apiVersion: kubeadm.k8s.io/v1beta3
+				controllerManager: {}
+				dns: {}
+				etcd: {}
+				featureGates:
+				  EtcdLearnerMode: true
+				  foo: true
+				kind: ClusterConfiguration
+				networking: {}
+				scheduler: {}
+				`),
+		},
+		{
+			name:    "no op for Kubernetes < 1.27",
+			version: semver.MustParse("1.26.4"),
+			clusterConfigurationData: yaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration
+				`),
+			wantClusterConfiguration: yaml.Raw(`
+				apiVersion: kubeadm.k8s.io/v1beta3
+				kind: ClusterConfiguration
+				`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			fakeClient := fake.NewClientBuilder().WithObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      kubeadmConfigKey,
+					Namespace: metav1.NamespaceSystem,
+				},
+				Data: map[string]string{
+					clusterConfigurationKey: tt.clusterConfigurationData,
+				},
+			}).Build()
+
+			w := &Workload{
+				Client: fakeClient,
+			}
+			err := w.EnableEtcdLearnerModeInKubeadmConfigMap(ctx, tt.version)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			var actualConfig corev1.ConfigMap
+			g.Expect(w.Client.Get(
+				ctx,
+				client.ObjectKey{Name: kubeadmConfigKey, Namespace: metav1.NamespaceSystem},
+				&actualConfig,
+			)).To(Succeed())
+			g.Expect(actualConfig.Data[clusterConfigurationKey]).Should(Equal(tt.wantClusterConfiguration), cmp.Diff(tt.wantClusterConfiguration, actualConfig.Data[clusterConfigurationKey]))
+		})
+	}
+}
+
 func TestRemoveEtcdMemberForMachine(t *testing.T) {
 	machine := &clusterv1.Machine{
 		Status: clusterv1.MachineStatus{
@@ -527,6 +607,391 @@ func TestForwardEtcdLeadership(t *testing.T) {
 	})
 }
 
+func TestSnapshotEtcd(t *testing.T) {
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		expectErr           bool
+	}{
+		{
+			name: "streams the snapshot from the etcd leader",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						SnapshotResponse: []byte("snapshot"),
+					},
+				},
+			},
+		},
+		{
+			name: "returns error if it fails to connect to the etcd leader",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderErr: errors.New("no leader"),
+			},
+			expectErr: true,
+		},
+		{
+			name: "returns error if the snapshot fails",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						ErrorResponse: errors.New("snapshot failed"),
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			w := &Workload{
+				Client: &fakeClient{list: &corev1.NodeList{
+					Items: []corev1.Node{nodeNamed("leader-node")},
+				}},
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			snapshot := &bytes.Buffer{}
+			n, err := w.SnapshotEtcd(ctx, snapshot)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(n).To(BeEquivalentTo(8))
+			g.Expect(snapshot.String()).To(Equal("snapshot"))
+		})
+	}
+}
+
+func TestDefragmentEtcd(t *testing.T) {
+	const mib = 1024 * 1024
+	input := EtcdDefragmentationInput{
+		MinDBSize:               100 * mib,
+		MinFragmentationPercent: 50,
+		QuotaBackendBytes:       2 * 1024 * mib,
+	}
+
+	type memberStatus struct {
+		dbSize      int64
+		dbSizeInUse int64
+		noSpace     bool
+	}
+	tests := []struct {
+		name                    string
+		members                 map[string]memberStatus
+		input                   EtcdDefragmentationInput
+		unreachableNode         string
+		expectErr               bool
+		expectDefragmentedNode  string
+		expectDisarmedNodeNames []string
+	}{
+		{
+			name: "defragments the first member in need that is not the leader",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 400 * mib, dbSizeInUse: 100 * mib},
+				"node-2": {dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+				"node-3": {dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+			},
+			input:                  input,
+			expectDefragmentedNode: "node-2",
+		},
+		{
+			name: "defragments the leader if no other member is in need",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 400 * mib, dbSizeInUse: 100 * mib},
+				"node-2": {dbSize: 120 * mib, dbSizeInUse: 100 * mib},
+				"node-3": {dbSize: 120 * mib, dbSizeInUse: 100 * mib},
+			},
+			input:                  input,
+			expectDefragmentedNode: "node-1",
+		},
+		{
+			name: "does not defragment members with a database smaller than MinDBSize",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 90 * mib, dbSizeInUse: 10 * mib},
+				"node-2": {dbSize: 90 * mib, dbSizeInUse: 10 * mib},
+				"node-3": {dbSize: 90 * mib, dbSizeInUse: 10 * mib},
+			},
+			input: input,
+		},
+		{
+			name: "does not defragment members with a fragmentation lower than MinFragmentationPercent",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-2": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-3": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+			},
+			input: input,
+		},
+		{
+			name: "defragments a member with a NOSPACE alarm and disarms the alarm",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-2": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-3": {dbSize: 300 * mib, dbSizeInUse: 200 * mib, noSpace: true},
+			},
+			input:                   input,
+			expectDefragmentedNode:  "node-3",
+			expectDisarmedNodeNames: []string{"node-3"},
+		},
+		{
+			name: "does not defragment a member with a NOSPACE alarm if the database would still be over quota",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-2": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-3": {dbSize: 300 * mib, dbSizeInUse: 200 * mib, noSpace: true},
+			},
+			input: EtcdDefragmentationInput{
+				MinDBSize:               input.MinDBSize,
+				MinFragmentationPercent: input.MinFragmentationPercent,
+				QuotaBackendBytes:       200 * mib,
+			},
+		},
+		{
+			name: "does not disarm a NOSPACE alarm if the database is still over quota after defragmentation",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-2": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-3": {dbSize: 600 * mib, dbSizeInUse: 250 * mib, noSpace: true},
+			},
+			input: EtcdDefragmentationInput{
+				MinDBSize:               input.MinDBSize,
+				MinFragmentationPercent: input.MinFragmentationPercent,
+				QuotaBackendBytes:       200 * mib,
+			},
+			expectDefragmentedNode: "node-3",
+		},
+		{
+			name: "does not defragment members defragmented less than MinInterval ago",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 300 * mib, dbSizeInUse: 200 * mib},
+				"node-2": {dbSize: 600 * mib, dbSizeInUse: 250 * mib, noSpace: true},
+				"node-3": {dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+			},
+			input: EtcdDefragmentationInput{
+				MinDBSize:               input.MinDBSize,
+				MinFragmentationPercent: input.MinFragmentationPercent,
+				QuotaBackendBytes:       200 * mib,
+				MinInterval:             time.Hour,
+				LastDefragmentations: map[string]time.Time{
+					"node-2": time.Now().Add(-10 * time.Minute),
+					"node-3": time.Now().Add(-2 * time.Hour),
+				},
+			},
+			expectDefragmentedNode: "node-3",
+		},
+		{
+			name: "returns error and does not defragment any member if a member can't be reached",
+			members: map[string]memberStatus{
+				"node-1": {dbSize: 400 * mib, dbSizeInUse: 100 * mib},
+				"node-2": {dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+				"node-3": {dbSize: 300 * mib, dbSizeInUse: 100 * mib},
+			},
+			input:           input,
+			unreachableNode: "node-3",
+			expectErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			nodeNames := []string{"node-3", "node-2", "node-1"}
+			memberList := &clientv3.MemberListResponse{Header: &pb.ResponseHeader{}}
+			alarms := &clientv3.AlarmResponse{}
+			for i, name := range []string{"node-1", "node-2", "node-3"} {
+				memberList.Members = append(memberList.Members, &pb.Member{ID: uint64(i + 1), Name: name})
+				if tt.members[name].noSpace {
+					alarms.Alarms = append(alarms.Alarms, &pb.AlarmMember{MemberID: uint64(i + 1), Alarm: pb.AlarmType_NOSPACE})
+				}
+			}
+			fakeEtcdClients := map[string]*fake2.FakeEtcdClient{}
+			for i, name := range []string{"node-1", "node-2", "node-3"} {
+				fakeEtcdClients[name] = &fake2.FakeEtcdClient{
+					EtcdEndpoints:       []string{name},
+					MemberListResponse:  memberList,
+					AlarmResponse:       alarms,
+					AlarmDisarmResponse: &clientv3.AlarmResponse{},
+					DefragmentResponse:  &clientv3.DefragmentResponse{},
+					StatusResponse: &clientv3.StatusResponse{
+						Header:      &pb.ResponseHeader{MemberId: uint64(i + 1)},
+						Leader:      1,
+						DbSize:      tt.members[name].dbSize,
+						DbSizeInUse: tt.members[name].dbSizeInUse,
+					},
+				}
+			}
+
+			w := &Workload{
+				etcdClientGenerator: &fakeEtcdClientGenerator{
+					forNodesClientFunc: func(n []string) (*etcd.Client, error) {
+						if n[0] == tt.unreachableNode {
+							return nil, errors.New("unreachable")
+						}
+						return &etcd.Client{
+							EtcdClient: fakeEtcdClients[n[0]],
+							Endpoint:   n[0],
+							LeaderID:   1,
+						}, nil
+					},
+				},
+			}
+			result, err := w.DefragmentEtcd(ctx, nodeNames, tt.input)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				for _, c := range fakeEtcdClients {
+					g.Expect(c.DefragmentedEndpoint).To(BeEmpty())
+				}
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result.DefragmentedMember).To(Equal(tt.expectDefragmentedNode))
+			g.Expect(result.DisarmedMembers).To(Equal(tt.expectDisarmedNodeNames))
+			for name, c := range fakeEtcdClients {
+				if name == tt.expectDefragmentedNode {
+					g.Expect(c.DefragmentedEndpoint).To(Equal(name))
+					continue
+				}
+				g.Expect(c.DefragmentedEndpoint).To(BeEmpty())
+			}
+		})
+	}
+}
+
+func TestPromoteEtcdLearners(t *testing.T) {
+	machine := defaultMachine(func(m *clusterv1.Machine) {
+		m.Name = "machine"
+	})
+	members := &clientv3.MemberListResponse{
+		Header: &pb.ResponseHeader{},
+		Members: []*pb.Member{
+			{Name: "leader-node", ID: uint64(101)},
+			{Name: "machine-node", ID: uint64(102), IsLearner: true},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		etcdClientGenerator etcdClientFor
+		expectedCondition   *clusterv1.Condition
+		expectedPromoted    uint64
+	}{
+		{
+			name: "promotes a learner in sync with the leader",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						MemberListResponse:    members,
+						AlarmResponse:         &clientv3.AlarmResponse{},
+						MemberPromoteResponse: &clientv3.MemberPromoteResponse{},
+					},
+				},
+			},
+			expectedCondition: conditions.TrueCondition(controlplanev1.MachineEtcdMemberVotingCondition),
+			expectedPromoted:  102,
+		},
+		{
+			name: "does not promote a voting member",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						MemberListResponse: &clientv3.MemberListResponse{
+							Header: &pb.ResponseHeader{},
+							Members: []*pb.Member{
+								{Name: "leader-node", ID: uint64(101)},
+								{Name: "machine-node", ID: uint64(102)},
+							},
+						},
+						AlarmResponse: &clientv3.AlarmResponse{},
+					},
+				},
+			},
+			expectedCondition: conditions.TrueCondition(controlplanev1.MachineEtcdMemberVotingCondition),
+		},
+		{
+			name: "reports a learner not in sync with the leader",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fakeMemberPromoteEtcdClient{
+						FakeEtcdClient: fake2.FakeEtcdClient{
+							MemberListResponse: members,
+							AlarmResponse:      &clientv3.AlarmResponse{},
+						},
+						promoteErr: rpctypes.ErrMemberLearnerNotReady,
+					},
+				},
+			},
+			expectedCondition: conditions.FalseCondition(controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberLearnerReason, clusterv1.ConditionSeverityInfo, "Etcd member is a learner catching up with the leader"),
+			expectedPromoted:  102,
+		},
+		{
+			name: "reports a failure in promoting a learner",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderClient: &etcd.Client{
+					EtcdClient: &fakeMemberPromoteEtcdClient{
+						FakeEtcdClient: fake2.FakeEtcdClient{
+							MemberListResponse: members,
+							AlarmResponse:      &clientv3.AlarmResponse{},
+						},
+						promoteErr: errors.New("something went wrong"),
+					},
+				},
+			},
+			expectedCondition: conditions.FalseCondition(controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberPromotionFailedReason, clusterv1.ConditionSeverityWarning, "Failed to promote the etcd member: failed to promote member: 102: something went wrong"),
+			expectedPromoted:  102,
+		},
+		{
+			name: "reports a failure in connecting to the etcd leader",
+			etcdClientGenerator: &fakeEtcdClientGenerator{
+				forLeaderErr: errors.New("no leader"),
+			},
+			expectedCondition: conditions.UnknownCondition(controlplanev1.MachineEtcdMemberVotingCondition, controlplanev1.EtcdMemberInspectionFailedReason, "Failed to connect to the etcd leader: no leader"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := machine.DeepCopy()
+			controlPlane := &ControlPlane{
+				Machines: collections.FromMachines(m),
+			}
+			w := &Workload{
+				etcdClientGenerator: tt.etcdClientGenerator,
+			}
+			w.PromoteEtcdLearners(ctx, controlPlane)
+
+			g.Expect(m.GetConditions()).To(conditions.MatchConditions(clusterv1.Conditions{*tt.expectedCondition}))
+
+			if generator, ok := tt.etcdClientGenerator.(*fakeEtcdClientGenerator); ok && generator.forLeaderClient != nil {
+				var promoted uint64
+				switch c := generator.forLeaderClient.EtcdClient.(type) {
+				case *fake2.FakeEtcdClient:
+					promoted = c.PromotedMember
+				case *fakeMemberPromoteEtcdClient:
+					promoted = c.PromotedMember
+				}
+				g.Expect(promoted).To(Equal(tt.expectedPromoted))
+			}
+		})
+	}
+}
+
+// fakeMemberPromoteEtcdClient is a FakeEtcdClient failing only when promoting members.
+type fakeMemberPromoteEtcdClient struct {
+	fake2.FakeEtcdClient
+	promoteErr error
+}
+
+func (c *fakeMemberPromoteEtcdClient) MemberPromote(_ context.Context, i uint64) (*clientv3.MemberPromoteResponse, error) {
+	c.PromotedMember = i
+	return nil, c.promoteErr
+}
+
 func TestReconcileEtcdMembers(t *testing.T) {
 	kubeadmConfig := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1279,6 +1279,64 @@ func TestClusterStatus(t *testing.T) {
 	}
 }
 
+func TestCalculateAPIServerPort(t *testing.T) {
+	tests := []struct {
+		name         string
+		config       *bootstrapv1.KubeadmConfig
+		expectedPort int32
+	}{
+		{
+			name:         "Default port if no init or join configuration",
+			config:       &bootstrapv1.KubeadmConfig{},
+			expectedPort: 6443,
+		},
+		{
+			name: "Port from init configuration",
+			config: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					InitConfiguration: &bootstrapv1.InitConfiguration{
+						LocalAPIEndpoint: bootstrapv1.APIEndpoint{
+							BindPort: 7443,
+						},
+					},
+				},
+			},
+			expectedPort: 7443,
+		},
+		{
+			name: "Port from join configuration",
+			config: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{
+						ControlPlane: &bootstrapv1.JoinControlPlane{
+							LocalAPIEndpoint: bootstrapv1.APIEndpoint{
+								BindPort: 8443,
+							},
+						},
+					},
+				},
+			},
+			expectedPort: 8443,
+		},
+		{
+			name: "Default port if join configuration has no control plane",
+			config: &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: &bootstrapv1.JoinConfiguration{},
+				},
+			},
+			expectedPort: 6443,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(calculateAPIServerPort(tt.config)).To(Equal(tt.expectedPort))
+		})
+	}
+}
+
 func getProxyImageInfo(ctx context.Context, c client.Client) (string, error) {
 	ds := &appsv1.DaemonSet{}
 
@@ -41,6 +41,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -49,6 +50,7 @@ import (
 	controlplanev1alpha4 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha4"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/controllers"
+	kcpmetrics "sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/metrics"
 	kcpwebhooks "sigs.k8s.io/cluster-api/controlplane/kubeadm/webhooks"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/version"
@@ -87,6 +89,7 @@ var (
 	webhookCertDir                 string
 	healthAddr                     string
 	etcdDialTimeout                time.Duration
+	managementClusterEndpoint      string
 	logOptions                     = logs.NewOptions()
 )
 
@@ -137,6 +140,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&etcdDialTimeout, "etcd-dial-timeout-duration", 10*time.Second,
 		"Duration that the etcd client waits at most to establish a connection with etcd")
 
+	fs.StringVar(&managementClusterEndpoint, "management-cluster-endpoint", "",
+		"The endpoint of the management cluster API server, reachable from the machines of the workload clusters; required to restore etcd snapshots, given that the first control plane machine fetches the snapshot from the management cluster")
+
 	feature.MutableGates.AddFlag(fs)
 }
 func main() {
@@ -192,6 +198,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
+	setupMetrics(mgr)
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
 
@@ -215,6 +222,11 @@ func setupChecks(mgr ctrl.Manager) {
 	}
 }
 
+func setupMetrics(mgr ctrl.Manager) {
+	// NOTE: Metrics are exposed on the manager's metrics endpoint, configured with --metrics-bind-addr.
+	ctrlmetrics.Registry.MustRegister(kcpmetrics.NewCollector(mgr.GetClient()))
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	// Set up a ClusterCacheTracker to provide to controllers
 	// requiring a connection to a remote cluster
@@ -242,13 +254,24 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	}
 
 	if err := (&kubeadmcontrolplanecontrollers.KubeadmControlPlaneReconciler{
+		Client:                    mgr.GetClient(),
+		APIReader:                 mgr.GetAPIReader(),
+		Tracker:                   tracker,
+		WatchFilterValue:          watchFilterValue,
+		EtcdDialTimeout:           etcdDialTimeout,
+		ManagementClusterEndpoint: managementClusterEndpoint,
+	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
+		os.Exit(1)
+	}
+
+	if err := (&kubeadmcontrolplanecontrollers.EtcdSnapshotReconciler{
 		Client:           mgr.GetClient(),
-		APIReader:        mgr.GetAPIReader(),
 		Tracker:          tracker,
 		WatchFilterValue: watchFilterValue,
 		EtcdDialTimeout:  etcdDialTimeout,
 	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
+		setupLog.Error(err, "unable to create controller", "controller", "EtcdSnapshot")
 		os.Exit(1)
 	}
 }
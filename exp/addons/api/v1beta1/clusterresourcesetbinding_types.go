@@ -31,6 +31,7 @@ type ResourceBinding struct {
 
 	// Hash is the hash of a resource's data. This can be used to decide if a resource is changed.
 	// For "ApplyOnce" ClusterResourceSet.spec.strategy, this is no-op as that strategy does not act on change.
+	// For "Reconcile" ClusterResourceSet.spec.strategy, the resource is reapplied when this hash changes.
 	// +optional
 	Hash string `json:"hash,omitempty"`
 
@@ -66,6 +67,16 @@ func (r *ResourceSetBinding) IsApplied(resourceRef ResourceRef) bool {
 	return false
 }
 
+// GetResource returns the ResourceBinding for the given resource if it exists, nil otherwise.
+func (r *ResourceSetBinding) GetResource(resourceRef ResourceRef) *ResourceBinding {
+	for i := range r.Resources {
+		if reflect.DeepEqual(r.Resources[i].ResourceRef, resourceRef) {
+			return &r.Resources[i]
+		}
+	}
+	return nil
+}
+
 // SetBinding sets resourceBinding for a resource in resourceSetbinding either by updating the existing one or
 // creating a new one.
 func (r *ResourceSetBinding) SetBinding(resourceBinding ResourceBinding) {
@@ -90,6 +90,43 @@ func TestIsResourceApplied(t *testing.T) {
 	}
 }
 
+func TestGetResourceBinding(t *testing.T) {
+	resourceRefExists := ResourceRef{
+		Name: "exists",
+		Kind: "ConfigMap",
+	}
+	resourceRefNotExist := ResourceRef{
+		Name: "notExist",
+		Kind: "ConfigMap",
+	}
+	CRSBinding := &ResourceSetBinding{
+		ClusterResourceSetName: "test-clusterResourceSet",
+		Resources: []ResourceBinding{
+			{
+				ResourceRef:     resourceRefExists,
+				Applied:         true,
+				Hash:            "xyz",
+				LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
+			},
+		},
+	}
+
+	t.Run("should return the resource binding if it exists", func(t *testing.T) {
+		gs := NewWithT(t)
+		resourceBinding := CRSBinding.GetResource(resourceRefExists)
+		gs.Expect(resourceBinding).ToNot(BeNil())
+		gs.Expect(resourceBinding.Hash).To(Equal("xyz"))
+
+		// Changes to the returned binding are reflected in the ResourceSetBinding.
+		resourceBinding.Hash = "abc"
+		gs.Expect(CRSBinding.Resources[0].Hash).To(Equal("abc"))
+	})
+	t.Run("should return nil if the resource binding does not exist", func(t *testing.T) {
+		gs := NewWithT(t)
+		gs.Expect(CRSBinding.GetResource(resourceRefNotExist)).To(BeNil())
+	})
+}
+
 func TestSetResourceBinding(t *testing.T) {
 	resourceRefApplyFailed := ResourceRef{
 		Name: "applyFailed",
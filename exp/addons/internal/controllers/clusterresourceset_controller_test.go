@@ -203,6 +203,85 @@ metadata:
 		g.Expect(env.Delete(ctx, testCluster)).To(Succeed())
 	})
 
+	t.Run("Should reapply resources when their data changes with the Reconcile strategy", func(t *testing.T) {
+		g := NewWithT(t)
+		ns := setup(t, g)
+		defer teardown(t, g, ns)
+
+		t.Log("Updating the cluster with labels")
+		testCluster.SetLabels(labels)
+		g.Expect(env.Update(ctx, testCluster)).To(Succeed())
+
+		t.Log("Creating a ClusterResourceSet instance with the Reconcile strategy")
+		clusterResourceSetInstance := &addonsv1.ClusterResourceSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      clusterResourceSetName,
+				Namespace: ns.Name,
+			},
+			Spec: addonsv1.ClusterResourceSetSpec{
+				ClusterSelector: metav1.LabelSelector{
+					MatchLabels: labels,
+				},
+				Resources: []addonsv1.ResourceRef{{Name: configmapName, Kind: "ConfigMap"}},
+				Strategy:  string(addonsv1.ClusterResourceSetStrategyReconcile),
+			},
+		}
+		// Create the ClusterResourceSet.
+		g.Expect(env.Create(ctx, clusterResourceSetInstance)).To(Succeed())
+
+		clusterResourceSetBindingKey := client.ObjectKey{
+			Namespace: testCluster.Namespace,
+			Name:      testCluster.Name,
+		}
+
+		t.Log("Verifying the resource is applied and its hash is recorded in the ClusterResourceSetBinding")
+		var firstHash string
+		g.Eventually(func() bool {
+			binding := &addonsv1.ClusterResourceSetBinding{}
+			if err := env.Get(ctx, clusterResourceSetBindingKey, binding); err != nil {
+				return false
+			}
+			if len(binding.Spec.Bindings) != 1 || len(binding.Spec.Bindings[0].Resources) != 1 {
+				return false
+			}
+			resourceBinding := binding.Spec.Bindings[0].Resources[0]
+			firstHash = resourceBinding.Hash
+			return resourceBinding.Applied && firstHash != ""
+		}, timeout).Should(BeTrue())
+
+		t.Log("Updating the ConfigMap referenced by the ClusterResourceSet")
+		testConfigmap := &corev1.ConfigMap{}
+		g.Expect(env.Get(ctx, client.ObjectKey{Namespace: ns.Name, Name: configmapName}, testConfigmap)).To(Succeed())
+		testConfigmap.Data["cm"] = `metadata:
+ name: resource-configmap
+ namespace: default
+kind: ConfigMap
+apiVersion: v1
+data:
+ reconciled: "true"`
+		g.Expect(env.Update(ctx, testConfigmap)).To(Succeed())
+
+		t.Log("Verifying the resource is reapplied to the cluster and the new hash is recorded")
+		g.Eventually(func() bool {
+			binding := &addonsv1.ClusterResourceSetBinding{}
+			if err := env.Get(ctx, clusterResourceSetBindingKey, binding); err != nil {
+				return false
+			}
+			resourceBinding := binding.Spec.Bindings[0].Resources[0]
+			return resourceBinding.Applied && resourceBinding.Hash != firstHash
+		}, timeout).Should(BeTrue())
+		g.Eventually(func() bool {
+			cm := &corev1.ConfigMap{}
+			if err := env.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "resource-configmap"}, cm); err != nil {
+				return false
+			}
+			return cm.Data["reconciled"] == "true"
+		}, timeout).Should(BeTrue())
+
+		t.Log("Deleting the Cluster")
+		g.Expect(env.Delete(ctx, testCluster)).To(Succeed())
+	})
+
 	t.Run("Should reconcile a cluster when its labels are changed to match a ClusterResourceSet's selector", func(t *testing.T) {
 		g := NewWithT(t)
 		ns := setup(t, g)
@@ -55,7 +55,12 @@ func isJSONList(data []byte) (bool, error) {
 	return bytes.HasPrefix(trim, jsonListPrefix), nil
 }
 
-func apply(ctx context.Context, c client.Client, data []byte) error {
+// clusterResourceSetManagerName is the field owner used when applying resources with server-side apply.
+const clusterResourceSetManagerName = "capi-clusterresourceset"
+
+// apply applies all the objects in data to the cluster, either by creating them or by using server-side apply
+// depending on the ClusterResourceSet strategy.
+func apply(ctx context.Context, c client.Client, data []byte, strategy addonsv1.ClusterResourceSetStrategy) error {
 	isJSONList, err := isJSONList(data)
 	if err != nil {
 		return err
@@ -83,14 +88,18 @@ func apply(ctx context.Context, c client.Client, data []byte) error {
 	errList := []error{}
 	sortedObjs := utilresource.SortForCreate(objs)
 	for i := range sortedObjs {
-		if err := applyUnstructured(ctx, c, &sortedObjs[i]); err != nil {
+		applyFunc := createUnstructured
+		if strategy == addonsv1.ClusterResourceSetStrategyReconcile {
+			applyFunc = serverSideApplyUnstructured
+		}
+		if err := applyFunc(ctx, c, &sortedObjs[i]); err != nil {
 			errList = append(errList, err)
 		}
 	}
 	return kerrors.NewAggregate(errList)
 }
 
-func applyUnstructured(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+func createUnstructured(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
 	// Create the object on the API server.
 	// TODO: Errors are only logged. If needed, exponential backoff or requeuing could be used here for remedying connection glitches etc.
 	if err := c.Create(ctx, obj); err != nil {
@@ -108,6 +117,20 @@ func applyUnstructured(ctx context.Context, c client.Client, obj *unstructured.U
 	return nil
 }
 
+func serverSideApplyUnstructured(ctx context.Context, c client.Client, obj *unstructured.Unstructured) error {
+	// Apply the object on the API server, forcing ownership of the fields set in the object so that
+	// any drift introduced in the cluster is corrected.
+	if err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(clusterResourceSetManagerName), client.ForceOwnership); err != nil {
+		return errors.Wrapf(
+			err,
+			"failed to apply object %s %s/%s",
+			obj.GroupVersionKind(),
+			obj.GetNamespace(),
+			obj.GetName())
+	}
+	return nil
+}
+
 // getOrCreateClusterResourceSetBinding retrieves ClusterResourceSetBinding resource owned by the cluster or create a new one if not found.
 func (r *ClusterResourceSetReconciler) getOrCreateClusterResourceSetBinding(ctx context.Context, cluster *clusterv1.Cluster, clusterResourceSet *addonsv1.ClusterResourceSet) (*addonsv1.ClusterResourceSetBinding, error) {
 	clusterResourceSetBinding := &addonsv1.ClusterResourceSetBinding{}
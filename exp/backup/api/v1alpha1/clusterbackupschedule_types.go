@@ -0,0 +1,223 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ClusterBackupScheduleNameLabel is the label set on the backups taken by a ClusterBackupSchedule,
+	// with the name of the ClusterBackupSchedule as a value.
+	ClusterBackupScheduleNameLabel = "backup.cluster.x-k8s.io/schedule-name"
+
+	// BackupSecretType is the type of the Secrets storing backups.
+	BackupSecretType = "backup.cluster.x-k8s.io/archive"
+
+	// BackupSecretDataKey is the key in the data of the Secrets storing backups.
+	BackupSecretDataKey = "archive"
+
+	// S3AccessKeyIDKey is the key of the access key ID in the Secret with the credentials of an S3 endpoint.
+	S3AccessKeyIDKey = "accessKeyID"
+
+	// S3SecretAccessKeyKey is the key of the secret access key in the Secret with the credentials of an S3 endpoint.
+	S3SecretAccessKeyKey = "secretAccessKey"
+
+	// PassphraseKey is the key of the passphrase in the Secret used for encrypting backups.
+	PassphraseKey = "passphrase"
+
+	// DefaultMaxBackups is the default number of backups retained by a ClusterBackupSchedule.
+	DefaultMaxBackups = 7
+)
+
+// ANCHOR: ClusterBackupScheduleSpec
+
+// ClusterBackupScheduleSpec defines the desired state of ClusterBackupSchedule.
+type ClusterBackupScheduleSpec struct {
+	// Interval is the time between two backups.
+	Interval metav1.Duration `json:"interval"`
+
+	// Suspend tells the controller to stop taking backups.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// MaxBackups is the number of backups to retain; when a new backup is taken, the oldest backups exceeding
+	// this number are deleted. Defaults to 7.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxBackups *int32 `json:"maxBackups,omitempty"`
+
+	// Storage defines where backups are stored.
+	Storage BackupStorage `json:"storage"`
+
+	// Encryption defines how backups are encrypted. If not set, backups are not encrypted.
+	// +optional
+	Encryption *BackupEncryption `json:"encryption,omitempty"`
+}
+
+// BackupStorage defines where backups are stored; exactly one of the storage types must be set.
+type BackupStorage struct {
+	// Secret stores each backup in a Secret in the namespace of the ClusterBackupSchedule.
+	// NOTE: Secrets are limited to 1MiB, so this storage is suitable only for small namespaces.
+	// +optional
+	Secret *SecretBackupStorage `json:"secret,omitempty"`
+
+	// Volume stores each backup as a file in the directory of the controller configured with the --backup-volume-path flag,
+	// usually backed by a PersistentVolumeClaim mounted in the controller Pod.
+	// Backups are stored in the <namespace>/<name> subdirectory, where namespace and name are the ones of the ClusterBackupSchedule.
+	// +optional
+	Volume *VolumeBackupStorage `json:"volume,omitempty"`
+
+	// S3 stores each backup as an object in a bucket of an S3 compatible endpoint.
+	// +optional
+	S3 *S3BackupStorage `json:"s3,omitempty"`
+}
+
+// SecretBackupStorage defines the storage of backups in Secrets.
+type SecretBackupStorage struct{}
+
+// VolumeBackupStorage defines the storage of backups in a volume mounted in the controller Pod.
+type VolumeBackupStorage struct{}
+
+// S3BackupStorage defines the storage of backups in a bucket of an S3 compatible endpoint.
+type S3BackupStorage struct {
+	// Endpoint is the URL of the S3 compatible endpoint, e.g. https://s3.us-east-1.amazonaws.com.
+	// Buckets are addressed using path-style requests.
+	Endpoint string `json:"endpoint"`
+
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the key of the objects storing backups, which is <prefix>/<namespace>/<name>/<backup>
+	// where namespace and name are the ones of the ClusterBackupSchedule.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the region used for signing requests. Defaults to us-east-1.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// CredentialsSecretName is the name of a Secret in the namespace of the ClusterBackupSchedule
+	// with the accessKeyID and secretAccessKey keys.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// BackupEncryption defines how backups are encrypted using the age format (https://age-encryption.org).
+type BackupEncryption struct {
+	// Recipients are the age public keys (age1...) backups are encrypted for.
+	// +optional
+	Recipients []string `json:"recipients,omitempty"`
+
+	// PassphraseSecretName is the name of a Secret in the namespace of the ClusterBackupSchedule
+	// with the passphrase the key for encrypting backups is derived from, stored in the passphrase key.
+	// It can't be combined with Recipients.
+	// +optional
+	PassphraseSecretName string `json:"passphraseSecretName,omitempty"`
+}
+
+// ANCHOR_END: ClusterBackupScheduleSpec
+
+// ANCHOR: ClusterBackupScheduleStatus
+
+// ClusterBackupScheduleStatus defines the observed state of ClusterBackupSchedule.
+type ClusterBackupScheduleStatus struct {
+	// LastBackupTime is the time of the last successful backup.
+	// +optional
+	LastBackupTime *metav1.Time `json:"lastBackupTime,omitempty"`
+
+	// Backups are the names of the backups currently retained, from the oldest to the newest.
+	// +optional
+	Backups []string `json:"backups,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines current service state of the ClusterBackupSchedule.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// ANCHOR_END: ClusterBackupScheduleStatus
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clusterbackupschedules,shortName=cbs,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Interval",type="string",JSONPath=".spec.interval",description="Time between two backups"
+// +kubebuilder:printcolumn:name="Last Backup",type="date",JSONPath=".status.lastBackupTime",description="Time of the last successful backup"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of ClusterBackupSchedule"
+
+// ClusterBackupSchedule is the Schema for the clusterbackupschedules API.
+// A ClusterBackupSchedule periodically takes a backup of the Cluster API objects in its namespace.
+type ClusterBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterBackupScheduleSpec   `json:"spec,omitempty"`
+	Status ClusterBackupScheduleStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (s *ClusterBackupSchedule) GetConditions() clusterv1.Conditions {
+	return s.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (s *ClusterBackupSchedule) SetConditions(conditions clusterv1.Conditions) {
+	s.Status.Conditions = conditions
+}
+
+// GetMaxBackups returns the number of backups to retain.
+func (s *ClusterBackupSchedule) GetMaxBackups() int {
+	if s.Spec.MaxBackups == nil {
+		return DefaultMaxBackups
+	}
+	return int(*s.Spec.MaxBackups)
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterBackupScheduleList contains a list of ClusterBackupSchedule.
+type ClusterBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterBackupSchedule{}, &ClusterBackupScheduleList{})
+}
+
+const (
+	// BackupSucceededCondition reports the result of the last backup taken by a ClusterBackupSchedule.
+	BackupSucceededCondition clusterv1.ConditionType = "BackupSucceeded"
+
+	// InvalidConfigurationReason documents a ClusterBackupSchedule with an invalid spec.
+	InvalidConfigurationReason = "InvalidConfiguration"
+
+	// BackupFailedReason documents a failure taking a backup.
+	BackupFailedReason = "BackupFailed"
+
+	// StorageFailedReason documents a failure storing a backup or deleting backups exceeding the retention.
+	StorageFailedReason = "StorageFailed"
+
+	// BackupSuspendedReason documents a ClusterBackupSchedule not taking backups because it is suspended.
+	BackupSuspendedReason = "BackupSuspended"
+)
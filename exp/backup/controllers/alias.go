@@ -0,0 +1,51 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	backupcontrollers "sigs.k8s.io/cluster-api/exp/backup/internal/controllers"
+)
+
+// ClusterBackupScheduleReconciler reconciles a ClusterBackupSchedule object.
+type ClusterBackupScheduleReconciler struct {
+	Client client.Client
+
+	// RestConfig is the config used for reading the objects to backup.
+	RestConfig *rest.Config
+
+	// VolumePath is the directory where backups using volume storage are stored.
+	VolumePath string
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+func (r *ClusterBackupScheduleReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&backupcontrollers.Reconciler{
+		Client:           r.Client,
+		RestConfig:       r.RestConfig,
+		VolumePath:       r.VolumePath,
+		WatchFilterValue: r.WatchFilterValue,
+	}).SetupWithManager(ctx, mgr, options)
+}
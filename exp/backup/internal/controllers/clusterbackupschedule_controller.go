@@ -0,0 +1,307 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	backupv1 "sigs.k8s.io/cluster-api/exp/backup/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+const (
+	// minInterval is the minimum time between two backups.
+	minInterval = time.Minute
+
+	// backupTimestampFormat is the format of the timestamp in the name of backups; it sorts backups chronologically.
+	backupTimestampFormat = "20060102150405"
+)
+
+// +kubebuilder:rbac:groups=backup.cluster.x-k8s.io,resources=clusterbackupschedules;clusterbackupschedules/status,verbs=get;list;watch;patch;update
+// +kubebuilder:rbac:groups=clusterctl.cluster.x-k8s.io,resources=providers,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;delete
+
+// Reconciler reconciles a ClusterBackupSchedule object.
+type Reconciler struct {
+	Client client.Client
+
+	// RestConfig is the config used for reading the objects to backup.
+	RestConfig *rest.Config
+
+	// VolumePath is the directory where backups using volume storage are stored.
+	VolumePath string
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	// snapshot writes the backup of a namespace to w; it defaults to the clusterctl ObjectMover.
+	snapshot func(namespace string, w io.Writer, encryption cluster.ArchiveEncryption) error
+
+	// now returns the current time.
+	now func() time.Time
+}
+
+func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.snapshot == nil {
+		r.snapshot = r.clusterctlSnapshot
+	}
+	if r.now == nil {
+		r.now = time.Now
+	}
+
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&backupv1.ClusterBackupSchedule{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+	return nil
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	schedule := &backupv1.ClusterBackupSchedule{}
+	if err := r.Client.Get(ctx, req.NamespacedName, schedule); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Return early if the ClusterBackupSchedule is paused.
+	if annotations.HasPaused(schedule) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	// Backups are retained when the ClusterBackupSchedule is deleted, so there is nothing to do on deletion.
+	if !schedule.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(schedule, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to patch the object and status after each reconciliation.
+		schedule.Status.ObservedGeneration = schedule.Generation
+		if err := patchHelper.Patch(ctx, schedule, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			backupv1.BackupSucceededCondition,
+		}}); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	return r.reconcile(ctx, schedule)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, schedule *backupv1.ClusterBackupSchedule) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if err := r.validate(schedule); err != nil {
+		// Invalid specs are not retried until the ClusterBackupSchedule is changed.
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.InvalidConfigurationReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	if schedule.Spec.Suspend {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.BackupSuspendedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	storage, err := r.getStorage(ctx, schedule)
+	if err != nil {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.StorageFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Enforce the retention before checking the schedule, so failures deleting old backups are retried.
+	if err := r.reconcileRetention(ctx, schedule, storage); err != nil {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.StorageFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the next backup, if required.
+	now := r.now().UTC()
+	if last := schedule.Status.LastBackupTime; last != nil {
+		if next := last.Add(schedule.Spec.Interval.Duration); now.Before(next) {
+			return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+		}
+	}
+
+	encryption, err := r.getEncryption(ctx, schedule)
+	if err != nil {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.BackupFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	name := fmt.Sprintf("%s-%s.tar.gz", schedule.Name, now.Format(backupTimestampFormat))
+	if !encryption.IsEmpty() {
+		name += ".age"
+	}
+
+	buf := &bytes.Buffer{}
+	if err := r.snapshot(schedule.Namespace, buf, encryption); err != nil {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.BackupFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, errors.Wrapf(err, "failed to take backup %s", name)
+	}
+
+	if err := storage.Save(ctx, name, buf.Bytes()); err != nil {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.StorageFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, errors.Wrapf(err, "failed to store backup %s", name)
+	}
+	log.Info("Backup completed", "backup", name, "size", buf.Len())
+
+	schedule.Status.LastBackupTime = &metav1.Time{Time: now}
+	conditions.MarkTrue(schedule, backupv1.BackupSucceededCondition)
+
+	if err := r.reconcileRetention(ctx, schedule, storage); err != nil {
+		conditions.MarkFalse(schedule, backupv1.BackupSucceededCondition, backupv1.StorageFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: schedule.Spec.Interval.Duration}, nil
+}
+
+// validate checks the parts of the ClusterBackupSchedule spec which are not validated by the OpenAPI schema.
+func (r *Reconciler) validate(schedule *backupv1.ClusterBackupSchedule) error {
+	if schedule.Spec.Interval.Duration < minInterval {
+		return errors.Errorf("interval must be at least %s", minInterval)
+	}
+
+	storages := 0
+	if schedule.Spec.Storage.Secret != nil {
+		storages++
+	}
+	if schedule.Spec.Storage.Volume != nil {
+		if r.VolumePath == "" {
+			return errors.New("volume storage is not enabled in the controller")
+		}
+		storages++
+	}
+	if schedule.Spec.Storage.S3 != nil {
+		storages++
+	}
+	if storages != 1 {
+		return errors.New("exactly one storage type must be set")
+	}
+
+	if e := schedule.Spec.Encryption; e != nil && len(e.Recipients) > 0 && e.PassphraseSecretName != "" {
+		return errors.New("encryption recipients can't be combined with a passphrase")
+	}
+	return nil
+}
+
+// getStorage returns the storage for the backups of a ClusterBackupSchedule.
+func (r *Reconciler) getStorage(ctx context.Context, schedule *backupv1.ClusterBackupSchedule) (backupStorage, error) {
+	switch {
+	case schedule.Spec.Storage.Secret != nil:
+		return &secretStorage{client: r.Client, schedule: schedule}, nil
+	case schedule.Spec.Storage.Volume != nil:
+		return newVolumeStorage(r.VolumePath, schedule), nil
+	default:
+		return newS3Storage(ctx, r.Client, schedule)
+	}
+}
+
+// getEncryption returns the encryption for the backups of a ClusterBackupSchedule.
+func (r *Reconciler) getEncryption(ctx context.Context, schedule *backupv1.ClusterBackupSchedule) (cluster.ArchiveEncryption, error) {
+	encryption := cluster.ArchiveEncryption{}
+	if schedule.Spec.Encryption == nil {
+		return encryption, nil
+	}
+
+	encryption.Recipients = schedule.Spec.Encryption.Recipients
+	if name := schedule.Spec.Encryption.PassphraseSecretName; name != "" {
+		passphrase, err := getSecretValue(ctx, r.Client, schedule.Namespace, name, backupv1.PassphraseKey)
+		if err != nil {
+			return encryption, err
+		}
+		encryption.Passphrase = passphrase
+	}
+	return encryption, nil
+}
+
+// reconcileRetention deletes the oldest backups exceeding the retention and records the retained backups in the status.
+func (r *Reconciler) reconcileRetention(ctx context.Context, schedule *backupv1.ClusterBackupSchedule, storage backupStorage) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	backups, err := storage.List(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list backups")
+	}
+	sort.Strings(backups)
+
+	errList := []error{}
+	for len(backups) > schedule.GetMaxBackups() {
+		if err := storage.Delete(ctx, backups[0]); err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to delete backup %s", backups[0]))
+			break
+		}
+		log.Info("Deleted backup exceeding the retention", "backup", backups[0])
+		backups = backups[1:]
+	}
+
+	schedule.Status.Backups = backups
+	return kerrors.NewAggregate(errList)
+}
+
+// clusterctlSnapshot takes a backup using the clusterctl object graph.
+func (r *Reconciler) clusterctlSnapshot(namespace string, w io.Writer, encryption cluster.ArchiveEncryption) error {
+	proxy := cluster.NewProxy(cluster.Kubeconfig{}, cluster.InjectRestConfig(r.RestConfig))
+	return cluster.New(cluster.Kubeconfig{}, nil, cluster.InjectProxy(proxy)).ObjectMover().Snapshot(namespace, w, encryption)
+}
+
+// getSecretValue returns the value of a key in a Secret.
+func getSecretValue(ctx context.Context, c client.Client, namespace, name, key string) (string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to get Secret %s/%s", namespace, name)
+	}
+	value, ok := secret.Data[key]
+	if !ok || len(value) == 0 {
+		return "", errors.Errorf("Secret %s/%s does not have the %s key", namespace, name, key)
+	}
+	return string(value), nil
+}
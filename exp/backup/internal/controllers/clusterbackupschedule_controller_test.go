@@ -0,0 +1,308 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	backupv1 "sigs.k8s.io/cluster-api/exp/backup/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newTestScheme(g *WithT) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(backupv1.AddToScheme(scheme)).To(Succeed())
+	return scheme
+}
+
+func newSchedule(name string, storage backupv1.BackupStorage) *backupv1.ClusterBackupSchedule {
+	return &backupv1.ClusterBackupSchedule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns1",
+		},
+		Spec: backupv1.ClusterBackupScheduleSpec{
+			Interval:   metav1.Duration{Duration: time.Hour},
+			MaxBackups: pointer.Int32(2),
+			Storage:    storage,
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	schedule := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(g)).WithObjects(schedule).Build()
+
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+	snapshots := 0
+	r := &Reconciler{
+		Client: c,
+		snapshot: func(namespace string, w io.Writer, encryption cluster.ArchiveEncryption) error {
+			snapshots++
+			g.Expect(namespace).To(Equal("ns1"))
+			g.Expect(encryption.IsEmpty()).To(BeTrue())
+			_, err := w.Write([]byte("backup"))
+			return err
+		},
+		now: func() time.Time { return now },
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(schedule)}
+
+	// The first reconcile takes a backup.
+	res, err := r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(time.Hour))
+	g.Expect(snapshots).To(Equal(1))
+
+	g.Expect(c.Get(ctx, req.NamespacedName, schedule)).To(Succeed())
+	g.Expect(schedule.Status.Backups).To(Equal([]string{"schedule-20220601100000.tar.gz"}))
+	g.Expect(schedule.Status.LastBackupTime.Time).To(BeTemporally("==", now))
+	g.Expect(conditions.IsTrue(schedule, backupv1.BackupSucceededCondition)).To(BeTrue())
+
+	secret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, client.ObjectKey{Namespace: "ns1", Name: "schedule-20220601100000.tar.gz"}, secret)).To(Succeed())
+	g.Expect(secret.Type).To(Equal(corev1.SecretType(backupv1.BackupSecretType)))
+	g.Expect(secret.Data).To(HaveKeyWithValue(backupv1.BackupSecretDataKey, []byte("backup")))
+
+	// Before the interval has elapsed, no backup is taken.
+	now = now.Add(20 * time.Minute)
+	res, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(Equal(40 * time.Minute))
+	g.Expect(snapshots).To(Equal(1))
+
+	// After the interval, backups are taken and the oldest are deleted according to the retention.
+	now = now.Add(40 * time.Minute)
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	now = now.Add(time.Hour)
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(snapshots).To(Equal(3))
+
+	g.Expect(c.Get(ctx, req.NamespacedName, schedule)).To(Succeed())
+	g.Expect(schedule.Status.Backups).To(Equal([]string{"schedule-20220601110000.tar.gz", "schedule-20220601120000.tar.gz"}))
+	secrets := &corev1.SecretList{}
+	g.Expect(c.List(ctx, secrets, client.InNamespace("ns1"))).To(Succeed())
+	g.Expect(secrets.Items).To(HaveLen(2))
+}
+
+func TestReconcile_reconcile(t *testing.T) {
+	now := time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		schedule            func() *backupv1.ClusterBackupSchedule
+		objs                []client.Object
+		volumePath          bool
+		snapshotErr         error
+		wantErr             bool
+		wantSnapshot        bool
+		wantConditionStatus corev1.ConditionStatus
+		wantConditionReason string
+	}{
+		{
+			name: "suspended schedule does not take backups",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				s := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+				s.Spec.Suspend = true
+				return s
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.BackupSuspendedReason,
+		},
+		{
+			name: "fails without storage",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				return newSchedule("schedule", backupv1.BackupStorage{})
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.InvalidConfigurationReason,
+		},
+		{
+			name: "fails with more than one storage",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				return newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}, S3: &backupv1.S3BackupStorage{}})
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.InvalidConfigurationReason,
+		},
+		{
+			name: "fails with volume storage if it is not enabled",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				return newSchedule("schedule", backupv1.BackupStorage{Volume: &backupv1.VolumeBackupStorage{}})
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.InvalidConfigurationReason,
+		},
+		{
+			name: "fails with an interval shorter than a minute",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				s := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+				s.Spec.Interval.Duration = time.Second
+				return s
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.InvalidConfigurationReason,
+		},
+		{
+			name: "fails with both recipients and passphrase",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				s := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+				s.Spec.Encryption = &backupv1.BackupEncryption{Recipients: []string{"age1"}, PassphraseSecretName: "passphrase"}
+				return s
+			},
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.InvalidConfigurationReason,
+		},
+		{
+			name: "takes backups to a volume",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				return newSchedule("schedule", backupv1.BackupStorage{Volume: &backupv1.VolumeBackupStorage{}})
+			},
+			volumePath:          true,
+			wantSnapshot:        true,
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "takes encrypted backups with a passphrase",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				s := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+				s.Spec.Encryption = &backupv1.BackupEncryption{PassphraseSecretName: "passphrase"}
+				return s
+			},
+			objs: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "passphrase", Namespace: "ns1"},
+					Data:       map[string][]byte{backupv1.PassphraseKey: []byte("secret")},
+				},
+			},
+			wantSnapshot:        true,
+			wantConditionStatus: corev1.ConditionTrue,
+		},
+		{
+			name: "fails if the passphrase Secret does not exist",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				s := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+				s.Spec.Encryption = &backupv1.BackupEncryption{PassphraseSecretName: "passphrase"}
+				return s
+			},
+			wantErr:             true,
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.BackupFailedReason,
+		},
+		{
+			name: "fails if the S3 credentials Secret does not exist",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				return newSchedule("schedule", backupv1.BackupStorage{S3: &backupv1.S3BackupStorage{
+					Endpoint:              "https://s3.example.com",
+					Bucket:                "bucket",
+					CredentialsSecretName: "credentials",
+				}})
+			},
+			wantErr:             true,
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.StorageFailedReason,
+		},
+		{
+			name: "fails if the backup fails",
+			schedule: func() *backupv1.ClusterBackupSchedule {
+				return newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+			},
+			snapshotErr:         errors.New("failed"),
+			wantErr:             true,
+			wantSnapshot:        true,
+			wantConditionStatus: corev1.ConditionFalse,
+			wantConditionReason: backupv1.BackupFailedReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			schedule := tt.schedule()
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithScheme(newTestScheme(g)).WithObjects(tt.objs...).Build(),
+				now:    func() time.Time { return now },
+			}
+			if tt.volumePath {
+				r.VolumePath = t.TempDir()
+			}
+			snapshot := false
+			r.snapshot = func(namespace string, w io.Writer, encryption cluster.ArchiveEncryption) error {
+				snapshot = true
+				if schedule.Spec.Encryption != nil && schedule.Spec.Encryption.PassphraseSecretName != "" {
+					g.Expect(encryption.Passphrase).To(Equal("secret"))
+				}
+				return tt.snapshotErr
+			}
+
+			_, err := r.reconcile(context.Background(), schedule)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(snapshot).To(Equal(tt.wantSnapshot))
+
+			condition := conditions.Get(schedule, backupv1.BackupSucceededCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(tt.wantConditionStatus))
+			g.Expect(condition.Reason).To(Equal(tt.wantConditionReason))
+			if tt.wantConditionStatus == corev1.ConditionTrue {
+				g.Expect(schedule.Status.Backups).To(HaveLen(1))
+			}
+		})
+	}
+}
+
+func TestReconcile_paused(t *testing.T) {
+	g := NewWithT(t)
+
+	schedule := newSchedule("schedule", backupv1.BackupStorage{Secret: &backupv1.SecretBackupStorage{}})
+	schedule.Annotations = map[string]string{clusterv1.PausedAnnotation: ""}
+	r := &Reconciler{
+		Client: fake.NewClientBuilder().WithScheme(newTestScheme(g)).WithObjects(schedule).Build(),
+		snapshot: func(string, io.Writer, cluster.ArchiveEncryption) error {
+			return errors.New("backups must not be taken when paused")
+		},
+		now: time.Now,
+	}
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(schedule)})
+	g.Expect(err).ToNot(HaveOccurred())
+}
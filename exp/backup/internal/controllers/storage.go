@@ -0,0 +1,235 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	backupv1 "sigs.k8s.io/cluster-api/exp/backup/api/v1alpha1"
+)
+
+// maxSecretBackupSize is the maximum size of a backup stored in a Secret, leaving room for the
+// metadata of the Secret within the 1MiB limit of objects stored in etcd.
+const maxSecretBackupSize = 1000 * 1024
+
+// backupStorage stores the backups of a ClusterBackupSchedule.
+type backupStorage interface {
+	// Save stores a backup.
+	Save(ctx context.Context, name string, data []byte) error
+
+	// List returns the names of the stored backups.
+	List(ctx context.Context) ([]string, error)
+
+	// Delete deletes a backup.
+	Delete(ctx context.Context, name string) error
+}
+
+// secretStorage stores backups in Secrets in the namespace of the ClusterBackupSchedule.
+// NOTE: The Secrets are not owned by the ClusterBackupSchedule, so backups are retained when it is deleted.
+type secretStorage struct {
+	client   client.Client
+	schedule *backupv1.ClusterBackupSchedule
+}
+
+var _ backupStorage = &secretStorage{}
+
+func (s *secretStorage) Save(ctx context.Context, name string, data []byte) error {
+	if len(data) > maxSecretBackupSize {
+		return errors.Errorf("backup size %d exceeds the maximum size of %d bytes for Secret storage", len(data), maxSecretBackupSize)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.schedule.Namespace,
+			Labels: map[string]string{
+				backupv1.ClusterBackupScheduleNameLabel: s.schedule.Name,
+			},
+		},
+		Type: backupv1.BackupSecretType,
+		Data: map[string][]byte{
+			backupv1.BackupSecretDataKey: data,
+		},
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return errors.Wrapf(err, "failed to create Secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return nil
+}
+
+func (s *secretStorage) List(ctx context.Context) ([]string, error) {
+	secrets := &corev1.SecretList{}
+	if err := s.client.List(ctx, secrets, client.InNamespace(s.schedule.Namespace), client.MatchingLabels{backupv1.ClusterBackupScheduleNameLabel: s.schedule.Name}); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Secrets in namespace %s", s.schedule.Namespace)
+	}
+
+	names := []string{}
+	for _, secret := range secrets.Items {
+		if secret.Type != backupv1.BackupSecretType {
+			continue
+		}
+		names = append(names, secret.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *secretStorage) Delete(ctx context.Context, name string) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.schedule.Namespace,
+		},
+	}
+	if err := s.client.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Secret %s/%s", secret.Namespace, secret.Name)
+	}
+	return nil
+}
+
+// volumeStorage stores backups as files in a directory.
+type volumeStorage struct {
+	dir    string
+	prefix string
+}
+
+var _ backupStorage = &volumeStorage{}
+
+// newVolumeStorage returns a volumeStorage storing the backups of a ClusterBackupSchedule in the
+// <namespace>/<name> subdirectory of path.
+func newVolumeStorage(path string, schedule *backupv1.ClusterBackupSchedule) *volumeStorage {
+	return &volumeStorage{
+		dir:    filepath.Join(path, schedule.Namespace, schedule.Name),
+		prefix: schedule.Name + "-",
+	}
+}
+
+func (s *volumeStorage) Save(_ context.Context, name string, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory %s", s.dir)
+	}
+
+	// Write to a temporary file first, so an interrupted write does not leave a truncated backup.
+	path := filepath.Join(s.dir, name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write file %s", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return errors.Wrapf(err, "failed to rename file %s to %s", tmp, path)
+	}
+	return nil
+}
+
+func (s *volumeStorage) List(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read directory %s", s.dir)
+	}
+
+	names := []string{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), s.prefix) || strings.HasSuffix(e.Name(), ".tmp") {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *volumeStorage) Delete(_ context.Context, name string) error {
+	path := filepath.Join(s.dir, filepath.Base(name))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete file %s", path)
+	}
+	return nil
+}
+
+// s3Storage stores backups as objects in a bucket of an S3 compatible endpoint.
+type s3Storage struct {
+	client *s3Client
+	prefix string
+}
+
+var _ backupStorage = &s3Storage{}
+
+// newS3Storage returns a s3Storage storing the backups of a ClusterBackupSchedule with the
+// <prefix>/<namespace>/<name>/ key prefix.
+func newS3Storage(ctx context.Context, c client.Client, schedule *backupv1.ClusterBackupSchedule) (*s3Storage, error) {
+	spec := schedule.Spec.Storage.S3
+	accessKeyID, err := getSecretValue(ctx, c, schedule.Namespace, spec.CredentialsSecretName, backupv1.S3AccessKeyIDKey)
+	if err != nil {
+		return nil, err
+	}
+	secretAccessKey, err := getSecretValue(ctx, c, schedule.Namespace, spec.CredentialsSecretName, backupv1.S3SecretAccessKeyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s3, err := newS3Client(spec.Endpoint, spec.Bucket, spec.Region, accessKeyID, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := strings.Join([]string{schedule.Namespace, schedule.Name, ""}, "/")
+	if p := strings.Trim(spec.Prefix, "/"); p != "" {
+		prefix = p + "/" + prefix
+	}
+	return &s3Storage{client: s3, prefix: prefix}, nil
+}
+
+func (s *s3Storage) Save(ctx context.Context, name string, data []byte) error {
+	return s.client.putObject(ctx, s.prefix+name, data)
+}
+
+func (s *s3Storage) List(ctx context.Context) ([]string, error) {
+	keys, err := s.client.listObjects(ctx, s.prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, key := range keys {
+		name := strings.TrimPrefix(key, s.prefix)
+		// Skip objects in nested "directories".
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *s3Storage) Delete(ctx context.Context, name string) error {
+	return s.client.deleteObject(ctx, s.prefix+name)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	backupv1 "sigs.k8s.io/cluster-api/exp/backup/api/v1alpha1"
+)
+
+func Test_secretStorage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	other := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other",
+			Namespace: "ns1",
+			Labels:    map[string]string{backupv1.ClusterBackupScheduleNameLabel: "schedule"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(g)).WithObjects(other).Build()
+	s := &secretStorage{client: c, schedule: newSchedule("schedule", backupv1.BackupStorage{})}
+
+	g.Expect(s.Save(ctx, "schedule-2", []byte("two"))).To(Succeed())
+	g.Expect(s.Save(ctx, "schedule-1", []byte("one"))).To(Succeed())
+	g.Expect(s.Save(ctx, "schedule-3", make([]byte, maxSecretBackupSize+1))).ToNot(Succeed())
+
+	// Secrets which are not backups are ignored, even if they have the label.
+	names, err := s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"schedule-1", "schedule-2"}))
+
+	g.Expect(s.Delete(ctx, "schedule-1")).To(Succeed())
+	g.Expect(s.Delete(ctx, "schedule-1")).To(Succeed())
+	names, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"schedule-2"}))
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(other), &corev1.Secret{})).To(Succeed())
+}
+
+func Test_volumeStorage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	s := newVolumeStorage(dir, newSchedule("schedule", backupv1.BackupStorage{}))
+
+	// Listing before the first backup returns no backups.
+	names, err := s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(BeEmpty())
+
+	g.Expect(s.Save(ctx, "schedule-2", []byte("two"))).To(Succeed())
+	g.Expect(s.Save(ctx, "schedule-1", []byte("one"))).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, "ns1", "schedule", "schedule-3.tmp"), []byte("partial"), 0600)).To(Succeed())
+
+	data, err := os.ReadFile(filepath.Join(dir, "ns1", "schedule", "schedule-1"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(Equal([]byte("one")))
+
+	names, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"schedule-1", "schedule-2"}))
+
+	g.Expect(s.Delete(ctx, "schedule-1")).To(Succeed())
+	names, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"schedule-2"}))
+}
+
+func Test_s3Storage(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	server := newFakeS3Server(t, "bucket", "id", "secret")
+	defer server.Close()
+
+	schedule := newSchedule("schedule", backupv1.BackupStorage{S3: &backupv1.S3BackupStorage{
+		Endpoint:              server.URL,
+		Bucket:                "bucket",
+		Prefix:                "/backups/",
+		CredentialsSecretName: "credentials",
+	}})
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "ns1"},
+		Data: map[string][]byte{
+			backupv1.S3AccessKeyIDKey:     []byte("id"),
+			backupv1.S3SecretAccessKeyKey: []byte("secret"),
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(g)).WithObjects(credentials).Build()
+
+	s, err := newS3Storage(ctx, c, schedule)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(s.Save(ctx, "schedule-2", []byte("two"))).To(Succeed())
+	g.Expect(s.Save(ctx, "schedule-1", []byte("one"))).To(Succeed())
+	server.objects["backups/ns1/schedule/nested/schedule-3"] = []byte("three")
+	g.Expect(server.objects).To(HaveKeyWithValue("backups/ns1/schedule/schedule-1", []byte("one")))
+
+	names, err := s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"schedule-1", "schedule-2"}))
+
+	g.Expect(s.Delete(ctx, "schedule-1")).To(Succeed())
+	names, err = s.List(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(names).To(Equal([]string{"schedule-2"}))
+}
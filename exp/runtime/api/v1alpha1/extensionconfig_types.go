@@ -203,3 +203,15 @@ const (
 	// DiscoveryFailedReason documents failure of a Discovery call.
 	DiscoveryFailedReason string = "DiscoveryFailed"
 )
+
+const (
+	// PendingHooksAnnotation is the annotation used to keep track of pending runtime hooks.
+	// The annotation will be used to track the intent to call a hook as soon as an operation completes;
+	// the intent will be removed as soon as the hook call completes successfully.
+	// NOTE: Being stored on the object, the intent survives controller restarts and clusterctl move.
+	PendingHooksAnnotation string = "runtime.cluster.x-k8s.io/pending-hooks"
+
+	// OkToDeleteAnnotation is the annotation used to indicate if a cluster is ready to be fully deleted.
+	// This annotation is added to the cluster after the BeforeClusterDelete hook has passed.
+	OkToDeleteAnnotation string = "runtime.cluster.x-k8s.io/ok-to-delete"
+)
@@ -50,6 +50,17 @@ const (
 	// alpha: v1.2
 	RuntimeSDK featuregate.Feature = "RuntimeSDK"
 
+	// ClusterBackup is a feature gate for the ClusterBackupSchedule functionality.
+	//
+	// alpha: v1.2
+	ClusterBackup featuregate.Feature = "ClusterBackup"
+
+	// EtcdLearnerMode is a feature gate for KubeadmControlPlane adding new etcd members as learners,
+	// and promoting them to voting members once they are in sync with the leader.
+	//
+	// alpha: v1.2
+	EtcdLearnerMode featuregate.Feature = "EtcdLearnerMode"
+
 	// KubeadmBootstrapFormatIgnition is a feature gate for the Ignition bootstrap format
 	// functionality.
 	//
@@ -70,4 +81,6 @@ var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	ClusterTopology:                {Default: false, PreRelease: featuregate.Alpha},
 	KubeadmBootstrapFormatIgnition: {Default: false, PreRelease: featuregate.Alpha},
 	RuntimeSDK:                     {Default: false, PreRelease: featuregate.Alpha},
+	ClusterBackup:                  {Default: false, PreRelease: featuregate.Alpha},
+	EtcdLearnerMode:                {Default: false, PreRelease: featuregate.Alpha},
 }
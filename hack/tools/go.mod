@@ -33,6 +33,7 @@ require (
 
 require (
 	cloud.google.com/go v0.99.0 // indirect
+	filippo.io/age v1.0.0 // indirect
 	github.com/MakeNowJust/heredoc v1.0.0 // indirect
 	github.com/Masterminds/goutils v1.1.1 // indirect
 	github.com/Masterminds/semver/v3 v3.1.1 // indirect
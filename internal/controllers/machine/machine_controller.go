@@ -82,6 +82,14 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// NodeLabelSyncPatterns are the patterns used to select the Machine labels to be kept in sync onto the Node.
+	// If not set, DefaultNodeLabelSyncPatterns are used.
+	NodeLabelSyncPatterns []string
+
+	// NodeAnnotationSyncPatterns are the patterns used to select the Machine annotations to be kept in sync onto the Node.
+	// If not set, DefaultNodeAnnotationSyncPatterns are used.
+	NodeAnnotationSyncPatterns []string
+
 	controller      controller.Controller
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
@@ -101,6 +109,13 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		r.nodeDeletionRetryTimeout = 10 * time.Second
 	}
 
+	if err := ValidateNodeMetadataSyncPatterns(r.NodeLabelSyncPatterns); err != nil {
+		return errors.Wrap(err, "invalid node label sync patterns")
+	}
+	if err := ValidateNodeMetadataSyncPatterns(r.NodeAnnotationSyncPatterns); err != nil {
+		return errors.Wrap(err, "invalid node annotation sync patterns")
+	}
+
 	controller, err := ctrl.NewControllerManagedBy(mgr).
 		For(&clusterv1.Machine{}).
 		WithOptions(options).
@@ -267,6 +282,7 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 		r.reconcileInfrastructure,
 		r.reconcileNode,
 		r.reconcileInterruptibleNodeLabel,
+		r.reconcileCertificateExpiry,
 	}
 
 	res := ctrl.Result{}
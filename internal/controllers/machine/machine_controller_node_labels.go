@@ -18,9 +18,13 @@ package machine
 
 import (
 	"context"
+	"sort"
+	"strings"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -93,3 +97,131 @@ func (r *Reconciler) setInterruptibleNodeLabel(ctx context.Context, remoteClient
 
 	return patchHelper.Patch(ctx, node)
 }
+
+var (
+	// DefaultNodeLabelSyncPatterns are the patterns used to select the Machine labels to be kept in sync onto
+	// the Node when no patterns are configured.
+	DefaultNodeLabelSyncPatterns = []string{
+		clusterv1.ManagedNodeMetadataDomain + "/*",
+		"node-role.kubernetes.io/*",
+		"node-restriction.kubernetes.io/*",
+	}
+
+	// DefaultNodeAnnotationSyncPatterns are the patterns used to select the Machine annotations to be kept in sync onto
+	// the Node when no patterns are configured.
+	DefaultNodeAnnotationSyncPatterns = []string{
+		clusterv1.ManagedNodeMetadataDomain + "/*",
+	}
+)
+
+func (r *Reconciler) nodeLabelSyncPatterns() []string {
+	if r.NodeLabelSyncPatterns == nil {
+		return DefaultNodeLabelSyncPatterns
+	}
+	return r.NodeLabelSyncPatterns
+}
+
+func (r *Reconciler) nodeAnnotationSyncPatterns() []string {
+	if r.NodeAnnotationSyncPatterns == nil {
+		return DefaultNodeAnnotationSyncPatterns
+	}
+	return r.NodeAnnotationSyncPatterns
+}
+
+// ValidateNodeMetadataSyncPatterns validates patterns used to select the Machine labels or annotations
+// to be kept in sync onto the Node.
+// A pattern is either a key, e.g. "example.com/role", or a domain followed by "/*", e.g. "node.cluster.x-k8s.io/*";
+// the latter also matches keys using a subdomain of the given domain.
+func ValidateNodeMetadataSyncPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "/*") {
+			if errs := validation.IsDNS1123Subdomain(strings.TrimSuffix(pattern, "/*")); len(errs) > 0 {
+				return errors.Errorf("invalid node metadata sync pattern %q: %s", pattern, strings.Join(errs, "; "))
+			}
+			continue
+		}
+		if errs := validation.IsQualifiedName(pattern); len(errs) > 0 {
+			return errors.Errorf("invalid node metadata sync pattern %q: %s", pattern, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// matchesNodeMetadataSyncPatterns returns true if the key matches at least one of the given patterns.
+func matchesNodeMetadataSyncPatterns(key string, patterns []string) bool {
+	// Never sync the annotations used to track the synced metadata.
+	if key == clusterv1.LabelsFromMachineAnnotation || key == clusterv1.AnnotationsFromMachineAnnotation ||
+		key == clusterv1.LabelsFromTemplateAnnotation || key == clusterv1.AnnotationsFromTemplateAnnotation {
+		return false
+	}
+	for _, pattern := range patterns {
+		if !strings.HasSuffix(pattern, "/*") {
+			if key == pattern {
+				return true
+			}
+			continue
+		}
+		prefix := strings.SplitN(key, "/", 2)
+		if len(prefix) != 2 {
+			continue
+		}
+		domain := strings.TrimSuffix(pattern, "/*")
+		if prefix[0] == domain || strings.HasSuffix(prefix[0], "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// syncNodeMetadata updates the Node with the Machine labels and annotations matching the given patterns.
+// Labels and annotations previously synced but not matching anymore, or removed from the Machine, are
+// removed from the Node; the keys of the synced metadata are tracked in the LabelsFromMachineAnnotation
+// and AnnotationsFromMachineAnnotation annotations on the Node.
+func syncNodeMetadata(node *corev1.Node, machine *clusterv1.Machine, labelPatterns, annotationPatterns []string) {
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+
+	labelKeys := syncMetadataMap(node.Labels, machine.Labels, labelPatterns, node.Annotations[clusterv1.LabelsFromMachineAnnotation])
+	annotationKeys := syncMetadataMap(node.Annotations, machine.Annotations, annotationPatterns, node.Annotations[clusterv1.AnnotationsFromMachineAnnotation])
+
+	setOrRemoveTrackingAnnotation(node.Annotations, clusterv1.LabelsFromMachineAnnotation, labelKeys)
+	setOrRemoveTrackingAnnotation(node.Annotations, clusterv1.AnnotationsFromMachineAnnotation, annotationKeys)
+}
+
+// syncMetadataMap copies the entries from source matching the patterns into target, and removes from target
+// the entries listed in previouslySynced which should not be synced anymore.
+// It returns the sorted list of synced keys.
+func syncMetadataMap(target, source map[string]string, patterns []string, previouslySynced string) []string {
+	synced := []string{}
+	for k, v := range source {
+		if !matchesNodeMetadataSyncPatterns(k, patterns) {
+			continue
+		}
+		target[k] = v
+		synced = append(synced, k)
+	}
+	sort.Strings(synced)
+
+	if previouslySynced == "" {
+		return synced
+	}
+	for _, k := range strings.Split(previouslySynced, ",") {
+		if _, ok := source[k]; ok && matchesNodeMetadataSyncPatterns(k, patterns) {
+			continue
+		}
+		delete(target, k)
+	}
+	return synced
+}
+
+func setOrRemoveTrackingAnnotation(annotations map[string]string, annotation string, keys []string) {
+	if len(keys) == 0 {
+		delete(annotations, annotation)
+		return
+	}
+	annotations[annotation] = strings.Join(keys, ",")
+}
@@ -33,6 +33,7 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	utillabels "sigs.k8s.io/cluster-api/util/labels"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
@@ -140,3 +141,181 @@ func TestReconcileInterruptibleNodeLabel(t *testing.T) {
 		return ok
 	}, 10*time.Second).Should(BeTrue())
 }
+
+func TestValidateNodeMetadataSyncPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		wantErr  bool
+	}{
+		{
+			name:     "no patterns",
+			patterns: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "valid domain and key patterns",
+			patterns: []string{"node.cluster.x-k8s.io/*", "example.com/role"},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid domain pattern",
+			patterns: []string{"Not_A_Domain/*"},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid key pattern",
+			patterns: []string{"example.com/*/role"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := ValidateNodeMetadataSyncPatterns(tt.patterns)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestMatchesNodeMetadataSyncPatterns(t *testing.T) {
+	patterns := []string{"node.cluster.x-k8s.io/*", "example.com/role"}
+
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "node.cluster.x-k8s.io/pool", want: true},
+		{key: "pool.node.cluster.x-k8s.io/name", want: true},
+		{key: "othernode.cluster.x-k8s.io/pool", want: false},
+		{key: "example.com/role", want: true},
+		{key: "example.com/other", want: false},
+		{key: "pool", want: false},
+		{key: clusterv1.LabelsFromMachineAnnotation, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(matchesNodeMetadataSyncPatterns(tt.key, patterns)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSyncNodeMetadata(t *testing.T) {
+	labelPatterns := []string{"node.cluster.x-k8s.io/*", "node-role.kubernetes.io/*"}
+	annotationPatterns := []string{"node.cluster.x-k8s.io/*"}
+
+	t.Run("Adds the matching labels and annotations to the Node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"kubernetes.io/hostname": "node-1"},
+			},
+		}
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"node.cluster.x-k8s.io/pool":     "pool-1",
+					"node-role.kubernetes.io/worker": "",
+					"not-synced":                     "value",
+				},
+				Annotations: map[string]string{
+					"node.cluster.x-k8s.io/owner": "team-a",
+					"not-synced":                  "value",
+				},
+			},
+		}
+
+		syncNodeMetadata(node, machine, labelPatterns, annotationPatterns)
+
+		g.Expect(node.Labels).To(Equal(map[string]string{
+			"kubernetes.io/hostname":         "node-1",
+			"node.cluster.x-k8s.io/pool":     "pool-1",
+			"node-role.kubernetes.io/worker": "",
+		}))
+		g.Expect(node.Annotations).To(Equal(map[string]string{
+			"node.cluster.x-k8s.io/owner":              "team-a",
+			clusterv1.LabelsFromMachineAnnotation:      "node-role.kubernetes.io/worker,node.cluster.x-k8s.io/pool",
+			clusterv1.AnnotationsFromMachineAnnotation: "node.cluster.x-k8s.io/owner",
+		}))
+	})
+
+	t.Run("Removes the labels and annotations not managed anymore from the Node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"kubernetes.io/hostname":         "node-1",
+					"node.cluster.x-k8s.io/pool":     "pool-1",
+					"node-role.kubernetes.io/worker": "",
+				},
+				Annotations: map[string]string{
+					"node.cluster.x-k8s.io/owner":              "team-a",
+					clusterv1.LabelsFromMachineAnnotation:      "node-role.kubernetes.io/worker,node.cluster.x-k8s.io/pool",
+					clusterv1.AnnotationsFromMachineAnnotation: "node.cluster.x-k8s.io/owner",
+				},
+			},
+		}
+		machine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{
+					"node.cluster.x-k8s.io/pool": "pool-2",
+				},
+			},
+		}
+
+		syncNodeMetadata(node, machine, labelPatterns, annotationPatterns)
+
+		g.Expect(node.Labels).To(Equal(map[string]string{
+			"kubernetes.io/hostname":     "node-1",
+			"node.cluster.x-k8s.io/pool": "pool-2",
+		}))
+		g.Expect(node.Annotations).To(Equal(map[string]string{
+			clusterv1.LabelsFromMachineAnnotation: "node.cluster.x-k8s.io/pool",
+		}))
+	})
+
+	t.Run("Removes the labels removed from the machine template from the Machine and then from the Node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"kubernetes.io/hostname": "node-1"},
+			},
+		}
+		machine := &clusterv1.Machine{}
+
+		// Propagate the template labels to the Machine, as the MachineSet controller does, and then to the Node.
+		utillabels.SyncLabels(machine, map[string]string{
+			"node.cluster.x-k8s.io/pool":     "pool-1",
+			"node-role.kubernetes.io/worker": "",
+		})
+		syncNodeMetadata(node, machine, labelPatterns, annotationPatterns)
+		g.Expect(node.Labels).To(HaveKeyWithValue("node.cluster.x-k8s.io/pool", "pool-1"))
+		g.Expect(node.Labels).To(HaveKeyWithValue("node-role.kubernetes.io/worker", ""))
+		g.Expect(node.Annotations).ToNot(HaveKey(clusterv1.LabelsFromTemplateAnnotation))
+
+		// Remove a label from the template.
+		utillabels.SyncLabels(machine, map[string]string{
+			"node.cluster.x-k8s.io/pool": "pool-1",
+		})
+		g.Expect(machine.Labels).ToNot(HaveKey("node-role.kubernetes.io/worker"))
+		syncNodeMetadata(node, machine, labelPatterns, annotationPatterns)
+
+		g.Expect(node.Labels).To(Equal(map[string]string{
+			"kubernetes.io/hostname":     "node-1",
+			"node.cluster.x-k8s.io/pool": "pool-1",
+		}))
+		g.Expect(node.Annotations).To(Equal(map[string]string{
+			clusterv1.LabelsFromMachineAnnotation: "node.cluster.x-k8s.io/pool",
+		}))
+	})
+}
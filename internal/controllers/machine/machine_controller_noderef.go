@@ -95,7 +95,7 @@ func (r *Reconciler) reconcileNode(ctx context.Context, cluster *clusterv1.Clust
 	// Set the NodeSystemInfo.
 	machine.Status.NodeInfo = &node.Status.NodeInfo
 
-	// Reconcile node annotations.
+	// Reconcile node labels and annotations.
 	patchHelper, err := patch.NewHelper(node, remoteClient)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -109,11 +109,14 @@ func (r *Reconciler) reconcileNode(ctx context.Context, cluster *clusterv1.Clust
 		desired[clusterv1.OwnerKindAnnotation] = owner.Kind
 		desired[clusterv1.OwnerNameAnnotation] = owner.Name
 	}
-	if annotations.AddAnnotations(node, desired) {
-		if err := patchHelper.Patch(ctx, node); err != nil {
-			log.V(2).Info("Failed patch node to set annotations", "err", err, "node name", node.Name)
-			return ctrl.Result{}, err
-		}
+	annotations.AddAnnotations(node, desired)
+
+	// Keep the Machine labels and annotations matching the sync patterns in sync onto the Node.
+	syncNodeMetadata(node, machine, r.nodeLabelSyncPatterns(), r.nodeAnnotationSyncPatterns())
+
+	if err := patchHelper.Patch(ctx, node); err != nil {
+		log.V(2).Info("Failed patch node to set labels and annotations", "err", err, "node name", node.Name)
+		return ctrl.Result{}, err
 	}
 
 	// Do the remaining node health checks, then set the node health to true if all checks pass.
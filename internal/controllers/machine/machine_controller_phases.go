@@ -312,3 +312,38 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, cluster *clust
 	m.Spec.ProviderID = pointer.StringPtr(providerID)
 	return ctrl.Result{}, nil
 }
+
+// reconcileCertificateExpiry updates the machine's certificate expiry date.
+func (r *Reconciler) reconcileCertificateExpiry(ctx context.Context, _ *clusterv1.Cluster, m *clusterv1.Machine) (ctrl.Result, error) {
+	// If the machine is not a control plane machine, return early.
+	if !util.IsControlPlaneMachine(m) {
+		return ctrl.Result{}, nil
+	}
+
+	// The expiry date set on the Machine takes precedence over the one set on the bootstrap config.
+	expiry, ok := m.GetAnnotations()[clusterv1.MachineCertificatesExpiryDateAnnotation]
+	if !ok && m.Spec.Bootstrap.ConfigRef != nil {
+		bootstrapConfig, err := external.Get(ctx, r.Client, m.Spec.Bootstrap.ConfigRef, m.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(errors.Cause(err)) {
+				return ctrl.Result{}, nil
+			}
+			return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificates expiry for Machine %q in namespace %q", m.Name, m.Namespace)
+		}
+		expiry, ok = bootstrapConfig.GetAnnotations()[clusterv1.MachineCertificatesExpiryDateAnnotation]
+	}
+
+	// If the expiry date is not set on the Machine nor on the bootstrap config, reset it.
+	if !ok {
+		m.Status.CertificatesExpiryDate = nil
+		return ctrl.Result{}, nil
+	}
+
+	expiryDate, err := time.Parse(time.RFC3339, expiry)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile certificates expiry for Machine %q in namespace %q: failed to parse expiry date %q", m.Name, m.Namespace, expiry)
+	}
+	expiryTime := metav1.NewTime(expiryDate)
+	m.Status.CertificatesExpiryDate = &expiryTime
+	return ctrl.Result{}, nil
+}
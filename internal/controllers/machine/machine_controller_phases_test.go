@@ -1100,3 +1100,136 @@ func TestReconcileInfrastructure(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileCertificateExpiry(t *testing.T) {
+	fakeTimeString := "2020-01-01T00:00:00Z"
+	fakeTime, _ := time.Parse(time.RFC3339, fakeTimeString)
+	fakeMetaTime := &metav1.Time{Time: fakeTime}
+
+	fakeTimeString2 := "2020-02-02T00:00:00Z"
+	fakeTime2, _ := time.Parse(time.RFC3339, fakeTimeString2)
+	fakeMetaTime2 := &metav1.Time{Time: fakeTime2}
+
+	bootstrapConfigWithExpiry := map[string]interface{}{
+		"kind":       "GenericBootstrapConfig",
+		"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-config-with-expiry",
+			"namespace": metav1.NamespaceDefault,
+			"annotations": map[string]interface{}{
+				clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString,
+			},
+		},
+		"spec":   map[string]interface{}{},
+		"status": map[string]interface{}{},
+	}
+
+	bootstrapConfigWithoutExpiry := map[string]interface{}{
+		"kind":       "GenericBootstrapConfig",
+		"apiVersion": "bootstrap.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-config-without-expiry",
+			"namespace": metav1.NamespaceDefault,
+		},
+		"spec":   map[string]interface{}{},
+		"status": map[string]interface{}{},
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+
+	newMachine := func(controlPlane bool, bootstrapConfigName string, annotations map[string]string) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "machine-test",
+				Namespace:   metav1.NamespaceDefault,
+				Labels:      map[string]string{},
+				Annotations: annotations,
+			},
+			Spec: clusterv1.MachineSpec{
+				Bootstrap: clusterv1.Bootstrap{
+					ConfigRef: &corev1.ObjectReference{
+						APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+						Kind:       "GenericBootstrapConfig",
+						Name:       bootstrapConfigName,
+					},
+				},
+			},
+		}
+		if controlPlane {
+			m.Labels[clusterv1.MachineControlPlaneLabelName] = ""
+		}
+		return m
+	}
+
+	tests := []struct {
+		name        string
+		machine     *clusterv1.Machine
+		expected    *metav1.Time
+		expectError bool
+	}{
+		{
+			name:     "worker machines should not be updated",
+			machine:  newMachine(false, "bootstrap-config-with-expiry", map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString}),
+			expected: nil,
+		},
+		{
+			name:     "control plane machine with the annotation on the machine should be updated",
+			machine:  newMachine(true, "bootstrap-config-without-expiry", map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString}),
+			expected: fakeMetaTime,
+		},
+		{
+			name:     "control plane machine with the annotation on the bootstrap config should be updated",
+			machine:  newMachine(true, "bootstrap-config-with-expiry", nil),
+			expected: fakeMetaTime,
+		},
+		{
+			name:     "the annotation on the machine should take precedence over the annotation on the bootstrap config",
+			machine:  newMachine(true, "bootstrap-config-with-expiry", map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: fakeTimeString2}),
+			expected: fakeMetaTime2,
+		},
+		{
+			name: "control plane machine without the annotation should be reset",
+			machine: func() *clusterv1.Machine {
+				m := newMachine(true, "bootstrap-config-without-expiry", nil)
+				m.Status.CertificatesExpiryDate = fakeMetaTime
+				return m
+			}(),
+			expected: nil,
+		},
+		{
+			name:        "control plane machine with an invalid annotation should fail",
+			machine:     newMachine(true, "bootstrap-config-without-expiry", map[string]string{clusterv1.MachineCertificatesExpiryDateAnnotation: "not-a-date"}),
+			expected:    nil,
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().
+					WithObjects(
+						tc.machine,
+						builder.GenericBootstrapConfigCRD.DeepCopy(),
+						&unstructured.Unstructured{Object: bootstrapConfigWithExpiry},
+						&unstructured.Unstructured{Object: bootstrapConfigWithoutExpiry},
+					).Build(),
+			}
+
+			_, err := r.reconcileCertificateExpiry(ctx, cluster, tc.machine)
+			if tc.expectError {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tc.machine.Status.CertificatesExpiryDate).To(Equal(tc.expected))
+		})
+	}
+}
@@ -119,7 +119,8 @@ func (r *Reconciler) getNewMachineSet(ctx context.Context, d *clusterv1.MachineD
 
 		minReadySecondsNeedsUpdate := msCopy.Spec.MinReadySeconds != *d.Spec.MinReadySeconds
 		deletePolicyNeedsUpdate := d.Spec.Strategy.RollingUpdate.DeletePolicy != nil && msCopy.Spec.DeletePolicy != *d.Spec.Strategy.RollingUpdate.DeletePolicy
-		if annotationsUpdated || minReadySecondsNeedsUpdate || deletePolicyNeedsUpdate {
+		templateNeedsUpdate := mdutil.SetNewMachineSetInPlaceMutableFields(d, msCopy)
+		if annotationsUpdated || minReadySecondsNeedsUpdate || deletePolicyNeedsUpdate || templateNeedsUpdate {
 			msCopy.Spec.MinReadySeconds = *d.Spec.MinReadySeconds
 
 			if deletePolicyNeedsUpdate {
@@ -31,6 +31,7 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/integer"
@@ -182,6 +183,58 @@ func getIntFromAnnotation(ms *clusterv1.MachineSet, annotationKey string, logger
 	return int32(intValue), true
 }
 
+// SetNewMachineSetInPlaceMutableFields sets new machine set's template labels, annotations, NodeDrainTimeout and
+// NodeDeletionTimeout to the ones defined in the deployment's template, so that they are propagated in place to the
+// machine set without a rollout; the machine-template-hash and the cluster name labels are preserved.
+// It returns true if the machine set's template is changed.
+func SetNewMachineSetInPlaceMutableFields(deployment *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet) bool {
+	desiredLabels := map[string]string{}
+	for k, v := range deployment.Spec.Template.Labels {
+		desiredLabels[k] = v
+	}
+	for _, k := range []string{clusterv1.MachineDeploymentUniqueLabel, clusterv1.ClusterLabelName} {
+		if v, ok := newMS.Spec.Template.Labels[k]; ok {
+			desiredLabels[k] = v
+		}
+	}
+	desiredAnnotations := map[string]string{}
+	for k, v := range deployment.Spec.Template.Annotations {
+		desiredAnnotations[k] = v
+	}
+
+	changed := false
+	if !equalStringMaps(newMS.Spec.Template.Labels, desiredLabels) {
+		newMS.Spec.Template.Labels = desiredLabels
+		changed = true
+	}
+	if !equalStringMaps(newMS.Spec.Template.Annotations, desiredAnnotations) {
+		newMS.Spec.Template.Annotations = desiredAnnotations
+		changed = true
+	}
+	if !apiequality.Semantic.DeepEqual(newMS.Spec.Template.Spec.NodeDrainTimeout, deployment.Spec.Template.Spec.NodeDrainTimeout) {
+		newMS.Spec.Template.Spec.NodeDrainTimeout = deployment.Spec.Template.Spec.NodeDrainTimeout
+		changed = true
+	}
+	if !apiequality.Semantic.DeepEqual(newMS.Spec.Template.Spec.NodeDeletionTimeout, deployment.Spec.Template.Spec.NodeDeletionTimeout) {
+		newMS.Spec.Template.Spec.NodeDeletionTimeout = deployment.Spec.Template.Spec.NodeDeletionTimeout
+		changed = true
+	}
+	return changed
+}
+
+// equalStringMaps returns true if the two maps have the same entries; nil and empty maps are considered equal.
+func equalStringMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
 // SetNewMachineSetAnnotations sets new machine set's annotations appropriately by updating its revision and
 // copying required deployment annotations to it; it returns true if machine set's annotation is changed.
 func SetNewMachineSetAnnotations(deployment *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, newRevision string, exists bool, logger logr.Logger) bool {
@@ -366,17 +419,23 @@ func getMachineSetFraction(ms clusterv1.MachineSet, d clusterv1.MachineDeploymen
 }
 
 // EqualMachineTemplate returns true if two given machineTemplateSpec are equal,
-// ignoring the diff in value of Labels["machine-template-hash"], and the version from external references.
+// ignoring the in-place mutable fields and the version from external references.
+// In-place mutable fields are labels, annotations, NodeDrainTimeout and NodeDeletionTimeout.
 func EqualMachineTemplate(template1, template2 *clusterv1.MachineTemplateSpec) bool {
 	t1Copy := template1.DeepCopy()
 	t2Copy := template2.DeepCopy()
 
-	// Remove `machine-template-hash` from the comparison:
+	// Remove the in-place mutable fields from the comparison, given that they are propagated
+	// in place to the existing MachineSets and Machines without triggering a rollout;
+	// this also drops `machine-template-hash` from the comparison:
 	// 1. The hash result would be different upon machineTemplateSpec API changes
 	//    (e.g. the addition of a new field will cause the hash code to change)
 	// 2. The deployment template won't have hash labels
-	delete(t1Copy.Labels, clusterv1.MachineDeploymentUniqueLabel)
-	delete(t2Copy.Labels, clusterv1.MachineDeploymentUniqueLabel)
+	for _, t := range []*clusterv1.MachineTemplateSpec{t1Copy, t2Copy} {
+		t.ObjectMeta = clusterv1.ObjectMeta{}
+		t.Spec.NodeDrainTimeout = nil
+		t.Spec.NodeDeletionTimeout = nil
+	}
 
 	// Remove the version part from the references APIVersion field,
 	// for more details see issue #2183 and #2140.
@@ -396,7 +455,7 @@ func EqualMachineTemplate(template1, template2 *clusterv1.MachineTemplateSpec) b
 func FindNewMachineSet(deployment *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) *clusterv1.MachineSet {
 	sort.Sort(MachineSetsByCreationTimestamp(msList))
 	for i := range msList {
-		if EqualMachineTemplate(&msList[i].Spec.Template, &deployment.Spec.Template) {
+		if EqualMachineTemplate(&msList[i].Spec.Template, &deployment.Spec.Template) && machineSetSelectorMatchesTemplate(msList[i], &deployment.Spec.Template) {
 			// In rare cases, such as after cluster upgrades, Deployment may end up with
 			// having more than one new MachineSets that have the same template,
 			// see https://github.com/kubernetes/kubernetes/issues/40415
@@ -408,6 +467,23 @@ func FindNewMachineSet(deployment *clusterv1.MachineDeployment, msList []*cluste
 	return nil
 }
 
+// machineSetSelectorMatchesTemplate returns true if the MachineSet selector matches the labels of the given template,
+// and thus the template labels can be propagated in place to the MachineSet.
+func machineSetSelectorMatchesTemplate(ms *clusterv1.MachineSet, template *clusterv1.MachineTemplateSpec) bool {
+	selector, err := metav1.LabelSelectorAsSelector(&ms.Spec.Selector)
+	if err != nil {
+		return false
+	}
+	templateLabels := labels.Set{}
+	for k, v := range template.Labels {
+		templateLabels[k] = v
+	}
+	if hash, ok := ms.Spec.Template.Labels[clusterv1.MachineDeploymentUniqueLabel]; ok {
+		templateLabels[clusterv1.MachineDeploymentUniqueLabel] = hash
+	}
+	return selector.Matches(templateLabels)
+}
+
 // FindOldMachineSets returns the old machine sets targeted by the given Deployment, with the given slice of MSes.
 // Returns two list of machine sets
 //  - the first contains all old machine sets with all non-zero replicas
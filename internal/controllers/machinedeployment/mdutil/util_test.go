@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apiserver/pkg/storage/names"
 	"k8s.io/klog/v2/klogr"
+	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -98,6 +99,17 @@ func generateMachineTemplateSpec(annotations, labels map[string]string) clusterv
 	}
 }
 
+func generateMachineTemplateSpecWithVersion(version string, labels map[string]string) clusterv1.MachineTemplateSpec {
+	return clusterv1.MachineTemplateSpec{
+		ObjectMeta: clusterv1.ObjectMeta{
+			Labels: labels,
+		},
+		Spec: clusterv1.MachineSpec{
+			Version: &version,
+		},
+	}
+}
+
 func TestEqualMachineTemplate(t *testing.T) {
 	tests := []struct {
 		Name           string
@@ -132,42 +144,68 @@ func TestEqualMachineTemplate(t *testing.T) {
 			Name:     "Same spec, the label is different, the former doesn't have machine-template-hash label, same number of labels",
 			Former:   generateMachineTemplateSpec(map[string]string{}, map[string]string{"something": "else"}),
 			Latter:   generateMachineTemplateSpec(map[string]string{}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-2"}),
-			Expected: false,
+			Expected: true,
 		},
 		{
 			Name:     "Same spec, the label is different, the latter doesn't have machine-template-hash label, same number of labels",
 			Former:   generateMachineTemplateSpec(map[string]string{}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1"}),
 			Latter:   generateMachineTemplateSpec(map[string]string{}, map[string]string{"something": "else"}),
-			Expected: false,
+			Expected: true,
 		},
 		{
 			Name:     "Same spec, the label is different, and the machine-template-hash label value is the same",
 			Former:   generateMachineTemplateSpec(map[string]string{}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1"}),
 			Latter:   generateMachineTemplateSpec(map[string]string{}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
-			Expected: false,
+			Expected: true,
 		},
 		{
-			Name:     "Different spec, same labels",
+			Name:     "Same spec, the annotations are different",
 			Former:   generateMachineTemplateSpec(map[string]string{"former": "value"}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
 			Latter:   generateMachineTemplateSpec(map[string]string{"latter": "value"}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
+			Expected: true,
+		},
+		{
+			Name: "Same spec, the node drain and node deletion timeouts are different",
+			Former: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels: map[string]string{},
+				},
+				Spec: clusterv1.MachineSpec{
+					NodeDrainTimeout: &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			Latter: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels: map[string]string{},
+				},
+				Spec: clusterv1.MachineSpec{
+					NodeDeletionTimeout: &metav1.Duration{Duration: 10 * time.Second},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name:     "Different spec, same labels",
+			Former:   generateMachineTemplateSpecWithVersion("v1.19.10", map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
+			Latter:   generateMachineTemplateSpecWithVersion("v1.20.2", map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
 			Expected: false,
 		},
 		{
 			Name:     "Different spec, different machine-template-hash label value",
-			Former:   generateMachineTemplateSpec(map[string]string{"x": ""}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
-			Latter:   generateMachineTemplateSpec(map[string]string{"x": "1"}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-2", "something": "else"}),
+			Former:   generateMachineTemplateSpecWithVersion("v1.19.10", map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-1", "something": "else"}),
+			Latter:   generateMachineTemplateSpecWithVersion("v1.20.2", map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-2", "something": "else"}),
 			Expected: false,
 		},
 		{
 			Name:     "Different spec, the former doesn't have machine-template-hash label",
-			Former:   generateMachineTemplateSpec(map[string]string{"x": ""}, map[string]string{"something": "else"}),
-			Latter:   generateMachineTemplateSpec(map[string]string{"x": "1"}, map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-2", "something": "else"}),
+			Former:   generateMachineTemplateSpecWithVersion("v1.19.10", map[string]string{"something": "else"}),
+			Latter:   generateMachineTemplateSpecWithVersion("v1.20.2", map[string]string{clusterv1.MachineDeploymentUniqueLabel: "value-2", "something": "else"}),
 			Expected: false,
 		},
 		{
 			Name:     "Different spec, different labels",
-			Former:   generateMachineTemplateSpec(map[string]string{}, map[string]string{"something": "else"}),
-			Latter:   generateMachineTemplateSpec(map[string]string{}, map[string]string{"nothing": "else"}),
+			Former:   generateMachineTemplateSpecWithVersion("v1.19.10", map[string]string{"something": "else"}),
+			Latter:   generateMachineTemplateSpecWithVersion("v1.20.2", map[string]string{"nothing": "else"}),
 			Expected: false,
 		},
 		{
@@ -267,6 +305,36 @@ func TestEqualMachineTemplate(t *testing.T) {
 	}
 }
 
+func TestSetNewMachineSetInPlaceMutableFields(t *testing.T) {
+	g := NewWithT(t)
+
+	deployment := generateDeployment("nginx")
+	deployment.Spec.Template.Labels = map[string]string{"name": "nginx", "node.cluster.x-k8s.io/pool": "pool-1"}
+	deployment.Spec.Template.Annotations = map[string]string{"owner": "team-a"}
+	deployment.Spec.Template.Spec.NodeDrainTimeout = &metav1.Duration{Duration: 10 * time.Second}
+
+	ms := generateMS(generateDeployment("nginx"))
+	ms.Spec.Template.Labels = map[string]string{
+		"name":                                 "nginx",
+		"removed":                              "value",
+		clusterv1.MachineDeploymentUniqueLabel: "hash",
+		clusterv1.ClusterLabelName:             "cluster",
+	}
+
+	g.Expect(SetNewMachineSetInPlaceMutableFields(&deployment, &ms)).To(BeTrue())
+	g.Expect(ms.Spec.Template.Labels).To(Equal(map[string]string{
+		"name":                                 "nginx",
+		"node.cluster.x-k8s.io/pool":           "pool-1",
+		clusterv1.MachineDeploymentUniqueLabel: "hash",
+		clusterv1.ClusterLabelName:             "cluster",
+	}))
+	g.Expect(ms.Spec.Template.Annotations).To(Equal(map[string]string{"owner": "team-a"}))
+	g.Expect(ms.Spec.Template.Spec.NodeDrainTimeout).To(Equal(&metav1.Duration{Duration: 10 * time.Second}))
+
+	// Calling it again should be a no-op.
+	g.Expect(SetNewMachineSetInPlaceMutableFields(&deployment, &ms)).To(BeFalse())
+}
+
 func TestFindNewMachineSet(t *testing.T) {
 	now := metav1.Now()
 	later := metav1.Time{Time: now.Add(time.Minute)}
@@ -280,11 +348,15 @@ func TestFindNewMachineSet(t *testing.T) {
 	newMSDup.Labels[clusterv1.MachineDeploymentUniqueLabel] = "different-hash"
 	newMSDup.CreationTimestamp = now
 
+	newMSWithDifferentMetadata := generateMS(deployment)
+	newMSWithDifferentMetadata.Spec.Template.Annotations = map[string]string{
+		"foo": "bar",
+	}
+	newMSWithDifferentMetadata.CreationTimestamp = later
+
 	oldDeployment := generateDeployment("nginx")
 	oldMS := generateMS(oldDeployment)
-	oldMS.Spec.Template.Annotations = map[string]string{
-		"old": "true",
-	}
+	oldMS.Spec.Template.Spec.Version = pointer.String("v1.19.10")
 	oldMS.Status.FullyLabeledReplicas = *(oldMS.Spec.Replicas)
 
 	tests := []struct {
@@ -305,6 +377,12 @@ func TestFindNewMachineSet(t *testing.T) {
 			msList:     []*clusterv1.MachineSet{&newMS, &oldMS, &newMSDup},
 			expected:   &newMSDup,
 		},
+		{
+			Name:       "Get new MachineSet with the same template as Deployment spec but different annotations",
+			deployment: deployment,
+			msList:     []*clusterv1.MachineSet{&newMSWithDifferentMetadata, &oldMS},
+			expected:   &newMSWithDifferentMetadata,
+		},
 		{
 			Name:       "Get nil new MachineSet",
 			deployment: deployment,
@@ -340,9 +418,7 @@ func TestFindOldMachineSets(t *testing.T) {
 
 	oldDeployment := generateDeployment("nginx")
 	oldMS := generateMS(oldDeployment)
-	oldMS.Spec.Template.Annotations = map[string]string{
-		"old": "true",
-	}
+	oldMS.Spec.Template.Spec.Version = pointer.String("v1.19.10")
 	oldMS.Status.FullyLabeledReplicas = *(oldMS.Spec.Replicas)
 	oldMS.CreationTimestamp = before
 
@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinehealthcheck
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// remediationTypeInternal is used for remediations performed by the owner of the Machine.
+	remediationTypeInternal = "internal"
+
+	// remediationTypeExternal is used for remediations performed via an external remediation request.
+	remediationTypeExternal = "external"
+)
+
+// remediationsTotal counts the Machines marked for remediation by MachineHealthChecks.
+var remediationsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "capi_machinehealthcheck_remediations_total",
+		Help: "Total number of Machines for which a remediation has been triggered by a MachineHealthCheck.",
+	},
+	[]string{"namespace", "name", "cluster_name", "type"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(remediationsTotal)
+}
@@ -45,6 +45,7 @@ import (
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+	utillabels "sigs.k8s.io/cluster-api/util/labels"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
 )
@@ -297,6 +298,12 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 		return ctrl.Result{}, errors.Wrap(err, "failed to remediate machines")
 	}
 
+	// Propagate the in-place mutable fields from the MachineSet template to the existing Machines,
+	// without replacing them.
+	if err := r.syncMachines(ctx, machineSet, filteredMachines); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to sync Machines")
+	}
+
 	syncErr := r.syncReplicas(ctx, machineSet, filteredMachines)
 
 	// Always updates status as machines come up or die.
@@ -335,6 +342,79 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 	return ctrl.Result{}, nil
 }
 
+// syncMachines propagates the in-place mutable fields from the MachineSet template to the given Machines,
+// and the labels and annotations to their InfrastructureMachines and bootstrap configs.
+// In-place mutable fields are labels, annotations, NodeDrainTimeout and NodeDeletionTimeout.
+// Labels and annotations removed from the MachineSet template are removed from the existing objects too; the keys
+// originated from the template are tracked in the LabelsFromTemplateAnnotation and AnnotationsFromTemplateAnnotation
+// annotations.
+func (r *Reconciler) syncMachines(ctx context.Context, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	var errs []error
+	for _, m := range machines {
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(m, r.Client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		utillabels.SyncLabels(m, ms.Spec.Template.Labels)
+		annotations.SyncAnnotations(m, ms.Spec.Template.Annotations)
+		m.Spec.NodeDrainTimeout = ms.Spec.Template.Spec.NodeDrainTimeout
+		if ms.Spec.Template.Spec.NodeDeletionTimeout != nil {
+			m.Spec.NodeDeletionTimeout = ms.Spec.Template.Spec.NodeDeletionTimeout
+		}
+
+		// The InfrastructureMachine and the bootstrap config are always synced, even if the Machine metadata is
+		// already up to date, because they might have been changed independently of the Machine.
+		// NOTE: syncExternalObjectMetadata does not patch objects which are already up to date.
+		if err := r.syncExternalObjectMetadata(ctx, &m.Spec.InfrastructureRef, m.Namespace, ms.Spec.Template.ObjectMeta); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to update InfrastructureMachine for Machine %q", m.Name))
+			continue
+		}
+		if m.Spec.Bootstrap.ConfigRef != nil {
+			if err := r.syncExternalObjectMetadata(ctx, m.Spec.Bootstrap.ConfigRef, m.Namespace, ms.Spec.Template.ObjectMeta); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to update bootstrap config for Machine %q", m.Name))
+				continue
+			}
+		}
+
+		if err := patchHelper.Patch(ctx, m); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to update Machine %q", m.Name))
+			continue
+		}
+		log.V(4).Info("Synced Machine with the MachineSet template", "machine", m.Name)
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// syncExternalObjectMetadata syncs the given labels and annotations on the referenced external object.
+func (r *Reconciler) syncExternalObjectMetadata(ctx context.Context, ref *corev1.ObjectReference, namespace string, metadata clusterv1.ObjectMeta) error {
+	obj, err := external.Get(ctx, r.Client, ref, namespace)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return nil
+		}
+		return err
+	}
+
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return err
+	}
+	labelsChanged := utillabels.SyncLabels(obj, metadata.Labels)
+	annotationsChanged := annotations.SyncAnnotations(obj, metadata.Annotations)
+	if !labelsChanged && !annotationsChanged {
+		return nil
+	}
+	return patchHelper.Patch(ctx, obj)
+}
+
 // syncReplicas scales Machine resources up or down.
 func (r *Reconciler) syncReplicas(ctx context.Context, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) error {
 	log := ctrl.LoggerFrom(ctx)
@@ -899,3 +899,130 @@ func TestMachineSetReconciler_updateStatusResizedCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineSetReconciler_syncMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	ms := newMachineSet("ms-sync", "foo", int32(1))
+	ms.Spec.Template.Labels["templateLabel"] = "templateLabelValue"
+	ms.Spec.Template.Annotations = map[string]string{
+		"templateAnnotation": "templateAnnotationValue",
+	}
+	ms.Spec.Template.Spec.NodeDrainTimeout = &metav1.Duration{Duration: 10 * time.Second}
+
+	infraMachine := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       builder.GenericInfrastructureMachineKind,
+			"apiVersion": builder.InfrastructureGroupVersion.String(),
+			"metadata": map[string]interface{}{
+				"name":      "infra",
+				"namespace": metav1.NamespaceDefault,
+			},
+		},
+	}
+	bootstrapConfig := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       builder.GenericBootstrapConfigKind,
+			"apiVersion": builder.BootstrapGroupVersion.String(),
+			"metadata": map[string]interface{}{
+				"name":      "bootstrap",
+				"namespace": metav1.NamespaceDefault,
+				"labels": map[string]interface{}{
+					"existingLabel": "existingLabelValue",
+				},
+			},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine",
+			Namespace: metav1.NamespaceDefault,
+			Labels: map[string]string{
+				"existingLabel": "existingLabelValue",
+			},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "foo",
+			InfrastructureRef: corev1.ObjectReference{
+				Kind:       infraMachine.GetKind(),
+				APIVersion: infraMachine.GetAPIVersion(),
+				Name:       infraMachine.GetName(),
+				Namespace:  metav1.NamespaceDefault,
+			},
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					Kind:       bootstrapConfig.GetKind(),
+					APIVersion: bootstrapConfig.GetAPIVersion(),
+					Name:       bootstrapConfig.GetName(),
+					Namespace:  metav1.NamespaceDefault,
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(machine.DeepCopy(), infraMachine.DeepCopy(), bootstrapConfig.DeepCopy()).Build()
+	r := &Reconciler{
+		Client:   fakeClient,
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	getObjects := func() (*clusterv1.Machine, *unstructured.Unstructured, *unstructured.Unstructured) {
+		gotMachine := &clusterv1.Machine{}
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), gotMachine)).To(Succeed())
+		gotInfraMachine := &unstructured.Unstructured{}
+		gotInfraMachine.SetGroupVersionKind(infraMachine.GroupVersionKind())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infraMachine), gotInfraMachine)).To(Succeed())
+		gotBootstrapConfig := &unstructured.Unstructured{}
+		gotBootstrapConfig.SetGroupVersionKind(bootstrapConfig.GroupVersionKind())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(bootstrapConfig), gotBootstrapConfig)).To(Succeed())
+		return gotMachine, gotInfraMachine, gotBootstrapConfig
+	}
+
+	// Verify that labels, annotations and timeouts are propagated to the Machine, and that labels and annotations
+	// are propagated to the InfrastructureMachine and to the bootstrap config, preserving the existing labels.
+	gotMachine, _, _ := getObjects()
+	g.Expect(r.syncMachines(ctx, ms, []*clusterv1.Machine{gotMachine})).To(Succeed())
+
+	gotMachine, gotInfraMachine, gotBootstrapConfig := getObjects()
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("templateLabel", "templateLabelValue"))
+	g.Expect(gotMachine.Annotations).To(HaveKeyWithValue("templateAnnotation", "templateAnnotationValue"))
+	g.Expect(gotMachine.Spec.NodeDrainTimeout).To(Equal(ms.Spec.Template.Spec.NodeDrainTimeout))
+	g.Expect(gotInfraMachine.GetLabels()).To(HaveKeyWithValue("templateLabel", "templateLabelValue"))
+	g.Expect(gotInfraMachine.GetAnnotations()).To(HaveKeyWithValue("templateAnnotation", "templateAnnotationValue"))
+	g.Expect(gotBootstrapConfig.GetLabels()).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotBootstrapConfig.GetLabels()).To(HaveKeyWithValue("templateLabel", "templateLabelValue"))
+	g.Expect(gotBootstrapConfig.GetAnnotations()).To(HaveKeyWithValue("templateAnnotation", "templateAnnotationValue"))
+
+	// Remove the label and the annotation from the MachineSet template, and verify that they are removed from
+	// all the objects, preserving the labels which are not originated from the template.
+	delete(ms.Spec.Template.Labels, "templateLabel")
+	ms.Spec.Template.Annotations = nil
+	g.Expect(r.syncMachines(ctx, ms, []*clusterv1.Machine{gotMachine})).To(Succeed())
+
+	gotMachine, gotInfraMachine, gotBootstrapConfig = getObjects()
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue(clusterv1.ClusterLabelName, "foo"))
+	g.Expect(gotMachine.Labels).ToNot(HaveKey("templateLabel"))
+	g.Expect(gotMachine.Annotations).ToNot(HaveKey("templateAnnotation"))
+	g.Expect(gotInfraMachine.GetLabels()).ToNot(HaveKey("templateLabel"))
+	g.Expect(gotInfraMachine.GetAnnotations()).ToNot(HaveKey("templateAnnotation"))
+	g.Expect(gotBootstrapConfig.GetLabels()).To(HaveKeyWithValue("existingLabel", "existingLabelValue"))
+	g.Expect(gotBootstrapConfig.GetLabels()).ToNot(HaveKey("templateLabel"))
+	g.Expect(gotBootstrapConfig.GetAnnotations()).ToNot(HaveKey("templateAnnotation"))
+
+	// Add the label back to the MachineSet template and then remove it from the InfrastructureMachine only, and
+	// verify that it is propagated to the InfrastructureMachine even if the Machine is already up to date.
+	ms.Spec.Template.Labels["templateLabel"] = "templateLabelValue"
+	g.Expect(r.syncMachines(ctx, ms, []*clusterv1.Machine{gotMachine})).To(Succeed())
+
+	gotMachine, gotInfraMachine, _ = getObjects()
+	gotInfraMachine.SetLabels(nil)
+	gotInfraMachine.SetAnnotations(nil)
+	g.Expect(fakeClient.Update(ctx, gotInfraMachine)).To(Succeed())
+	g.Expect(r.syncMachines(ctx, ms, []*clusterv1.Machine{gotMachine})).To(Succeed())
+
+	gotMachine, gotInfraMachine, _ = getObjects()
+	g.Expect(gotMachine.Labels).To(HaveKeyWithValue("templateLabel", "templateLabelValue"))
+	g.Expect(gotInfraMachine.GetLabels()).To(HaveKeyWithValue("templateLabel", "templateLabelValue"))
+}
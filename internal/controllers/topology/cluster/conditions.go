@@ -37,6 +37,7 @@ func (r *Reconciler) reconcileConditions(s *scope.Scope, cluster *clusterv1.Clus
 // cluster are in sync with the topology defined in the cluster.
 // The condition is false under the following conditions:
 // - An error occurred during the reconcile process of the cluster topology.
+// - A lifecycle hook is blocking the reconcile process of the cluster topology.
 // - The cluster upgrade has not yet propagated to all the components of the cluster.
 //   - For a managed topology cluster the version upgrade is propagated one component at a time.
 //     In such a case, since some of the component's spec would be adrift from the topology the
@@ -57,20 +58,42 @@ func (r *Reconciler) reconcileTopologyReconciledCondition(s *scope.Scope, cluste
 		return nil
 	}
 
-	// If either the Control Plane or any of the MachineDeployments are still pending to pick up the new version (generally
+	// If any of the lifecycle hooks are blocking any part of the reconciliation then topology
+	// is not considered as fully reconciled.
+	if s.HookResponseTracker.AggregateRetryAfter() != 0 {
+		conditions.Set(
+			cluster,
+			conditions.FalseCondition(
+				clusterv1.TopologyReconciledCondition,
+				clusterv1.TopologyReconciledHookBlockingReason,
+				clusterv1.ConditionSeverityInfo,
+				s.HookResponseTracker.AggregateMessage(),
+			),
+		)
+		return nil
+	}
+
+	// If either the Control Plane or any of the MachineDeployments or MachinePools are still pending to pick up the new version (generally
 	// happens when upgrading the cluster) then the topology is not considered as fully reconciled.
-	if s.UpgradeTracker.ControlPlane.PendingUpgrade || s.UpgradeTracker.MachineDeployments.PendingUpgrade() {
+	if s.UpgradeTracker.ControlPlane.PendingUpgrade || s.UpgradeTracker.MachineDeployments.PendingUpgrade() || s.UpgradeTracker.MachinePools.PendingUpgrade() {
 		msgBuilder := &strings.Builder{}
 		var reason string
-		if s.UpgradeTracker.ControlPlane.PendingUpgrade {
+		switch {
+		case s.UpgradeTracker.ControlPlane.PendingUpgrade:
 			msgBuilder.WriteString(fmt.Sprintf("Control plane upgrade to %s on hold. ", s.Blueprint.Topology.Version))
 			reason = clusterv1.TopologyReconciledControlPlaneUpgradePendingReason
-		} else {
+		case s.UpgradeTracker.MachineDeployments.PendingUpgrade():
 			msgBuilder.WriteString(fmt.Sprintf("MachineDeployment(s) %s upgrade to version %s on hold. ",
 				strings.Join(s.UpgradeTracker.MachineDeployments.PendingUpgradeNames(), ", "),
 				s.Blueprint.Topology.Version,
 			))
 			reason = clusterv1.TopologyReconciledMachineDeploymentsUpgradePendingReason
+		default:
+			msgBuilder.WriteString(fmt.Sprintf("MachinePool(s) %s upgrade to version %s on hold. ",
+				strings.Join(s.UpgradeTracker.MachinePools.PendingUpgradeNames(), ", "),
+				s.Blueprint.Topology.Version,
+			))
+			reason = clusterv1.TopologyReconciledMachinePoolsUpgradePendingReason
 		}
 
 		switch {
@@ -91,6 +114,11 @@ func (r *Reconciler) reconcileTopologyReconciledCondition(s *scope.Scope, cluste
 			msgBuilder.WriteString(fmt.Sprintf("MachineDeployment(s) %s are rolling out", strings.Join(
 				s.UpgradeTracker.MachineDeployments.RolloutNames(), ", ",
 			)))
+
+		case s.Current.MachinePools.IsAnyRollingOut():
+			msgBuilder.WriteString(fmt.Sprintf("MachinePool(s) %s are rolling out", strings.Join(
+				s.UpgradeTracker.MachinePools.RolloutNames(), ", ",
+			)))
 		}
 
 		conditions.Set(
@@ -26,6 +26,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
@@ -67,6 +69,18 @@ func (r *Reconciler) getCurrentState(ctx context.Context, s *scope.Scope) (*scop
 	}
 	currentState.MachineDeployments = m
 
+	// A Cluster may have zero or more MachinePools and a Cluster is expected to have zero MachinePools on
+	// first reconcile.
+	// NOTE: MachinePools are read only if the MachinePool feature gate is enabled, given that otherwise
+	// the MachinePool API might not be installed.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		mp, err := r.getCurrentMachinePoolState(ctx, currentState.Cluster)
+		if err != nil {
+			return nil, err
+		}
+		currentState.MachinePools = mp
+	}
+
 	return currentState, nil
 }
 
@@ -214,3 +228,71 @@ func (r *Reconciler) getCurrentMachineDeploymentState(ctx context.Context, clust
 	}
 	return state, nil
 }
+
+// getCurrentMachinePoolState queries for all MachinePools and filters them for their linked Cluster and
+// whether they are managed by a ClusterClass using labels. A Cluster may have zero or more MachinePools. Zero is
+// expected on first reconcile. If MachinePools are found for the Cluster their Infrastructure and Bootstrap references
+// are inspected. Where these are not found the function will throw an error.
+func (r *Reconciler) getCurrentMachinePoolState(ctx context.Context, cluster *clusterv1.Cluster) (scope.MachinePoolsStateMap, error) {
+	state := make(scope.MachinePoolsStateMap)
+
+	// List all the machine pools in the current cluster and in a managed topology.
+	mp := &expv1.MachinePoolList{}
+	err := r.APIReader.List(ctx, mp,
+		client.MatchingLabels{
+			clusterv1.ClusterLabelName:          cluster.Name,
+			clusterv1.ClusterTopologyOwnedLabel: "",
+		},
+		client.InNamespace(cluster.Namespace),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read MachinePools for managed topology")
+	}
+
+	// Loop over each machine pool and create the current
+	// state by retrieving all required references.
+	for i := range mp.Items {
+		m := &mp.Items[i]
+
+		// Retrieve the name which is assigned in Cluster's topology
+		// from a well-defined label.
+		mpTopologyName, ok := m.ObjectMeta.Labels[clusterv1.ClusterTopologyMachinePoolLabelName]
+		if !ok || mpTopologyName == "" {
+			return nil, fmt.Errorf("failed to find label %s in %s", clusterv1.ClusterTopologyMachinePoolLabelName, tlog.KObj{Obj: m})
+		}
+
+		// Make sure that the name of the MachinePool stays unique.
+		// If we've already have seen a MachinePool with the same name
+		// this is an error, probably caused from manual modifications or a race condition.
+		if _, ok := state[mpTopologyName]; ok {
+			return nil, fmt.Errorf("duplicate %s found for label %s: %s", tlog.KObj{Obj: m}, clusterv1.ClusterTopologyMachinePoolLabelName, mpTopologyName)
+		}
+
+		// Gets the bootstrap config.
+		bootstrapRef := m.Spec.Template.Spec.Bootstrap.ConfigRef
+		if bootstrapRef == nil {
+			return nil, fmt.Errorf("%s does not have a reference to a Bootstrap Config", tlog.KObj{Obj: m})
+		}
+		b, err := r.getReference(ctx, bootstrapRef)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("%s Bootstrap reference could not be retrieved", tlog.KObj{Obj: m}))
+		}
+
+		// Gets the InfrastructureMachinePool.
+		infraRef := m.Spec.Template.Spec.InfrastructureRef
+		if infraRef.Name == "" {
+			return nil, fmt.Errorf("%s does not have a reference to a InfrastructureMachinePool", tlog.KObj{Obj: m})
+		}
+		infra, err := r.getReference(ctx, &infraRef)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("%s Infrastructure reference could not be retrieved", tlog.KObj{Obj: m}))
+		}
+
+		state[mpTopologyName] = &scope.MachinePoolState{
+			Object:                          m,
+			BootstrapObject:                 b,
+			InfrastructureMachinePoolObject: infra,
+		}
+	}
+	return state, nil
+}
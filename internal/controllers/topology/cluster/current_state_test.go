@@ -24,10 +24,12 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	. "sigs.k8s.io/cluster-api/internal/test/matchers"
@@ -562,3 +564,69 @@ func TestGetCurrentState(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCurrentStateMachinePools(t *testing.T) {
+	machinePoolInfrastructure := builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").
+		Build()
+	machinePoolBootstrap := builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").
+		Build()
+	machinePool := builder.MachinePool(metav1.NamespaceDefault, "mp1").
+		WithLabels(map[string]string{
+			clusterv1.ClusterLabelName:                    "cluster1",
+			clusterv1.ClusterTopologyOwnedLabel:           "",
+			clusterv1.ClusterTopologyMachinePoolLabelName: "mp1",
+		}).
+		WithBootstrapTemplate(machinePoolBootstrap).
+		WithInfrastructureTemplate(machinePoolInfrastructure).
+		Build()
+
+	tests := []struct {
+		name                   string
+		machinePoolEnabled     bool
+		wantMachinePoolsLength int
+	}{
+		{
+			name:                   "Reads MachinePools when the MachinePool feature gate is enabled",
+			machinePoolEnabled:     true,
+			wantMachinePoolsLength: 1,
+		},
+		{
+			name:                   "Does not read MachinePools when the MachinePool feature gate is disabled",
+			machinePoolEnabled:     false,
+			wantMachinePoolsLength: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachinePool, tt.machinePoolEnabled)()
+
+			cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				Build()
+			s := scope.New(cluster)
+			s.Blueprint = &scope.ClusterBlueprint{ClusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").Build()}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(fakeScheme).
+				WithObjects(
+					builder.GenericBootstrapConfigTemplateCRD,
+					builder.GenericInfrastructureMachineTemplateCRD,
+					machinePoolInfrastructure,
+					machinePoolBootstrap,
+					machinePool,
+					cluster,
+				).
+				Build()
+
+			r := &Reconciler{
+				Client:                    fakeClient,
+				APIReader:                 fakeClient,
+				UnstructuredCachingClient: fakeClient,
+				patchHelperFactory:        dryRunPatchHelperFactory(fakeClient),
+			}
+			got, err := r.getCurrentState(ctx, s)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(got.MachinePools).To(HaveLen(tt.wantMachinePoolsLength))
+		})
+	}
+}
@@ -31,9 +31,15 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
+	"sigs.k8s.io/cluster-api/internal/hooks"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
 )
 
 // computeDesiredState computes the desired state of the cluster topology.
@@ -60,7 +66,7 @@ func (r *Reconciler) computeDesiredState(ctx context.Context, s *scope.Scope) (*
 
 	// Compute the desired state of the ControlPlane object, eventually adding a reference to the
 	// InfrastructureMachineTemplate generated by the previous step.
-	if desiredState.ControlPlane.Object, err = computeControlPlane(ctx, s, desiredState.ControlPlane.InfrastructureMachineTemplate); err != nil {
+	if desiredState.ControlPlane.Object, err = r.computeControlPlane(ctx, s, desiredState.ControlPlane.InfrastructureMachineTemplate); err != nil {
 		return nil, errors.Wrapf(err, "failed to compute ControlPlane")
 	}
 
@@ -88,6 +94,15 @@ func (r *Reconciler) computeDesiredState(ctx context.Context, s *scope.Scope) (*
 		}
 	}
 
+	// If required, compute the desired state of the MachinePools from the list of MachinePoolTopologies
+	// defined in the cluster.
+	if feature.Gates.Enabled(feature.MachinePool) && s.Blueprint.HasMachinePools() {
+		desiredState.MachinePools, err = computeMachinePools(ctx, s, desiredState.ControlPlane)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to compute MachinePools")
+		}
+	}
+
 	// Apply patches the desired state according to the patches from the ClusterClass, variables from the Cluster
 	// and builtin variables.
 	// NOTE: We have to make sure all spec fields that were explicitly set in desired objects during the computation above
@@ -167,7 +182,7 @@ func computeControlPlaneInfrastructureMachineTemplate(_ context.Context, s *scop
 
 // computeControlPlane computes the desired state for the ControlPlane object starting from the
 // corresponding template defined in the blueprint.
-func computeControlPlane(_ context.Context, s *scope.Scope, infrastructureMachineTemplate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (r *Reconciler) computeControlPlane(ctx context.Context, s *scope.Scope, infrastructureMachineTemplate *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	template := s.Blueprint.ControlPlane.Template
 	templateClonedFromRef := s.Blueprint.ClusterClass.Spec.ControlPlane.Ref
 	cluster := s.Current.Cluster
@@ -240,7 +255,7 @@ func computeControlPlane(_ context.Context, s *scope.Scope, infrastructureMachin
 	}
 
 	// Sets the desired Kubernetes version for the control plane.
-	version, err := computeControlPlaneVersion(s)
+	version, err := r.computeControlPlaneVersion(ctx, s)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to compute version of control plane")
 	}
@@ -254,7 +269,10 @@ func computeControlPlane(_ context.Context, s *scope.Scope, infrastructureMachin
 // computeControlPlaneVersion calculates the version of the desired control plane.
 // The version is calculated using the state of the current machine deployments, the current control plane
 // and the version defined in the topology.
-func computeControlPlaneVersion(s *scope.Scope) (string, error) {
+// If the RuntimeSDK feature flag is enabled, this func also takes care of calling the BeforeClusterUpgrade
+// hook before picking up the new version, and the AfterControlPlaneUpgrade hook once the control plane upgrade completes.
+func (r *Reconciler) computeControlPlaneVersion(ctx context.Context, s *scope.Scope) (string, error) {
+	log := tlog.LoggerFrom(ctx)
 	desiredVersion := s.Blueprint.Topology.Version
 	// If we are creating the control plane object (current control plane is nil), use version from topology.
 	if s.Current.ControlPlane == nil || s.Current.ControlPlane.Object == nil {
@@ -306,6 +324,13 @@ func computeControlPlaneVersion(s *scope.Scope) (string, error) {
 	// Return here if the control plane is already at the desired version
 	if !s.UpgradeTracker.ControlPlane.PendingUpgrade {
 		// At this stage the control plane is not upgrading and is already at the desired version.
+		// If the control plane just completed an upgrade, call the AfterControlPlaneUpgrade hook.
+		if feature.Gates.Enabled(feature.RuntimeSDK) {
+			if err := r.callAfterControlPlaneUpgradeHook(ctx, s, *currentVersion); err != nil {
+				return "", err
+			}
+		}
+
 		// We can return.
 		// Nb. We do not return early in the function if the control plane is already at the desired version so as
 		// to know if the control plane is being upgraded. This information
@@ -334,11 +359,80 @@ func computeControlPlaneVersion(s *scope.Scope) (string, error) {
 		return *currentVersion, nil
 	}
 
+	// Same applies to MachinePools.
+	if s.Current.MachinePools.IsAnyRollingOut() {
+		return *currentVersion, nil
+	}
+
+	// At this point the control plane and the machine deployments are stable and we are almost ready to pick
+	// up the desiredVersion. Call the BeforeClusterUpgrade hook before picking up the desired version.
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		hookRequest := &runtimehooksv1.BeforeClusterUpgradeRequest{
+			Cluster:               *s.Current.Cluster,
+			FromKubernetesVersion: *currentVersion,
+			ToKubernetesVersion:   desiredVersion,
+		}
+		hookResponse := &runtimehooksv1.BeforeClusterUpgradeResponse{}
+		if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeClusterUpgrade, hookRequest, hookResponse); err != nil {
+			return "", errors.Wrapf(err, "error calling the %s hook", runtimecatalog.HookName(runtimehooksv1.BeforeClusterUpgrade))
+		}
+		s.HookResponseTracker.Add(runtimehooksv1.BeforeClusterUpgrade, hookResponse)
+		if hookResponse.RetryAfterSeconds != 0 {
+			// Cannot pickup the new version right now. Need to try again later.
+			log.Infof("Cluster upgrade to version %s is blocked by %s hook", desiredVersion, runtimecatalog.HookName(runtimehooksv1.BeforeClusterUpgrade))
+			return *currentVersion, nil
+		}
+
+		// We are picking up the new version here.
+		// Track the intent of calling the AfterControlPlaneUpgrade and the AfterClusterUpgrade hooks once we are done with the upgrade.
+		// NOTE: The intent is persisted on the Cluster before the new version is picked up, so it survives controller restarts.
+		if err := hooks.MarkAsPending(ctx, r.Client, s.Current.Cluster, runtimehooksv1.AfterControlPlaneUpgrade, runtimehooksv1.AfterClusterUpgrade); err != nil {
+			return "", errors.Wrapf(err, "failed to track the intent to call the %s and the %s hooks",
+				runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneUpgrade), runtimecatalog.HookName(runtimehooksv1.AfterClusterUpgrade))
+		}
+	}
+
 	// Control plane and machine deployments are stable.
 	// Ready to pick up the topology version.
 	return desiredVersion, nil
 }
 
+// callAfterControlPlaneUpgradeHook calls the AfterControlPlaneUpgrade hook if there is an intent to do so,
+// i.e. if the control plane just completed an upgrade triggered by the topology controller.
+// If the hook returns a blocking response, the upgrade of the MachineDeployments and MachinePools is held back.
+func (r *Reconciler) callAfterControlPlaneUpgradeHook(ctx context.Context, s *scope.Scope, currentVersion string) error {
+	// Call the hook only if we are tracking the intent to do so. If it is not tracked it means we don't need to call the
+	// hook because we didn't go through an upgrade or we already called the hook after the upgrade.
+	if !hooks.IsPending(runtimehooksv1.AfterControlPlaneUpgrade, s.Current.Cluster) {
+		return nil
+	}
+
+	hookRequest := &runtimehooksv1.AfterControlPlaneUpgradeRequest{
+		Cluster:           *s.Current.Cluster,
+		KubernetesVersion: currentVersion,
+	}
+	hookResponse := &runtimehooksv1.AfterControlPlaneUpgradeResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterControlPlaneUpgrade, hookRequest, hookResponse); err != nil {
+		return errors.Wrapf(err, "error calling the %s hook", runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneUpgrade))
+	}
+	// Add the response to the tracker so we can later update condition or requeue when required.
+	s.HookResponseTracker.Add(runtimehooksv1.AfterControlPlaneUpgrade, hookResponse)
+
+	// If the extension responds to hold off on starting MachineDeployments and MachinePools upgrades,
+	// change the UpgradeTracker accordingly, otherwise the hook call is completed and we
+	// can remove this hook from the list of pending hooks.
+	if hookResponse.RetryAfterSeconds != 0 {
+		tlog.LoggerFrom(ctx).Infof("MachineDeployments/MachinePools upgrade to version %s is blocked by %s hook", currentVersion, runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneUpgrade))
+		s.UpgradeTracker.MachineDeployments.HoldUpgrades(true)
+		s.UpgradeTracker.MachinePools.HoldUpgrades(true)
+		return nil
+	}
+	if err := hooks.MarkAsDone(ctx, r.Client, s.Current.Cluster, runtimehooksv1.AfterControlPlaneUpgrade); err != nil {
+		return errors.Wrapf(err, "failed to remove the %s hook from pending hooks", runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneUpgrade))
+	}
+	return nil
+}
+
 // computeCluster computes the desired state for the Cluster object.
 // NOTE: Some fields of the Cluster’s fields contribute to defining the Cluster blueprint (e.g. Cluster.Spec.Topology),
 // while some other fields should be managed as part of the actual Cluster (e.g. Cluster.Spec.ControlPlaneRef); in this func
@@ -355,6 +449,10 @@ func computeCluster(_ context.Context, s *scope.Scope, infrastructureCluster, co
 	cluster.Labels[clusterv1.ClusterLabelName] = cluster.Name
 	cluster.Labels[clusterv1.ClusterTopologyOwnedLabel] = ""
 
+	// The pending hooks annotation is managed by the lifecycle hooks helpers, which patch the Cluster directly;
+	// drop it from the desired state so the topology controller never restores a stale list of pending hooks.
+	delete(cluster.Annotations, runtimev1.PendingHooksAnnotation)
+
 	// Set the references to the infrastructureCluster and controlPlane objects.
 	// NOTE: Once set for the first time, the references are not expected to change.
 	cluster.Spec.InfrastructureRef = contract.ObjToRef(infrastructureCluster)
@@ -640,6 +738,261 @@ func computeMachineDeploymentVersion(s *scope.Scope, desiredControlPlaneState *s
 	return desiredVersion, nil
 }
 
+// computeMachinePools computes the desired state of the list of MachinePools.
+func computeMachinePools(ctx context.Context, s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState) (scope.MachinePoolsStateMap, error) {
+	// Mark all the machine pools that are currently rolling out.
+	// This captured information will be used for
+	//   - Building the TopologyReconciled condition.
+	//   - Making upgrade decisions on machine pools.
+	s.UpgradeTracker.MachinePools.MarkRollingOut(s.Current.MachinePools.RollingOut()...)
+	machinePoolsStateMap := make(scope.MachinePoolsStateMap)
+	for _, mpTopology := range s.Blueprint.Topology.Workers.MachinePools {
+		desiredMachinePool, err := computeMachinePool(ctx, s, desiredControlPlaneState, mpTopology)
+		if err != nil {
+			return nil, err
+		}
+		machinePoolsStateMap[mpTopology.Name] = desiredMachinePool
+	}
+	return machinePoolsStateMap, nil
+}
+
+// computeMachinePool computes the desired state for a MachinePoolTopology.
+// The generated machinePool object is calculated using the values from the machinePoolTopology and
+// the machinePool class.
+// NOTE: Differently from MachineDeployments, MachinePools directly reference a bootstrap config and an
+// InfrastructureMachinePool object, which are generated from the templates defined in the MachinePool class.
+func computeMachinePool(_ context.Context, s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState, machinePoolTopology clusterv1.MachinePoolTopology) (*scope.MachinePoolState, error) {
+	desiredMachinePool := &scope.MachinePoolState{}
+
+	// Gets the blueprint for the MachinePool class.
+	className := machinePoolTopology.Class
+	machinePoolBlueprint, ok := s.Blueprint.MachinePools[className]
+	if !ok {
+		return nil, errors.Errorf("MachinePool class %s not found in %s", className, tlog.KObj{Obj: s.Blueprint.ClusterClass})
+	}
+
+	// Compute the bootstrap config.
+	currentMachinePool := s.Current.MachinePools[machinePoolTopology.Name]
+	var currentBootstrapConfigRef *corev1.ObjectReference
+	if currentMachinePool != nil && currentMachinePool.BootstrapObject != nil {
+		currentBootstrapConfigRef = currentMachinePool.Object.Spec.Template.Spec.Bootstrap.ConfigRef
+	}
+	var err error
+	desiredMachinePool.BootstrapObject, err = templateToObject(templateToInput{
+		template:              machinePoolBlueprint.BootstrapTemplate,
+		templateClonedFromRef: contract.ObjToRef(machinePoolBlueprint.BootstrapTemplate),
+		cluster:               s.Current.Cluster,
+		namePrefix:            bootstrapConfigNamePrefix(s.Current.Cluster.Name, machinePoolTopology.Name),
+		currentObjectRef:      currentBootstrapConfigRef,
+		// Note: we are adding an ownerRef to Cluster so the bootstrap config will be automatically garbage collected
+		// in case of errors in between creating this object and creating/updating the MachinePool object
+		// with the reference to it.
+		ownerRef: ownerReferenceTo(s.Current.Cluster),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute bootstrap object for topology %q", machinePoolTopology.Name)
+	}
+
+	bootstrapObjectLabels := desiredMachinePool.BootstrapObject.GetLabels()
+	if bootstrapObjectLabels == nil {
+		bootstrapObjectLabels = map[string]string{}
+	}
+	// Add ClusterTopologyMachinePoolLabel to the generated Bootstrap config
+	bootstrapObjectLabels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+	desiredMachinePool.BootstrapObject.SetLabels(bootstrapObjectLabels)
+
+	// Compute the InfrastructureMachinePool.
+	var currentInfraMachinePoolRef *corev1.ObjectReference
+	if currentMachinePool != nil && currentMachinePool.InfrastructureMachinePoolObject != nil {
+		currentInfraMachinePoolRef = &currentMachinePool.Object.Spec.Template.Spec.InfrastructureRef
+	}
+	desiredMachinePool.InfrastructureMachinePoolObject, err = templateToObject(templateToInput{
+		template:              machinePoolBlueprint.InfrastructureMachinePoolTemplate,
+		templateClonedFromRef: contract.ObjToRef(machinePoolBlueprint.InfrastructureMachinePoolTemplate),
+		cluster:               s.Current.Cluster,
+		namePrefix:            infrastructureMachinePoolNamePrefix(s.Current.Cluster.Name, machinePoolTopology.Name),
+		currentObjectRef:      currentInfraMachinePoolRef,
+		// Note: we are adding an ownerRef to Cluster so the InfrastructureMachinePool will be automatically garbage collected
+		// in case of errors in between creating this object and creating/updating the MachinePool object
+		// with the reference to it.
+		ownerRef: ownerReferenceTo(s.Current.Cluster),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute infrastructure object for topology %q", machinePoolTopology.Name)
+	}
+
+	infraMachinePoolObjectLabels := desiredMachinePool.InfrastructureMachinePoolObject.GetLabels()
+	if infraMachinePoolObjectLabels == nil {
+		infraMachinePoolObjectLabels = map[string]string{}
+	}
+	// Add ClusterTopologyMachinePoolLabel to the generated InfrastructureMachinePool object
+	infraMachinePoolObjectLabels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+	desiredMachinePool.InfrastructureMachinePoolObject.SetLabels(infraMachinePoolObjectLabels)
+	version, err := computeMachinePoolVersion(s, desiredControlPlaneState, currentMachinePool)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compute version for %s", machinePoolTopology.Name)
+	}
+
+	// Compute the MachinePool object.
+	gv := expv1.GroupVersion
+	desiredMachinePoolObj := &expv1.MachinePool{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       gv.WithKind("MachinePool").Kind,
+			APIVersion: gv.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      names.SimpleNameGenerator.GenerateName(fmt.Sprintf("%s-%s-", s.Current.Cluster.Name, machinePoolTopology.Name)),
+			Namespace: s.Current.Cluster.Namespace,
+		},
+		Spec: expv1.MachinePoolSpec{
+			ClusterName:    s.Current.Cluster.Name,
+			FailureDomains: machinePoolTopology.FailureDomains,
+			Template: clusterv1.MachineTemplateSpec{
+				ObjectMeta: clusterv1.ObjectMeta{
+					Labels:      mergeMap(machinePoolTopology.Metadata.Labels, machinePoolBlueprint.Metadata.Labels),
+					Annotations: mergeMap(machinePoolTopology.Metadata.Annotations, machinePoolBlueprint.Metadata.Annotations),
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       s.Current.Cluster.Name,
+					Version:           pointer.String(version),
+					Bootstrap:         clusterv1.Bootstrap{ConfigRef: contract.ObjToRef(desiredMachinePool.BootstrapObject)},
+					InfrastructureRef: *contract.ObjToRef(desiredMachinePool.InfrastructureMachinePoolObject),
+				},
+			},
+		},
+	}
+
+	// If an existing MachinePool is present, override the MachinePool generate name
+	// re-using the existing name (this will help in reconcile).
+	if currentMachinePool != nil && currentMachinePool.Object != nil {
+		desiredMachinePoolObj.SetName(currentMachinePool.Object.Name)
+	}
+
+	// Apply Labels
+	// NOTE: On top of all the labels applied to managed objects we are applying the ClusterTopologyMachinePoolLabel
+	// keeping track of the MachinePool name from the Topology; this will be used to identify the object in next reconcile loops.
+	labels := map[string]string{}
+	labels[clusterv1.ClusterLabelName] = s.Current.Cluster.Name
+	labels[clusterv1.ClusterTopologyOwnedLabel] = ""
+	labels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+	desiredMachinePoolObj.SetLabels(labels)
+
+	// Also set the labels in .spec.template.labels so that they are propagated to
+	// the Machines and Nodes belonging to the MachinePool.
+	if desiredMachinePoolObj.Spec.Template.Labels == nil {
+		desiredMachinePoolObj.Spec.Template.Labels = map[string]string{}
+	}
+	desiredMachinePoolObj.Spec.Template.Labels[clusterv1.ClusterLabelName] = s.Current.Cluster.Name
+	desiredMachinePoolObj.Spec.Template.Labels[clusterv1.ClusterTopologyOwnedLabel] = ""
+	desiredMachinePoolObj.Spec.Template.Labels[clusterv1.ClusterTopologyMachinePoolLabelName] = machinePoolTopology.Name
+
+	// Set the desired replicas.
+	desiredMachinePoolObj.Spec.Replicas = machinePoolTopology.Replicas
+
+	desiredMachinePool.Object = desiredMachinePoolObj
+
+	return desiredMachinePool, nil
+}
+
+// computeMachinePoolVersion calculates the version of the desired machine pool.
+// The version is calculated using the state of the current machine pools,
+// the current control plane and the version defined in the topology.
+// Nb: No MachinePool upgrades will be triggered while any MachinePool is in the middle
+// of an upgrade. Even if the number of MachinePools that are being upgraded is less
+// than the number of allowed concurrent upgrades.
+func computeMachinePoolVersion(s *scope.Scope, desiredControlPlaneState *scope.ControlPlaneState, currentMPState *scope.MachinePoolState) (string, error) {
+	desiredVersion := s.Blueprint.Topology.Version
+	// If creating a new machine pool, we can pick up the desired version
+	// Note: We are not blocking the creation of new machine pools when
+	// the control plane or any of the machine pools are upgrading/scaling.
+	if currentMPState == nil || currentMPState.Object == nil {
+		return desiredVersion, nil
+	}
+
+	// Get the current version of the machine pool.
+	currentVersion := *currentMPState.Object.Spec.Template.Spec.Version
+
+	// Return early if the currentVersion is already equal to the desiredVersion
+	// no further checks required.
+	if currentVersion == desiredVersion {
+		return currentVersion, nil
+	}
+
+	// Return early if we are not allowed to upgrade the machine pool.
+	if !s.UpgradeTracker.MachinePools.AllowUpgrade() {
+		s.UpgradeTracker.MachinePools.MarkPendingUpgrade(currentMPState.Object.Name)
+		return currentVersion, nil
+	}
+
+	// If the control plane is being created (current control plane is nil), do not perform
+	// any machine pool upgrade in this case.
+	// Return the current version of the machine pool.
+	// NOTE: this case should never happen (upgrading a MachinePool) before creating a CP,
+	// but we are implementing this check for extra safety.
+	if s.Current.ControlPlane == nil || s.Current.ControlPlane.Object == nil {
+		s.UpgradeTracker.MachinePools.MarkPendingUpgrade(currentMPState.Object.Name)
+		return currentVersion, nil
+	}
+
+	// If the current control plane is upgrading, then do not pick up the desiredVersion yet.
+	// Return the current version of the machine pool. We will pick up the new version after the control
+	// plane is stable.
+	cpUpgrading, err := contract.ControlPlane().IsUpgrading(s.Current.ControlPlane.Object)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to check if control plane is upgrading")
+	}
+	if cpUpgrading {
+		s.UpgradeTracker.MachinePools.MarkPendingUpgrade(currentMPState.Object.Name)
+		return currentVersion, nil
+	}
+
+	// If control plane supports replicas, check if the control plane is in the middle of a scale operation.
+	// If the current control plane is scaling, then do not pick up the desiredVersion yet.
+	// Return the current version of the machine pool. We will pick up the new version after the control
+	// plane is stable.
+	if s.Blueprint.Topology.ControlPlane.Replicas != nil {
+		cpScaling, err := contract.ControlPlane().IsScaling(s.Current.ControlPlane.Object)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to check if the control plane is scaling")
+		}
+		if cpScaling {
+			s.UpgradeTracker.MachinePools.MarkPendingUpgrade(currentMPState.Object.Name)
+			return currentVersion, nil
+		}
+	}
+
+	// Check if we are about to upgrade the control plane. In that case, do not upgrade the machine pool yet.
+	// Wait for the new upgrade operation on the control plane to finish before picking up the new version for the
+	// machine pool.
+	currentCPVersion, err := contract.ControlPlane().Version().Get(s.Current.ControlPlane.Object)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get version of current control plane")
+	}
+	desiredCPVersion, err := contract.ControlPlane().Version().Get(desiredControlPlaneState.Object)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get version of desired control plane")
+	}
+	if *currentCPVersion != *desiredCPVersion {
+		// The versions of the current and desired control planes do no match,
+		// implies we are about to upgrade the control plane.
+		s.UpgradeTracker.MachinePools.MarkPendingUpgrade(currentMPState.Object.Name)
+		return currentVersion, nil
+	}
+
+	// At this point the control plane is stable (not scaling, not upgrading, not being upgraded).
+	// Checking to see if the machine pools are also stable.
+	// If any of the MachinePools is rolling out, do not upgrade the machine pool yet.
+	if s.Current.MachinePools.IsAnyRollingOut() {
+		s.UpgradeTracker.MachinePools.MarkPendingUpgrade(currentMPState.Object.Name)
+		return currentVersion, nil
+	}
+
+	// Control plane and machine pools are stable.
+	// Ready to pick up the topology version.
+	s.UpgradeTracker.MachinePools.MarkRollingOut(currentMPState.Object.Name)
+	return desiredVersion, nil
+}
+
 type templateToInput struct {
 	template              *unstructured.Unstructured
 	templateClonedFromRef *corev1.ObjectReference
@@ -28,12 +28,21 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 )
 
@@ -291,7 +300,8 @@ func TestComputeControlPlane(t *testing.T) {
 		scope := scope.New(cluster)
 		scope.Blueprint = blueprint
 
-		obj, err := computeControlPlane(ctx, scope, nil)
+		r := &Reconciler{}
+		obj, err := r.computeControlPlane(ctx, scope, nil)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(obj).ToNot(BeNil())
 
@@ -330,7 +340,8 @@ func TestComputeControlPlane(t *testing.T) {
 		scope := scope.New(clusterWithoutReplicas)
 		scope.Blueprint = blueprint
 
-		obj, err := computeControlPlane(ctx, scope, nil)
+		r := &Reconciler{}
+		obj, err := r.computeControlPlane(ctx, scope, nil)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(obj).ToNot(BeNil())
 
@@ -370,7 +381,8 @@ func TestComputeControlPlane(t *testing.T) {
 		scope := scope.New(cluster)
 		scope.Blueprint = blueprint
 
-		obj, err := computeControlPlane(ctx, scope, infrastructureMachineTemplate)
+		r := &Reconciler{}
+		obj, err := r.computeControlPlane(ctx, scope, infrastructureMachineTemplate)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(obj).ToNot(BeNil())
 
@@ -420,7 +432,8 @@ func TestComputeControlPlane(t *testing.T) {
 		scope := scope.New(clusterWithControlPlaneRef)
 		scope.Blueprint = blueprint
 
-		obj, err := computeControlPlane(ctx, scope, nil)
+		r := &Reconciler{}
+		obj, err := r.computeControlPlane(ctx, scope, nil)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(obj).ToNot(BeNil())
 
@@ -486,7 +499,8 @@ func TestComputeControlPlane(t *testing.T) {
 					Object: tt.currentControlPlane,
 				}
 
-				obj, err := computeControlPlane(ctx, s, nil)
+				r := &Reconciler{}
+				obj, err := r.computeControlPlane(ctx, s, nil)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(obj).NotTo(BeNil())
 				assertNestedField(g, obj, tt.expectedVersion, contract.ControlPlane().Version().Path()...)
@@ -524,7 +538,8 @@ func TestComputeControlPlane(t *testing.T) {
 		s.Current.ControlPlane.Object.SetOwnerReferences([]metav1.OwnerReference{*ownerReferenceTo(shim)})
 		s.Blueprint = blueprint
 
-		obj, err := computeControlPlane(ctx, s, nil)
+		r := &Reconciler{}
+		obj, err := r.computeControlPlane(ctx, s, nil)
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(obj).ToNot(BeNil())
 		g.Expect(hasOwnerReferenceFrom(obj, shim)).To(BeTrue())
@@ -693,13 +708,272 @@ func TestComputeControlPlaneVersion(t *testing.T) {
 				},
 				UpgradeTracker: scope.NewUpgradeTracker(),
 			}
-			version, err := computeControlPlaneVersion(s)
+			r := &Reconciler{}
+			version, err := r.computeControlPlaneVersion(ctx, s)
 			g.Expect(err).NotTo(HaveOccurred())
 			g.Expect(version).To(Equal(tt.expectedVersion))
 		})
 	}
 }
 
+func TestComputeControlPlaneVersionWithLifecycleHooks(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	beforeClusterUpgradeGVH, err := catalog.GroupVersionHook(runtimehooksv1.BeforeClusterUpgrade)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+	afterControlPlaneUpgradeGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterControlPlaneUpgrade)
+	if err != nil {
+		panic("unable to compute GVH")
+	}
+
+	nonBlockingBeforeClusterUpgradeResponse := &runtimehooksv1.BeforeClusterUpgradeResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		},
+	}
+	blockingBeforeClusterUpgradeResponse := &runtimehooksv1.BeforeClusterUpgradeResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			RetryAfterSeconds: int32(10),
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		},
+	}
+	failureBeforeClusterUpgradeResponse := &runtimehooksv1.BeforeClusterUpgradeResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusFailure,
+			},
+		},
+	}
+	nonBlockingAfterControlPlaneUpgradeResponse := &runtimehooksv1.AfterControlPlaneUpgradeResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		},
+	}
+	blockingAfterControlPlaneUpgradeResponse := &runtimehooksv1.AfterControlPlaneUpgradeResponse{
+		CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+			RetryAfterSeconds: int32(10),
+			CommonResponse: runtimehooksv1.CommonResponse{
+				Status: runtimehooksv1.ResponseStatusSuccess,
+			},
+		},
+	}
+
+	stableControlPlane := func(version string) *unstructured.Unstructured {
+		return builder.ControlPlane("test1", "cp1").
+			WithSpecFields(map[string]interface{}{
+				"spec.version":  version,
+				"spec.replicas": int64(2),
+			}).
+			WithStatusFields(map[string]interface{}{
+				"status.version":         version,
+				"status.replicas":        int64(2),
+				"status.updatedReplicas": int64(2),
+				"status.readyReplicas":   int64(2),
+			}).
+			Build()
+	}
+
+	t.Run("Calling the BeforeClusterUpgrade hook", func(t *testing.T) {
+		tests := []struct {
+			name                            string
+			hookResponse                    *runtimehooksv1.BeforeClusterUpgradeResponse
+			wantVersion                     string
+			wantIntentToCallAfterHooks      bool
+			wantErr                         bool
+			wantHookResponseTrackerBlocking bool
+		}{
+			{
+				name:                       "should return the topology version if the BeforeClusterUpgrade hook returns a non blocking response",
+				hookResponse:               nonBlockingBeforeClusterUpgradeResponse,
+				wantVersion:                "v1.2.3",
+				wantIntentToCallAfterHooks: true,
+			},
+			{
+				name:                            "should return the control plane version if the BeforeClusterUpgrade hook returns a blocking response",
+				hookResponse:                    blockingBeforeClusterUpgradeResponse,
+				wantVersion:                     "v1.2.2",
+				wantHookResponseTrackerBlocking: true,
+			},
+			{
+				name:         "should fail if the BeforeClusterUpgrade hook returns a failure response",
+				hookResponse: failureBeforeClusterUpgradeResponse,
+				wantErr:      true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				s := &scope.Scope{
+					Blueprint: &scope.ClusterBlueprint{Topology: &clusterv1.Topology{
+						Version: "v1.2.3",
+						ControlPlane: clusterv1.ControlPlaneTopology{
+							Replicas: pointer.Int32(2),
+						},
+					}},
+					Current: &scope.ClusterState{
+						Cluster: &clusterv1.Cluster{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-cluster",
+								Namespace: "test-ns",
+								UID:       "test-uid",
+							},
+						},
+						ControlPlane: &scope.ControlPlaneState{Object: stableControlPlane("v1.2.2")},
+					},
+					UpgradeTracker:      scope.NewUpgradeTracker(),
+					HookResponseTracker: scope.NewHookResponseTracker(),
+				}
+
+				runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+						beforeClusterUpgradeGVH: tt.hookResponse,
+					}).
+					Build()
+
+				fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(s.Current.Cluster).Build()
+
+				r := &Reconciler{
+					Client:        fakeClient,
+					APIReader:     fakeClient,
+					RuntimeClient: runtimeClient,
+				}
+				version, err := r.computeControlPlaneVersion(ctx, s)
+				if tt.wantErr {
+					g.Expect(err).To(HaveOccurred())
+					return
+				}
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(version).To(Equal(tt.wantVersion))
+				g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeClusterUpgrade)).To(Equal(1))
+				g.Expect(s.HookResponseTracker.IsBlocking(runtimehooksv1.BeforeClusterUpgrade)).To(Equal(tt.wantHookResponseTrackerBlocking))
+				g.Expect(hooks.IsPending(runtimehooksv1.AfterControlPlaneUpgrade, s.Current.Cluster)).To(Equal(tt.wantIntentToCallAfterHooks))
+				g.Expect(hooks.IsPending(runtimehooksv1.AfterClusterUpgrade, s.Current.Cluster)).To(Equal(tt.wantIntentToCallAfterHooks))
+			})
+		}
+	})
+
+	t.Run("Calling the AfterControlPlaneUpgrade hook", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			controlPlaneObj  *unstructured.Unstructured
+			hookResponse     *runtimehooksv1.AfterControlPlaneUpgradeResponse
+			intentToCall     bool
+			wantCallCount    int
+			wantIntentToCall bool
+			wantHoldUpgrades bool
+		}{
+			{
+				name:             "should not call the hook if there is no intent to call it",
+				controlPlaneObj:  stableControlPlane("v1.2.3"),
+				hookResponse:     nonBlockingAfterControlPlaneUpgradeResponse,
+				intentToCall:     false,
+				wantCallCount:    0,
+				wantIntentToCall: false,
+			},
+			{
+				name: "should not call the hook if the control plane is still upgrading",
+				controlPlaneObj: builder.ControlPlane("test1", "cp1").
+					WithSpecFields(map[string]interface{}{
+						"spec.version": "v1.2.3",
+					}).
+					WithStatusFields(map[string]interface{}{
+						"status.version": "v1.2.2",
+					}).
+					Build(),
+				hookResponse:     nonBlockingAfterControlPlaneUpgradeResponse,
+				intentToCall:     true,
+				wantCallCount:    0,
+				wantIntentToCall: true,
+			},
+			{
+				name:             "should call the hook and remove the intent if the hook returns a non blocking response",
+				controlPlaneObj:  stableControlPlane("v1.2.3"),
+				hookResponse:     nonBlockingAfterControlPlaneUpgradeResponse,
+				intentToCall:     true,
+				wantCallCount:    1,
+				wantIntentToCall: false,
+			},
+			{
+				name:             "should call the hook, hold the MachineDeployment upgrades and preserve the intent if the hook returns a blocking response",
+				controlPlaneObj:  stableControlPlane("v1.2.3"),
+				hookResponse:     blockingAfterControlPlaneUpgradeResponse,
+				intentToCall:     true,
+				wantCallCount:    1,
+				wantIntentToCall: true,
+				wantHoldUpgrades: true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				s := &scope.Scope{
+					Blueprint: &scope.ClusterBlueprint{Topology: &clusterv1.Topology{
+						Version: "v1.2.3",
+						ControlPlane: clusterv1.ControlPlaneTopology{
+							Replicas: pointer.Int32(2),
+						},
+					}},
+					Current: &scope.ClusterState{
+						Cluster: &clusterv1.Cluster{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test-cluster",
+								Namespace: "test-ns",
+								UID:       "test-uid",
+							},
+						},
+						ControlPlane: &scope.ControlPlaneState{Object: tt.controlPlaneObj},
+					},
+					UpgradeTracker:      scope.NewUpgradeTracker(),
+					HookResponseTracker: scope.NewHookResponseTracker(),
+				}
+
+				runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+					WithCatalog(catalog).
+					WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+						afterControlPlaneUpgradeGVH: tt.hookResponse,
+					}).
+					Build()
+
+				if tt.intentToCall {
+					s.Current.Cluster.Annotations = map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterControlPlaneUpgrade",
+					}
+				}
+
+				fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(s.Current.Cluster).Build()
+
+				r := &Reconciler{
+					Client:        fakeClient,
+					APIReader:     fakeClient,
+					RuntimeClient: runtimeClient,
+				}
+
+				_, err := r.computeControlPlaneVersion(ctx, s)
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(runtimeClient.CallAllCount(runtimehooksv1.AfterControlPlaneUpgrade)).To(Equal(tt.wantCallCount))
+				g.Expect(hooks.IsPending(runtimehooksv1.AfterControlPlaneUpgrade, s.Current.Cluster)).To(Equal(tt.wantIntentToCall))
+				g.Expect(s.UpgradeTracker.MachineDeployments.AllowUpgrade()).To(Equal(!tt.wantHoldUpgrades))
+			})
+		}
+	})
+}
+
 func TestComputeCluster(t *testing.T) {
 	g := NewWithT(t)
 
@@ -714,6 +988,9 @@ func TestComputeCluster(t *testing.T) {
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "cluster1",
 			Namespace: metav1.NamespaceDefault,
+			Annotations: map[string]string{
+				runtimev1.PendingHooksAnnotation: "AfterClusterUpgrade",
+			},
 		},
 	}
 
@@ -732,6 +1009,7 @@ func TestComputeCluster(t *testing.T) {
 	g.Expect(obj.Namespace).To(Equal(cluster.Namespace))
 	g.Expect(obj.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterLabelName, cluster.Name))
 	g.Expect(obj.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterTopologyOwnedLabel, ""))
+	g.Expect(obj.GetAnnotations()).ToNot(HaveKey(runtimev1.PendingHooksAnnotation))
 
 	// Spec
 	g.Expect(obj.Spec.InfrastructureRef).To(Equal(contract.ObjToRef(infrastructureCluster)))
@@ -1257,6 +1535,164 @@ func TestComputeMachineDeploymentVersion(t *testing.T) {
 	}
 }
 
+func TestComputeMachinePool(t *testing.T) {
+	workerInfrastructureMachinePoolTemplate := builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "linux-worker-inframachinepooltemplate").
+		Build()
+	workerBootstrapTemplate := builder.BootstrapTemplate(metav1.NamespaceDefault, "linux-worker-bootstraptemplate").
+		Build()
+	labels := map[string]string{"fizz": "buzz", "foo": "bar"}
+	annotations := map[string]string{"annotation-1": "annotation-1-val"}
+
+	mp1 := builder.MachinePoolClass("linux-worker").
+		WithLabels(labels).
+		WithAnnotations(annotations).
+		WithInfrastructureTemplate(workerInfrastructureMachinePoolTemplate).
+		WithBootstrapTemplate(workerBootstrapTemplate).
+		Build()
+	fakeClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithWorkerMachinePoolClasses(*mp1).
+		Build()
+
+	version := "v1.21.2"
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Version: version,
+			},
+		},
+	}
+
+	blueprint := &scope.ClusterBlueprint{
+		Topology:     cluster.Spec.Topology,
+		ClusterClass: fakeClass,
+		MachinePools: map[string]*scope.MachinePoolBlueprint{
+			"linux-worker": {
+				Metadata: clusterv1.ObjectMeta{
+					Labels:      labels,
+					Annotations: annotations,
+				},
+				BootstrapTemplate:                 workerBootstrapTemplate,
+				InfrastructureMachinePoolTemplate: workerInfrastructureMachinePoolTemplate,
+			},
+		},
+	}
+
+	replicas := int32(5)
+	failureDomains := []string{"always-up-region"}
+	mpTopology := clusterv1.MachinePoolTopology{
+		Metadata: clusterv1.ObjectMeta{
+			Labels: map[string]string{"foo": "baz"},
+		},
+		Class:          "linux-worker",
+		Name:           "big-pool-of-machines",
+		Replicas:       &replicas,
+		FailureDomains: failureDomains,
+	}
+
+	t.Run("Generates the machine pool and the referenced objects", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+
+		actual, err := computeMachinePool(ctx, scope, nil, mpTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(actual.BootstrapObject.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachinePoolLabelName, "big-pool-of-machines"))
+
+		// Ensure Cluster ownership is added to generated BootstrapObject.
+		g.Expect(actual.BootstrapObject.GetOwnerReferences()).To(HaveLen(1))
+		g.Expect(actual.BootstrapObject.GetOwnerReferences()[0].Kind).To(Equal("Cluster"))
+		g.Expect(actual.BootstrapObject.GetOwnerReferences()[0].Name).To(Equal(cluster.Name))
+
+		g.Expect(actual.InfrastructureMachinePoolObject.GetLabels()).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachinePoolLabelName, "big-pool-of-machines"))
+
+		// Ensure Cluster ownership is added to generated InfrastructureMachinePoolObject.
+		g.Expect(actual.InfrastructureMachinePoolObject.GetOwnerReferences()).To(HaveLen(1))
+		g.Expect(actual.InfrastructureMachinePoolObject.GetOwnerReferences()[0].Kind).To(Equal("Cluster"))
+		g.Expect(actual.InfrastructureMachinePoolObject.GetOwnerReferences()[0].Name).To(Equal(cluster.Name))
+
+		actualMp := actual.Object
+		g.Expect(*actualMp.Spec.Replicas).To(Equal(replicas))
+		g.Expect(actualMp.Spec.FailureDomains).To(Equal(failureDomains))
+		g.Expect(actualMp.Spec.ClusterName).To(Equal("cluster1"))
+		g.Expect(*actualMp.Spec.Template.Spec.Version).To(Equal(version))
+		g.Expect(actualMp.Name).To(ContainSubstring("cluster1"))
+		g.Expect(actualMp.Name).To(ContainSubstring("big-pool-of-machines"))
+
+		g.Expect(actualMp.Labels).To(HaveKeyWithValue(clusterv1.ClusterTopologyMachinePoolLabelName, "big-pool-of-machines"))
+		g.Expect(actualMp.Labels).To(HaveKey(clusterv1.ClusterTopologyOwnedLabel))
+
+		g.Expect(actualMp.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("foo", "baz"))
+		g.Expect(actualMp.Spec.Template.ObjectMeta.Labels).To(HaveKeyWithValue("fizz", "buzz"))
+		g.Expect(actualMp.Spec.Template.ObjectMeta.Labels).To(HaveKey(clusterv1.ClusterTopologyOwnedLabel))
+		g.Expect(actualMp.Spec.Template.Spec.InfrastructureRef.Name).ToNot(Equal("linux-worker-inframachinepooltemplate"))
+		g.Expect(actualMp.Spec.Template.Spec.Bootstrap.ConfigRef.Name).ToNot(Equal("linux-worker-bootstraptemplate"))
+	})
+
+	t.Run("If there is already a machine pool, it preserves the object name and the reference names", func(t *testing.T) {
+		g := NewWithT(t)
+		s := scope.New(cluster)
+		s.Blueprint = blueprint
+
+		currentReplicas := int32(3)
+		currentMp := &expv1.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "existing-pool-1",
+			},
+			Spec: expv1.MachinePoolSpec{
+				Replicas: &currentReplicas,
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: pointer.String(version),
+						Bootstrap: clusterv1.Bootstrap{
+							ConfigRef: contract.ObjToRef(workerBootstrapTemplate),
+						},
+						InfrastructureRef: *contract.ObjToRef(workerInfrastructureMachinePoolTemplate),
+					},
+				},
+			},
+		}
+		s.Current.MachinePools = map[string]*scope.MachinePoolState{
+			"big-pool-of-machines": {
+				Object:                          currentMp,
+				BootstrapObject:                 workerBootstrapTemplate,
+				InfrastructureMachinePoolObject: workerInfrastructureMachinePoolTemplate,
+			},
+		}
+
+		actual, err := computeMachinePool(ctx, s, nil, mpTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		actualMp := actual.Object
+
+		g.Expect(*actualMp.Spec.Replicas).NotTo(Equal(currentReplicas))
+		g.Expect(actualMp.Name).To(Equal("existing-pool-1"))
+		g.Expect(actualMp.Spec.Template.Spec.InfrastructureRef.Name).To(Equal("linux-worker-inframachinepooltemplate"))
+		g.Expect(actualMp.Spec.Template.Spec.Bootstrap.ConfigRef.Name).To(Equal("linux-worker-bootstraptemplate"))
+	})
+
+	t.Run("If a machine pool references a topology class that does not exist, machine pool generation fails", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+
+		mpTopology = clusterv1.MachinePoolTopology{
+			Metadata: clusterv1.ObjectMeta{
+				Labels: map[string]string{"foo": "baz"},
+			},
+			Class: "windows-worker",
+			Name:  "big-pool-of-machines",
+		}
+
+		_, err := computeMachinePool(ctx, scope, nil, mpTopology)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
 func TestTemplateToObject(t *testing.T) {
 	template := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infrastructureClusterTemplate").
 		WithSpecFields(map[string]interface{}{"spec.template.spec.fakeSetting": true}).
@@ -15,8 +15,8 @@ limitations under the License.
 */
 
 // Package api contains the API definition for the patch engine.
-// NOTE: We are introducing this API as a decoupling layer between the patch engine and the concrete components
-// responsible for generating patches, because we aim to provide support for external patches in a future iteration.
+// NOTE: This API is a decoupling layer between the patch engine and the concrete components
+// responsible for generating patches, i.e. inline patches and external patches implemented by Runtime Extensions.
 // We also assume that this API and all the related types will be moved in a separate (versioned) package thus
 // providing a versioned contract between Cluster API and the components implementing external patch extensions.
 package api
@@ -26,12 +26,15 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/api"
+	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/external"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/inline"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/patches/variables"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
 )
 
 // Engine is a patch engine which applies patches defined in a ClusterBlueprint to a ClusterState.
@@ -40,18 +43,23 @@ type Engine interface {
 }
 
 // NewEngine creates a new patch engine.
-func NewEngine() Engine {
+// NOTE: runtimeClient is used only for external patches, and thus it is required only
+// if the RuntimeSDK feature flag is enabled.
+func NewEngine(runtimeClient runtimeclient.Client) Engine {
 	return &engine{
+		runtimeClient:        runtimeClient,
 		createPatchGenerator: createPatchGenerator,
 	}
 }
 
 // engine implements the Engine interface.
 type engine struct {
+	runtimeClient runtimeclient.Client
+
 	// createPatchGenerator is the func which returns a patch generator
 	// based on a ClusterClassPatch.
 	// Note: This field is also used to inject patches in unit tests.
-	createPatchGenerator func(patch *clusterv1.ClusterClassPatch) (api.Generator, error)
+	createPatchGenerator func(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch) (api.Generator, error)
 }
 
 // Apply applies patches to the desired state according to the patches from the ClusterClass, variables from the Cluster
@@ -59,6 +67,7 @@ type engine struct {
 // * A GeneratePatchesRequest with all templates and global and template-specific variables is created.
 // * Then for all ClusterClassPatches of a ClusterClass, JSON or JSON merge patches are generated
 //   and successively applied to the templates in the GeneratePatchesRequest.
+// * The patched templates are validated by all the external patches with a ValidateExtension.
 // * Eventually the patched templates are used to update the specs of the desired objects.
 func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, desired *scope.ClusterState) error {
 	// Return if there are no patches.
@@ -80,10 +89,15 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 		clusterClassPatch := blueprint.ClusterClass.Spec.Patches[i]
 		ctx, log = log.WithValues("patch", clusterClassPatch.Name).Into(ctx)
 
+		// External patches which only define a ValidateExtension do not generate patches.
+		if clusterClassPatch.External != nil && clusterClassPatch.External.GenerateExtension == nil {
+			continue
+		}
+
 		log.V(5).Infof("Applying patch to templates")
 
 		// Create patch generator for the current patch.
-		generator, err := e.createPatchGenerator(&clusterClassPatch)
+		generator, err := e.createPatchGenerator(e.runtimeClient, &clusterClassPatch)
 		if err != nil {
 			return err
 		}
@@ -103,6 +117,12 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 		}
 	}
 
+	// Validate the patched templates before they are used to update the desired state objects,
+	// so nothing is written if the resulting topology is not valid.
+	if err := e.validateTopology(ctx, blueprint, req); err != nil {
+		return err
+	}
+
 	// Use patched templates to update the desired state objects.
 	log.V(5).Infof("Applying patched templates to desired state")
 	if err := updateDesiredState(ctx, req, blueprint, desired); err != nil {
@@ -115,9 +135,9 @@ func (e *engine) Apply(ctx context.Context, blueprint *scope.ClusterBlueprint, d
 // createRequest creates a GeneratePatchesRequest based on the ClusterBlueprint and the desired state.
 // ClusterBlueprint supplies the templates. Desired state is used to calculate variables which are later used
 // as input for the patch generation.
-// NOTE: GenerateRequestTemplates are created for the templates of each individual MachineDeployment in the desired
-// state. This is necessary because some builtin variables are MachineDeployment specific. For example version and
-// replicas of a MachineDeployment.
+// NOTE: GenerateRequestTemplates are created for the templates of each individual MachineDeployment and MachinePool
+// in the desired state. This is necessary because some builtin variables are MachineDeployment or MachinePool specific.
+// For example version and replicas of a MachineDeployment.
 // NOTE: A single GeneratePatchesRequest object is used to carry templates state across subsequent Generate calls.
 func createRequest(blueprint *scope.ClusterBlueprint, desired *scope.ClusterState) (*runtimehooksv1.GeneratePatchesRequest, error) {
 	req := &runtimehooksv1.GeneratePatchesRequest{}
@@ -218,6 +238,54 @@ func createRequest(blueprint *scope.ClusterBlueprint, desired *scope.ClusterStat
 		req.Items = append(req.Items, *t)
 	}
 
+	// Add BootstrapConfigTemplate and InfrastructureMachinePoolTemplate for all MachinePoolTopologies
+	// in the Cluster.
+	// NOTE: We intentionally iterate over MachinePools in the Cluster instead of over
+	// MachinePoolClasses in the ClusterClass because each MachinePool in a topology
+	// has its own state, e.g. version or replicas. This state is used to calculate builtin variables,
+	// which can then be used e.g. to compute the machine image for a specific Kubernetes version.
+	for mpTopologyName, mp := range desired.MachinePools {
+		// Lookup MachinePoolTopology definition from cluster.spec.topology.
+		mpTopology, err := lookupMPTopology(blueprint.Topology, mpTopologyName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Get corresponding MachinePoolClass from the ClusterClass.
+		mpClass, ok := blueprint.MachinePools[mpTopology.Class]
+		if !ok {
+			return nil, errors.Errorf("failed to lookup MachinePool class %q in ClusterClass", mpTopology.Class)
+		}
+
+		// Calculate MachinePool variables.
+		mpVariables, err := variables.MachinePool(mpTopology, mp.Object, mp.BootstrapObject, mp.InfrastructureMachinePoolObject)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to calculate variables for %s", tlog.KObj{Obj: mp.Object})
+		}
+
+		// Add the BootstrapTemplate.
+		t, err := newRequestItemBuilder(mpClass.BootstrapTemplate).
+			WithHolder(mp.Object, "spec.template.spec.bootstrap.configRef").
+			Build()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to prepare BootstrapConfig template %s for MachinePool topology %s for patching",
+				tlog.KObj{Obj: mpClass.BootstrapTemplate}, mpTopologyName)
+		}
+		t.Variables = mpVariables
+		req.Items = append(req.Items, *t)
+
+		// Add the InfrastructureMachinePoolTemplate.
+		t, err = newRequestItemBuilder(mpClass.InfrastructureMachinePoolTemplate).
+			WithHolder(mp.Object, "spec.template.spec.infrastructureRef").
+			Build()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to prepare InfrastructureMachinePool template %s for MachinePool topology %s for patching",
+				tlog.KObj{Obj: mpClass.InfrastructureMachinePoolTemplate}, mpTopologyName)
+		}
+		t.Variables = mpVariables
+		req.Items = append(req.Items, *t)
+	}
+
 	return req, nil
 }
 
@@ -231,15 +299,84 @@ func lookupMDTopology(topology *clusterv1.Topology, mdTopologyName string) (*clu
 	return nil, errors.Errorf("failed to lookup MachineDeployment topology %q in Cluster.spec.topology.workers.machineDeployments", mdTopologyName)
 }
 
+// lookupMPTopology looks up the MachinePoolTopology based on a mpTopologyName in a topology.
+func lookupMPTopology(topology *clusterv1.Topology, mpTopologyName string) (*clusterv1.MachinePoolTopology, error) {
+	for _, mpTopology := range topology.Workers.MachinePools {
+		if mpTopology.Name == mpTopologyName {
+			return &mpTopology, nil
+		}
+	}
+	return nil, errors.Errorf("failed to lookup MachinePool topology %q in Cluster.spec.topology.workers.machinePools", mpTopologyName)
+}
+
+// validateTopology calls the ValidateExtension of all the external patches of the ClusterClass
+// with the patched templates of the GeneratePatchesRequest.
+func (e *engine) validateTopology(ctx context.Context, blueprint *scope.ClusterBlueprint, req *runtimehooksv1.GeneratePatchesRequest) error {
+	log := tlog.LoggerFrom(ctx)
+
+	var validationReq *runtimehooksv1.ValidateTopologyRequest
+	for i := range blueprint.ClusterClass.Spec.Patches {
+		clusterClassPatch := blueprint.ClusterClass.Spec.Patches[i]
+		if clusterClassPatch.External == nil || clusterClassPatch.External.ValidateExtension == nil {
+			continue
+		}
+
+		if !feature.Gates.Enabled(feature.RuntimeSDK) {
+			return errors.Errorf("failed to validate topology with patch %q: external patches require the RuntimeSDK feature flag to be enabled", clusterClassPatch.Name)
+		}
+		if e.runtimeClient == nil {
+			return errors.Errorf("failed to validate topology with patch %q: runtime client is not set", clusterClassPatch.Name)
+		}
+
+		// Create the validation request only once, when the first ValidateExtension is found.
+		if validationReq == nil {
+			validationReq = convertToValidateTopologyRequest(req)
+		}
+
+		log.V(5).Infof("Validating topology with patch %q", clusterClassPatch.Name)
+
+		resp := &runtimehooksv1.ValidateTopologyResponse{}
+		if err := e.runtimeClient.CallExtension(ctx, runtimehooksv1.ValidateTopology, *clusterClassPatch.External.ValidateExtension, validationReq, resp); err != nil {
+			return errors.Wrapf(err, "failed to validate topology with patch %q", clusterClassPatch.Name)
+		}
+	}
+	return nil
+}
+
+// convertToValidateTopologyRequest converts a GeneratePatchesRequest into a ValidateTopologyRequest.
+func convertToValidateTopologyRequest(req *runtimehooksv1.GeneratePatchesRequest) *runtimehooksv1.ValidateTopologyRequest {
+	validationReq := &runtimehooksv1.ValidateTopologyRequest{
+		Variables: req.Variables,
+	}
+	for i := range req.Items {
+		item := req.Items[i]
+		validationReq.Items = append(validationReq.Items, &runtimehooksv1.ValidateTopologyRequestItem{
+			HolderReference: item.HolderReference,
+			Object:          item.Object,
+			Variables:       item.Variables,
+		})
+	}
+	return validationReq
+}
+
 // createPatchGenerator creates a patch generator for the given patch.
-// NOTE: Currently only inline JSON patches are supported; in the future we will add
-// external patches as well.
-func createPatchGenerator(patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
+func createPatchGenerator(runtimeClient runtimeclient.Client, patch *clusterv1.ClusterClassPatch) (api.Generator, error) {
 	// Return a jsonPatchGenerator if there are PatchDefinitions in the patch.
 	if len(patch.Definitions) > 0 {
 		return inline.New(patch), nil
 	}
 
+	// Return an externalPatchGenerator if there is a GenerateExtension in the patch.
+	if patch.External != nil && patch.External.GenerateExtension != nil {
+		if !feature.Gates.Enabled(feature.RuntimeSDK) {
+			return nil, errors.Errorf("failed to create patch generator for patch %q: external patches require the RuntimeSDK feature flag to be enabled", patch.Name)
+		}
+		if runtimeClient == nil {
+			return nil, errors.Errorf("failed to create patch generator for patch %q: runtime client is not set", patch.Name)
+		}
+		return external.New(runtimeClient, patch), nil
+	}
+
 	return nil, errors.Errorf("failed to create patch generator for patch %q", patch.Name)
 }
 
@@ -302,7 +439,7 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 	var err error
 
 	// Update the InfrastructureCluster.
-	infrastructureClusterTemplate, err := getTemplateAsUnstructured(req, "Cluster", "spec.infrastructureRef", "")
+	infrastructureClusterTemplate, err := getTemplateAsUnstructured(req, "Cluster", "spec.infrastructureRef", requestTopologyName{})
 	if err != nil {
 		return err
 	}
@@ -311,7 +448,7 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 	}
 
 	// Update the ControlPlane.
-	controlPlaneTemplate, err := getTemplateAsUnstructured(req, "Cluster", "spec.controlPlaneRef", "")
+	controlPlaneTemplate, err := getTemplateAsUnstructured(req, "Cluster", "spec.controlPlaneRef", requestTopologyName{})
 	if err != nil {
 		return err
 	}
@@ -328,7 +465,7 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 	// If the ClusterClass mandates the ControlPlane has InfrastructureMachines,
 	// update the InfrastructureMachineTemplate for ControlPlane machines.
 	if blueprint.HasControlPlaneInfrastructureMachine() {
-		infrastructureMachineTemplate, err := getTemplateAsUnstructured(req, desired.ControlPlane.Object.GetKind(), strings.Join(contract.ControlPlane().MachineTemplate().InfrastructureRef().Path(), "."), "")
+		infrastructureMachineTemplate, err := getTemplateAsUnstructured(req, desired.ControlPlane.Object.GetKind(), strings.Join(contract.ControlPlane().MachineTemplate().InfrastructureRef().Path(), "."), requestTopologyName{})
 		if err != nil {
 			return err
 		}
@@ -340,7 +477,7 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 	// Update the templates for all MachineDeployments.
 	for mdTopologyName, md := range desired.MachineDeployments {
 		// Update the BootstrapConfigTemplate.
-		bootstrapTemplate, err := getTemplateAsUnstructured(req, "MachineDeployment", "spec.template.spec.bootstrap.configRef", mdTopologyName)
+		bootstrapTemplate, err := getTemplateAsUnstructured(req, "MachineDeployment", "spec.template.spec.bootstrap.configRef", requestTopologyName{mdTopologyName: mdTopologyName})
 		if err != nil {
 			return err
 		}
@@ -349,7 +486,7 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 		}
 
 		// Update the InfrastructureMachineTemplate.
-		infrastructureMachineTemplate, err := getTemplateAsUnstructured(req, "MachineDeployment", "spec.template.spec.infrastructureRef", mdTopologyName)
+		infrastructureMachineTemplate, err := getTemplateAsUnstructured(req, "MachineDeployment", "spec.template.spec.infrastructureRef", requestTopologyName{mdTopologyName: mdTopologyName})
 		if err != nil {
 			return err
 		}
@@ -358,5 +495,28 @@ func updateDesiredState(ctx context.Context, req *runtimehooksv1.GeneratePatches
 		}
 	}
 
+	// Update the bootstrap and infrastructure objects for all MachinePools.
+	// NOTE: MachinePools reference objects instead of templates, so the patched templates are used
+	// to update the spec of the desired objects.
+	for mpTopologyName, mp := range desired.MachinePools {
+		// Update the bootstrap config.
+		bootstrapTemplate, err := getTemplateAsUnstructured(req, "MachinePool", "spec.template.spec.bootstrap.configRef", requestTopologyName{mpTopologyName: mpTopologyName})
+		if err != nil {
+			return err
+		}
+		if err := patchObject(ctx, mp.BootstrapObject, bootstrapTemplate); err != nil {
+			return err
+		}
+
+		// Update the InfrastructureMachinePool.
+		infrastructureMachinePoolTemplate, err := getTemplateAsUnstructured(req, "MachinePool", "spec.template.spec.infrastructureRef", requestTopologyName{mpTopologyName: mpTopologyName})
+		if err != nil {
+			return err
+		}
+		if err := patchObject(ctx, mp.InfrastructureMachinePoolObject, infrastructureMachinePoolTemplate); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
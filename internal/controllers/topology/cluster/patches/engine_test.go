@@ -26,10 +26,15 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	. "sigs.k8s.io/cluster-api/internal/test/matchers"
 )
@@ -361,7 +366,7 @@ func TestApply(t *testing.T) {
 			blueprint, desired := setupTestObjects()
 
 			// If there are patches, set up patch generators.
-			patchEngine := NewEngine()
+			patchEngine := NewEngine(nil)
 			if len(tt.patches) > 0 {
 				// Add the patches.
 				blueprint.ClusterClass.Spec.Patches = tt.patches
@@ -414,6 +419,114 @@ func TestApply(t *testing.T) {
 	}
 }
 
+func TestApplyWithExternalPatches(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	successGenerateResponse := &runtimehooksv1.GeneratePatchesResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+	}
+	failureGenerateResponse := &runtimehooksv1.GeneratePatchesResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusFailure,
+		},
+	}
+	successValidateResponse := &runtimehooksv1.ValidateTopologyResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+	}
+	failureValidateResponse := &runtimehooksv1.ValidateTopologyResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusFailure,
+		},
+	}
+
+	tests := []struct {
+		name               string
+		patches            []clusterv1.ClusterClassPatch
+		extensionResponses map[string]runtimehooksv1.ResponseObject
+		wantErr            bool
+	}{
+		{
+			name: "Should succeed if the GenerateExtension and the ValidateExtension succeed",
+			patches: []clusterv1.ClusterClassPatch{
+				{
+					Name: "fake-patch1",
+					External: &clusterv1.ExternalPatchDefinition{
+						GenerateExtension: pointer.String("generate-extension"),
+						ValidateExtension: pointer.String("validate-extension"),
+					},
+				},
+			},
+			extensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"generate-extension": successGenerateResponse,
+				"validate-extension": successValidateResponse,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Should fail if the GenerateExtension fails",
+			patches: []clusterv1.ClusterClassPatch{
+				{
+					Name: "fake-patch1",
+					External: &clusterv1.ExternalPatchDefinition{
+						GenerateExtension: pointer.String("generate-extension"),
+					},
+				},
+			},
+			extensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"generate-extension": failureGenerateResponse,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Should fail if the ValidateExtension fails",
+			patches: []clusterv1.ClusterClassPatch{
+				{
+					Name: "fake-patch1",
+					External: &clusterv1.ExternalPatchDefinition{
+						ValidateExtension: pointer.String("validate-extension"),
+					},
+				},
+			},
+			extensionResponses: map[string]runtimehooksv1.ResponseObject{
+				"validate-extension": failureValidateResponse,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			blueprint, desired := setupTestObjects()
+			blueprint.ClusterClass.Spec.Patches = tt.patches
+
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallExtensionResponses(tt.extensionResponses).
+				Build()
+
+			// Copy the desired InfrastructureCluster, so it can be used to verify that nothing changed if the topology is invalid.
+			expectedInfrastructureCluster := desired.InfrastructureCluster.DeepCopy()
+
+			patchEngine := NewEngine(runtimeClient)
+			err := patchEngine.Apply(context.Background(), blueprint, desired)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(desired.InfrastructureCluster).To(EqualObject(expectedInfrastructureCluster))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 func setupTestObjects() (*scope.ClusterBlueprint, *scope.ClusterState) {
 	infrastructureClusterTemplate := builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infraClusterTemplate1").
 		Build()
@@ -0,0 +1,108 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
+)
+
+func TestGenerate(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	patch := &clusterv1.ClusterClassPatch{
+		Name: "patch1",
+		External: &clusterv1.ExternalPatchDefinition{
+			GenerateExtension: pointer.String("generate-extension"),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		response     *runtimehooksv1.GeneratePatchesResponse
+		wantResponse *runtimehooksv1.GeneratePatchesResponse
+	}{
+		{
+			name: "Should return the patches generated by the extension",
+			response: &runtimehooksv1.GeneratePatchesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status: runtimehooksv1.ResponseStatusSuccess,
+				},
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{
+						UID:       "1",
+						PatchType: runtimehooksv1.JSONMergePatchType,
+						Patch:     []byte(`{"spec":{"template":{"spec":{"resource":"value"}}}}`),
+					},
+				},
+			},
+			wantResponse: &runtimehooksv1.GeneratePatchesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status: runtimehooksv1.ResponseStatusSuccess,
+				},
+				Items: []runtimehooksv1.GeneratePatchesResponseItem{
+					{
+						UID:       "1",
+						PatchType: runtimehooksv1.JSONMergePatchType,
+						Patch:     []byte(`{"spec":{"template":{"spec":{"resource":"value"}}}}`),
+					},
+				},
+			},
+		},
+		{
+			name: "Should return a failure response if the extension fails",
+			response: &runtimehooksv1.GeneratePatchesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status:  runtimehooksv1.ResponseStatusFailure,
+					Message: "invalid variable",
+				},
+			},
+			wantResponse: &runtimehooksv1.GeneratePatchesResponse{
+				CommonResponse: runtimehooksv1.CommonResponse{
+					Status:  runtimehooksv1.ResponseStatusFailure,
+					Message: `runtime extension handler "generate-extension" failed with message "invalid variable"`,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallExtensionResponses(map[string]runtimehooksv1.ResponseObject{
+					"generate-extension": tt.response,
+				}).
+				Build()
+
+			generator := New(runtimeClient, patch)
+			got := generator.Generate(context.Background(), &runtimehooksv1.GeneratePatchesRequest{})
+			g.Expect(got).To(Equal(tt.wantResponse))
+		})
+	}
+}
@@ -191,6 +191,31 @@ func matchesSelector(req *runtimehooksv1.GeneratePatchesRequestItem, templateVar
 		}
 	}
 
+	// Check if the request is for a BootstrapConfigTemplate or an InfrastructureMachinePoolTemplate
+	// of one of the configured MachinePoolClasses.
+	if selector.MatchResources.MachinePoolClass != nil {
+		// MachinePool.spec.template.spec.bootstrap.configRef or
+		// MachinePool.spec.template.spec.infrastructureRef holds the BootstrapConfigTemplate or
+		// InfrastructureMachinePoolTemplate.
+		if req.HolderReference.Kind == "MachinePool" &&
+			(req.HolderReference.FieldPath == "spec.template.spec.bootstrap.configRef" ||
+				req.HolderReference.FieldPath == "spec.template.spec.infrastructureRef") {
+			// Read the builtin.machinePool.class variable.
+			templateMPClassJSON, err := patchvariables.GetVariableValue(templateVariables, "builtin.machinePool.class")
+
+			// If the builtin variable could be read.
+			if err == nil {
+				// If templateMPClass matches one of the configured MachinePoolClasses.
+				for _, mpClass := range selector.MatchResources.MachinePoolClass.Names {
+					// We have to quote mpClass as templateMPClassJSON is a JSON string (e.g. "default-worker").
+					if string(templateMPClassJSON.Raw) == strconv.Quote(mpClass) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
 	return false
 }
 
@@ -28,6 +28,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 )
@@ -683,6 +684,96 @@ func TestMachineDeployment(t *testing.T) {
 	}
 }
 
+func TestMachinePool(t *testing.T) {
+	tests := []struct {
+		name                              string
+		mpTopology                        *clusterv1.MachinePoolTopology
+		mp                                *expv1.MachinePool
+		mpBootstrapObject                 *unstructured.Unstructured
+		mpInfrastructureMachinePoolObject *unstructured.Unstructured
+		want                              []runtimehooksv1.Variable
+	}{
+		{
+			name: "Should calculate MachinePool variables",
+			mpTopology: &clusterv1.MachinePoolTopology{
+				Replicas: pointer.Int32(3),
+				Name:     "mp-topology",
+				Class:    "mp-class",
+				Variables: &clusterv1.MachinePoolVariables{
+					Overrides: []clusterv1.ClusterVariable{
+						{
+							Name:  "location",
+							Value: toJSON("\"us-central\""),
+						},
+					},
+				},
+			},
+			mp: builder.MachinePool(metav1.NamespaceDefault, "mp1").
+				WithReplicas(3).
+				WithVersion("v1.21.1").
+				Build(),
+			want: []runtimehooksv1.Variable{
+				{
+					Name:  "location",
+					Value: toJSON("\"us-central\""),
+				},
+				{
+					Name: BuiltinsName,
+					Value: toJSONCompact(`{
+					"machinePool":{
+						"version": "v1.21.1",
+						"class": "mp-class",
+						"name": "mp1",
+						"topologyName": "mp-topology",
+						"replicas":3
+					}}`),
+				},
+			},
+		},
+		{
+			name: "Should calculate MachinePool variables with bootstrap and infrastructure objects",
+			mpTopology: &clusterv1.MachinePoolTopology{
+				Name:  "mp-topology",
+				Class: "mp-class",
+			},
+			mp: builder.MachinePool(metav1.NamespaceDefault, "mp1").
+				WithVersion("v1.21.1").
+				Build(),
+			mpBootstrapObject:                 builder.BootstrapConfig(metav1.NamespaceDefault, "mpBC1").Build(),
+			mpInfrastructureMachinePoolObject: builder.InfrastructureMachinePool(metav1.NamespaceDefault, "mpIMP1").Build(),
+			want: []runtimehooksv1.Variable{
+				{
+					Name: BuiltinsName,
+					Value: toJSONCompact(`{
+					"machinePool":{
+						"version": "v1.21.1",
+						"class": "mp-class",
+						"name": "mp1",
+						"topologyName": "mp-topology",
+						"bootstrap":{
+							"configRef":{
+								"name": "mpBC1"
+							}
+						},
+						"infrastructureRef":{
+							"name": "mpIMP1"
+						}
+					}}`),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := MachinePool(tt.mpTopology, tt.mp, tt.mpBootstrapObject, tt.mpInfrastructureMachinePoolObject)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
 func toJSON(value string) apiextensionsv1.JSON {
 	return apiextensionsv1.JSON{Raw: []byte(value)}
 }
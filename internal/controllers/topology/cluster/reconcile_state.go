@@ -32,11 +32,17 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
+	"sigs.k8s.io/cluster-api/internal/hooks"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
 	"sigs.k8s.io/cluster-api/internal/topology/check"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const (
@@ -53,6 +59,13 @@ func (r *Reconciler) reconcileState(ctx context.Context, s *scope.Scope) error {
 	log := tlog.LoggerFrom(ctx)
 	log.Infof("Reconciling state for topology owned objects")
 
+	// Call the "after" lifecycle hooks, if the corresponding operations are completed.
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		if err := r.callAfterHooks(ctx, s); err != nil {
+			return err
+		}
+	}
+
 	// Reconcile the Cluster shim, a temporary object used a mean to collect
 	// objects/templates that can be orphaned in case of errors during the
 	// remaining part of the reconcile process.
@@ -76,7 +89,98 @@ func (r *Reconciler) reconcileState(ctx context.Context, s *scope.Scope) error {
 	}
 
 	// Reconcile desired state of the MachineDeployment objects.
-	return r.reconcileMachineDeployments(ctx, s)
+	if err := r.reconcileMachineDeployments(ctx, s); err != nil {
+		return err
+	}
+
+	// Reconcile desired state of the MachinePool objects.
+	if feature.Gates.Enabled(feature.MachinePool) {
+		return r.reconcileMachinePools(ctx, s)
+	}
+	return nil
+}
+
+// callAfterHooks calls the AfterControlPlaneInitialized and the AfterClusterUpgrade hooks, if required.
+func (r *Reconciler) callAfterHooks(ctx context.Context, s *scope.Scope) error {
+	if err := r.callAfterControlPlaneInitialized(ctx, s); err != nil {
+		return err
+	}
+
+	return r.callAfterClusterUpgrade(ctx, s)
+}
+
+// callAfterControlPlaneInitialized calls the AfterControlPlaneInitialized hook once the control plane
+// of a Cluster created by the topology controller becomes available for the first time.
+func (r *Reconciler) callAfterControlPlaneInitialized(ctx context.Context, s *scope.Scope) error {
+	// If the cluster topology is being created then track the intent to call the AfterControlPlaneInitialized hook
+	// so that we can call it later.
+	if s.Current.Cluster.Spec.InfrastructureRef == nil && s.Current.Cluster.Spec.ControlPlaneRef == nil {
+		if err := hooks.MarkAsPending(ctx, r.Client, s.Current.Cluster, runtimehooksv1.AfterControlPlaneInitialized); err != nil {
+			return errors.Wrapf(err, "failed to track the intent to call the %s hook", runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneInitialized))
+		}
+	}
+
+	// Call the hook only if we are tracking the intent to do so. If it is not tracked it means we don't need to call the
+	// hook because we already called the hook after the control plane was initialized.
+	if !hooks.IsPending(runtimehooksv1.AfterControlPlaneInitialized, s.Current.Cluster) {
+		return nil
+	}
+	if !conditions.IsTrue(s.Current.Cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return nil
+	}
+
+	// The control plane is initialized for the first time. Call all the registered extensions for the hook.
+	hookRequest := &runtimehooksv1.AfterControlPlaneInitializedRequest{
+		Cluster: *s.Current.Cluster,
+	}
+	hookResponse := &runtimehooksv1.AfterControlPlaneInitializedResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterControlPlaneInitialized, hookRequest, hookResponse); err != nil {
+		return errors.Wrapf(err, "error calling the %s hook", runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneInitialized))
+	}
+	s.HookResponseTracker.Add(runtimehooksv1.AfterControlPlaneInitialized, hookResponse)
+	if err := hooks.MarkAsDone(ctx, r.Client, s.Current.Cluster, runtimehooksv1.AfterControlPlaneInitialized); err != nil {
+		return errors.Wrapf(err, "failed to remove the %s hook from pending hooks", runtimecatalog.HookName(runtimehooksv1.AfterControlPlaneInitialized))
+	}
+	return nil
+}
+
+// callAfterClusterUpgrade calls the AfterClusterUpgrade hook once the control plane and all the
+// MachineDeployments and MachinePools of a Cluster completed an upgrade triggered by the topology controller.
+func (r *Reconciler) callAfterClusterUpgrade(ctx context.Context, s *scope.Scope) error {
+	// Call the hook only if we are tracking the intent to do so. If it is not tracked it means we don't need to call the
+	// hook because we didn't go through an upgrade or we already called the hook after the upgrade.
+	if !hooks.IsPending(runtimehooksv1.AfterClusterUpgrade, s.Current.Cluster) {
+		return nil
+	}
+
+	// A Cluster is considered fully upgraded if:
+	// - the AfterControlPlaneUpgrade hook has been already called.
+	// - Control plane is stable (not upgrading, not about to upgrade).
+	// - MachineDeployments and MachinePools are not rolling out and are not about to upgrade.
+	if hooks.IsPending(runtimehooksv1.AfterControlPlaneUpgrade, s.Current.Cluster) ||
+		s.UpgradeTracker.ControlPlane.PendingUpgrade ||
+		s.UpgradeTracker.ControlPlane.IsUpgrading ||
+		s.UpgradeTracker.MachineDeployments.PendingUpgrade() ||
+		len(s.UpgradeTracker.MachineDeployments.RolloutNames()) > 0 ||
+		s.UpgradeTracker.MachinePools.PendingUpgrade() ||
+		len(s.UpgradeTracker.MachinePools.RolloutNames()) > 0 {
+		return nil
+	}
+
+	// Call all the registered extensions for the hook.
+	hookRequest := &runtimehooksv1.AfterClusterUpgradeRequest{
+		Cluster:           *s.Current.Cluster,
+		KubernetesVersion: s.Current.Cluster.Spec.Topology.Version,
+	}
+	hookResponse := &runtimehooksv1.AfterClusterUpgradeResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.AfterClusterUpgrade, hookRequest, hookResponse); err != nil {
+		return errors.Wrapf(err, "error calling the %s hook", runtimecatalog.HookName(runtimehooksv1.AfterClusterUpgrade))
+	}
+	s.HookResponseTracker.Add(runtimehooksv1.AfterClusterUpgrade, hookResponse)
+	if err := hooks.MarkAsDone(ctx, r.Client, s.Current.Cluster, runtimehooksv1.AfterClusterUpgrade); err != nil {
+		return errors.Wrapf(err, "failed to remove the %s hook from pending hooks", runtimecatalog.HookName(runtimehooksv1.AfterClusterUpgrade))
+	}
+	return nil
 }
 
 // Reconcile the Cluster shim, a temporary object used a mean to collect objects/templates
@@ -573,6 +677,162 @@ func calculateMachineDeploymentDiff(current, desired map[string]*scope.MachineDe
 	return diff
 }
 
+// reconcileMachinePools reconciles the desired state of the MachinePool objects.
+func (r *Reconciler) reconcileMachinePools(ctx context.Context, s *scope.Scope) error {
+	diff := calculateMachinePoolDiff(s.Current.MachinePools, s.Desired.MachinePools)
+
+	// Create MachinePools.
+	for _, mpTopologyName := range diff.toCreate {
+		mp := s.Desired.MachinePools[mpTopologyName]
+		if err := r.createMachinePool(ctx, s.Current.Cluster, mp); err != nil {
+			return err
+		}
+	}
+
+	// Update MachinePools.
+	for _, mpTopologyName := range diff.toUpdate {
+		currentMP := s.Current.MachinePools[mpTopologyName]
+		desiredMP := s.Desired.MachinePools[mpTopologyName]
+		if err := r.updateMachinePool(ctx, s.Current.Cluster, currentMP, desiredMP); err != nil {
+			return err
+		}
+	}
+
+	// Delete MachinePools.
+	for _, mpTopologyName := range diff.toDelete {
+		mp := s.Current.MachinePools[mpTopologyName]
+		if err := r.deleteMachinePool(ctx, s.Current.Cluster, mp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createMachinePool creates a MachinePool and the corresponding bootstrap and infrastructure objects.
+func (r *Reconciler) createMachinePool(ctx context.Context, cluster *clusterv1.Cluster, mp *scope.MachinePoolState) error {
+	log := tlog.LoggerFrom(ctx).WithMachinePool(mp.Object)
+
+	infraCtx, _ := log.WithObject(mp.InfrastructureMachinePoolObject).Into(ctx)
+	if err := r.reconcileReferencedObject(infraCtx, reconcileReferencedObjectInput{
+		cluster: cluster,
+		desired: mp.InfrastructureMachinePoolObject,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to create %s", mp.Object.Kind)
+	}
+
+	bootstrapCtx, _ := log.WithObject(mp.BootstrapObject).Into(ctx)
+	if err := r.reconcileReferencedObject(bootstrapCtx, reconcileReferencedObjectInput{
+		cluster: cluster,
+		desired: mp.BootstrapObject,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to create %s", mp.Object.Kind)
+	}
+
+	log = log.WithObject(mp.Object)
+	log.Infof(fmt.Sprintf("Creating %s", tlog.KObj{Obj: mp.Object}))
+	helper, err := r.patchHelperFactory(nil, mp.Object)
+	if err != nil {
+		return createErrorWithoutObjectName(err, mp.Object)
+	}
+	if err := helper.Patch(ctx); err != nil {
+		return createErrorWithoutObjectName(err, mp.Object)
+	}
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, createEventReason, "Created %q", tlog.KObj{Obj: mp.Object})
+	return nil
+}
+
+// updateMachinePool updates a MachinePool. Also updates the corresponding bootstrap and infrastructure objects if necessary.
+// NOTE: Differently from MachineDeployments, the objects referenced by a MachinePool are not templates, so they are
+// patched in place instead of being rotated.
+func (r *Reconciler) updateMachinePool(ctx context.Context, cluster *clusterv1.Cluster, currentMP, desiredMP *scope.MachinePoolState) error {
+	log := tlog.LoggerFrom(ctx).WithMachinePool(desiredMP.Object)
+
+	infraCtx, _ := log.WithObject(desiredMP.InfrastructureMachinePoolObject).Into(ctx)
+	if err := r.reconcileReferencedObject(infraCtx, reconcileReferencedObjectInput{
+		cluster: cluster,
+		current: currentMP.InfrastructureMachinePoolObject,
+		desired: desiredMP.InfrastructureMachinePoolObject,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to reconcile %s", tlog.KObj{Obj: currentMP.Object})
+	}
+
+	bootstrapCtx, _ := log.WithObject(desiredMP.BootstrapObject).Into(ctx)
+	if err := r.reconcileReferencedObject(bootstrapCtx, reconcileReferencedObjectInput{
+		cluster: cluster,
+		current: currentMP.BootstrapObject,
+		desired: desiredMP.BootstrapObject,
+	}); err != nil {
+		return errors.Wrapf(err, "failed to reconcile %s", tlog.KObj{Obj: currentMP.Object})
+	}
+
+	// Check differences between current and desired MachinePool, and eventually patch the current object.
+	log = log.WithObject(desiredMP.Object)
+	patchHelper, err := r.patchHelperFactory(currentMP.Object, desiredMP.Object)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create patch helper for %s", tlog.KObj{Obj: currentMP.Object})
+	}
+	if !patchHelper.HasChanges() {
+		log.V(3).Infof("No changes for %s", tlog.KObj{Obj: currentMP.Object})
+		return nil
+	}
+
+	log.Infof("Patching %s", tlog.KObj{Obj: currentMP.Object})
+	if err := patchHelper.Patch(ctx); err != nil {
+		return errors.Wrapf(err, "failed to patch %s", tlog.KObj{Obj: currentMP.Object})
+	}
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, updateEventReason, "Updated %q%s", tlog.KObj{Obj: currentMP.Object}, logMachinePoolVersionChange(currentMP.Object, desiredMP.Object))
+	return nil
+}
+
+func logMachinePoolVersionChange(current, desired *expv1.MachinePool) string {
+	if current.Spec.Template.Spec.Version == nil || desired.Spec.Template.Spec.Version == nil {
+		return ""
+	}
+
+	if *current.Spec.Template.Spec.Version != *desired.Spec.Template.Spec.Version {
+		return fmt.Sprintf(" with version change from %s to %s", *current.Spec.Template.Spec.Version, *desired.Spec.Template.Spec.Version)
+	}
+	return ""
+}
+
+// deleteMachinePool deletes a MachinePool.
+// NOTE: The bootstrap and infrastructure objects are owned by the MachinePool, so they are garbage collected with it.
+func (r *Reconciler) deleteMachinePool(ctx context.Context, cluster *clusterv1.Cluster, mp *scope.MachinePoolState) error {
+	log := tlog.LoggerFrom(ctx).WithMachinePool(mp.Object).WithObject(mp.Object)
+	log.Infof("Deleting %s", tlog.KObj{Obj: mp.Object})
+	if err := r.Client.Delete(ctx, mp.Object); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete %s", tlog.KObj{Obj: mp.Object})
+	}
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, deleteEventReason, "Deleted %q", tlog.KObj{Obj: mp.Object})
+	return nil
+}
+
+type machinePoolDiff struct {
+	toCreate, toUpdate, toDelete []string
+}
+
+// calculateMachinePoolDiff compares two maps of MachinePoolState and calculates which
+// MachinePools should be created, updated or deleted.
+func calculateMachinePoolDiff(current, desired map[string]*scope.MachinePoolState) machinePoolDiff {
+	var diff machinePoolDiff
+
+	for mp := range desired {
+		if _, ok := current[mp]; ok {
+			diff.toUpdate = append(diff.toUpdate, mp)
+		} else {
+			diff.toCreate = append(diff.toCreate, mp)
+		}
+	}
+
+	for mp := range current {
+		if _, ok := desired[mp]; !ok {
+			diff.toDelete = append(diff.toDelete, mp)
+		}
+	}
+
+	return diff
+}
+
 type unstructuredVersionGetter func(obj *unstructured.Unstructured) (*string, error)
 
 type reconcileReferencedObjectInput struct {
@@ -32,11 +32,17 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/scope"
 	"sigs.k8s.io/cluster-api/internal/controllers/topology/cluster/structuredmerge"
+	"sigs.k8s.io/cluster-api/internal/hooks"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	. "sigs.k8s.io/cluster-api/internal/test/matchers"
 )
@@ -269,6 +275,319 @@ func TestReconcileShim(t *testing.T) {
 	})
 }
 
+func TestReconcile_callAfterControlPlaneInitialized(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	afterControlPlaneInitializedGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterControlPlaneInitialized)
+	if err != nil {
+		panic(err)
+	}
+
+	successResponse := &runtimehooksv1.AfterControlPlaneInitializedResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+	}
+	failureResponse := &runtimehooksv1.AfterControlPlaneInitializedResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusFailure,
+		},
+	}
+
+	tests := []struct {
+		name               string
+		cluster            *clusterv1.Cluster
+		hookResponse       *runtimehooksv1.AfterControlPlaneInitializedResponse
+		wantMarked         bool
+		wantHookToBeCalled bool
+		wantError          bool
+	}{
+		{
+			name: "hook should be marked if the cluster is about to be created",
+			cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					UID:       "test-uid",
+				},
+				Spec: clusterv1.ClusterSpec{},
+			},
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+			wantError:          false,
+		},
+		{
+			name: "hook should be called if it is marked and the control plane is ready - the hook should become unmarked for a success response",
+			cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					UID:       "test-uid",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterControlPlaneInitialized",
+					},
+				},
+				Spec: clusterv1.ClusterSpec{
+					ControlPlaneRef:   &corev1.ObjectReference{},
+					InfrastructureRef: &corev1.ObjectReference{},
+				},
+				Status: clusterv1.ClusterStatus{
+					Conditions: clusterv1.Conditions{
+						{
+							Type:   clusterv1.ControlPlaneInitializedCondition,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: true,
+			wantError:          false,
+		},
+		{
+			name: "hook should be called if it is marked and the control plane is ready - the hook should remain marked for a failure response",
+			cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					UID:       "test-uid",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterControlPlaneInitialized",
+					},
+				},
+				Spec: clusterv1.ClusterSpec{
+					ControlPlaneRef:   &corev1.ObjectReference{},
+					InfrastructureRef: &corev1.ObjectReference{},
+				},
+				Status: clusterv1.ClusterStatus{
+					Conditions: clusterv1.Conditions{
+						{
+							Type:   clusterv1.ControlPlaneInitializedCondition,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			hookResponse:       failureResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: true,
+			wantError:          true,
+		},
+		{
+			name: "hook should not be called if it is marked and the control plane is not ready - the hook should remain marked",
+			cluster: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					UID:       "test-uid",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterControlPlaneInitialized",
+					},
+				},
+				Spec: clusterv1.ClusterSpec{
+					ControlPlaneRef:   &corev1.ObjectReference{},
+					InfrastructureRef: &corev1.ObjectReference{},
+				},
+				Status: clusterv1.ClusterStatus{
+					Conditions: clusterv1.Conditions{
+						{
+							Type:   clusterv1.ControlPlaneInitializedCondition,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			},
+			hookResponse:       failureResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+			wantError:          false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &scope.Scope{
+				Current: &scope.ClusterState{
+					Cluster: tt.cluster,
+				},
+				HookResponseTracker: scope.NewHookResponseTracker(),
+			}
+
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					afterControlPlaneInitializedGVH: tt.hookResponse,
+				}).
+				Build()
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(tt.cluster).Build()
+
+			r := &Reconciler{
+				Client:        fakeClient,
+				APIReader:     fakeClient,
+				RuntimeClient: fakeRuntimeClient,
+			}
+
+			err := r.callAfterControlPlaneInitialized(ctx, s)
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterControlPlaneInitialized) == 1).To(Equal(tt.wantHookToBeCalled))
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterControlPlaneInitialized, tt.cluster)).To(Equal(tt.wantMarked))
+			g.Expect(err != nil).To(Equal(tt.wantError))
+		})
+	}
+}
+
+func TestReconcile_callAfterClusterUpgrade(t *testing.T) {
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+
+	afterClusterUpgradeGVH, err := catalog.GroupVersionHook(runtimehooksv1.AfterClusterUpgrade)
+	if err != nil {
+		panic(err)
+	}
+
+	successResponse := &runtimehooksv1.AfterClusterUpgradeResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusSuccess,
+		},
+	}
+	failureResponse := &runtimehooksv1.AfterClusterUpgradeResponse{
+		CommonResponse: runtimehooksv1.CommonResponse{
+			Status: runtimehooksv1.ResponseStatusFailure,
+		},
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "test-ns",
+			UID:       "test-uid",
+		},
+		Spec: clusterv1.ClusterSpec{
+			Topology: &clusterv1.Topology{
+				Version: "v1.2.3",
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		pendingHooks       string
+		upgradeTracker     func() *scope.UpgradeTracker
+		hookResponse       *runtimehooksv1.AfterClusterUpgradeResponse
+		wantMarked         bool
+		wantHookToBeCalled bool
+		wantError          bool
+	}{
+		{
+			name:               "hook should not be called if it is not marked",
+			pendingHooks:       "",
+			upgradeTracker:     scope.NewUpgradeTracker,
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: false,
+			wantError:          false,
+		},
+		{
+			name:               "hook should not be called if the AfterControlPlaneUpgrade hook is still pending",
+			pendingHooks:       "AfterClusterUpgrade,AfterControlPlaneUpgrade",
+			upgradeTracker:     scope.NewUpgradeTracker,
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+			wantError:          false,
+		},
+		{
+			name:         "hook should not be called if the control plane is upgrading",
+			pendingHooks: "AfterClusterUpgrade",
+			upgradeTracker: func() *scope.UpgradeTracker {
+				ut := scope.NewUpgradeTracker()
+				ut.ControlPlane.IsUpgrading = true
+				return ut
+			},
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+			wantError:          false,
+		},
+		{
+			name:         "hook should not be called if MachineDeployments are rolling out",
+			pendingHooks: "AfterClusterUpgrade",
+			upgradeTracker: func() *scope.UpgradeTracker {
+				ut := scope.NewUpgradeTracker()
+				ut.MachineDeployments.MarkRollingOut("md1")
+				return ut
+			},
+			hookResponse:       successResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: false,
+			wantError:          false,
+		},
+		{
+			name:               "hook should be called if the cluster is fully upgraded - the hook should become unmarked for a success response",
+			pendingHooks:       "AfterClusterUpgrade",
+			upgradeTracker:     scope.NewUpgradeTracker,
+			hookResponse:       successResponse,
+			wantMarked:         false,
+			wantHookToBeCalled: true,
+			wantError:          false,
+		},
+		{
+			name:               "hook should be called if the cluster is fully upgraded - the hook should remain marked for a failure response",
+			pendingHooks:       "AfterClusterUpgrade",
+			upgradeTracker:     scope.NewUpgradeTracker,
+			hookResponse:       failureResponse,
+			wantMarked:         true,
+			wantHookToBeCalled: true,
+			wantError:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s := &scope.Scope{
+				Current: &scope.ClusterState{
+					Cluster: cluster.DeepCopy(),
+				},
+				UpgradeTracker:      tt.upgradeTracker(),
+				HookResponseTracker: scope.NewHookResponseTracker(),
+			}
+
+			fakeRuntimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					afterClusterUpgradeGVH: tt.hookResponse,
+				}).
+				Build()
+
+			if tt.pendingHooks != "" {
+				s.Current.Cluster.Annotations = map[string]string{
+					runtimev1.PendingHooksAnnotation: tt.pendingHooks,
+				}
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(s.Current.Cluster).Build()
+
+			r := &Reconciler{
+				Client:        fakeClient,
+				APIReader:     fakeClient,
+				RuntimeClient: fakeRuntimeClient,
+			}
+
+			err := r.callAfterClusterUpgrade(ctx, s)
+			g.Expect(fakeRuntimeClient.CallAllCount(runtimehooksv1.AfterClusterUpgrade) == 1).To(Equal(tt.wantHookToBeCalled))
+			g.Expect(hooks.IsPending(runtimehooksv1.AfterClusterUpgrade, s.Current.Cluster)).To(Equal(tt.wantMarked))
+			g.Expect(err != nil).To(Equal(tt.wantError))
+		})
+	}
+}
+
 func TestReconcileCluster(t *testing.T) {
 	cluster1 := builder.Cluster(metav1.NamespaceDefault, "cluster1").
 		Build()
@@ -39,6 +39,9 @@ type ClusterBlueprint struct {
 
 	// MachineDeployments holds the MachineDeploymentBlueprints derived from ClusterClass.
 	MachineDeployments map[string]*MachineDeploymentBlueprint
+
+	// MachinePools holds the MachinePoolBlueprints derived from ClusterClass.
+	MachinePools map[string]*MachinePoolBlueprint
 }
 
 // ControlPlaneBlueprint holds the templates required for computing the desired state of a managed control plane.
@@ -83,7 +86,27 @@ func (b *ClusterBlueprint) HasControlPlaneMachineHealthCheck() bool {
 	return b.HasControlPlaneInfrastructureMachine() && b.ClusterClass.Spec.ControlPlane.MachineHealthCheck != nil
 }
 
+// MachinePoolBlueprint holds the templates required for computing the desired state of a managed MachinePool;
+// it also holds a copy of the MachinePool metadata from Cluster.Topology, thus providing all the required info
+// in a single place.
+type MachinePoolBlueprint struct {
+	// Metadata holds the metadata for a MachinePool.
+	// NOTE: This is a convenience copy of the metadata field from Cluster.Spec.Topology.Workers.MachinePools[x].
+	Metadata clusterv1.ObjectMeta
+
+	// BootstrapTemplate holds the bootstrap template for a MachinePool referenced from ClusterClass.
+	BootstrapTemplate *unstructured.Unstructured
+
+	// InfrastructureMachinePoolTemplate holds the infrastructure machine pool template for a MachinePool referenced from ClusterClass.
+	InfrastructureMachinePoolTemplate *unstructured.Unstructured
+}
+
 // HasMachineDeployments checks whether the topology has MachineDeployments.
 func (b *ClusterBlueprint) HasMachineDeployments() bool {
 	return b.Topology.Workers != nil && len(b.Topology.Workers.MachineDeployments) > 0
 }
+
+// HasMachinePools checks whether the topology has MachinePools.
+func (b *ClusterBlueprint) HasMachinePools() bool {
+	return b.Topology.Workers != nil && len(b.Topology.Workers.MachinePools) > 0
+}
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
 )
 
@@ -38,6 +39,9 @@ type ClusterState struct {
 
 	// MachineDeployments holds the machine deployments in the Cluster.
 	MachineDeployments MachineDeploymentsStateMap
+
+	// MachinePools holds the machine pools in the Cluster.
+	MachinePools MachinePoolsStateMap
 }
 
 // ControlPlaneState holds all the objects representing the state of a managed control plane.
@@ -96,3 +100,51 @@ type MachineDeploymentState struct {
 func (md *MachineDeploymentState) IsRollingOut() bool {
 	return !mdutil.DeploymentComplete(md.Object, &md.Object.Status) || *md.Object.Spec.Replicas != md.Object.Status.ReadyReplicas
 }
+
+// MachinePoolsStateMap holds a collection of MachinePool states.
+type MachinePoolsStateMap map[string]*MachinePoolState
+
+// RollingOut returns the list of the machine pools
+// that are rolling out.
+func (mps MachinePoolsStateMap) RollingOut() []string {
+	names := []string{}
+	for _, mp := range mps {
+		if mp.IsRollingOut() {
+			names = append(names, mp.Object.Name)
+		}
+	}
+	return names
+}
+
+// IsAnyRollingOut returns true if at least one of the
+// machine pools is rolling out. False, otherwise.
+func (mps MachinePoolsStateMap) IsAnyRollingOut() bool {
+	return len(mps.RollingOut()) != 0
+}
+
+// MachinePoolState holds all the objects representing the state of a managed pool.
+type MachinePoolState struct {
+	// Object holds the MachinePool object.
+	Object *expv1.MachinePool
+
+	// BootstrapObject holds the MachinePool bootstrap object.
+	BootstrapObject *unstructured.Unstructured
+
+	// InfrastructureMachinePoolObject holds the infrastructure machine pool object referenced by the MachinePool object.
+	InfrastructureMachinePoolObject *unstructured.Unstructured
+}
+
+// IsRollingOut determines if the machine pool is upgrading.
+// A machine pool is considered upgrading if:
+// - the latest spec has not been observed yet.
+// - if any of the replicas of the machine pool is not ready.
+func (mp *MachinePoolState) IsRollingOut() bool {
+	if mp.Object.Status.ObservedGeneration < mp.Object.Generation {
+		return true
+	}
+	desiredReplicas := int32(1)
+	if mp.Object.Spec.Replicas != nil {
+		desiredReplicas = *mp.Object.Spec.Replicas
+	}
+	return mp.Object.Status.Replicas != desiredReplicas || mp.Object.Status.ReadyReplicas != desiredReplicas
+}
@@ -18,12 +18,13 @@ package scope
 
 import "k8s.io/apimachinery/pkg/util/sets"
 
-const maxMachineDeploymentUpgradeConcurrency = 1
+const maxWorkerUpgradeConcurrency = 1
 
 // UpgradeTracker is a helper to capture the upgrade status and make upgrade decisions.
 type UpgradeTracker struct {
 	ControlPlane       ControlPlaneUpgradeTracker
-	MachineDeployments MachineDeploymentUpgradeTracker
+	MachineDeployments WorkerUpgradeTracker
+	MachinePools       WorkerUpgradeTracker
 }
 
 // ControlPlaneUpgradeTracker holds the current upgrade status of the Control Plane.
@@ -43,64 +44,77 @@ type ControlPlaneUpgradeTracker struct {
 	IsScaling bool
 }
 
-// MachineDeploymentUpgradeTracker holds the current upgrade status and makes upgrade
-// decisions for MachineDeployments.
-type MachineDeploymentUpgradeTracker struct {
+// WorkerUpgradeTracker holds the current upgrade status and makes upgrade
+// decisions for a group of workers, either MachineDeployments or MachinePools.
+type WorkerUpgradeTracker struct {
 	pendingNames    sets.String
 	rollingOutNames sets.String
+	holdUpgrades    bool
 }
 
 // NewUpgradeTracker returns an upgrade tracker with empty tracking information.
 func NewUpgradeTracker() *UpgradeTracker {
 	return &UpgradeTracker{
-		MachineDeployments: MachineDeploymentUpgradeTracker{
+		MachineDeployments: WorkerUpgradeTracker{
+			pendingNames:    sets.NewString(),
+			rollingOutNames: sets.NewString(),
+		},
+		MachinePools: WorkerUpgradeTracker{
 			pendingNames:    sets.NewString(),
 			rollingOutNames: sets.NewString(),
 		},
 	}
 }
 
-// MarkRollingOut marks a MachineDeployment as currently rolling out or
+// MarkRollingOut marks a MachineDeployment or MachinePool as currently rolling out or
 // is about to rollout.
 // NOTE: We are using Rollout because this includes upgrades and also other changes
 // that could imply Machines being created/deleted; in both cases we should wait for
 // the operation to complete before moving to the next step of the Cluster upgrade.
-func (m *MachineDeploymentUpgradeTracker) MarkRollingOut(names ...string) {
+func (m *WorkerUpgradeTracker) MarkRollingOut(names ...string) {
 	for _, name := range names {
 		m.rollingOutNames.Insert(name)
 	}
 }
 
-// RolloutNames returns the list of machine deployments that are rolling out or
+// RolloutNames returns the list of workers that are rolling out or
 // are about to rollout.
-func (m *MachineDeploymentUpgradeTracker) RolloutNames() []string {
+func (m *WorkerUpgradeTracker) RolloutNames() []string {
 	return m.rollingOutNames.List()
 }
 
-// AllowUpgrade returns true if a MachineDeployment is allowed to upgrade,
+// HoldUpgrades is used to set if any subsequent upgrade operations should be paused,
+// e.g. because a lifecycle hook is blocking the workers upgrade.
+func (m *WorkerUpgradeTracker) HoldUpgrades(val bool) {
+	m.holdUpgrades = val
+}
+
+// AllowUpgrade returns true if a MachineDeployment or MachinePool is allowed to upgrade,
 // returns false otherwise.
-// Note: If AllowUpgrade returns true the machine deployment will pick up
-// the topology version. This will eventually trigger a machine deployment
-// rollout.
-func (m *MachineDeploymentUpgradeTracker) AllowUpgrade() bool {
-	return m.rollingOutNames.Len() < maxMachineDeploymentUpgradeConcurrency
+// Note: If AllowUpgrade returns true the worker will pick up
+// the topology version. This will eventually trigger a rollout.
+func (m *WorkerUpgradeTracker) AllowUpgrade() bool {
+	if m.holdUpgrades {
+		return false
+	}
+	return m.rollingOutNames.Len() < maxWorkerUpgradeConcurrency
 }
 
-// MarkPendingUpgrade marks a machine deployment as in need of an upgrade.
-// This is generally used to capture machine deployments that have not yet
+// MarkPendingUpgrade marks a worker as in need of an upgrade.
+// This is generally used to capture workers that have not yet
 // picked up the topology version.
-func (m *MachineDeploymentUpgradeTracker) MarkPendingUpgrade(name string) {
+func (m *WorkerUpgradeTracker) MarkPendingUpgrade(name string) {
 	m.pendingNames.Insert(name)
 }
 
-// PendingUpgradeNames returns the list of machine deployment names that
+// PendingUpgradeNames returns the list of worker names that
 // are pending an upgrade.
-func (m *MachineDeploymentUpgradeTracker) PendingUpgradeNames() []string {
+func (m *WorkerUpgradeTracker) PendingUpgradeNames() []string {
 	return m.pendingNames.List()
 }
 
-// PendingUpgrade returns true if any of the machine deployments are pending
+// PendingUpgrade returns true if any of the workers are pending
 // an upgrade. Returns false, otherwise.
-func (m *MachineDeploymentUpgradeTracker) PendingUpgrade() bool {
+func (m *WorkerUpgradeTracker) PendingUpgrade() bool {
 	return len(m.pendingNames) != 0
 }
@@ -0,0 +1,331 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hooks
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
+)
+
+var fakeScheme = runtime.NewScheme()
+
+func init() {
+	_ = clusterv1.AddToScheme(fakeScheme)
+}
+
+func TestMarkAsOkToDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  client.Object
+	}{
+		{
+			name: "should add the OkToDelete annotation on an object without annotations",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+				},
+			},
+		},
+		{
+			name: "should add the OkToDelete annotation preserving existing annotations",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(tt.obj).Build()
+			ctx := context.Background()
+			g.Expect(MarkAsOkToDelete(ctx, fakeClient, tt.obj)).To(Succeed())
+
+			g.Expect(tt.obj.GetAnnotations()).To(HaveKey(runtimev1.OkToDeleteAnnotation))
+			g.Expect(IsOkToDelete(tt.obj)).To(BeTrue())
+
+			// Verify the change has been persisted.
+			got := &clusterv1.Cluster{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.obj), got)).To(Succeed())
+			g.Expect(IsOkToDelete(got)).To(BeTrue())
+		})
+	}
+}
+
+func TestIsOkToDelete(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  client.Object
+		want bool
+	}{
+		{
+			name: "should return true if the object has the OkToDelete annotation",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.OkToDeleteAnnotation: "",
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "should return false if the object does not have the OkToDelete annotation",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsOkToDelete(tt.obj)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestIsPending(t *testing.T) {
+	tests := []struct {
+		name string
+		obj  client.Object
+		hook runtimecatalog.Hook
+		want bool
+	}{
+		{
+			name: "should return true if the hook is marked as pending",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterClusterUpgrade",
+					},
+				},
+			},
+			hook: runtimehooksv1.AfterClusterUpgrade,
+			want: true,
+		},
+		{
+			name: "should return true if the hook is marked - other hooks are marked as well",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterClusterUpgrade,AfterControlPlaneUpgrade",
+					},
+				},
+			},
+			hook: runtimehooksv1.AfterClusterUpgrade,
+			want: true,
+		},
+		{
+			name: "should return false if the hook is not marked",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+				},
+			},
+			hook: runtimehooksv1.AfterClusterUpgrade,
+			want: false,
+		},
+		{
+			name: "should return false if the hook is not marked - other hooks are marked",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterControlPlaneUpgrade",
+					},
+				},
+			},
+			hook: runtimehooksv1.AfterClusterUpgrade,
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(IsPending(tt.hook, tt.obj)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestMarkAsPending(t *testing.T) {
+	tests := []struct {
+		name           string
+		obj            client.Object
+		hook           runtimecatalog.Hook
+		wantAnnotation string
+	}{
+		{
+			name: "should add the marker if not already marked",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+				},
+			},
+			hook:           runtimehooksv1.AfterClusterUpgrade,
+			wantAnnotation: "AfterClusterUpgrade",
+		},
+		{
+			name: "should add the marker if not already marked - other hooks are present",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterControlPlaneUpgrade",
+					},
+				},
+			},
+			hook:           runtimehooksv1.AfterClusterUpgrade,
+			wantAnnotation: "AfterClusterUpgrade,AfterControlPlaneUpgrade",
+		},
+		{
+			name: "should pass if the marker is already marked",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterClusterUpgrade",
+					},
+				},
+			},
+			hook:           runtimehooksv1.AfterClusterUpgrade,
+			wantAnnotation: "AfterClusterUpgrade",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(tt.obj).Build()
+			ctx := context.Background()
+			g.Expect(MarkAsPending(ctx, fakeClient, tt.obj, tt.hook)).To(Succeed())
+
+			g.Expect(tt.obj.GetAnnotations()).To(HaveKeyWithValue(runtimev1.PendingHooksAnnotation, tt.wantAnnotation))
+
+			// Verify the change has been persisted.
+			got := &clusterv1.Cluster{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.obj), got)).To(Succeed())
+			g.Expect(got.GetAnnotations()).To(HaveKeyWithValue(runtimev1.PendingHooksAnnotation, tt.wantAnnotation))
+		})
+	}
+}
+
+func TestMarkAsDone(t *testing.T) {
+	tests := []struct {
+		name                  string
+		obj                   client.Object
+		hook                  runtimecatalog.Hook
+		wantAnnotationPresent bool
+		wantAnnotation        string
+	}{
+		{
+			name: "should pass if the marker is not already present",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+				},
+			},
+			hook:                  runtimehooksv1.AfterClusterUpgrade,
+			wantAnnotationPresent: false,
+		},
+		{
+			name: "should remove if the marker is already present",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterClusterUpgrade",
+					},
+				},
+			},
+			hook:                  runtimehooksv1.AfterClusterUpgrade,
+			wantAnnotationPresent: false,
+		},
+		{
+			name: "should remove if the marker is already present among multiple hooks",
+			obj: &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-cluster",
+					Namespace: "test-ns",
+					Annotations: map[string]string{
+						runtimev1.PendingHooksAnnotation: "AfterClusterUpgrade,AfterControlPlaneUpgrade",
+					},
+				},
+			},
+			hook:                  runtimehooksv1.AfterClusterUpgrade,
+			wantAnnotationPresent: true,
+			wantAnnotation:        "AfterControlPlaneUpgrade",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(tt.obj).Build()
+			ctx := context.Background()
+			g.Expect(MarkAsDone(ctx, fakeClient, tt.obj, tt.hook)).To(Succeed())
+
+			got := &clusterv1.Cluster{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(tt.obj), got)).To(Succeed())
+			if tt.wantAnnotationPresent {
+				g.Expect(got.GetAnnotations()).To(HaveKeyWithValue(runtimev1.PendingHooksAnnotation, tt.wantAnnotation))
+			} else {
+				g.Expect(got.GetAnnotations()).NotTo(HaveKey(runtimev1.PendingHooksAnnotation))
+			}
+			g.Expect(IsPending(tt.hook, got)).To(BeFalse())
+		})
+	}
+}
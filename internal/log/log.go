@@ -26,6 +26,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 )
 
 // LoggerFrom returns a logger with predefined values from a context.Context.
@@ -58,6 +59,9 @@ type Logger interface {
 	// WithMachineDeployment adds to the logger information about the MachineDeployment object being processed.
 	WithMachineDeployment(md *clusterv1.MachineDeployment) Logger
 
+	// WithMachinePool adds to the logger information about the MachinePool object being processed.
+	WithMachinePool(mp *expv1.MachinePool) Logger
+
 	// WithValues adds key-value pairs of context to a logger.
 	WithValues(keysAndValues ...interface{}) Logger
 
@@ -115,6 +119,17 @@ func (l *topologyReconcileLogger) WithMachineDeployment(md *clusterv1.MachineDep
 	}
 }
 
+// WithMachinePool adds to the logger information about the MachinePool object being processed.
+func (l *topologyReconcileLogger) WithMachinePool(mp *expv1.MachinePool) Logger {
+	topologyName := mp.Labels[clusterv1.ClusterTopologyMachinePoolLabelName]
+	return &topologyReconcileLogger{
+		Logger: l.Logger.WithValues(
+			"machinePool name", mp.GetName(),
+			"machinePool topologyName", topologyName,
+		),
+	}
+}
+
 // WithValues adds key-value pairs of context to a logger.
 func (l *topologyReconcileLogger) WithValues(keysAndValues ...interface{}) Logger {
 	l.Logger = l.Logger.WithValues(keysAndValues...)
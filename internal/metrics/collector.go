@@ -0,0 +1,214 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+var (
+	clusterPhaseDesc = prometheus.NewDesc(
+		"capi_cluster_status_phase",
+		"The phase of the Cluster, set to 1 for the current phase and to 0 for all the others.",
+		[]string{"namespace", "name", "phase"}, nil,
+	)
+
+	machinePhaseDesc = prometheus.NewDesc(
+		"capi_machines_status_phase",
+		"The number of Machines in each phase, grouped by Cluster and MachineDeployment.",
+		[]string{"namespace", "cluster_name", "machine_deployment", "phase"}, nil,
+	)
+
+	machinePhaseDurationDesc = prometheus.NewDesc(
+		"capi_machine_phase_duration_seconds",
+		"The time a Machine has spent in the Provisioning or Deleting phase.",
+		[]string{"namespace", "name", "cluster_name", "phase"}, nil,
+	)
+
+	clusterPhases = []clusterv1.ClusterPhase{
+		clusterv1.ClusterPhasePending,
+		clusterv1.ClusterPhaseProvisioning,
+		clusterv1.ClusterPhaseProvisioned,
+		clusterv1.ClusterPhaseDeleting,
+		clusterv1.ClusterPhaseFailed,
+		clusterv1.ClusterPhaseUnknown,
+	}
+)
+
+// Collector collects metrics about Cluster API objects at scrape time.
+// NOTE: Objects are read using the client provided by the manager, so metrics are computed from
+// the cache without additional calls to the API server.
+type Collector struct {
+	client client.Reader
+
+	// now is used to compute the time spent by Machines in a phase; it can be overridden in tests.
+	now func() time.Time
+}
+
+// NewCollector returns a new Collector reading objects using the given client.
+func NewCollector(c client.Reader) *Collector {
+	return &Collector{
+		client: c,
+		now:    time.Now,
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- clusterPhaseDesc
+	ch <- machinePhaseDesc
+	ch <- machinePhaseDurationDesc
+	ch <- ConditionDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx := context.Background()
+	log := ctrl.Log.WithName("metrics")
+
+	// NOTE: Errors are only logged, so a failure in reading one kind of object does not prevent
+	// the metrics for the other kinds and for the controllers from being reported.
+	if err := c.collectClusters(ctx, ch); err != nil {
+		log.Error(err, "failed to collect Cluster metrics")
+	}
+	if err := c.collectMachineDeployments(ctx, ch); err != nil {
+		log.Error(err, "failed to collect MachineDeployment metrics")
+	}
+	if err := c.collectMachines(ctx, ch); err != nil {
+		log.Error(err, "failed to collect Machine metrics")
+	}
+	if err := c.collectMachineHealthChecks(ctx, ch); err != nil {
+		log.Error(err, "failed to collect MachineHealthCheck metrics")
+	}
+}
+
+func (c *Collector) collectClusters(ctx context.Context, ch chan<- prometheus.Metric) error {
+	clusters := &clusterv1.ClusterList{}
+	if err := c.client.List(ctx, clusters); err != nil {
+		return err
+	}
+
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		for _, phase := range clusterPhases {
+			value := 0.0
+			if cluster.Status.GetTypedPhase() == phase {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(clusterPhaseDesc, prometheus.GaugeValue, value,
+				cluster.Namespace, cluster.Name, string(phase),
+			)
+		}
+		CollectConditions(ch, "Cluster", cluster)
+	}
+	return nil
+}
+
+func (c *Collector) collectMachineDeployments(ctx context.Context, ch chan<- prometheus.Metric) error {
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := c.client.List(ctx, machineDeployments); err != nil {
+		return err
+	}
+
+	for i := range machineDeployments.Items {
+		CollectConditions(ch, "MachineDeployment", &machineDeployments.Items[i])
+	}
+	return nil
+}
+
+func (c *Collector) collectMachines(ctx context.Context, ch chan<- prometheus.Metric) error {
+	machines := &clusterv1.MachineList{}
+	if err := c.client.List(ctx, machines); err != nil {
+		return err
+	}
+
+	type phaseKey struct {
+		namespace         string
+		clusterName       string
+		machineDeployment string
+		phase             clusterv1.MachinePhase
+	}
+	machinesByPhase := map[phaseKey]int{}
+
+	now := c.now()
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		phase := machine.Status.GetTypedPhase()
+
+		// NOTE: Machines not belonging to a MachineDeployment, e.g. control plane Machines, are
+		// reported with an empty machine_deployment label.
+		machinesByPhase[phaseKey{
+			namespace:         machine.Namespace,
+			clusterName:       machine.Spec.ClusterName,
+			machineDeployment: machine.Labels[clusterv1.MachineDeploymentLabelName],
+			phase:             phase,
+		}]++
+
+		if since := phaseStartTime(machine); since != nil {
+			ch <- prometheus.MustNewConstMetric(machinePhaseDurationDesc, prometheus.GaugeValue, now.Sub(since.Time).Seconds(),
+				machine.Namespace, machine.Name, machine.Spec.ClusterName, string(phase),
+			)
+		}
+		CollectConditions(ch, "Machine", machine)
+	}
+
+	for key, count := range machinesByPhase {
+		ch <- prometheus.MustNewConstMetric(machinePhaseDesc, prometheus.GaugeValue, float64(count),
+			key.namespace, key.clusterName, key.machineDeployment, string(key.phase),
+		)
+	}
+	return nil
+}
+
+func (c *Collector) collectMachineHealthChecks(ctx context.Context, ch chan<- prometheus.Metric) error {
+	machineHealthChecks := &clusterv1.MachineHealthCheckList{}
+	if err := c.client.List(ctx, machineHealthChecks); err != nil {
+		return err
+	}
+
+	for i := range machineHealthChecks.Items {
+		CollectConditions(ch, "MachineHealthCheck", &machineHealthChecks.Items[i])
+	}
+	return nil
+}
+
+// phaseStartTime returns when a Machine entered the Provisioning or the Deleting phase, nil if the
+// Machine is in any other phase.
+func phaseStartTime(machine *clusterv1.Machine) *metav1.Time {
+	switch machine.Status.GetTypedPhase() {
+	case clusterv1.MachinePhaseProvisioning:
+		if machine.Status.LastUpdated != nil {
+			return machine.Status.LastUpdated
+		}
+		return &machine.CreationTimestamp
+	case clusterv1.MachinePhaseDeleting:
+		if machine.DeletionTimestamp != nil {
+			return machine.DeletionTimestamp
+		}
+		return machine.Status.LastUpdated
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestCollector(t *testing.T) {
+	now := time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"},
+		Status: clusterv1.ClusterStatus{
+			Phase: string(clusterv1.ClusterPhaseProvisioned),
+			Conditions: clusterv1.Conditions{
+				{Type: clusterv1.ReadyCondition, Status: "False", Reason: "WaitingForControlPlane"},
+			},
+		},
+	}
+	machineRunning := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "running",
+			Labels:    map[string]string{clusterv1.MachineDeploymentLabelName: "md1"},
+		},
+		Spec:   clusterv1.MachineSpec{ClusterName: "cluster1"},
+		Status: clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseRunning)},
+	}
+	machineProvisioning := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "provisioning",
+			Labels:    map[string]string{clusterv1.MachineDeploymentLabelName: "md1"},
+		},
+		Spec: clusterv1.MachineSpec{ClusterName: "cluster1"},
+		Status: clusterv1.MachineStatus{
+			Phase:       string(clusterv1.MachinePhaseProvisioning),
+			LastUpdated: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+		},
+	}
+	machineControlPlane := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns1",
+			Name:      "control-plane",
+			Labels:    map[string]string{clusterv1.MachineControlPlaneLabelName: ""},
+		},
+		Spec:   clusterv1.MachineSpec{ClusterName: "cluster1"},
+		Status: clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseRunning)},
+	}
+
+	c := NewCollector(fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, machineRunning, machineProvisioning, machineControlPlane).Build())
+	c.now = func() time.Time { return now }
+
+	t.Run("reports the Cluster phase", func(t *testing.T) {
+		g := NewWithT(t)
+
+		expected := `
+# HELP capi_cluster_status_phase The phase of the Cluster, set to 1 for the current phase and to 0 for all the others.
+# TYPE capi_cluster_status_phase gauge
+capi_cluster_status_phase{name="cluster1",namespace="ns1",phase="Deleting"} 0
+capi_cluster_status_phase{name="cluster1",namespace="ns1",phase="Failed"} 0
+capi_cluster_status_phase{name="cluster1",namespace="ns1",phase="Pending"} 0
+capi_cluster_status_phase{name="cluster1",namespace="ns1",phase="Provisioned"} 1
+capi_cluster_status_phase{name="cluster1",namespace="ns1",phase="Provisioning"} 0
+capi_cluster_status_phase{name="cluster1",namespace="ns1",phase="Unknown"} 0
+`
+		g.Expect(testutil.CollectAndCompare(c, strings.NewReader(expected), "capi_cluster_status_phase")).To(Succeed())
+	})
+	t.Run("reports the Machine phases grouped by MachineDeployment", func(t *testing.T) {
+		g := NewWithT(t)
+
+		expected := `
+# HELP capi_machines_status_phase The number of Machines in each phase, grouped by Cluster and MachineDeployment.
+# TYPE capi_machines_status_phase gauge
+capi_machines_status_phase{cluster_name="cluster1",machine_deployment="",namespace="ns1",phase="Running"} 1
+capi_machines_status_phase{cluster_name="cluster1",machine_deployment="md1",namespace="ns1",phase="Provisioning"} 1
+capi_machines_status_phase{cluster_name="cluster1",machine_deployment="md1",namespace="ns1",phase="Running"} 1
+`
+		g.Expect(testutil.CollectAndCompare(c, strings.NewReader(expected), "capi_machines_status_phase")).To(Succeed())
+	})
+	t.Run("reports the time spent by Machines in the Provisioning phase", func(t *testing.T) {
+		g := NewWithT(t)
+
+		expected := `
+# HELP capi_machine_phase_duration_seconds The time a Machine has spent in the Provisioning or Deleting phase.
+# TYPE capi_machine_phase_duration_seconds gauge
+capi_machine_phase_duration_seconds{cluster_name="cluster1",name="provisioning",namespace="ns1",phase="Provisioning"} 600
+`
+		g.Expect(testutil.CollectAndCompare(c, strings.NewReader(expected), "capi_machine_phase_duration_seconds")).To(Succeed())
+	})
+	t.Run("reports conditions", func(t *testing.T) {
+		g := NewWithT(t)
+
+		expected := `
+# HELP capi_object_condition The condition of a Cluster API object, with its status and reason.
+# TYPE capi_object_condition gauge
+capi_object_condition{kind="Cluster",name="cluster1",namespace="ns1",reason="WaitingForControlPlane",status="False",type="Ready"} 1
+`
+		g.Expect(testutil.CollectAndCompare(c, strings.NewReader(expected), "capi_object_condition")).To(Succeed())
+	})
+}
+
+func TestPhaseStartTime(t *testing.T) {
+	creation := metav1.NewTime(time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC))
+	lastUpdated := metav1.NewTime(time.Date(2022, 5, 1, 11, 0, 0, 0, time.UTC))
+	deletion := metav1.NewTime(time.Date(2022, 5, 1, 11, 30, 0, 0, time.UTC))
+
+	tests := []struct {
+		name    string
+		machine *clusterv1.Machine
+		want    *metav1.Time
+	}{
+		{
+			name: "Provisioning Machine uses the last phase transition",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creation},
+				Status:     clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseProvisioning), LastUpdated: &lastUpdated},
+			},
+			want: &lastUpdated,
+		},
+		{
+			name: "Provisioning Machine without last phase transition uses the creation timestamp",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creation},
+				Status:     clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseProvisioning)},
+			},
+			want: &creation,
+		},
+		{
+			name: "Deleting Machine uses the deletion timestamp",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creation, DeletionTimestamp: &deletion},
+				Status:     clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseDeleting), LastUpdated: &lastUpdated},
+			},
+			want: &deletion,
+		},
+		{
+			name: "Running Machine is not reported",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: creation},
+				Status:     clusterv1.MachineStatus{Phase: string(clusterv1.MachinePhaseRunning), LastUpdated: &lastUpdated},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(phaseStartTime(tt.machine)).To(Equal(tt.want))
+		})
+	}
+}
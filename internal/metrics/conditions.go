@@ -0,0 +1,39 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// ConditionDesc describes the metric reporting the conditions of Cluster API objects.
+var ConditionDesc = prometheus.NewDesc(
+	"capi_object_condition",
+	"The condition of a Cluster API object, with its status and reason.",
+	[]string{"kind", "namespace", "name", "type", "status", "reason"}, nil,
+)
+
+// CollectConditions sends one ConditionDesc metric for each of the conditions of the given object.
+func CollectConditions(ch chan<- prometheus.Metric, kind string, obj conditions.Getter) {
+	for _, c := range obj.GetConditions() {
+		ch <- prometheus.MustNewConstMetric(ConditionDesc, prometheus.GaugeValue, 1,
+			kind, obj.GetNamespace(), obj.GetName(), string(c.Type), string(c.Status), c.Reason,
+		)
+	}
+}
@@ -144,14 +144,10 @@ func (c *Catalog) AddHook(gv schema.GroupVersion, hookFunc Hook, hookMeta *HookM
 		panic("hook response (second parameter) must be a runtime.Object")
 	}
 
-	// Calculate the hook name based on the func name.
-	hookFuncName := goruntime.FuncForPC(reflect.ValueOf(hookFunc).Pointer()).Name()
-	hookName := hookFuncName[strings.LastIndex(hookFuncName, ".")+1:]
-
 	gvh := GroupVersionHook{
 		Group:   gv.Group,
 		Version: gv.Version,
-		Hook:    hookName,
+		Hook:    HookName(hookFunc),
 	}
 
 	// Validate that the GVH is not already registered with another type.
@@ -366,6 +362,13 @@ func (gh GroupHook) String() string {
 	return gh.Hook + "." + gh.Group
 }
 
+// HookName returns the name of the runtime hook.
+// The name is calculated based on the func name of the Hook, e.g. "BeforeClusterCreate".
+func HookName(hook Hook) string {
+	hookFuncName := goruntime.FuncForPC(reflect.ValueOf(hook).Pointer()).Name()
+	return hookFuncName[strings.LastIndex(hookFuncName, ".")+1:]
+}
+
 // GVHToPath calculates the path for a given GroupVersionHook.
 // This func is aligned with Kubernetes paths for cluster-wide resources, e.g.:
 // /apis/storage.k8s.io/v1/storageclasses/standard.
@@ -49,6 +49,7 @@ func TestCatalog(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(hookGVH.GroupVersion()).To(Equal(expectedGV))
 		g.Expect(hookGVH.Hook).To(Equal("FakeHook"))
+		g.Expect(runtimecatalog.HookName(hook)).To(Equal("FakeHook"))
 
 		// Test Request
 		requestGVK, err := c.Request(hookGVH)
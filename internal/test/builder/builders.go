@@ -145,6 +145,12 @@ func (c *ClusterTopologyBuilder) WithMachineDeployment(mdc clusterv1.MachineDepl
 	return c
 }
 
+// WithMachinePool passes the full MachinePoolTopology and adds it to an existing list in the ClusterTopologyBuilder.
+func (c *ClusterTopologyBuilder) WithMachinePool(mpc clusterv1.MachinePoolTopology) *ClusterTopologyBuilder {
+	c.workers.MachinePools = append(c.workers.MachinePools, mpc)
+	return c
+}
+
 // WithVariables adds the passed variables to the ClusterTopologyBuilder.
 func (c *ClusterTopologyBuilder) WithVariables(vars ...clusterv1.ClusterVariable) *ClusterTopologyBuilder {
 	c.variables = vars
@@ -214,6 +220,56 @@ func (m *MachineDeploymentTopologyBuilder) Build() clusterv1.MachineDeploymentTo
 	return md
 }
 
+// MachinePoolTopologyBuilder holds the values needed to create a testable MachinePoolTopology.
+type MachinePoolTopologyBuilder struct {
+	class     string
+	name      string
+	replicas  *int32
+	variables []clusterv1.ClusterVariable
+}
+
+// MachinePoolTopology returns a builder used to create a testable MachinePoolTopology.
+func MachinePoolTopology(name string) *MachinePoolTopologyBuilder {
+	return &MachinePoolTopologyBuilder{
+		name: name,
+	}
+}
+
+// WithClass adds a class string used as the MachinePoolTopology class.
+func (m *MachinePoolTopologyBuilder) WithClass(class string) *MachinePoolTopologyBuilder {
+	m.class = class
+	return m
+}
+
+// WithReplicas adds a replicas value used as the MachinePoolTopology replicas value.
+func (m *MachinePoolTopologyBuilder) WithReplicas(replicas int32) *MachinePoolTopologyBuilder {
+	m.replicas = &replicas
+	return m
+}
+
+// WithVariables adds variables used as the MachinePoolTopology variables value.
+func (m *MachinePoolTopologyBuilder) WithVariables(variables ...clusterv1.ClusterVariable) *MachinePoolTopologyBuilder {
+	m.variables = variables
+	return m
+}
+
+// Build returns a testable MachinePoolTopology with any values passed to the builder.
+func (m *MachinePoolTopologyBuilder) Build() clusterv1.MachinePoolTopology {
+	mp := clusterv1.MachinePoolTopology{
+		Class:    m.class,
+		Name:     m.name,
+		Replicas: m.replicas,
+	}
+
+	if len(m.variables) > 0 {
+		mp.Variables = &clusterv1.MachinePoolVariables{
+			Overrides: m.variables,
+		}
+	}
+
+	return mp
+}
+
 // ClusterClassBuilder holds the variables and objects required to build a clusterv1.ClusterClass.
 type ClusterClassBuilder struct {
 	namespace                                 string
@@ -224,6 +280,7 @@ type ClusterClassBuilder struct {
 	controlPlaneInfrastructureMachineTemplate *unstructured.Unstructured
 	controlPlaneMHC                           *clusterv1.MachineHealthCheckClass
 	machineDeploymentClasses                  []clusterv1.MachineDeploymentClass
+	machinePoolClasses                        []clusterv1.MachinePoolClass
 	variables                                 []clusterv1.ClusterClassVariable
 	patches                                   []clusterv1.ClusterClassPatch
 }
@@ -290,6 +347,15 @@ func (c *ClusterClassBuilder) WithWorkerMachineDeploymentClasses(mdcs ...cluster
 	return c
 }
 
+// WithWorkerMachinePoolClasses adds the variables and objects needed to create MachinePoolTemplates for a ClusterClassBuilder.
+func (c *ClusterClassBuilder) WithWorkerMachinePoolClasses(mpcs ...clusterv1.MachinePoolClass) *ClusterClassBuilder {
+	if c.machinePoolClasses == nil {
+		c.machinePoolClasses = make([]clusterv1.MachinePoolClass, 0)
+	}
+	c.machinePoolClasses = append(c.machinePoolClasses, mpcs...)
+	return c
+}
+
 // Build takes the objects and variables in the ClusterClass builder and uses them to create a ClusterClass object.
 func (c *ClusterClassBuilder) Build() *clusterv1.ClusterClass {
 	obj := &clusterv1.ClusterClass{
@@ -329,6 +395,7 @@ func (c *ClusterClassBuilder) Build() *clusterv1.ClusterClass {
 	}
 
 	obj.Spec.Workers.MachineDeployments = c.machineDeploymentClasses
+	obj.Spec.Workers.MachinePools = c.machinePoolClasses
 	return obj
 }
 
@@ -402,6 +469,66 @@ func (m *MachineDeploymentClassBuilder) Build() *clusterv1.MachineDeploymentClas
 	return obj
 }
 
+// MachinePoolClassBuilder holds the variables and objects required to build a clusterv1.MachinePoolClass.
+type MachinePoolClassBuilder struct {
+	class                             string
+	infrastructureMachinePoolTemplate *unstructured.Unstructured
+	bootstrapTemplate                 *unstructured.Unstructured
+	labels                            map[string]string
+	annotations                       map[string]string
+}
+
+// MachinePoolClass returns a MachinePoolClassBuilder with the given name.
+func MachinePoolClass(class string) *MachinePoolClassBuilder {
+	return &MachinePoolClassBuilder{
+		class: class,
+	}
+}
+
+// WithInfrastructureTemplate registers the passed Unstructured object as the InfrastructureMachinePoolTemplate for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithInfrastructureTemplate(t *unstructured.Unstructured) *MachinePoolClassBuilder {
+	m.infrastructureMachinePoolTemplate = t
+	return m
+}
+
+// WithBootstrapTemplate registers the passed Unstructured object as the BootstrapTemplate for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithBootstrapTemplate(t *unstructured.Unstructured) *MachinePoolClassBuilder {
+	m.bootstrapTemplate = t
+	return m
+}
+
+// WithLabels sets the labels for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithLabels(labels map[string]string) *MachinePoolClassBuilder {
+	m.labels = labels
+	return m
+}
+
+// WithAnnotations sets the annotations for the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) WithAnnotations(annotations map[string]string) *MachinePoolClassBuilder {
+	m.annotations = annotations
+	return m
+}
+
+// Build creates a full MachinePoolClass object with the variables passed to the MachinePoolClassBuilder.
+func (m *MachinePoolClassBuilder) Build() *clusterv1.MachinePoolClass {
+	obj := &clusterv1.MachinePoolClass{
+		Class: m.class,
+		Template: clusterv1.MachinePoolClassTemplate{
+			Metadata: clusterv1.ObjectMeta{
+				Labels:      m.labels,
+				Annotations: m.annotations,
+			},
+		},
+	}
+	if m.bootstrapTemplate != nil {
+		obj.Template.Bootstrap.Ref = objToRef(m.bootstrapTemplate)
+	}
+	if m.infrastructureMachinePoolTemplate != nil {
+		obj.Template.Infrastructure.Ref = objToRef(m.infrastructureMachinePoolTemplate)
+	}
+	return obj
+}
+
 // InfrastructureMachineTemplateBuilder holds the variables and objects needed to build an InfrastructureMachineTemplate.
 // +kubebuilder:object:generate=false
 type InfrastructureMachineTemplateBuilder struct {
@@ -491,6 +618,122 @@ func (i *TestInfrastructureMachineTemplateBuilder) Build() *unstructured.Unstruc
 	return obj
 }
 
+// InfrastructureMachinePoolTemplateBuilder holds the variables and objects needed to build an InfrastructureMachinePoolTemplate.
+// +kubebuilder:object:generate=false
+type InfrastructureMachinePoolTemplateBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// InfrastructureMachinePoolTemplate creates an InfrastructureMachinePoolTemplateBuilder with the given name and namespace.
+func InfrastructureMachinePoolTemplate(namespace, name string) *InfrastructureMachinePoolTemplateBuilder {
+	return &InfrastructureMachinePoolTemplateBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields sets a map of spec fields on the unstructured object. The keys in the map represent the path and the value corresponds
+// to the value of the spec field.
+//
+// Note: all the paths should start with "spec."
+//
+// Example map: map[string]interface{}{
+//     "spec.version": "v1.2.3",
+// }.
+func (i *InfrastructureMachinePoolTemplateBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureMachinePoolTemplateBuilder {
+	i.specFields = fields
+	return i
+}
+
+// Build takes the objects and variables in the InfrastructureMachinePoolTemplateBuilder and generates an unstructured object.
+func (i *InfrastructureMachinePoolTemplateBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(InfrastructureGroupVersion.String())
+	obj.SetKind(GenericInfrastructureMachinePoolTemplateKind)
+	obj.SetNamespace(i.namespace)
+	obj.SetName(i.name)
+
+	// Initialize the spec.template.spec to make the object valid in reconciliation.
+	setSpecFields(obj, map[string]interface{}{"spec.template.spec": map[string]interface{}{}})
+
+	setSpecFields(obj, i.specFields)
+	return obj
+}
+
+// InfrastructureMachinePoolBuilder holds the variables and objects needed to build an InfrastructureMachinePool.
+// +kubebuilder:object:generate=false
+type InfrastructureMachinePoolBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// InfrastructureMachinePool creates an InfrastructureMachinePoolBuilder with the given name and namespace.
+func InfrastructureMachinePool(namespace, name string) *InfrastructureMachinePoolBuilder {
+	return &InfrastructureMachinePoolBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields sets a map of spec fields on the unstructured object. The keys in the map represent the path and the value corresponds
+// to the value of the spec field.
+//
+// Note: all the paths should start with "spec."
+func (i *InfrastructureMachinePoolBuilder) WithSpecFields(fields map[string]interface{}) *InfrastructureMachinePoolBuilder {
+	i.specFields = fields
+	return i
+}
+
+// Build takes the objects and variables in the InfrastructureMachinePoolBuilder and generates an unstructured object.
+func (i *InfrastructureMachinePoolBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(InfrastructureGroupVersion.String())
+	obj.SetKind(GenericInfrastructureMachinePoolKind)
+	obj.SetNamespace(i.namespace)
+	obj.SetName(i.name)
+
+	setSpecFields(obj, i.specFields)
+	return obj
+}
+
+// BootstrapConfigBuilder holds the variables needed to build a generic BootstrapConfig.
+// +kubebuilder:object:generate=false
+type BootstrapConfigBuilder struct {
+	namespace  string
+	name       string
+	specFields map[string]interface{}
+}
+
+// BootstrapConfig creates a BootstrapConfigBuilder with the given name and namespace.
+func BootstrapConfig(namespace, name string) *BootstrapConfigBuilder {
+	return &BootstrapConfigBuilder{
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// WithSpecFields will add fields of any type to the object spec. It takes an argument, fields, which is of the form path: object.
+func (b *BootstrapConfigBuilder) WithSpecFields(fields map[string]interface{}) *BootstrapConfigBuilder {
+	b.specFields = fields
+	return b
+}
+
+// Build creates a new Unstructured object with the information passed to the BootstrapConfigBuilder.
+func (b *BootstrapConfigBuilder) Build() *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(BootstrapGroupVersion.String())
+	obj.SetKind(GenericBootstrapConfigKind)
+	obj.SetNamespace(b.namespace)
+	obj.SetName(b.name)
+
+	setSpecFields(obj, b.specFields)
+
+	return obj
+}
+
 // BootstrapTemplateBuilder holds the variables needed to build a generic BootstrapTemplate.
 // +kubebuilder:object:generate=false
 type BootstrapTemplateBuilder struct {
@@ -102,6 +102,13 @@ func (in *ClusterClassBuilder) DeepCopyInto(out *ClusterClassBuilder) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.machinePoolClasses != nil {
+		in, out := &in.machinePoolClasses, &out.machinePoolClasses
+		*out = make([]v1beta1.MachinePoolClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.variables != nil {
 		in, out := &in.variables, &out.variables
 		*out = make([]v1beta1.ClusterClassVariable, len(*in))
@@ -383,6 +390,70 @@ func (in *MachinePoolBuilder) DeepCopy() *MachinePoolBuilder {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolClassBuilder) DeepCopyInto(out *MachinePoolClassBuilder) {
+	*out = *in
+	if in.infrastructureMachinePoolTemplate != nil {
+		in, out := &in.infrastructureMachinePoolTemplate, &out.infrastructureMachinePoolTemplate
+		*out = (*in).DeepCopy()
+	}
+	if in.bootstrapTemplate != nil {
+		in, out := &in.bootstrapTemplate, &out.bootstrapTemplate
+		*out = (*in).DeepCopy()
+	}
+	if in.labels != nil {
+		in, out := &in.labels, &out.labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.annotations != nil {
+		in, out := &in.annotations, &out.annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolClassBuilder.
+func (in *MachinePoolClassBuilder) DeepCopy() *MachinePoolClassBuilder {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolClassBuilder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachinePoolTopologyBuilder) DeepCopyInto(out *MachinePoolTopologyBuilder) {
+	*out = *in
+	if in.replicas != nil {
+		in, out := &in.replicas, &out.replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.variables != nil {
+		in, out := &in.variables, &out.variables
+		*out = make([]v1beta1.ClusterVariable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolTopologyBuilder.
+func (in *MachinePoolTopologyBuilder) DeepCopy() *MachinePoolTopologyBuilder {
+	if in == nil {
+		return nil
+	}
+	out := new(MachinePoolTopologyBuilder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineSetBuilder) DeepCopyInto(out *MachineSetBuilder) {
 	*out = *in
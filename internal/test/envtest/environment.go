@@ -184,6 +184,8 @@ func newEnvironment(uncachedObjs ...client.Object) *Environment {
 			builder.GenericControlPlaneTemplateCRD.DeepCopy(),
 			builder.GenericInfrastructureMachineCRD.DeepCopy(),
 			builder.GenericInfrastructureMachineTemplateCRD.DeepCopy(),
+			builder.GenericInfrastructureMachinePoolCRD.DeepCopy(),
+			builder.GenericInfrastructureMachinePoolTemplateCRD.DeepCopy(),
 			builder.GenericInfrastructureClusterCRD.DeepCopy(),
 			builder.GenericInfrastructureClusterTemplateCRD.DeepCopy(),
 			builder.GenericRemediationCRD.DeepCopy(),
@@ -196,6 +196,7 @@ func LocalObjectTemplateIsValid(template *clusterv1.LocalObjectTemplate, namespa
 // 2) ControlPlane Templates are compatible.
 // 3) ControlPlane InfrastructureMachineTemplates are compatible.
 // 4) MachineDeploymentClasses have not been deleted and are compatible.
+// 5) MachinePoolClasses have not been deleted and are compatible.
 func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 	if current == nil {
@@ -217,6 +218,9 @@ func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field
 	// Validate changes to MachineDeployments.
 	allErrs = append(allErrs, MachineDeploymentClassesAreCompatible(current, desired)...)
 
+	// Validate changes to MachinePools.
+	allErrs = append(allErrs, MachinePoolClassesAreCompatible(current, desired)...)
+
 	return allErrs
 }
 
@@ -314,6 +318,98 @@ func MachineDeploymentTopologiesAreValidAndDefinedInClusterClass(desired *cluste
 	return allErrs
 }
 
+// MachinePoolClassesAreCompatible checks if each MachinePoolClass in the new ClusterClass is a compatible change from the previous ClusterClass.
+// It checks if the MachinePoolClass.Template.Infrastructure reference has changed its Group or Kind.
+func MachinePoolClassesAreCompatible(current, desired *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// Ensure previous MachinePool class was modified in a compatible way.
+	for _, class := range desired.Spec.Workers.MachinePools {
+		for i, oldClass := range current.Spec.Workers.MachinePools {
+			if class.Class == oldClass.Class {
+				// NOTE: class.Template.Metadata and class.Template.Bootstrap are allowed to change;
+
+				// class.Template.Bootstrap is ensured syntactically correct by LocalObjectTemplateIsValid.
+
+				// Validates class.Template.Infrastructure template changes in a compatible way
+				allErrs = append(allErrs, LocalObjectTemplatesAreCompatible(oldClass.Template.Infrastructure, class.Template.Infrastructure,
+					field.NewPath("spec", "workers", "machinePools").Index(i))...)
+			}
+		}
+	}
+	return allErrs
+}
+
+// MachinePoolClassesAreUnique checks that no two MachinePoolClasses in a ClusterClass share a name.
+func MachinePoolClassesAreUnique(clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+	classes := sets.NewString()
+	for i, class := range clusterClass.Spec.Workers.MachinePools {
+		if classes.Has(class.Class) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "workers", "machinePools").Index(i).Child("class"),
+					class.Class,
+					fmt.Sprintf("MachinePool class must be unique. MachinePool with class %q is defined more than once", class.Class),
+				),
+			)
+		}
+		classes.Insert(class.Class)
+	}
+	return allErrs
+}
+
+// MachinePoolTopologiesAreValidAndDefinedInClusterClass checks that each MachinePoolTopology name is not empty
+// and unique, and each class in use is defined in ClusterClass.spec.Workers.MachinePools.
+func MachinePoolTopologiesAreValidAndDefinedInClusterClass(desired *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+	if desired.Spec.Topology.Workers == nil {
+		return nil
+	}
+	if len(desired.Spec.Topology.Workers.MachinePools) == 0 {
+		return nil
+	}
+	// MachinePool clusterClass must be defined in the ClusterClass.
+	machinePoolClasses := mpClassNamesFromWorkerClass(clusterClass.Spec.Workers)
+	names := sets.String{}
+	for i, mp := range desired.Spec.Topology.Workers.MachinePools {
+		if !machinePoolClasses.Has(mp.Class) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("class"),
+					mp.Class,
+					fmt.Sprintf("MachinePoolClass with name %q does not exist in ClusterClass %q",
+						mp.Class, clusterClass.Name),
+				),
+			)
+		}
+
+		// MachinePoolTopology name should not be empty.
+		if mp.Name == "" {
+			allErrs = append(
+				allErrs,
+				field.Required(
+					field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("name"),
+					"name must not be empty",
+				),
+			)
+			continue
+		}
+
+		if names.Has(mp.Name) {
+			allErrs = append(allErrs,
+				field.Invalid(
+					field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("name"),
+					mp.Name,
+					fmt.Sprintf("name must be unique. MachinePool with name %q is defined more than once", mp.Name),
+				),
+			)
+		}
+		names.Insert(mp.Name)
+	}
+	return allErrs
+}
+
 // ClusterClassReferencesAreValid checks that each template reference in the ClusterClass is valid .
 func ClusterClassReferencesAreValid(clusterClass *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
@@ -332,6 +428,13 @@ func ClusterClassReferencesAreValid(clusterClass *clusterv1.ClusterClass) field.
 		allErrs = append(allErrs, LocalObjectTemplateIsValid(&mdc.Template.Infrastructure, clusterClass.Namespace,
 			field.NewPath("spec", "workers", "machineDeployments").Index(i).Child("template", "infrastructure"))...)
 	}
+
+	for i, mpc := range clusterClass.Spec.Workers.MachinePools {
+		allErrs = append(allErrs, LocalObjectTemplateIsValid(&mpc.Template.Bootstrap, clusterClass.Namespace,
+			field.NewPath("spec", "workers", "machinePools").Index(i).Child("template", "bootstrap"))...)
+		allErrs = append(allErrs, LocalObjectTemplateIsValid(&mpc.Template.Infrastructure, clusterClass.Namespace,
+			field.NewPath("spec", "workers", "machinePools").Index(i).Child("template", "infrastructure"))...)
+	}
 	return allErrs
 }
 
@@ -343,3 +446,12 @@ func classNamesFromWorkerClass(w clusterv1.WorkersClass) sets.String {
 	}
 	return classes
 }
+
+// mpClassNamesFromWorkerClass returns the set of MachinePool class names.
+func mpClassNamesFromWorkerClass(w clusterv1.WorkersClass) sets.String {
+	classes := sets.NewString()
+	for _, class := range w.MachinePools {
+		classes.Insert(class.Class)
+	}
+	return classes
+}
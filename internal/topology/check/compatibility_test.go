@@ -1118,3 +1118,173 @@ func refToUnstructured(ref *corev1.ObjectReference) *unstructured.Unstructured {
 	output.SetNamespace(ref.Namespace)
 	return output
 }
+
+func TestMachinePoolClassesAreUnique(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterClass *clusterv1.ClusterClass
+		wantErr      bool
+	}{
+		{
+			name: "pass if MachinePoolClasses are unique",
+			clusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlane(metav1.NamespaceDefault, "cp1").Build()).
+				WithWorkerMachinePoolClasses(
+					*builder.MachinePoolClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build(),
+					*builder.MachinePoolClass("bb").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build()).
+				Build(),
+			wantErr: false,
+		},
+		{
+			name: "fail if MachinePoolClasses are duplicated",
+			clusterClass: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlane(metav1.NamespaceDefault, "cp1").Build()).
+				WithWorkerMachinePoolClasses(
+					*builder.MachinePoolClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build(),
+					*builder.MachinePoolClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build()).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			allErrs := MachinePoolClassesAreUnique(tt.clusterClass)
+			if tt.wantErr {
+				g.Expect(allErrs).ToNot(BeEmpty())
+				return
+			}
+			g.Expect(allErrs).To(BeEmpty())
+		})
+	}
+}
+
+func TestMachinePoolTopologiesAreUniqueAndDefinedInClusterClass(t *testing.T) {
+	clusterClass := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithInfrastructureClusterTemplate(
+			builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+		WithControlPlaneTemplate(
+			builder.ControlPlane(metav1.NamespaceDefault, "cp1").Build()).
+		WithWorkerMachinePoolClasses(
+			*builder.MachinePoolClass("aa").
+				WithInfrastructureTemplate(
+					builder.InfrastructureMachinePoolTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithBootstrapTemplate(
+					builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+				Build()).
+		Build()
+
+	tests := []struct {
+		name         string
+		clusterClass *clusterv1.ClusterClass
+		cluster      *clusterv1.Cluster
+		wantErr      bool
+	}{
+		{
+			name:         "fail if MachinePoolTopologies name is empty",
+			clusterClass: clusterClass,
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("class1").
+					WithVersion("v1.22.2").
+					WithMachinePool(
+						// The name should not be empty.
+						builder.MachinePoolTopology("").
+							WithClass("aa").
+							Build()).
+					Build()).
+				Build(),
+			wantErr: true,
+		},
+		{
+			name:         "pass if MachinePoolTopologies are unique and defined in ClusterClass",
+			clusterClass: clusterClass,
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("class1").
+					WithVersion("v1.22.2").
+					WithMachinePool(
+						builder.MachinePoolTopology("pool1").
+							WithClass("aa").
+							Build()).
+					WithMachinePool(
+						builder.MachinePoolTopology("pool2").
+							WithClass("aa").
+							Build()).
+					Build()).
+				Build(),
+			wantErr: false,
+		},
+		{
+			name:         "fail if MachinePoolTopologies are unique but not defined in ClusterClass",
+			clusterClass: clusterClass,
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("class1").
+					WithVersion("v1.22.2").
+					WithMachinePool(
+						builder.MachinePoolTopology("pool1").
+							WithClass("bb").
+							Build()).
+					Build()).
+				Build(),
+			wantErr: true,
+		},
+		{
+			name:         "fail if MachinePoolTopologies are not unique but is defined in ClusterClass",
+			clusterClass: clusterClass,
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("class1").
+					WithVersion("v1.22.2").
+					WithMachinePool(
+						builder.MachinePoolTopology("pool1").
+							WithClass("aa").
+							Build()).
+					WithMachinePool(
+						builder.MachinePoolTopology("pool1").
+							WithClass("aa").
+							Build()).
+					Build()).
+				Build(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			allErrs := MachinePoolTopologiesAreValidAndDefinedInClusterClass(tt.cluster, tt.clusterClass)
+			if tt.wantErr {
+				g.Expect(allErrs).ToNot(BeEmpty())
+				return
+			}
+			g.Expect(allErrs).To(BeEmpty())
+		})
+	}
+}
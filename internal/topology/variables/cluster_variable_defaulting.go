@@ -39,6 +39,11 @@ func DefaultMachineDeploymentVariables(clusterVariables []clusterv1.ClusterVaria
 	return defaultClusterVariables(clusterVariables, clusterClassVariables, false, fldPath)
 }
 
+// DefaultMachinePoolVariables defaults MachinePoolVariables.
+func DefaultMachinePoolVariables(clusterVariables []clusterv1.ClusterVariable, clusterClassVariables []clusterv1.ClusterClassVariable, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
+	return defaultClusterVariables(clusterVariables, clusterClassVariables, false, fldPath)
+}
+
 // defaultClusterVariables defaults variables.
 // If they do not exist yet, they are created if createVariables is set.
 func defaultClusterVariables(clusterVariables []clusterv1.ClusterVariable, clusterClassVariables []clusterv1.ClusterClassVariable, createVariables bool, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
@@ -37,6 +37,11 @@ func ValidateMachineDeploymentVariables(clusterVariables []clusterv1.ClusterVari
 	return validateClusterVariables(clusterVariables, clusterClassVariables, false, fldPath)
 }
 
+// ValidateMachinePoolVariables validates MachinePoolVariables.
+func ValidateMachinePoolVariables(clusterVariables []clusterv1.ClusterVariable, clusterClassVariables []clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
+	return validateClusterVariables(clusterVariables, clusterClassVariables, false, fldPath)
+}
+
 // validateClusterVariables validates variables via the schemas in the corresponding clusterClassVariable.
 func validateClusterVariables(clusterVariables []clusterv1.ClusterVariable, clusterClassVariables []clusterv1.ClusterClassVariable, validateRequired bool, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -110,6 +110,21 @@ func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 					md.Variables.Overrides = defaultedVariables
 				}
 			}
+
+			for i, mp := range cluster.Spec.Topology.Workers.MachinePools {
+				// Continue if there are no variable overrides.
+				if mp.Variables == nil || len(mp.Variables.Overrides) == 0 {
+					continue
+				}
+
+				defaultedVariables, errs := variables.DefaultMachinePoolVariables(mp.Variables.Overrides, clusterClass.Spec.Variables,
+					field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("variables", "overrides"))
+				if len(errs) > 0 {
+					allErrs = append(allErrs, errs...)
+				} else {
+					mp.Variables.Overrides = defaultedVariables
+				}
+			}
 		}
 
 		if len(allErrs) > 0 {
@@ -208,6 +223,18 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 
 	var allErrs field.ErrorList
 
+	// NOTE: MachinePools are behind MachinePool feature gate flag; the web hook
+	// must prevent the usage of Cluster.Topology.Workers.MachinePools in case the feature flag is disabled.
+	if newCluster.Spec.Topology.Workers != nil && len(newCluster.Spec.Topology.Workers.MachinePools) > 0 && !feature.Gates.Enabled(feature.MachinePool) {
+		allErrs = append(
+			allErrs,
+			field.Forbidden(
+				fldPath.Child("workers", "machinePools"),
+				"can be set only if the MachinePool feature flag is enabled",
+			),
+		)
+	}
+
 	// class should be defined.
 	if newCluster.Spec.Topology.Class == "" {
 		allErrs = append(
@@ -244,6 +271,7 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 	}
 
 	allErrs = append(allErrs, check.MachineDeploymentTopologiesAreValidAndDefinedInClusterClass(newCluster, clusterClass)...)
+	allErrs = append(allErrs, check.MachinePoolTopologiesAreValidAndDefinedInClusterClass(newCluster, clusterClass)...)
 
 	// Check if the variables defined in the ClusterClass are valid.
 	allErrs = append(allErrs, variables.ValidateClusterVariables(newCluster.Spec.Topology.Variables, clusterClass.Spec.Variables,
@@ -262,6 +290,19 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 			allErrs = append(allErrs, variables.ValidateMachineDeploymentVariables(md.Variables.Overrides, clusterClass.Spec.Variables,
 				fldPath.Child("workers", "machineDeployments").Index(i).Child("variables", "overrides"))...)
 		}
+
+		for i, mp := range newCluster.Spec.Topology.Workers.MachinePools {
+			// Continue if there are no variable overrides.
+			if mp.Variables == nil || len(mp.Variables.Overrides) == 0 {
+				continue
+			}
+
+			allErrs = append(allErrs, variables.ValidateTopLevelClusterVariablesExist(mp.Variables.Overrides, newCluster.Spec.Topology.Variables,
+				fldPath.Child("workers", "machinePools").Index(i).Child("variables", "overrides"))...)
+
+			allErrs = append(allErrs, variables.ValidateMachinePoolVariables(mp.Variables.Overrides, clusterClass.Spec.Variables,
+				fldPath.Child("workers", "machinePools").Index(i).Child("variables", "overrides"))...)
+		}
 	}
 
 	if oldCluster != nil { // On update
@@ -75,6 +75,11 @@ func (webhook *ClusterClass) Default(_ context.Context, obj runtime.Object) erro
 		defaultNamespace(in.Spec.Workers.MachineDeployments[i].Template.Bootstrap.Ref, in.Namespace)
 		defaultNamespace(in.Spec.Workers.MachineDeployments[i].Template.Infrastructure.Ref, in.Namespace)
 	}
+
+	for i := range in.Spec.Workers.MachinePools {
+		defaultNamespace(in.Spec.Workers.MachinePools[i].Template.Bootstrap.Ref, in.Namespace)
+		defaultNamespace(in.Spec.Workers.MachinePools[i].Template.Infrastructure.Ref, in.Namespace)
+	}
 	return nil
 }
 
@@ -137,12 +142,24 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	}
 	var allErrs field.ErrorList
 
+	// NOTE: MachinePools are behind the MachinePool feature gate flag; the web hook
+	// must prevent defining MachinePool classes in case the feature flag is disabled.
+	if len(newClusterClass.Spec.Workers.MachinePools) > 0 && !feature.Gates.Enabled(feature.MachinePool) {
+		allErrs = append(allErrs, field.Forbidden(
+			field.NewPath("spec", "workers", "machinePools"),
+			"can be set only if the MachinePool feature flag is enabled",
+		))
+	}
+
 	// Ensure all references are valid.
 	allErrs = append(allErrs, check.ClusterClassReferencesAreValid(newClusterClass)...)
 
 	// Ensure all MachineDeployment classes are unique.
 	allErrs = append(allErrs, check.MachineDeploymentClassesAreUnique(newClusterClass)...)
 
+	// Ensure all MachinePool classes are unique.
+	allErrs = append(allErrs, check.MachinePoolClassesAreUnique(newClusterClass)...)
+
 	// Ensure MachineHealthChecks are valid.
 	allErrs = append(allErrs, validateMachineHealthCheckClasses(newClusterClass)...)
 
@@ -171,6 +188,10 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 		allErrs = append(allErrs,
 			webhook.validateRemovedMachineDeploymentClassesAreNotUsed(clusters, oldClusterClass, newClusterClass)...)
 
+		// Ensure no MachinePoolClass currently in use has been removed from the ClusterClass.
+		allErrs = append(allErrs,
+			webhook.validateRemovedMachinePoolClassesAreNotUsed(clusters, oldClusterClass, newClusterClass)...)
+
 		// Ensure no Variable would be invalidated by the update in spec
 		allErrs = append(allErrs,
 			validateVariableUpdates(clusters, oldClusterClass, newClusterClass, field.NewPath("spec", "variables"))...)
@@ -248,35 +269,15 @@ func validateVariableUpdates(clusters []clusterv1.Cluster, oldClusterClass, newC
 			if md.Variables == nil || len(md.Variables.Overrides) == 0 {
 				continue
 			}
+			validateVariableOverrideUpdates(md.Name, md.Variables.Overrides, cluster.Name, varsDiff, errorInfo)
+		}
 
-			for _, c := range md.Variables.Overrides {
-				// copy variable to avoid memory aliasing.
-				clusterVar := c
-
-				// 1) Error if a variable with a schema altered in the update is no longer valid on the Cluster.
-				if alteredVar, ok := varsDiff[variableValidationKey{clusterVar.Name, altered}]; ok {
-					if errs := variables.ValidateClusterVariable(&clusterVar, alteredVar, field.NewPath("")); len(errs) > 0 {
-						errorInfo.add(fmt.Sprintf("%s/%s", md.Name, alteredVar.Name), altered, cluster.Name)
-					}
-					continue
-				}
-
-				// 2) Error if a variable removed in the update is still in use in some Clusters.
-				if _, ok := varsDiff[variableValidationKey{clusterVar.Name, removed}]; ok {
-					errorInfo.add(fmt.Sprintf("%s/%s", md.Name, clusterVar.Name), removed, cluster.Name)
-					continue
-				}
-
-				// 3) Error if a variable has been added in the update check is no longer valid on the Cluster.
-				// NOTE: This can't occur in normal circumstances as a variable must be defined in a ClusterClass in order to be introduced in
-				// a Cluster. This check may catch errors in cases involving broken Clusters.
-				if addedVar, ok := varsDiff[variableValidationKey{clusterVar.Name, added}]; ok {
-					if errs := variables.ValidateClusterVariable(&clusterVar, addedVar, field.NewPath("")); len(errs) > 0 {
-						errorInfo.add(fmt.Sprintf("%s/%s", md.Name, addedVar.Name), added, cluster.Name)
-					}
-					continue
-				}
+		for _, mp := range cluster.Spec.Topology.Workers.MachinePools {
+			// Continue if there are no variable overrides.
+			if mp.Variables == nil || len(mp.Variables.Overrides) == 0 {
+				continue
 			}
+			validateVariableOverrideUpdates(mp.Name, mp.Variables.Overrides, cluster.Name, varsDiff, errorInfo)
 		}
 	}
 
@@ -294,6 +295,39 @@ func validateVariableUpdates(clusters []clusterv1.Cluster, oldClusterClass, newC
 	return aggregateErrors(errorInfo, clusterClassVariablesIndex, path)
 }
 
+// validateVariableOverrideUpdates validates the variable overrides of a MachineDeployment or MachinePool topology
+// against the updates made to ClusterClassVariables; errors are recorded in errorInfo.
+func validateVariableOverrideUpdates(topologyName string, overrides []clusterv1.ClusterVariable, clusterName string, varsDiff map[variableValidationKey]*clusterv1.ClusterClassVariable, errorInfo errorAggregator) {
+	for _, c := range overrides {
+		// copy variable to avoid memory aliasing.
+		clusterVar := c
+
+		// 1) Error if a variable with a schema altered in the update is no longer valid on the Cluster.
+		if alteredVar, ok := varsDiff[variableValidationKey{clusterVar.Name, altered}]; ok {
+			if errs := variables.ValidateClusterVariable(&clusterVar, alteredVar, field.NewPath("")); len(errs) > 0 {
+				errorInfo.add(fmt.Sprintf("%s/%s", topologyName, alteredVar.Name), altered, clusterName)
+			}
+			continue
+		}
+
+		// 2) Error if a variable removed in the update is still in use in some Clusters.
+		if _, ok := varsDiff[variableValidationKey{clusterVar.Name, removed}]; ok {
+			errorInfo.add(fmt.Sprintf("%s/%s", topologyName, clusterVar.Name), removed, clusterName)
+			continue
+		}
+
+		// 3) Error if a variable has been added in the update check is no longer valid on the Cluster.
+		// NOTE: This can't occur in normal circumstances as a variable must be defined in a ClusterClass in order to be introduced in
+		// a Cluster. This check may catch errors in cases involving broken Clusters.
+		if addedVar, ok := varsDiff[variableValidationKey{clusterVar.Name, added}]; ok {
+			if errs := variables.ValidateClusterVariable(&clusterVar, addedVar, field.NewPath("")); len(errs) > 0 {
+				errorInfo.add(fmt.Sprintf("%s/%s", topologyName, addedVar.Name), added, clusterName)
+			}
+			continue
+		}
+	}
+}
+
 type errorAggregator map[variableValidationKey][]string
 
 func (e errorAggregator) add(name string, action variableValidationType, references ...string) {
@@ -418,6 +452,46 @@ func (webhook *ClusterClass) validateRemovedMachineDeploymentClassesAreNotUsed(c
 	return allErrs
 }
 
+func (webhook *ClusterClass) validateRemovedMachinePoolClassesAreNotUsed(clusters []clusterv1.Cluster, oldClusterClass, newClusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	removedClasses := webhook.removedMachinePoolClasses(oldClusterClass, newClusterClass)
+	// If no classes have been removed return early as no further checks are needed.
+	if len(removedClasses) == 0 {
+		return nil
+	}
+	// Error if any Cluster using the ClusterClass uses a MachinePoolClass that has been removed.
+	for _, c := range clusters {
+		if c.Spec.Topology.Workers == nil {
+			continue
+		}
+		for _, machinePoolTopology := range c.Spec.Topology.Workers.MachinePools {
+			if removedClasses.Has(machinePoolTopology.Class) {
+				allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "workers", "machinePools"),
+					fmt.Sprintf("MachinePoolClass %q cannot be deleted because it is used by Cluster %q",
+						machinePoolTopology.Class, c.Name),
+				))
+			}
+		}
+	}
+	return allErrs
+}
+
+func (webhook *ClusterClass) removedMachinePoolClasses(oldClusterClass, newClusterClass *clusterv1.ClusterClass) sets.String {
+	removedClasses := sets.NewString()
+
+	classes := sets.NewString()
+	for _, class := range newClusterClass.Spec.Workers.MachinePools {
+		classes.Insert(class.Class)
+	}
+	for _, oldClass := range oldClusterClass.Spec.Workers.MachinePools {
+		if !classes.Has(oldClass.Class) {
+			removedClasses.Insert(oldClass.Class)
+		}
+	}
+	return removedClasses
+}
+
 func (webhook *ClusterClass) removedMachineClasses(oldClusterClass, newClusterClass *clusterv1.ClusterClass) sets.String {
 	removedClasses := sets.NewString()
 
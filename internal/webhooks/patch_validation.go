@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 )
 
 // validatePatches returns errors if the Patches in the ClusterClass violate any validation rules.
@@ -54,6 +55,9 @@ func validatePatch(patch clusterv1.ClusterClassPatch, names sets.String, cluster
 	allErrs = append(allErrs,
 		validatePatchDefinitions(patch, clusterClass, path)...,
 	)
+	allErrs = append(allErrs,
+		validateExternalPatch(patch, path)...,
+	)
 	return allErrs
 }
 
@@ -83,6 +87,25 @@ func validatePatchName(patch clusterv1.ClusterClassPatch, names sets.String, pat
 func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *clusterv1.ClusterClass, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
+	if patch.Definitions == nil && patch.External == nil {
+		allErrs = append(allErrs,
+			field.Required(
+				path,
+				"one of definitions or external must be set",
+			),
+		)
+	}
+
+	if patch.Definitions != nil && patch.External != nil {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path,
+				prettyPrint(patch),
+				"only one of definitions or external can be set",
+			),
+		)
+	}
+
 	allErrs = append(allErrs, validateEnabledIf(patch.EnabledIf, path.Child("enabledIf"))...)
 
 	for i, definition := range patch.Definitions {
@@ -94,7 +117,48 @@ func validatePatchDefinitions(patch clusterv1.ClusterClassPatch, clusterClass *c
 	return allErrs
 }
 
-// validateSelectors validates if enabledIf is a valid template if it is set.
+// validateExternalPatch validates the External definition of a patch, if it is set.
+func validateExternalPatch(patch clusterv1.ClusterClassPatch, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if patch.External == nil {
+		return allErrs
+	}
+
+	if !feature.Gates.Enabled(feature.RuntimeSDK) {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				path.Child("external"),
+				"external patches can be used only if the RuntimeSDK feature flag is enabled",
+			),
+		)
+	}
+
+	if patch.External.GenerateExtension == nil && patch.External.ValidateExtension == nil {
+		allErrs = append(allErrs,
+			field.Invalid(
+				path.Child("external"),
+				prettyPrint(patch.External),
+				"one of generateExtension or validateExtension must be set",
+			),
+		)
+	}
+
+	// EnabledIf is evaluated by the inline patch generator only; an external patch
+	// is always called and can decide on its own whether to return patches.
+	if patch.EnabledIf != nil {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				path.Child("enabledIf"),
+				"enabledIf cannot be used with external patches",
+			),
+		)
+	}
+
+	return allErrs
+}
+
+// validateEnabledIf validates if enabledIf is a valid template if it is set.
 func validateEnabledIf(enabledIf *string, path *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -121,7 +185,8 @@ func validateSelectors(selector clusterv1.PatchSelector, class *clusterv1.Cluste
 
 	// Return an error if none of the possible selectors are enabled.
 	if !(selector.MatchResources.InfrastructureCluster || selector.MatchResources.ControlPlane ||
-		(selector.MatchResources.MachineDeploymentClass != nil && len(selector.MatchResources.MachineDeploymentClass.Names) > 0)) {
+		(selector.MatchResources.MachineDeploymentClass != nil && len(selector.MatchResources.MachineDeploymentClass.Names) > 0) ||
+		(selector.MatchResources.MachinePoolClass != nil && len(selector.MatchResources.MachinePoolClass.Names) > 0)) {
 		return append(allErrs,
 			field.Invalid(
 				path,
@@ -160,6 +225,21 @@ func validateSelectors(selector clusterv1.PatchSelector, class *clusterv1.Cluste
 			}
 		}
 	}
+
+	if selector.MatchResources.MachinePoolClass != nil && len(selector.MatchResources.MachinePoolClass.Names) > 0 {
+		for _, name := range selector.MatchResources.MachinePoolClass.Names {
+			for _, mp := range class.Spec.Workers.MachinePools {
+				if mp.Class == name {
+					if selectorMatchTemplate(selector, mp.Template.Infrastructure.Ref) {
+						return nil
+					}
+					if selectorMatchTemplate(selector, mp.Template.Bootstrap.Ref) {
+						return nil
+					}
+				}
+			}
+		}
+	}
 	// if the code has not returned at this point there is no matching template in the ClusterClass. Return an error.
 	return append(allErrs,
 		field.Invalid(
@@ -361,6 +441,17 @@ var builtinVariables = sets.NewString(
 	// MachineDeployment ref builtins.
 	"builtin.machineDeployment.bootstrap.configRef.name",
 	"builtin.machineDeployment.infrastructureRef.name",
+
+	// MachinePool builtins.
+	"builtin.machinePool",
+	"builtin.machinePool.class",
+	"builtin.machinePool.name",
+	"builtin.machinePool.replicas",
+	"builtin.machinePool.topologyName",
+	"builtin.machinePool.version",
+	// MachinePool ref builtins.
+	"builtin.machinePool.bootstrap.configRef.name",
+	"builtin.machinePool.infrastructureRef.name",
 )
 
 // validateIndexAccess checks to see if the jsonPath is attempting to add an element in the array i.e. access by number
@@ -24,9 +24,11 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 )
 
@@ -34,6 +36,7 @@ func TestValidatePatches(t *testing.T) {
 	tests := []struct {
 		name         string
 		clusterClass clusterv1.ClusterClass
+		runtimeSDK   bool
 		wantErr      bool
 	}{
 		{
@@ -281,6 +284,24 @@ func TestValidatePatches(t *testing.T) {
 						{
 							Name:      "patch1",
 							EnabledIf: pointer.String(`template {{ .variableB }}`),
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: true,
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:    "add",
+											Path:  "/spec/template/spec/kubeadmConfigSpec/clusterConfiguration/controllerManager/extraArgs/cluster-name",
+											Value: &apiextensionsv1.JSON{Raw: []byte(`"foo"`)},
+										},
+									},
+								},
+							},
 						},
 					},
 				},
@@ -309,6 +330,175 @@ func TestValidatePatches(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		// External patch validation
+		{
+			name: "pass if an external patch is defined and the RuntimeSDK feature flag is enabled",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{
+							Ref: &corev1.ObjectReference{
+								APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+								Kind:       "ControlPlaneTemplate",
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							External: &clusterv1.ExternalPatchDefinition{
+								GenerateExtension: pointer.String("generate-extension"),
+								ValidateExtension: pointer.String("validate-extension"),
+							},
+						},
+					},
+				},
+			},
+			runtimeSDK: true,
+			wantErr:    false,
+		},
+		{
+			name: "error if an external patch is defined and the RuntimeSDK feature flag is disabled",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{
+							Ref: &corev1.ObjectReference{
+								APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+								Kind:       "ControlPlaneTemplate",
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							External: &clusterv1.ExternalPatchDefinition{
+								GenerateExtension: pointer.String("generate-extension"),
+								ValidateExtension: pointer.String("validate-extension"),
+							},
+						},
+					},
+				},
+			},
+			runtimeSDK: false,
+			wantErr:    true,
+		},
+		{
+			name: "error if an external patch defines neither generateExtension nor validateExtension",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{
+							Ref: &corev1.ObjectReference{
+								APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+								Kind:       "ControlPlaneTemplate",
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:     "patch1",
+							External: &clusterv1.ExternalPatchDefinition{},
+						},
+					},
+				},
+			},
+			runtimeSDK: true,
+			wantErr:    true,
+		},
+		{
+			name: "error if both definitions and external are set",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{
+							Ref: &corev1.ObjectReference{
+								APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+								Kind:       "ControlPlaneTemplate",
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+							Definitions: []clusterv1.PatchDefinition{
+								{
+									Selector: clusterv1.PatchSelector{
+										APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+										Kind:       "ControlPlaneTemplate",
+										MatchResources: clusterv1.PatchSelectorMatch{
+											ControlPlane: true,
+										},
+									},
+									JSONPatches: []clusterv1.JSONPatch{
+										{
+											Op:    "add",
+											Path:  "/spec/template/spec/kubeadmConfigSpec/clusterConfiguration/controllerManager/extraArgs/cluster-name",
+											Value: &apiextensionsv1.JSON{Raw: []byte(`"foo"`)},
+										},
+									},
+								},
+							},
+							External: &clusterv1.ExternalPatchDefinition{
+								GenerateExtension: pointer.String("generate-extension"),
+								ValidateExtension: pointer.String("validate-extension"),
+							},
+						},
+					},
+				},
+			},
+			runtimeSDK: true,
+			wantErr:    true,
+		},
+		{
+			name: "error if neither definitions nor external are set",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{
+							Ref: &corev1.ObjectReference{
+								APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+								Kind:       "ControlPlaneTemplate",
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name: "patch1",
+						},
+					},
+				},
+			},
+			runtimeSDK: true,
+			wantErr:    true,
+		},
+		{
+			name: "error if enabledIf is set on an external patch",
+			clusterClass: clusterv1.ClusterClass{
+				Spec: clusterv1.ClusterClassSpec{
+					ControlPlane: clusterv1.ControlPlaneClass{
+						LocalObjectTemplate: clusterv1.LocalObjectTemplate{
+							Ref: &corev1.ObjectReference{
+								APIVersion: "controlplane.cluster.x-k8s.io/v1beta1",
+								Kind:       "ControlPlaneTemplate",
+							},
+						},
+					},
+					Patches: []clusterv1.ClusterClassPatch{
+						{
+							Name:      "patch1",
+							EnabledIf: pointer.String(`{{ .variableB }}`),
+							External: &clusterv1.ExternalPatchDefinition{
+								GenerateExtension: pointer.String("generate-extension"),
+								ValidateExtension: pointer.String("validate-extension"),
+							},
+						},
+					},
+				},
+			},
+			runtimeSDK: true,
+			wantErr:    true,
+		},
 		// Patch "op" (operation) validation
 		{
 			name: "error if patch op is not \"add\" \"remove\" or \"replace\"",
@@ -1381,6 +1571,7 @@ func TestValidatePatches(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, tt.runtimeSDK)()
 			g := NewWithT(t)
 
 			errList := validatePatches(&tt.clusterClass)
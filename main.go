@@ -39,6 +39,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	clusterv1alpha3 "sigs.k8s.io/cluster-api/api/v1alpha3"
 	clusterv1alpha4 "sigs.k8s.io/cluster-api/api/v1alpha4"
@@ -53,11 +54,14 @@ import (
 	expv1alpha3 "sigs.k8s.io/cluster-api/exp/api/v1alpha3"
 	expv1alpha4 "sigs.k8s.io/cluster-api/exp/api/v1alpha4"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	backupv1 "sigs.k8s.io/cluster-api/exp/backup/api/v1alpha1"
+	backupcontrollers "sigs.k8s.io/cluster-api/exp/backup/controllers"
 	expcontrollers "sigs.k8s.io/cluster-api/exp/controllers"
 	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
 	runtimecontrollers "sigs.k8s.io/cluster-api/exp/runtime/controllers"
 	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/metrics"
 	runtimecatalog "sigs.k8s.io/cluster-api/internal/runtime/catalog"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
@@ -90,6 +94,10 @@ var (
 	machinePoolConcurrency        int
 	clusterResourceSetConcurrency int
 	machineHealthCheckConcurrency int
+	clusterBackupConcurrency      int
+	backupVolumePath              string
+	nodeLabelSyncPatterns         []string
+	nodeAnnotationSyncPatterns    []string
 	syncPeriod                    time.Duration
 	webhookPort                   int
 	webhookCertDir                string
@@ -114,6 +122,8 @@ func init() {
 	_ = addonsv1.AddToScheme(scheme)
 
 	_ = runtimev1.AddToScheme(scheme)
+
+	_ = backupv1.AddToScheme(scheme)
 	// +kubebuilder:scaffold:scheme
 
 	// Register the RuntimeHook types into the catalog.
@@ -179,6 +189,18 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&machineHealthCheckConcurrency, "machinehealthcheck-concurrency", 10,
 		"Number of machine health checks to process simultaneously")
 
+	fs.IntVar(&clusterBackupConcurrency, "clusterbackupschedule-concurrency", 1,
+		"Number of cluster backup schedules to process simultaneously")
+
+	fs.StringVar(&backupVolumePath, "backup-volume-path", "",
+		"Directory where backups of ClusterBackupSchedules using volume storage are stored. Volume storage is disabled if not set.")
+
+	fs.StringSliceVar(&nodeLabelSyncPatterns, "node-label-sync-patterns", nil,
+		"Comma separated list of patterns selecting the Machine labels to be kept in sync onto the Node. A pattern is either a label key or a domain followed by /* (e.g. node.cluster.x-k8s.io/*). If unspecified, defaults to node.cluster.x-k8s.io/*,node-role.kubernetes.io/*,node-restriction.kubernetes.io/*")
+
+	fs.StringSliceVar(&nodeAnnotationSyncPatterns, "node-annotation-sync-patterns", nil,
+		"Comma separated list of patterns selecting the Machine annotations to be kept in sync onto the Node. A pattern is either an annotation key or a domain followed by /* (e.g. node.cluster.x-k8s.io/*). If unspecified, defaults to node.cluster.x-k8s.io/*")
+
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Minute,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)")
 
@@ -258,6 +280,7 @@ func main() {
 	ctx := ctrl.SetupSignalHandler()
 
 	setupChecks(mgr)
+	setupMetrics(mgr)
 	setupIndexes(ctx, mgr)
 	setupReconcilers(ctx, mgr)
 	setupWebhooks(mgr)
@@ -289,6 +312,11 @@ func setupIndexes(ctx context.Context, mgr ctrl.Manager) {
 	}
 }
 
+func setupMetrics(mgr ctrl.Manager) {
+	// NOTE: Metrics are exposed on the manager's metrics endpoint, configured with --metrics-bind-addr.
+	ctrlmetrics.Registry.MustRegister(metrics.NewCollector(mgr.GetClient()))
+}
+
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	// Set up a ClusterCacheTracker and ClusterCacheReconciler to provide to controllers
 	// requiring a connection to a remote cluster
@@ -313,6 +341,16 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
+	var runtimeClient runtimeclient.Client
+	if feature.Gates.Enabled(feature.RuntimeSDK) {
+		// This is the creation of the single RuntimeClient for the controllers, embedding a shared
+		// catalog and RuntimeExtension registry instance.
+		runtimeClient = runtimeclient.New(runtimeclient.Options{
+			Catalog:  catalog,
+			Registry: runtimeregistry.New(),
+		})
+	}
+
 	if feature.Gates.Enabled(feature.ClusterTopology) {
 		unstructuredCachingClient, err := client.NewDelegatingClient(
 			client.NewDelegatingClientInput{
@@ -343,6 +381,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			Client:                    mgr.GetClient(),
 			APIReader:                 mgr.GetAPIReader(),
 			UnstructuredCachingClient: unstructuredCachingClient,
+			RuntimeClient:             runtimeClient,
 			WatchFilterValue:          watchFilterValue,
 		}).SetupWithManager(ctx, mgr, concurrency(clusterTopologyConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ClusterTopology")
@@ -369,15 +408,10 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	}
 
 	if feature.Gates.Enabled(feature.RuntimeSDK) {
-		// This is the creation of the single RuntimeExtension registry for the controller.
-		registry := runtimeregistry.New()
 		if err = (&runtimecontrollers.ExtensionConfigReconciler{
-			Client:    mgr.GetClient(),
-			APIReader: mgr.GetAPIReader(),
-			RuntimeClient: runtimeclient.New(runtimeclient.Options{
-				Catalog:  catalog,
-				Registry: registry,
-			}),
+			Client:           mgr.GetClient(),
+			APIReader:        mgr.GetAPIReader(),
+			RuntimeClient:    runtimeClient,
 			WatchFilterValue: watchFilterValue,
 		}).SetupWithManager(ctx, mgr, concurrency(extensionConfigConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ExtensionConfig")
@@ -394,10 +428,12 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 	if err := (&controllers.MachineReconciler{
-		Client:           mgr.GetClient(),
-		APIReader:        mgr.GetAPIReader(),
-		Tracker:          tracker,
-		WatchFilterValue: watchFilterValue,
+		Client:                     mgr.GetClient(),
+		APIReader:                  mgr.GetAPIReader(),
+		Tracker:                    tracker,
+		WatchFilterValue:           watchFilterValue,
+		NodeLabelSyncPatterns:      nodeLabelSyncPatterns,
+		NodeAnnotationSyncPatterns: nodeAnnotationSyncPatterns,
 	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Machine")
 		os.Exit(1)
@@ -448,6 +484,18 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		}
 	}
 
+	if feature.Gates.Enabled(feature.ClusterBackup) {
+		if err := (&backupcontrollers.ClusterBackupScheduleReconciler{
+			Client:           mgr.GetClient(),
+			RestConfig:       mgr.GetConfig(),
+			VolumePath:       backupVolumePath,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, concurrency(clusterBackupConcurrency)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterBackupSchedule")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&controllers.MachineHealthCheckReconciler{
 		Client:           mgr.GetClient(),
 		Tracker:          tracker,
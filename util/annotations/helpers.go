@@ -18,6 +18,7 @@ limitations under the License.
 package annotations
 
 import (
+	"sort"
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -66,7 +67,6 @@ func AddAnnotations(o metav1.Object, desired map[string]string) bool {
 	annotations := o.GetAnnotations()
 	if annotations == nil {
 		annotations = make(map[string]string)
-		o.SetAnnotations(annotations)
 	}
 	hasChanged := false
 	for k, v := range desired {
@@ -75,6 +75,60 @@ func AddAnnotations(o metav1.Object, desired map[string]string) bool {
 			hasChanged = true
 		}
 	}
+	// NOTE: Set the annotations back, because some implementations (e.g. Unstructured) return a copy.
+	o.SetAnnotations(annotations)
+	return hasChanged
+}
+
+// SyncAnnotations sets the desired annotations on the object, and removes the annotations previously set from the
+// desired annotations which are not desired anymore; the keys of the desired annotations are tracked in the
+// AnnotationsFromTemplateAnnotation annotation on the object. It returns true if the annotations have changed.
+func SyncAnnotations(o metav1.Object, desired map[string]string) bool {
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	hasChanged := false
+	keys := make([]string, 0, len(desired))
+	for k, v := range desired {
+		// Never sync the annotations used to track the synced metadata.
+		if k == clusterv1.LabelsFromTemplateAnnotation || k == clusterv1.AnnotationsFromTemplateAnnotation {
+			continue
+		}
+		if cur, ok := annotations[k]; !ok || cur != v {
+			annotations[k] = v
+			hasChanged = true
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if previous, ok := annotations[clusterv1.AnnotationsFromTemplateAnnotation]; ok {
+		for _, k := range strings.Split(previous, ",") {
+			if _, ok := desired[k]; ok {
+				continue
+			}
+			if _, ok := annotations[k]; ok {
+				delete(annotations, k)
+				hasChanged = true
+			}
+		}
+	}
+
+	tracked := strings.Join(keys, ",")
+	if cur, ok := annotations[clusterv1.AnnotationsFromTemplateAnnotation]; len(keys) > 0 && (!ok || cur != tracked) {
+		annotations[clusterv1.AnnotationsFromTemplateAnnotation] = tracked
+		hasChanged = true
+	} else if ok && len(keys) == 0 {
+		delete(annotations, clusterv1.AnnotationsFromTemplateAnnotation)
+		hasChanged = true
+	}
+
+	if hasChanged {
+		// NOTE: Set the annotations back, because some implementations (e.g. Unstructured) return a copy.
+		o.SetAnnotations(annotations)
+	}
 	return hasChanged
 }
 
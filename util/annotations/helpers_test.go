@@ -22,6 +22,9 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestAddAnnotations(t *testing.T) {
@@ -141,6 +144,26 @@ func TestAddAnnotations(t *testing.T) {
 			},
 			changed: true,
 		},
+		{
+			name: "should return true if annotations are added to an unstructured object",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							"foo": "bar",
+						},
+					},
+				},
+			},
+			input: map[string]string{
+				"thing1": "thing2",
+			},
+			expected: map[string]string{
+				"foo":    "bar",
+				"thing1": "thing2",
+			},
+			changed: true,
+		},
 	}
 
 	for _, tc := range testcases {
@@ -151,3 +174,105 @@ func TestAddAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestSyncAnnotations(t *testing.T) {
+	var testcases = []struct {
+		name     string
+		obj      metav1.Object
+		input    map[string]string
+		expected map[string]string
+		changed  bool
+	}{
+		{
+			name: "should add annotations and track them",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"other": "value",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+				"baz": "qux",
+			},
+			expected: map[string]string{
+				"other": "value",
+				"foo":   "bar",
+				"baz":   "qux",
+				clusterv1.AnnotationsFromTemplateAnnotation: "baz,foo",
+			},
+			changed: true,
+		},
+		{
+			name: "should return false if no changes are made",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"foo": "bar",
+						clusterv1.AnnotationsFromTemplateAnnotation: "foo",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+			},
+			expected: map[string]string{
+				"foo": "bar",
+				clusterv1.AnnotationsFromTemplateAnnotation: "foo",
+			},
+			changed: false,
+		},
+		{
+			name: "should remove tracked annotations which are not desired anymore",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"foo":   "bar",
+						"baz":   "qux",
+						"other": "value",
+						clusterv1.AnnotationsFromTemplateAnnotation: "baz,foo",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+			},
+			expected: map[string]string{
+				"foo":   "bar",
+				"other": "value",
+				clusterv1.AnnotationsFromTemplateAnnotation: "foo",
+			},
+			changed: true,
+		},
+		{
+			name: "should remove the tracking annotation if no annotations are desired",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]interface{}{
+							"foo":   "bar",
+							"other": "value",
+							clusterv1.AnnotationsFromTemplateAnnotation: "foo",
+						},
+					},
+				},
+			},
+			input: map[string]string{},
+			expected: map[string]string{
+				"other": "value",
+			},
+			changed: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			res := SyncAnnotations(tc.obj, tc.input)
+			g.Expect(res).To(Equal(tc.changed))
+			g.Expect(tc.obj.GetAnnotations()).To(Equal(tc.expected))
+		})
+	}
+}
@@ -165,6 +165,52 @@ func TestShouldRolloutAfter(t *testing.T) {
 	})
 }
 
+func TestShouldRolloutBefore(t *testing.T) {
+	reconciliationTime := metav1.Date(2009, time.November, 10, 23, 0, 0, 0, time.UTC)
+	rolloutBefore := &controlplanev1.RolloutBefore{
+		CertificatesExpiryDays: pointer.Int32Ptr(10),
+	}
+	t.Run("if the machine is nil it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(nil)).To(BeFalse())
+	})
+	t.Run("if the rolloutBefore is nil it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, nil)(m)).To(BeFalse())
+	})
+	t.Run("if the certificatesExpiryDays is nil it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, &controlplanev1.RolloutBefore{})(m)).To(BeFalse())
+	})
+	t.Run("if the machine certificates expiry date is not set it returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(m)).To(BeFalse())
+	})
+	t.Run("if the machine certificates are not going to expire within the expiry days, return false", func(t *testing.T) {
+		g := NewWithT(t)
+		expiryDate := metav1.NewTime(reconciliationTime.Add(20 * 24 * time.Hour))
+		m := &clusterv1.Machine{
+			Status: clusterv1.MachineStatus{
+				CertificatesExpiryDate: &expiryDate,
+			},
+		}
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(m)).To(BeFalse())
+	})
+	t.Run("if the machine certificates are going to expire within the expiry days, return true", func(t *testing.T) {
+		g := NewWithT(t)
+		expiryDate := metav1.NewTime(reconciliationTime.Add(5 * 24 * time.Hour))
+		m := &clusterv1.Machine{
+			Status: clusterv1.MachineStatus{
+				CertificatesExpiryDate: &expiryDate,
+			},
+		}
+		g.Expect(collections.ShouldRolloutBefore(&reconciliationTime, rolloutBefore)(m)).To(BeTrue())
+	})
+}
+
 func TestHashAnnotationKey(t *testing.T) {
 	t.Run("machine with specified annotation returns true", func(t *testing.T) {
 		g := NewWithT(t)
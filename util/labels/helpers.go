@@ -18,6 +18,9 @@ limitations under the License.
 package labels
 
 import (
+	"sort"
+	"strings"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -37,3 +40,77 @@ func HasWatchLabel(o metav1.Object, labelValue string) bool {
 	}
 	return val == labelValue
 }
+
+// AddLabels sets the desired labels on the object and returns true if the labels have changed.
+func AddLabels(o metav1.Object, desired map[string]string) bool {
+	if len(desired) == 0 {
+		return false
+	}
+	labels := o.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	hasChanged := false
+	for k, v := range desired {
+		if cur, ok := labels[k]; !ok || cur != v {
+			labels[k] = v
+			hasChanged = true
+		}
+	}
+	// NOTE: Set the labels back, because some implementations (e.g. Unstructured) return a copy.
+	o.SetLabels(labels)
+	return hasChanged
+}
+
+// SyncLabels sets the desired labels on the object, and removes the labels previously set from the desired labels
+// which are not desired anymore; the keys of the desired labels are tracked in the LabelsFromTemplateAnnotation
+// annotation on the object. It returns true if the labels or the tracking annotation have changed.
+func SyncLabels(o metav1.Object, desired map[string]string) bool {
+	labels := o.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	annotations := o.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	hasChanged := false
+	keys := make([]string, 0, len(desired))
+	for k, v := range desired {
+		if cur, ok := labels[k]; !ok || cur != v {
+			labels[k] = v
+			hasChanged = true
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if previous, ok := annotations[clusterv1.LabelsFromTemplateAnnotation]; ok {
+		for _, k := range strings.Split(previous, ",") {
+			if _, ok := desired[k]; ok {
+				continue
+			}
+			if _, ok := labels[k]; ok {
+				delete(labels, k)
+				hasChanged = true
+			}
+		}
+	}
+
+	tracked := strings.Join(keys, ",")
+	if cur, ok := annotations[clusterv1.LabelsFromTemplateAnnotation]; len(keys) > 0 && (!ok || cur != tracked) {
+		annotations[clusterv1.LabelsFromTemplateAnnotation] = tracked
+		hasChanged = true
+	} else if ok && len(keys) == 0 {
+		delete(annotations, clusterv1.LabelsFromTemplateAnnotation)
+		hasChanged = true
+	}
+
+	if hasChanged {
+		// NOTE: Set the labels and annotations back, because some implementations (e.g. Unstructured) return a copy.
+		o.SetLabels(labels)
+		o.SetAnnotations(annotations)
+	}
+	return hasChanged
+}
@@ -22,6 +22,7 @@ import (
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
@@ -84,3 +85,227 @@ func TestHasWatchLabel(t *testing.T) {
 		})
 	}
 }
+
+func TestAddLabels(t *testing.T) {
+	g := NewWithT(t)
+
+	var testcases = []struct {
+		name     string
+		obj      metav1.Object
+		input    map[string]string
+		expected map[string]string
+		changed  bool
+	}{
+		{
+			name: "should return false if no changes are made",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+			},
+			expected: map[string]string{
+				"foo": "bar",
+			},
+			changed: false,
+		},
+		{
+			name: "should do nothing if no labels are provided and have been nil before",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: nil,
+				},
+			},
+			input:    map[string]string{},
+			expected: nil,
+			changed:  false,
+		},
+		{
+			name: "should return true if labels are added",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: nil,
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+			},
+			expected: map[string]string{
+				"foo": "bar",
+			},
+			changed: true,
+		},
+		{
+			name: "should return true if labels are updated",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"foo": "bar",
+						"baz": "qux",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "buzz",
+			},
+			expected: map[string]string{
+				"foo": "buzz",
+				"baz": "qux",
+			},
+			changed: true,
+		},
+		{
+			name: "should return true if labels are added to an unstructured object",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							"foo": "bar",
+						},
+					},
+				},
+			},
+			input: map[string]string{
+				"thing1": "thing2",
+			},
+			expected: map[string]string{
+				"foo":    "bar",
+				"thing1": "thing2",
+			},
+			changed: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := AddLabels(tc.obj, tc.input)
+			g.Expect(res).To(Equal(tc.changed))
+			g.Expect(tc.obj.GetLabels()).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestSyncLabels(t *testing.T) {
+	var testcases = []struct {
+		name                string
+		obj                 metav1.Object
+		input               map[string]string
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+		changed             bool
+	}{
+		{
+			name: "should add labels and track them",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"other": "value",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+				"baz": "qux",
+			},
+			expectedLabels: map[string]string{
+				"other": "value",
+				"foo":   "bar",
+				"baz":   "qux",
+			},
+			expectedAnnotations: map[string]string{
+				clusterv1.LabelsFromTemplateAnnotation: "baz,foo",
+			},
+			changed: true,
+		},
+		{
+			name: "should return false if no changes are made",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"foo": "bar",
+					},
+					Annotations: map[string]string{
+						clusterv1.LabelsFromTemplateAnnotation: "foo",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+			},
+			expectedLabels: map[string]string{
+				"foo": "bar",
+			},
+			expectedAnnotations: map[string]string{
+				clusterv1.LabelsFromTemplateAnnotation: "foo",
+			},
+			changed: false,
+		},
+		{
+			name: "should remove tracked labels which are not desired anymore",
+			obj: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{
+						"foo":   "bar",
+						"baz":   "qux",
+						"other": "value",
+					},
+					Annotations: map[string]string{
+						clusterv1.LabelsFromTemplateAnnotation: "baz,foo",
+					},
+				},
+			},
+			input: map[string]string{
+				"foo": "bar",
+			},
+			expectedLabels: map[string]string{
+				"foo":   "bar",
+				"other": "value",
+			},
+			expectedAnnotations: map[string]string{
+				clusterv1.LabelsFromTemplateAnnotation: "foo",
+			},
+			changed: true,
+		},
+		{
+			name: "should remove the tracking annotation if no labels are desired",
+			obj: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							"foo":   "bar",
+							"other": "value",
+						},
+						"annotations": map[string]interface{}{
+							clusterv1.LabelsFromTemplateAnnotation: "foo",
+						},
+					},
+				},
+			},
+			input: map[string]string{},
+			expectedLabels: map[string]string{
+				"other": "value",
+			},
+			expectedAnnotations: nil,
+			changed:             true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			res := SyncLabels(tc.obj, tc.input)
+			g.Expect(res).To(Equal(tc.changed))
+			g.Expect(tc.obj.GetLabels()).To(Equal(tc.expectedLabels))
+			if tc.expectedAnnotations == nil {
+				g.Expect(tc.obj.GetAnnotations()).To(BeEmpty())
+			} else {
+				g.Expect(tc.obj.GetAnnotations()).To(Equal(tc.expectedAnnotations))
+			}
+		})
+	}
+}
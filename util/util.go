@@ -604,3 +604,17 @@ func LowestNonZeroResult(i, j ctrl.Result) ctrl.Result {
 		return j
 	}
 }
+
+// LowestNonZeroInt32 returns the lowest non-zero value of the two provided values.
+func LowestNonZeroInt32(i, j int32) int32 {
+	if i == 0 {
+		return j
+	}
+	if j == 0 {
+		return i
+	}
+	if i < j {
+		return i
+	}
+	return j
+}
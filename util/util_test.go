@@ -906,3 +906,24 @@ func TestUnstructuredUnmarshalField(t *testing.T) {
 		})
 	}
 }
+
+func TestLowestNonZeroInt32(t *testing.T) {
+	tests := []struct {
+		name string
+		i    int32
+		j    int32
+		want int32
+	}{
+		{name: "both zero", i: 0, j: 0, want: 0},
+		{name: "first zero", i: 0, j: 10, want: 10},
+		{name: "second zero", i: 10, j: 0, want: 10},
+		{name: "first lower", i: 5, j: 10, want: 5},
+		{name: "second lower", i: 10, j: 5, want: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(LowestNonZeroInt32(tt.i, tt.j)).To(Equal(tt.want))
+		})
+	}
+}